@@ -1,40 +1,393 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+	_ "time/tzdata" // 商家营业时间按 IANA 时区计算，运行环境可能没有时区数据
 
+	"order-service/internal/adapter/eventbus"
+	graphqladapter "order-service/internal/adapter/graphql"
+	grpcadapter "order-service/internal/adapter/grpc"
+	"order-service/internal/adapter/metrics"
+	"order-service/internal/adapter/payment"
 	"order-service/internal/adapter/persistence"
+	"order-service/internal/adapter/ratelimit"
+	"order-service/internal/adapter/tracing"
 	"order-service/internal/adapter/web"
+	"order-service/internal/adapter/webhook"
 	"order-service/internal/application"
+	"order-service/internal/config"
+	"order-service/internal/domain"
+	"order-service/internal/logging"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
 )
 
 func main() {
+	// 0. 加载配置（默认值 < 配置文件 < 环境变量 < 命令行参数）
+	slog.SetDefault(logging.New(os.Stdout, slog.LevelInfo))
+	cfg, cli, err := config.Load(os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fatal("failed to load config", err)
+	}
+	if cli.PrintConfig {
+		if err := cfg.WriteRedacted(os.Stdout); err != nil {
+			fatal("failed to print config", err)
+		}
+		return
+	}
+	slog.SetDefault(logging.New(os.Stdout, cfg.Log.Level))
+	web.ConfigureJWT(cfg.Auth.JWTSecret, cfg.Auth.TokenTTL)
+
+	tracerProvider, shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal("failed to set up tracing", err)
+	}
+	tracer := tracerProvider.Tracer(tracing.InstrumentationName)
+
 	// 1. 初始化 Repository
 	repo := persistence.NewInMemoryOrderRepository()
 
-	// 2. 初始化 Application Service
-	orderService := application.NewOrderService(repo)
+	// 2. 初始化 Application Service（仓储、支付网关和应用服务由指标和链路追踪装饰器包装）
+	serviceMetrics := metrics.New()
+	paymentGateway := tracing.NewPaymentGateway(payment.NewInMemoryPaymentGateway(), tracer)
+	instrumentedRepo := tracing.NewOrderRepository(metrics.NewOrderRepository(repo, serviceMetrics), tracer)
+	menuCatalog := newDemoMenuCatalog()
+	profileRepo := persistence.NewInMemoryMerchantProfileRepository()
+	slotRepo := persistence.NewInMemoryDeliverySlotRepository()
+	areaRepo := persistence.NewInMemoryDeliveryAreaRepository()
+	addressRepo := persistence.NewInMemoryAddressBookRepository()
+	inventoryRepo := persistence.NewInMemoryInventoryRepository()
+	slotPolicy := application.SlotPolicy{
+		Duration:    cfg.Schedule.SlotDuration,
+		MinLeadTime: cfg.Schedule.MinLeadTime,
+		MaxAdvance:  cfg.Schedule.MaxAdvance,
+	}
+	fees := domain.Fees{
+		PackagingFee: cfg.Pricing.PackagingFee,
+		DeliveryFee:  cfg.Pricing.DeliveryFee,
+	}
+	orderService := application.NewOrderService(instrumentedRepo,
+		application.WithPaymentGateway(paymentGateway),
+		application.WithMenuCatalog(menuCatalog),
+		application.WithFees(fees),
+		application.WithMerchantProfiles(profileRepo),
+		application.WithDeliverySlots(slotRepo, slotPolicy),
+		application.WithDeliveryAreas(areaRepo),
+		application.WithAddressBook(addressRepo),
+		application.WithInventory(inventoryRepo),
+		application.WithQuoteSigner(application.NewQuoteSigner([]byte(cfg.Auth.JWTSecret), application.WithQuoteTTL(cfg.Quote.TTL))),
+	)
+	// 下单限流在应用层执行，HTTP、购物车结算、GraphQL 和 gRPC 共用同一组令牌桶（内存实现，多实例部署时替换为共享存储实现）
+	rateLimiter := ratelimit.NewInMemoryRateLimiter()
+	if cfg.RateLimit.Enabled {
+		orderService = application.NewRateLimitedOrderService(orderService, rateLimiter,
+			application.RateLimit(cfg.RateLimit.CreateOrderPerUser),
+			application.RateLimit(cfg.RateLimit.CreateOrderPerMerchant))
+	}
+	orderService = tracing.NewOrderService(metrics.NewOrderService(orderService, serviceMetrics), tracer)
+
+	// 购物车与下单使用同一菜单和费用，结算通过（带指标和链路追踪的）订单服务下单
+	cartService := application.NewCartService(persistence.NewInMemoryCartRepository(), orderService,
+		application.WithCartMenuCatalog(menuCatalog),
+		application.WithCartFees(fees),
+		application.WithCartTTL(cfg.Cart.TTL),
+	)
+
+	profileService := application.NewMerchantProfileService(profileRepo)
+	slotService := application.NewDeliverySlotService(profileRepo, slotRepo, slotPolicy)
+	areaService := application.NewDeliveryAreaService(areaRepo)
+	addressService := application.NewAddressBookService(addressRepo)
+	inventoryService := application.NewInventoryService(profileRepo, inventoryRepo)
+
+	webhookRepo := persistence.NewInMemoryWebhookRepository()
+	webhookService := application.NewWebhookService(webhookRepo)
+
+	// 启动 outbox 投递器（将 outbox 中的领域事件投递给进程内订阅者）
+	eventPublisher := eventbus.NewInProcessPublisher()
+	eventPublisher.SubscribeAll(webhookService.HandleEvent)
+	statusBroker := application.NewOrderStatusBroker()
+	eventPublisher.SubscribeAll(statusBroker.HandleEvent)
+	intakeHub := application.NewMerchantIntakeHub()
+	eventPublisher.Subscribe(domain.EventTypeOrderCreated, intakeHub.HandleEvent)
+	eventPublisher.Subscribe(domain.EventTypeOrderPaid, intakeHub.HandleEvent)
+	eventPublisher.Subscribe(domain.EventTypeOrderReleased, intakeHub.HandleEvent)
+	outboxRelay := application.NewOutboxRelay(repo, eventPublisher)
+	stopOutboxRelay := startWorker(func(ctx context.Context) {
+		outboxRelay.Run(ctx, cfg.Outbox.PollInterval)
+	})
+
+	// 启动预订单推送器（在配送时段开始前将已支付的预订单推送给商家）
+	scheduledReleaser := application.NewScheduledOrderReleaser(instrumentedRepo,
+		application.WithReleaseLeadTime(cfg.Schedule.ReleaseLeadTime))
+	stopScheduledReleaser := startWorker(func(ctx context.Context) {
+		scheduledReleaser.Run(ctx, cfg.Schedule.PollInterval)
+	})
+
+	// 启动支付超时取消器（取消超时未支付的订单，释放配送时段名额和餐品库存）
+	paymentCanceller := application.NewPaymentTimeoutCanceller(instrumentedRepo,
+		application.WithPaymentTimeout(cfg.Payment.Timeout),
+		application.WithTimeoutReservations(slotRepo, inventoryRepo))
+	stopPaymentCanceller := startWorker(func(ctx context.Context) {
+		paymentCanceller.Run(ctx, cfg.Payment.PollInterval)
+	})
+
+	// 启动 Webhook 投递器（只连接公网地址、不跟随重定向，出站请求携带 traceparent 头）
+	webhookSender := webhook.NewHTTPSender(&http.Client{
+		Timeout:       webhook.DefaultTimeout,
+		Transport:     tracing.NewTransport(webhook.NewPublicTransport(), tracer),
+		CheckRedirect: webhook.NoRedirect,
+	})
+	webhookDispatcher := application.NewWebhookDispatcher(webhookRepo, webhookSender)
+	stopWebhookDispatcher := startWorker(func(ctx context.Context) {
+		webhookDispatcher.Run(ctx, cfg.Webhook.DispatchInterval)
+	})
+
+	// 就绪检查项（由各适配器实现）
+	health := application.NewHealth()
+	health.Register("orderRepository", repo)
+	health.Register("outboxRelay", outboxRelay)
+	health.Register("scheduledOrderReleaser", scheduledReleaser)
+	health.Register("paymentTimeoutCanceller", paymentCanceller)
+	health.Register("webhookDispatcher", webhookDispatcher)
 
 	// 3. 初始化 Handler
 	orderHandler := web.NewOrderHandler(orderService)
+	cartHandler := web.NewCartHandler(cartService)
+	webhookHandler := web.NewWebhookHandler(webhookService)
+	streamHandler := web.NewOrderStreamHandler(orderService, statusBroker)
+	intakeHandler := web.NewMerchantIntakeHandler(orderService, intakeHub)
+	profileHandler := web.NewMerchantProfileHandler(profileService)
+	slotHandler := web.NewDeliverySlotHandler(slotService)
+	areaHandler := web.NewDeliveryAreaHandler(areaService)
+	addressHandler := web.NewAddressBookHandler(addressService)
+	inventoryHandler := web.NewInventoryHandler(inventoryService)
+	paymentHandler := web.NewPaymentHandler(orderService, cfg.Payment.CallbackSecret)
+	healthHandler := web.NewHealthHandler(health)
+	graphqlHandler, err := graphqladapter.NewHandler(orderService)
+	if err != nil {
+		fatal("failed to build GraphQL schema", err)
+	}
 
 	// 4. 创建 Echo 实例
 	e := echo.New()
+	// 启动信息由结构化日志输出，保持标准输出均为 JSON
+	e.HideBanner = true
+	e.HidePort = true
+	if cfg.Server.TrustProxyHeaders {
+		e.IPExtractor = echo.ExtractIPFromXFFHeader()
+	} else {
+		e.IPExtractor = echo.ExtractIPDirect()
+	}
+	// 停机时断开 SSE 和 WebSocket 长连接，否则排空会一直等到超时
+	e.Server.RegisterOnShutdown(statusBroker.DisconnectAll)
+	e.Server.RegisterOnShutdown(intakeHub.DisconnectAll)
 
 	// 5. 配置中间件
-	e.Use(middleware.Logger())
+	e.Use(web.RequestID)
+	e.Use(tracing.HTTPMiddleware(tracer))
+	e.Use(web.AccessLog)
+	e.Use(serviceMetrics.HTTPMiddleware)
 	e.Use(middleware.Recover())
 
 	// 6. 注册路由
+	web.RegisterHealthRoutes(e, healthHandler)
+	e.GET("/metrics", echo.WrapHandler(serviceMetrics.Handler()))
+	preAuthPolicy, rateLimitPolicy := newRateLimitPolicies(cfg.RateLimit, rateLimiter)
 	api := e.Group("/api/v1")
-	api.POST("/orders", orderHandler.CreateOrder, web.AuthMiddleware)
+	web.RegisterRoutes(api, web.Handlers{
+		Order:     orderHandler,
+		Cart:      cartHandler,
+		Webhook:   webhookHandler,
+		Stream:    streamHandler,
+		Intake:    intakeHandler,
+		Profile:   profileHandler,
+		Slots:     slotHandler,
+		Areas:     areaHandler,
+		Addresses: addressHandler,
+		Inventory: inventoryHandler,
+		Payment:   paymentHandler,
+	},
+		web.WithRequestValidation(web.WithValidationObserver(serviceMetrics.RecordRequestSchemaFailure)),
+		web.WithPreAuthRateLimit(preAuthPolicy),
+		web.WithRateLimit(rateLimitPolicy),
+	)
+	web.RegisterDocsRoutes(api)
+	// GraphQL 不经过请求体校验，单独限制请求体大小（超过时返回 413）
+	api.POST(graphqlPath, graphqlHandler.Serve, web.RateLimit(preAuthPolicy),
+		middleware.BodyLimit(strconv.FormatInt(web.DefaultMaxBodySize, 10)),
+		web.AuthMiddleware, web.RateLimit(rateLimitPolicy))
+
+	// 7. 启动 gRPC 服务器（独立端口，与 HTTP 共用应用服务）
+	grpcServer := grpcadapter.NewServer(orderService,
+		grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor(tracer)),
+	)
+	lis, err := net.Listen("tcp", cfg.Server.GRPCAddr)
+	if err != nil {
+		fatal("failed to listen for gRPC", err)
+	}
+	go func() {
+		slog.Info("starting gRPC server", "addr", cfg.Server.GRPCAddr)
+		if err := grpcServer.Serve(lis); err != nil {
+			fatal("failed to start gRPC server", err)
+		}
+	}()
+
+	// 8. 启动 HTTP 服务器
+	go func() {
+		slog.Info("starting HTTP server", "addr", cfg.Server.HTTPAddr)
+		if err := e.Start(cfg.Server.HTTPAddr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal("failed to start HTTP server", err)
+		}
+	}()
+
+	// 9. 优雅停机：收到 SIGTERM/SIGINT 后依次摘除流量、排空连接、停止后台任务
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	<-ctx.Done()
+	stop() // 再次收到信号时立即退出
+
+	slog.Info("shutting down: marking instance not ready", "drain_delay", cfg.Server.DrainDelay)
+	health.StartDraining()
+	time.Sleep(cfg.Server.DrainDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	// 先停止接收请求并等待处理中的请求完成，保证已接收的下单请求写入仓储和 outbox
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		slog.Error("HTTP server shutdown failed", logging.KeyError, err)
+	}
+	stopGRPCServer(shutdownCtx, grpcServer)
+
+	// 再停止后台任务：outbox 投递器会产生 Webhook 投递，因此先于 Webhook 投递器停止
+	stopOutboxRelay(shutdownCtx)
+	stopScheduledReleaser(shutdownCtx)
+	stopPaymentCanceller(shutdownCtx)
+	stopWebhookDispatcher(shutdownCtx)
+
+	// 最后导出剩余的 span
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("tracing shutdown failed", logging.KeyError, err)
+	}
+	slog.Info("shutdown complete")
+}
+
+// fatal 记录错误并退出
+func fatal(msg string, err error) {
+	slog.Error(msg, logging.KeyError, err)
+	os.Exit(1)
+}
+
+// graphqlPath GraphQL 接口路径（相对 /api/v1）
+const graphqlPath = "/graphql"
+
+// newRateLimitPolicies 按配置创建 HTTP 接口的限流策略：认证前按 IP、认证后按用户，未启用时均为 nil
+// 下单限额由应用层的 RateLimitedOrderService 执行，这里只配置作用于全部需认证接口（含 GraphQL）的规则
+func newRateLimitPolicies(cfg config.RateLimitConfig, limiter application.RateLimiter) (preAuth, afterAuth *web.RateLimitPolicy) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	apiRoutes := append(web.AuthenticatedRoutes(), http.MethodPost+" "+graphqlPath)
+
+	preAuth = web.NewRateLimitPolicy(limiter,
+		web.RateLimitRule{Name: "api_ip", Routes: apiRoutes, Key: web.RateLimitByIP, Limit: application.RateLimit(cfg.APIPerIP)})
+	afterAuth = web.NewRateLimitPolicy(limiter,
+		web.RateLimitRule{Name: "api_user", Routes: apiRoutes, Key: web.RateLimitByUser, Limit: application.RateLimit(cfg.APIPerUser)})
+	return preAuth, afterAuth
+}
+
+// newDemoMenuCatalog 创建内置演示餐品和套餐的商家菜单（接入商家菜单服务后替换）
+func newDemoMenuCatalog() *persistence.InMemoryMenuCatalog {
+	catalog := persistence.NewInMemoryMenuCatalog()
+	catalog.SaveDish("merchant_001", &domain.Dish{
+		DishID: "dish_101",
+		Name:   "牛肉面",
+		Price:  decimal.NewFromInt(22),
+		OptionGroups: []domain.OptionGroup{
+			{GroupID: "size", Name: "份量", Required: true, MaxSelect: 1, Options: []domain.DishOption{
+				{OptionID: "regular", Name: "标准份"},
+				{OptionID: "large", Name: "大份", PriceDelta: decimal.NewFromInt(4)},
+			}},
+			{GroupID: "spice", Name: "辣度", MaxSelect: 1, Options: []domain.DishOption{
+				{OptionID: "none", Name: "不辣"},
+				{OptionID: "mild", Name: "微辣"},
+				{OptionID: "hot", Name: "特辣"},
+			}},
+			{GroupID: "addons", Name: "加料", MaxSelect: 3, Options: []domain.DishOption{
+				{OptionID: "egg", Name: "卤蛋", PriceDelta: decimal.NewFromInt(2)},
+				{OptionID: "beef", Name: "加牛肉", PriceDelta: decimal.NewFromInt(8)},
+				{OptionID: "noodles", Name: "加面", PriceDelta: decimal.NewFromInt(3)},
+			}},
+		},
+	})
+	catalog.SaveCombo("merchant_001", &domain.Combo{
+		ComboID: "combo_201",
+		Name:    "牛肉面套餐",
+		Price:   decimal.NewFromInt(30),
+		Slots: []domain.ComboSlot{
+			{SlotID: "main", Name: "主食", Quantity: 1, Choices: []domain.ComboChoice{
+				{DishID: "dish_101", DishName: "牛肉面（标准份）", ListPrice: decimal.NewFromInt(22)},
+			}},
+			{SlotID: "side", Name: "小菜", Quantity: 1, Choices: []domain.ComboChoice{
+				{DishID: "dish_102", DishName: "拍黄瓜", ListPrice: decimal.NewFromInt(8)},
+				{DishID: "dish_103", DishName: "卤蛋", ListPrice: decimal.NewFromInt(2)},
+			}},
+			{SlotID: "drink", Name: "饮料", Quantity: 1, Choices: []domain.ComboChoice{
+				{DishID: "dish_104", DishName: "酸梅汤", ListPrice: decimal.NewFromInt(6)},
+				{DishID: "dish_105", DishName: "鲜榨橙汁", ListPrice: decimal.NewFromInt(12), Surcharge: decimal.NewFromInt(5)},
+			}},
+		},
+	})
+	return catalog
+}
+
+// startWorker 在后台运行任务，返回的 stop 取消任务并等待其退出（最多等到 ctx 结束）
+func startWorker(run func(ctx context.Context)) (stop func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(ctx)
+	}()
+
+	return func(waitCtx context.Context) {
+		cancel()
+		select {
+		case <-done:
+		case <-waitCtx.Done():
+			slog.Warn("background worker did not stop before shutdown deadline")
+		}
+	}
+}
+
+// stopGRPCServer 等待处理中的 RPC 完成，超过 ctx 期限时强制关闭
+func stopGRPCServer(ctx context.Context, server *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
 
-	// 7. 启动服务器
-	log.Println("Starting server on :8080")
-	if err := e.Start(":8080"); err != nil {
-		log.Fatal("Failed to start server:", err)
+	select {
+	case <-done:
+	case <-ctx.Done():
+		slog.Warn("gRPC server did not stop before shutdown deadline, forcing close")
+		server.Stop()
 	}
 }
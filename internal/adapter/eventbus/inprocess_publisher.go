@@ -0,0 +1,78 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"order-service/internal/domain"
+)
+
+// Handler 领域事件处理函数
+type Handler func(ctx context.Context, event domain.Event) error
+
+// InProcessPublisher 进程内同步事件发布器
+// 按订阅顺序同步调用订阅者，单个订阅者失败不影响其他订阅者
+type InProcessPublisher struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler // 按事件类型索引
+	all      []Handler            // 订阅全部事件的处理函数
+}
+
+// NewInProcessPublisher 创建进程内事件发布器
+func NewInProcessPublisher() *InProcessPublisher {
+	return &InProcessPublisher{
+		handlers: make(map[string][]Handler),
+	}
+}
+
+// Subscribe 订阅指定类型的事件
+func (p *InProcessPublisher) Subscribe(eventType string, handler Handler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.handlers[eventType] = append(p.handlers[eventType], handler)
+}
+
+// SubscribeAll 订阅全部事件
+func (p *InProcessPublisher) SubscribeAll(handler Handler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.all = append(p.all, handler)
+}
+
+// Publish 依次将事件分发给订阅者，返回所有订阅者错误的合并结果
+func (p *InProcessPublisher) Publish(ctx context.Context, events ...domain.Event) error {
+	var errs []error
+	for _, event := range events {
+		for _, handler := range p.subscribers(event.EventType()) {
+			if err := dispatch(ctx, handler, event); err != nil {
+				errs = append(errs, fmt.Errorf("handle %s event %s: %w", event.EventType(), event.EventID(), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// subscribers 返回某事件类型的订阅者快照
+func (p *InProcessPublisher) subscribers(eventType string) []Handler {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	result := make([]Handler, 0, len(p.handlers[eventType])+len(p.all))
+	result = append(result, p.handlers[eventType]...)
+	result = append(result, p.all...)
+	return result
+}
+
+// dispatch 调用订阅者并将 panic 转换为错误
+func dispatch(ctx context.Context, handler Handler, event domain.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panic: %v", r)
+		}
+	}()
+	return handler(ctx, event)
+}
@@ -0,0 +1,74 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"order-service/internal/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOrder() *domain.Order {
+	return domain.NewOrder(1001, "merchant_001", []domain.OrderItem{
+		{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: decimal.NewFromFloat(28.00)},
+	}, domain.DeliveryInfo{}, "")
+}
+
+func TestInProcessPublisher_Publish_ByType(t *testing.T) {
+	// Arrange
+	publisher := NewInProcessPublisher()
+	var created, paid, all []string
+	publisher.Subscribe(domain.EventTypeOrderCreated, func(ctx context.Context, event domain.Event) error {
+		created = append(created, event.AggregateID())
+		return nil
+	})
+	publisher.Subscribe(domain.EventTypeOrderPaid, func(ctx context.Context, event domain.Event) error {
+		paid = append(paid, event.AggregateID())
+		return nil
+	})
+	publisher.SubscribeAll(func(ctx context.Context, event domain.Event) error {
+		all = append(all, event.EventType())
+		return nil
+	})
+
+	order := newTestOrder()
+	require.NoError(t, order.MarkPaid("pay_001"))
+
+	// Act
+	err := publisher.Publish(context.Background(), order.PullEvents()...)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{order.OrderNumber}, created)
+	assert.Equal(t, []string{order.OrderNumber}, paid)
+	assert.Equal(t, []string{domain.EventTypeOrderCreated, domain.EventTypeOrderPaid}, all)
+}
+
+func TestInProcessPublisher_Publish_HandlerFailureDoesNotStopOthers(t *testing.T) {
+	// Arrange
+	publisher := NewInProcessPublisher()
+	calls := 0
+	publisher.SubscribeAll(func(ctx context.Context, event domain.Event) error {
+		return fmt.Errorf("boom")
+	})
+	publisher.SubscribeAll(func(ctx context.Context, event domain.Event) error {
+		panic("unexpected")
+	})
+	publisher.SubscribeAll(func(ctx context.Context, event domain.Event) error {
+		calls++
+		return nil
+	})
+
+	// Act
+	err := publisher.Publish(context.Background(), newTestOrder().PullEvents()...)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+	assert.Contains(t, err.Error(), "handler panic")
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,79 @@
+package graphql
+
+import (
+	"context"
+
+	"order-service/internal/application"
+	"order-service/internal/logging"
+)
+
+// GraphQL 错误码（extensions.code）
+const (
+	CodeBadUserInput          = "BAD_USER_INPUT"
+	CodeBusinessRuleViolation = "BUSINESS_RULE_VIOLATION"
+	CodeNotFound              = "NOT_FOUND"
+	CodeUnauthenticated       = "UNAUTHENTICATED"
+	CodeQueryTooComplex       = "QUERY_TOO_COMPLEX"
+	CodeRateLimited           = "RATE_LIMITED"
+	CodeInternal              = "INTERNAL_SERVER_ERROR"
+)
+
+// Error 携带 extensions 的 GraphQL 错误
+type Error struct {
+	Code       string
+	Message    string
+	Field      string // 验证失败的字段（仅 BAD_USER_INPUT）
+	ErrorCode  string // 业务错误码（仅 BUSINESS_RULE_VIOLATION）
+	RetryAfter int    // 需等待的秒数（仅 RATE_LIMITED）
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions 实现 gqlerrors.ExtendedError，错误详情输出到 extensions
+func (e *Error) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.Code}
+	if e.Field != "" {
+		extensions["field"] = e.Field
+	}
+	if e.ErrorCode != "" {
+		extensions["errorCode"] = e.ErrorCode
+	}
+	if e.RetryAfter > 0 {
+		extensions["retryAfter"] = e.RetryAfter
+	}
+	return extensions
+}
+
+// toGraphQLError 将应用层错误映射为 GraphQL 错误
+//   - ValidationError -> BAD_USER_INPUT（附带字段名）
+//   - BusinessError   -> BUSINESS_RULE_VIOLATION（附带业务错误码）
+//   - NotFoundError   -> NOT_FOUND
+//   - RateLimitError  -> RATE_LIMITED（附带需等待的秒数）
+//   - 其他错误        -> INTERNAL_SERVER_ERROR（不暴露内部细节）
+func toGraphQLError(ctx context.Context, err error) error {
+	switch e := err.(type) {
+	case *application.ValidationError:
+		return &Error{Code: CodeBadUserInput, Message: e.Message, Field: e.Field}
+	case *application.BusinessError:
+		return &Error{Code: CodeBusinessRuleViolation, Message: e.Message, ErrorCode: e.Code}
+	case *application.NotFoundError:
+		return &Error{Code: CodeNotFound, Message: e.Message}
+	case *application.RateLimitError:
+		return &Error{Code: CodeRateLimited, Message: e.Message, RetryAfter: e.RetryAfterSeconds()}
+	default:
+		// 记录详细错误日志（响应中不暴露内部细节）
+		logging.FromContext(ctx).Error("internal error", logging.KeyError, err)
+		return &Error{Code: CodeInternal, Message: "internal server error"}
+	}
+}
+
+// requireUserID 获取认证后的用户ID，未认证时返回 UNAUTHENTICATED
+func requireUserID(ctx context.Context) (uint64, error) {
+	userID, ok := userIDFromContext(ctx)
+	if !ok {
+		return 0, &Error{Code: CodeUnauthenticated, Message: "user not authenticated"}
+	}
+	return userID, nil
+}
@@ -0,0 +1,102 @@
+package graphql
+
+import (
+	"net/http"
+
+	"order-service/internal/adapter/web"
+	"order-service/internal/application"
+
+	graphqlgo "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/labstack/echo/v4"
+)
+
+// Request GraphQL 请求体
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Handler GraphQL HTTP 处理器
+type Handler struct {
+	schema        graphqlgo.Schema
+	maxDepth      int
+	maxComplexity int
+}
+
+// HandlerOption GraphQL 处理器可选配置
+type HandlerOption func(*Handler)
+
+// WithMaxDepth 配置查询最大深度（0 表示不限制）
+func WithMaxDepth(depth int) HandlerOption {
+	return func(h *Handler) {
+		h.maxDepth = depth
+	}
+}
+
+// WithMaxComplexity 配置查询最大复杂度（0 表示不限制）
+func WithMaxComplexity(complexity int) HandlerOption {
+	return func(h *Handler) {
+		h.maxComplexity = complexity
+	}
+}
+
+// NewHandler 创建 GraphQL 处理器
+func NewHandler(orderService application.OrderService, opts ...HandlerOption) (*Handler, error) {
+	schema, err := NewSchema(orderService)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Handler{
+		schema:        schema,
+		maxDepth:      DefaultMaxDepth,
+		maxComplexity: DefaultMaxComplexity,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h, nil
+}
+
+// Serve GraphQL 请求处理器（需经过 web.AuthMiddleware）
+// 执行前先校验查询深度和复杂度；业务错误通过 errors[].extensions 返回，HTTP 状态码为 200
+func (h *Handler) Serve(c echo.Context) error {
+	userID, ok := c.Get(web.UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, web.ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	var req Request
+	if err := c.Bind(&req); err != nil || req.Query == "" {
+		return c.JSON(http.StatusBadRequest, web.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid graphql request",
+		})
+	}
+
+	// 语法错误交给执行阶段统一报告
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query)})})
+	if err == nil {
+		if err := checkLimits(analyzeQuery(doc, req.OperationName, req.Variables, h.maxDepth, h.maxComplexity), h.maxDepth, h.maxComplexity); err != nil {
+			return c.JSON(http.StatusOK, &graphqlgo.Result{
+				Errors: gqlerrors.FormatErrors(gqlerrors.NewError(err.Error(), nil, "", nil, nil, err)),
+			})
+		}
+	}
+
+	result := graphqlgo.Do(graphqlgo.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        withUserID(c.Request().Context(), userID),
+	})
+	return c.JSON(http.StatusOK, result)
+}
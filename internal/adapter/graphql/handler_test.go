@@ -0,0 +1,272 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"order-service/internal/adapter/persistence"
+	"order-service/internal/adapter/ratelimit"
+	"order-service/internal/adapter/web"
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// graphQLResponse GraphQL 响应（测试用）
+type graphQLResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// newTestServer 创建挂载 GraphQL 处理器的 Echo 实例（请求体大小限制与服务相同）
+func newTestServer(t *testing.T, opts ...HandlerOption) *echo.Echo {
+	handler, err := NewHandler(application.NewOrderService(persistence.NewInMemoryOrderRepository()), opts...)
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.POST("/api/v1/graphql", handler.Serve,
+		middleware.BodyLimit(strconv.FormatInt(web.DefaultMaxBodySize, 10)), web.AuthMiddleware)
+	return e
+}
+
+// execute 以指定用户身份执行 GraphQL 请求
+func execute(t *testing.T, e *echo.Echo, userID uint64, query string, variables map[string]interface{}) (int, graphQLResponse) {
+	token, err := web.GenerateToken(userID)
+	require.NoError(t, err)
+
+	body, _ := json.Marshal(Request{Query: query, Variables: variables})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	var resp graphQLResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return rec.Code, resp
+}
+
+const createOrderMutation = `mutation Create($input: CreateOrderInput!) {
+  createOrder(input: $input) {
+    orderNumber
+    status
+    items { dishId quantity price unitPrice options { groupId optionName priceDelta } components { slotId allocatedPrice } }
+    pricing { itemsTotal finalAmount }
+    deliveryInfo { recipientName address location { latitude longitude } }
+    remark
+  }
+}`
+
+// validInput 创建合法的下单输入
+func validInput(phone string) map[string]interface{} {
+	return map[string]interface{}{
+		"input": map[string]interface{}{
+			"merchantId": "merchant_001",
+			"items": []interface{}{
+				map[string]interface{}{"dishId": "dish_001", "dishName": "宫保鸡丁", "quantity": 2, "price": 28.00},
+			},
+			"deliveryInfo": map[string]interface{}{
+				"recipientName":  "张三",
+				"recipientPhone": phone,
+				"address":        "北京市朝阳区xxx",
+			},
+			"remark": "少辣",
+		},
+	}
+}
+
+func TestHandler_CreateOrderAndQuery(t *testing.T) {
+	// Arrange
+	e := newTestServer(t)
+
+	// Act
+	code, created := execute(t, e, 1001, createOrderMutation, validInput("13800138000"))
+	require.Empty(t, created.Errors)
+	order := created.Data["createOrder"].(map[string]interface{})
+	_, found := execute(t, e, 1001, `query($n: String!) { order(orderNumber: $n) { orderNumber status pricing { finalAmount } } }`,
+		map[string]interface{}{"n": order["orderNumber"]})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "PENDING_PAYMENT", order["status"])
+	assert.Equal(t, "少辣", order["remark"])
+	assert.Equal(t, "28.00", order["items"].([]interface{})[0].(map[string]interface{})["price"])
+	assert.Equal(t, "张三", order["deliveryInfo"].(map[string]interface{})["recipientName"])
+	require.Empty(t, found.Errors)
+	assert.Equal(t, order["pricing"].(map[string]interface{})["finalAmount"],
+		found.Data["order"].(map[string]interface{})["pricing"].(map[string]interface{})["finalAmount"])
+}
+
+func TestHandler_CreateOrderWithLocation(t *testing.T) {
+	// Arrange
+	e := newTestServer(t)
+	input := validInput("13800138000")
+	delivery := input["input"].(map[string]interface{})["deliveryInfo"].(map[string]interface{})
+	delivery["location"] = map[string]interface{}{"latitude": 39.905, "longitude": 116.40}
+
+	// Act
+	_, created := execute(t, e, 1001, createOrderMutation, input)
+	_, plain := execute(t, e, 1001, createOrderMutation, validInput("13800138000"))
+
+	// Assert - 未提供收货坐标时 location 为 null
+	require.Empty(t, created.Errors)
+	require.Empty(t, plain.Errors)
+	order := created.Data["createOrder"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"latitude": 39.905, "longitude": 116.40},
+		order["deliveryInfo"].(map[string]interface{})["location"])
+	assert.Nil(t, plain.Data["createOrder"].(map[string]interface{})["deliveryInfo"].(map[string]interface{})["location"])
+}
+
+func TestHandler_OrdersConnection(t *testing.T) {
+	// Arrange
+	e := newTestServer(t)
+	for i := 0; i < 3; i++ {
+		_, resp := execute(t, e, 1001, createOrderMutation, validInput("13800138000"))
+		require.Empty(t, resp.Errors)
+	}
+	query := `query($after: String) {
+  orders(first: 2, after: $after) {
+    edges { cursor node { orderNumber } }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+	// Act
+	_, first := execute(t, e, 1001, query, nil)
+	firstPage := first.Data["orders"].(map[string]interface{})
+	endCursor := firstPage["pageInfo"].(map[string]interface{})["endCursor"]
+	_, second := execute(t, e, 1001, query, map[string]interface{}{"after": endCursor})
+	secondPage := second.Data["orders"].(map[string]interface{})
+
+	// Assert
+	assert.Len(t, firstPage["edges"], 2)
+	assert.Equal(t, true, firstPage["pageInfo"].(map[string]interface{})["hasNextPage"])
+	assert.Len(t, secondPage["edges"], 1)
+	assert.Equal(t, false, secondPage["pageInfo"].(map[string]interface{})["hasNextPage"])
+}
+
+func TestHandler_ValidationErrorExtensions(t *testing.T) {
+	// Arrange
+	e := newTestServer(t)
+
+	// Act
+	_, resp := execute(t, e, 1001, createOrderMutation, validInput("12345"))
+
+	// Assert
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, CodeBadUserInput, resp.Errors[0].Extensions["code"])
+	assert.Equal(t, "RecipientPhone", resp.Errors[0].Extensions["field"])
+}
+
+func TestHandler_CreateOrderRateLimited(t *testing.T) {
+	// Arrange - 应用层下单限流：每个用户 1 单
+	orders := application.NewRateLimitedOrderService(application.NewOrderService(persistence.NewInMemoryOrderRepository()),
+		ratelimit.NewInMemoryRateLimiter(), application.RateLimit{Rate: 1, Period: time.Minute, Burst: 1}, application.RateLimit{})
+	handler, err := NewHandler(orders)
+	require.NoError(t, err)
+	e := echo.New()
+	e.POST("/api/v1/graphql", handler.Serve, web.AuthMiddleware)
+
+	// Act
+	_, first := execute(t, e, 1001, createOrderMutation, validInput("13800138000"))
+	_, limited := execute(t, e, 1001, createOrderMutation, validInput("13800138000"))
+
+	// Assert - GraphQL 下单同样受下单限额约束
+	assert.Empty(t, first.Errors)
+	require.Len(t, limited.Errors, 1)
+	assert.Equal(t, CodeRateLimited, limited.Errors[0].Extensions["code"])
+	assert.Equal(t, float64(60), limited.Errors[0].Extensions["retryAfter"])
+}
+
+func TestHandler_OrderNotFoundForOtherUser(t *testing.T) {
+	// Arrange
+	e := newTestServer(t)
+	_, created := execute(t, e, 1001, createOrderMutation, validInput("13800138000"))
+	orderNumber := created.Data["createOrder"].(map[string]interface{})["orderNumber"]
+
+	// Act
+	_, resp := execute(t, e, 2002, `query($n: String!) { order(orderNumber: $n) { status } }`,
+		map[string]interface{}{"n": orderNumber})
+
+	// Assert
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, CodeNotFound, resp.Errors[0].Extensions["code"])
+}
+
+func TestHandler_RejectsTooDeepQuery(t *testing.T) {
+	// Arrange
+	e := newTestServer(t, WithMaxDepth(3))
+
+	// Act
+	_, resp := execute(t, e, 1001, `{ orders { edges { node { items { price } } } } }`, nil)
+
+	// Assert
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, CodeQueryTooComplex, resp.Errors[0].Extensions["code"])
+	assert.Nil(t, resp.Data)
+}
+
+func TestHandler_RejectsDeepIntrospectionQuery(t *testing.T) {
+	// Arrange
+	e := newTestServer(t)
+
+	// Act
+	_, typename := execute(t, e, 1001, `{ __typename }`, nil)
+	_, resp := execute(t, e, 1001, `{ __schema { types { fields { type { ofType { ofType { ofType { name } } } } } } } }`, nil)
+
+	// Assert - 内省查询同样受深度限制
+	assert.Empty(t, typename.Errors)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, CodeQueryTooComplex, resp.Errors[0].Extensions["code"])
+}
+
+func TestHandler_RejectsOversizedBody(t *testing.T) {
+	// Arrange
+	e := newTestServer(t)
+	padding := strings.Repeat(" ", int(web.DefaultMaxBodySize))
+
+	// Act
+	status, _ := execute(t, e, 1001, `{ __typename`+padding+`}`, nil)
+
+	// Assert - 超过请求体大小上限时不解析查询
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+}
+
+func TestHandler_RejectsTooComplexQuery(t *testing.T) {
+	// Arrange
+	e := newTestServer(t, WithMaxComplexity(50))
+
+	// Act
+	_, resp := execute(t, e, 1001, `query($n: Int) { orders(first: $n) { edges { node { orderNumber status } } } }`,
+		map[string]interface{}{"n": 100})
+
+	// Assert
+	require.Len(t, resp.Errors, 1)
+	assert.Contains(t, resp.Errors[0].Message, "complexity")
+}
+
+func TestHandler_RequiresAuthentication(t *testing.T) {
+	// Arrange
+	e := newTestServer(t)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", bytes.NewReader([]byte(`{"query":"{ orders { edges { cursor } } }"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	// Act
+	e.ServeHTTP(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
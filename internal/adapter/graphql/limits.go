@@ -0,0 +1,181 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+
+	"order-service/internal/application"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// 默认查询限制
+const (
+	DefaultMaxDepth      = 6
+	DefaultMaxComplexity = 1000
+)
+
+// listMultipliers 列表字段及其分页参数，子字段的复杂度按分页大小放大
+var listMultipliers = map[string]struct {
+	arg          string
+	defaultValue int
+}{
+	"orders": {arg: "first", defaultValue: application.DefaultListLimit},
+}
+
+// queryCost 查询的深度和复杂度
+type queryCost struct {
+	Depth      int
+	Complexity int
+}
+
+// costAnalyzer 在执行前静态计算查询的深度和复杂度
+// 每个片段只计算一次并缓存（片段的代价与使用位置无关）；内省字段（以 __ 开头）与普通字段同样计入；
+// 深度或复杂度一旦超过限制即停止计算，返回的代价保证仍超过限制
+type costAnalyzer struct {
+	fragments     map[string]*ast.FragmentDefinition
+	costs         map[string]queryCost
+	variables     map[string]interface{}
+	visiting      map[string]bool
+	maxDepth      int
+	maxComplexity int
+}
+
+// analyzeQuery 计算指定操作的深度和复杂度（找不到操作时返回零值，由执行阶段报告错误）
+// maxDepth、maxComplexity 为 0 时不限制，否则超过任一限制后提前结束计算
+func analyzeQuery(doc *ast.Document, operationName string, variables map[string]interface{}, maxDepth, maxComplexity int) queryCost {
+	a := &costAnalyzer{
+		fragments:     make(map[string]*ast.FragmentDefinition),
+		costs:         make(map[string]queryCost),
+		variables:     variables,
+		visiting:      make(map[string]bool),
+		maxDepth:      maxDepth,
+		maxComplexity: maxComplexity,
+	}
+
+	var operation *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch d := definition.(type) {
+		case *ast.FragmentDefinition:
+			a.fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				if operation == nil {
+					operation = d
+				}
+			}
+		}
+	}
+	if operation == nil {
+		return queryCost{}
+	}
+	return a.selectionSet(operation.SelectionSet)
+}
+
+// exceeded 代价是否已超过深度或复杂度限制
+func (a *costAnalyzer) exceeded(cost queryCost) bool {
+	return (a.maxDepth > 0 && cost.Depth > a.maxDepth) ||
+		(a.maxComplexity > 0 && cost.Complexity > a.maxComplexity)
+}
+
+// selectionSet 计算选择集的深度（最深字段）和复杂度（所有字段之和）
+func (a *costAnalyzer) selectionSet(set *ast.SelectionSet) queryCost {
+	var cost queryCost
+	if set == nil {
+		return cost
+	}
+
+	for _, selection := range set.Selections {
+		var child queryCost
+		switch s := selection.(type) {
+		case *ast.Field:
+			child = a.field(s)
+		case *ast.InlineFragment:
+			child = a.selectionSet(s.SelectionSet)
+		case *ast.FragmentSpread:
+			child = a.fragment(s.Name.Value)
+		}
+		cost.Depth = max(cost.Depth, child.Depth)
+		cost.Complexity += child.Complexity
+		if a.exceeded(cost) {
+			return cost
+		}
+	}
+	return cost
+}
+
+// fragment 计算片段的代价（缓存结果，重复展开不再重新计算）
+func (a *costAnalyzer) fragment(name string) queryCost {
+	if cost, ok := a.costs[name]; ok {
+		return cost
+	}
+	fragment, ok := a.fragments[name]
+	// 循环引用由执行阶段的校验报告
+	if !ok || a.visiting[name] {
+		return queryCost{}
+	}
+
+	a.visiting[name] = true
+	cost := a.selectionSet(fragment.SelectionSet)
+	delete(a.visiting, name)
+	a.costs[name] = cost
+	return cost
+}
+
+// field 计算单个字段：自身深度 1、复杂度 1，列表字段的子字段复杂度乘以分页大小
+func (a *costAnalyzer) field(field *ast.Field) queryCost {
+	children := a.selectionSet(field.SelectionSet)
+	if multiplier, ok := listMultipliers[field.Name.Value]; ok && !a.exceeded(children) {
+		n := a.intArgument(field, multiplier.arg, multiplier.defaultValue)
+		if a.maxComplexity > 0 {
+			// 超过限制即可判定，避免超大分页参数导致乘法溢出
+			n = min(n, a.maxComplexity+1)
+		}
+		children.Complexity *= n
+	}
+	return queryCost{
+		Depth:      children.Depth + 1,
+		Complexity: children.Complexity + 1,
+	}
+}
+
+// intArgument 读取整数参数（支持字面量和变量），无法解析时返回默认值
+func (a *costAnalyzer) intArgument(field *ast.Field, name string, defaultValue int) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != name {
+			continue
+		}
+		switch v := argument.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			switch n := a.variables[v.Name.Value].(type) {
+			case int:
+				return max(n, 1)
+			case float64:
+				// JSON 解码后的数字为 float64
+				return max(int(n), 1)
+			}
+		}
+	}
+	return defaultValue
+}
+
+// checkLimits 校验查询是否超过深度和复杂度限制
+func checkLimits(cost queryCost, maxDepth, maxComplexity int) error {
+	if maxDepth > 0 && cost.Depth > maxDepth {
+		return &Error{
+			Code:    CodeQueryTooComplex,
+			Message: fmt.Sprintf("query depth %d exceeds limit %d", cost.Depth, maxDepth),
+		}
+	}
+	if maxComplexity > 0 && cost.Complexity > maxComplexity {
+		return &Error{
+			Code:    CodeQueryTooComplex,
+			Message: fmt.Sprintf("query complexity %d exceeds limit %d", cost.Complexity, maxComplexity),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// analyze 解析并计算查询代价（不限制深度和复杂度）
+func analyze(t *testing.T, query string, variables map[string]interface{}) queryCost {
+	return analyzeWithLimits(t, query, variables, 0, 0)
+}
+
+// analyzeWithLimits 解析并按限制计算查询代价
+func analyzeWithLimits(t *testing.T, query string, variables map[string]interface{}, maxDepth, maxComplexity int) queryCost {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	require.NoError(t, err)
+	return analyzeQuery(doc, "", variables, maxDepth, maxComplexity)
+}
+
+func TestAnalyzeQuery_DepthAndComplexity(t *testing.T) {
+	// Act
+	cost := analyze(t, `{ order(orderNumber: "1") { status pricing { finalAmount } } }`, nil)
+
+	// Assert - order(1) + status(1) + pricing(1) + finalAmount(1)
+	assert.Equal(t, 3, cost.Depth)
+	assert.Equal(t, 4, cost.Complexity)
+}
+
+func TestAnalyzeQuery_ListMultiplier(t *testing.T) {
+	// Act
+	literal := analyze(t, `{ orders(first: 5) { edges { cursor } } }`, nil)
+	variable := analyze(t, `query($n: Int) { orders(first: $n) { edges { cursor } } }`, map[string]interface{}{"n": float64(10)})
+	defaulted := analyze(t, `{ orders { edges { cursor } } }`, nil)
+
+	// Assert - 子字段 edges + cursor 的复杂度 2 乘以分页大小
+	assert.Equal(t, 1+2*5, literal.Complexity)
+	assert.Equal(t, 1+2*10, variable.Complexity)
+	assert.Equal(t, 1+2*20, defaulted.Complexity)
+}
+
+func TestAnalyzeQuery_ExpandsFragmentsAndCountsIntrospection(t *testing.T) {
+	// Act
+	cost := analyze(t, `
+query { __schema { types { name } } order(orderNumber: "1") { ...fields } }
+fragment fields on Order { status deliveryInfo { address } }`, nil)
+	introspection := analyze(t, `{ __schema { types { fields { type { ofType { ofType { ofType { name } } } } } } } }`, nil)
+
+	// Assert - 内省字段与普通字段同样计入
+	assert.Equal(t, 3, cost.Depth)
+	assert.Equal(t, 3+4, cost.Complexity)
+	assert.Equal(t, 8, introspection.Depth)
+	assert.Equal(t, 8, introspection.Complexity)
+}
+
+func TestAnalyzeQuery_RepeatedFragmentSpreads(t *testing.T) {
+	// Arrange - F1..F28 每个片段展开前一个片段两次，完全展开后复杂度约为 2^28
+	var query strings.Builder
+	query.WriteString("query { order(orderNumber: \"1\") { ...F28 } }\nfragment F0 on Order { status }\n")
+	for i := 1; i <= 28; i++ {
+		fmt.Fprintf(&query, "fragment F%d on Order { ...F%d ...F%d }\n", i, i-1, i-1)
+	}
+
+	// Act
+	start := time.Now()
+	unlimited := analyze(t, query.String(), nil)
+	limited := analyzeWithLimits(t, query.String(), nil, DefaultMaxDepth, DefaultMaxComplexity)
+
+	// Assert - 片段代价只计算一次，超过限制后提前结束
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 1+1<<28, unlimited.Complexity)
+	assert.Greater(t, limited.Complexity, DefaultMaxComplexity)
+	assert.Error(t, checkLimits(limited, DefaultMaxDepth, DefaultMaxComplexity))
+}
+
+func TestAnalyzeQuery_StopsAtLimit(t *testing.T) {
+	// Act - 超大分页参数不会导致复杂度溢出
+	cost := analyzeWithLimits(t, `{ orders(first: 9223372036854775807) { edges { cursor } } a: orders { edges { cursor } } }`, nil, 6, 100)
+
+	// Assert
+	assert.Greater(t, cost.Complexity, 100)
+	assert.Error(t, checkLimits(cost, 6, 100))
+}
+
+func TestCheckLimits(t *testing.T) {
+	assert.NoError(t, checkLimits(queryCost{Depth: 6, Complexity: 100}, 6, 100))
+	assert.Error(t, checkLimits(queryCost{Depth: 7, Complexity: 1}, 6, 100))
+	assert.Error(t, checkLimits(queryCost{Depth: 1, Complexity: 101}, 6, 100))
+	assert.NoError(t, checkLimits(queryCost{Depth: 100, Complexity: 10000}, 0, 0))
+}
@@ -0,0 +1,386 @@
+package graphql
+
+import (
+	"context"
+
+	"order-service/internal/application"
+
+	graphqlgo "github.com/graphql-go/graphql"
+)
+
+// userIDKey 用户ID在 context 中的键
+type userIDKey struct{}
+
+// withUserID 将认证后的用户ID放入 context
+func withUserID(ctx context.Context, userID uint64) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// userIDFromContext 获取认证后的用户ID
+func userIDFromContext(ctx context.Context) (uint64, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(uint64)
+	return userID, ok
+}
+
+// orderEdge 订单连接中的边
+type orderEdge struct {
+	Cursor string
+	Node   *application.OrderData
+}
+
+// pageInfo 分页信息
+type pageInfo struct {
+	HasNextPage bool
+	EndCursor   *string
+}
+
+// orderConnection 订单连接（Relay cursor connection）
+type orderConnection struct {
+	Edges    []orderEdge
+	PageInfo pageInfo
+}
+
+var orderStatusEnum = graphqlgo.NewEnum(graphqlgo.EnumConfig{
+	Name:        "OrderStatus",
+	Description: "订单状态",
+	Values: graphqlgo.EnumValueConfigMap{
+		"PENDING_PAYMENT": &graphqlgo.EnumValueConfig{Value: "PENDING_PAYMENT"},
+		"PAID":            &graphqlgo.EnumValueConfig{Value: "PAID"},
+		"ACCEPTED":        &graphqlgo.EnumValueConfig{Value: "ACCEPTED"},
+		"REJECTED":        &graphqlgo.EnumValueConfig{Value: "REJECTED"},
+		"CANCELLED":       &graphqlgo.EnumValueConfig{Value: "CANCELLED"},
+		"REFUNDED":        &graphqlgo.EnumValueConfig{Value: "REFUNDED"},
+	},
+})
+
+var orderItemOptionType = graphqlgo.NewObject(graphqlgo.ObjectConfig{
+	Name:        "OrderItemOption",
+	Description: "订单项已选规格",
+	Fields: graphqlgo.Fields{
+		"groupId":    &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"groupName":  &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"optionId":   &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"optionName": &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"priceDelta": &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+	},
+})
+
+var orderItemComponentType = graphqlgo.NewObject(graphqlgo.ObjectConfig{
+	Name:        "OrderItemComponent",
+	Description: "套餐组件（allocatedPrice 为每份套餐分摊到该组件的金额）",
+	Fields: graphqlgo.Fields{
+		"slotId":         &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"slotName":       &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"dishId":         &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"dishName":       &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"quantity":       &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.Int)},
+		"surcharge":      &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"allocatedPrice": &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+	},
+})
+
+var orderItemType = graphqlgo.NewObject(graphqlgo.ObjectConfig{
+	Name:        "OrderItem",
+	Description: "订单项（金额为两位小数的字符串，unitPrice 含规格加价）",
+	Fields: graphqlgo.Fields{
+		"dishId":     &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"dishName":   &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"quantity":   &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.Int)},
+		"price":      &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"unitPrice":  &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"subtotal":   &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"options":    &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.NewList(graphqlgo.NewNonNull(orderItemOptionType)))},
+		"components": &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.NewList(graphqlgo.NewNonNull(orderItemComponentType)))},
+	},
+})
+
+var pricingType = graphqlgo.NewObject(graphqlgo.ObjectConfig{
+	Name:        "Pricing",
+	Description: "价格信息（金额为两位小数的字符串）",
+	Fields: graphqlgo.Fields{
+		"itemsTotal":   &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"packagingFee": &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"deliveryFee":  &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"finalAmount":  &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+	},
+})
+
+var geoPointType = graphqlgo.NewObject(graphqlgo.ObjectConfig{
+	Name:        "GeoPoint",
+	Description: "地理坐标（WGS-84 经纬度）",
+	Fields: graphqlgo.Fields{
+		"latitude":  &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.Float)},
+		"longitude": &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.Float)},
+	},
+})
+
+var deliveryInfoType = graphqlgo.NewObject(graphqlgo.ObjectConfig{
+	Name:        "DeliveryInfo",
+	Description: "配送信息（未提供收货坐标时 location 为 null）",
+	Fields: graphqlgo.Fields{
+		"recipientName":  &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"recipientPhone": &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"address":        &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"location":       &graphqlgo.Field{Type: geoPointType},
+	},
+})
+
+var orderType = graphqlgo.NewObject(graphqlgo.ObjectConfig{
+	Name:        "Order",
+	Description: "订单",
+	Fields: graphqlgo.Fields{
+		"orderNumber":  &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"merchantId":   &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"status":       &graphqlgo.Field{Type: graphqlgo.NewNonNull(orderStatusEnum)},
+		"items":        &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.NewList(graphqlgo.NewNonNull(orderItemType)))},
+		"pricing":      &graphqlgo.Field{Type: graphqlgo.NewNonNull(pricingType)},
+		"deliveryInfo": &graphqlgo.Field{Type: graphqlgo.NewNonNull(deliveryInfoType)},
+		"remark":       &graphqlgo.Field{Type: graphqlgo.String},
+		"createdAt":    &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"updatedAt":    &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+	},
+})
+
+var orderEdgeType = graphqlgo.NewObject(graphqlgo.ObjectConfig{
+	Name: "OrderEdge",
+	Fields: graphqlgo.Fields{
+		"cursor": &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"node":   &graphqlgo.Field{Type: graphqlgo.NewNonNull(orderType)},
+	},
+})
+
+var pageInfoType = graphqlgo.NewObject(graphqlgo.ObjectConfig{
+	Name: "PageInfo",
+	Fields: graphqlgo.Fields{
+		"hasNextPage": &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.Boolean)},
+		"endCursor":   &graphqlgo.Field{Type: graphqlgo.String},
+	},
+})
+
+var orderConnectionType = graphqlgo.NewObject(graphqlgo.ObjectConfig{
+	Name:        "OrderConnection",
+	Description: "订单连接（按创建时间倒序）",
+	Fields: graphqlgo.Fields{
+		"edges":    &graphqlgo.Field{Type: graphqlgo.NewNonNull(graphqlgo.NewList(graphqlgo.NewNonNull(orderEdgeType)))},
+		"pageInfo": &graphqlgo.Field{Type: graphqlgo.NewNonNull(pageInfoType)},
+	},
+})
+
+var orderItemOptionInputType = graphqlgo.NewInputObject(graphqlgo.InputObjectConfig{
+	Name: "OrderItemOptionInput",
+	Fields: graphqlgo.InputObjectConfigFieldMap{
+		"groupId":  &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"optionId": &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+	},
+})
+
+var comboComponentInputType = graphqlgo.NewInputObject(graphqlgo.InputObjectConfig{
+	Name: "ComboComponentInput",
+	Fields: graphqlgo.InputObjectConfigFieldMap{
+		"slotId": &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"dishId": &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+	},
+})
+
+var orderItemInputType = graphqlgo.NewInputObject(graphqlgo.InputObjectConfig{
+	Name: "OrderItemInput",
+	Fields: graphqlgo.InputObjectConfigFieldMap{
+		"dishId":     &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"dishName":   &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"quantity":   &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.Int)},
+		"price":      &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.Float)},
+		"options":    &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewList(graphqlgo.NewNonNull(orderItemOptionInputType))},
+		"components": &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewList(graphqlgo.NewNonNull(comboComponentInputType))},
+	},
+})
+
+var geoPointInputType = graphqlgo.NewInputObject(graphqlgo.InputObjectConfig{
+	Name: "GeoPointInput",
+	Fields: graphqlgo.InputObjectConfigFieldMap{
+		"latitude":  &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.Float)},
+		"longitude": &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.Float)},
+	},
+})
+
+var deliveryInfoInputType = graphqlgo.NewInputObject(graphqlgo.InputObjectConfig{
+	Name: "DeliveryInfoInput",
+	Fields: graphqlgo.InputObjectConfigFieldMap{
+		"recipientName":  &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"recipientPhone": &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"address":        &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"location":       &graphqlgo.InputObjectFieldConfig{Type: geoPointInputType},
+	},
+})
+
+var createOrderInputType = graphqlgo.NewInputObject(graphqlgo.InputObjectConfig{
+	Name: "CreateOrderInput",
+	Fields: graphqlgo.InputObjectConfigFieldMap{
+		"merchantId":   &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+		"items":        &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(graphqlgo.NewList(graphqlgo.NewNonNull(orderItemInputType)))},
+		"deliveryInfo": &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.NewNonNull(deliveryInfoInputType)},
+		"remark":       &graphqlgo.InputObjectFieldConfig{Type: graphqlgo.String},
+	},
+})
+
+// resolver 查询和变更的解析器
+type resolver struct {
+	orderService application.OrderService
+}
+
+// NewSchema 创建订单 GraphQL Schema
+func NewSchema(orderService application.OrderService) (graphqlgo.Schema, error) {
+	r := &resolver{orderService: orderService}
+
+	query := graphqlgo.NewObject(graphqlgo.ObjectConfig{
+		Name: "Query",
+		Fields: graphqlgo.Fields{
+			"order": &graphqlgo.Field{
+				Type:        orderType,
+				Description: "查询自己的订单",
+				Args: graphqlgo.FieldConfigArgument{
+					"orderNumber": &graphqlgo.ArgumentConfig{Type: graphqlgo.NewNonNull(graphqlgo.String)},
+				},
+				Resolve: r.order,
+			},
+			"orders": &graphqlgo.Field{
+				Type:        graphqlgo.NewNonNull(orderConnectionType),
+				Description: "分页查询自己的订单",
+				Args: graphqlgo.FieldConfigArgument{
+					"first":  &graphqlgo.ArgumentConfig{Type: graphqlgo.Int, DefaultValue: application.DefaultListLimit},
+					"after":  &graphqlgo.ArgumentConfig{Type: graphqlgo.String},
+					"status": &graphqlgo.ArgumentConfig{Type: orderStatusEnum},
+				},
+				Resolve: r.orders,
+			},
+		},
+	})
+
+	mutation := graphqlgo.NewObject(graphqlgo.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphqlgo.Fields{
+			"createOrder": &graphqlgo.Field{
+				Type:        graphqlgo.NewNonNull(orderType),
+				Description: "创建订单",
+				Args: graphqlgo.FieldConfigArgument{
+					"input": &graphqlgo.ArgumentConfig{Type: graphqlgo.NewNonNull(createOrderInputType)},
+				},
+				Resolve: r.createOrder,
+			},
+		},
+	})
+
+	return graphqlgo.NewSchema(graphqlgo.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// order 解析 Query.order
+func (r *resolver) order(p graphqlgo.ResolveParams) (interface{}, error) {
+	userID, err := requireUserID(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	orderData, err := r.orderService.GetOrder(p.Context, userID, p.Args["orderNumber"].(string))
+	if err != nil {
+		return nil, toGraphQLError(p.Context, err)
+	}
+	return orderData, nil
+}
+
+// orders 解析 Query.orders
+func (r *resolver) orders(p graphqlgo.ResolveParams) (interface{}, error) {
+	userID, err := requireUserID(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &application.ListOrdersRequest{}
+	if first, ok := p.Args["first"].(int); ok {
+		req.Limit = first
+	}
+	if after, ok := p.Args["after"].(string); ok {
+		req.Cursor = after
+	}
+	if status, ok := p.Args["status"].(string); ok {
+		req.Status = status
+	}
+
+	list, err := r.orderService.ListOrders(p.Context, userID, req)
+	if err != nil {
+		return nil, toGraphQLError(p.Context, err)
+	}
+
+	connection := &orderConnection{
+		Edges:    make([]orderEdge, len(list.Orders)),
+		PageInfo: pageInfo{HasNextPage: list.HasMore},
+	}
+	for i := range list.Orders {
+		connection.Edges[i] = orderEdge{Cursor: list.Orders[i].Cursor, Node: &list.Orders[i]}
+	}
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges)-1].Cursor
+	}
+	return connection, nil
+}
+
+// createOrder 解析 Mutation.createOrder
+func (r *resolver) createOrder(p graphqlgo.ResolveParams) (interface{}, error) {
+	userID, err := requireUserID(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	orderData, err := r.orderService.CreateOrder(p.Context, userID, toCreateOrderRequest(p.Args["input"].(map[string]interface{})))
+	if err != nil {
+		return nil, toGraphQLError(p.Context, err)
+	}
+	return orderData, nil
+}
+
+// toCreateOrderRequest 转换 GraphQL 输入到应用层 DTO（类型已由 Schema 校验）
+func toCreateOrderRequest(input map[string]interface{}) *application.CreateOrderRequest {
+	rawItems, _ := input["items"].([]interface{})
+	items := make([]application.OrderItemRequest, len(rawItems))
+	for i, raw := range rawItems {
+		item := raw.(map[string]interface{})
+		items[i] = application.OrderItemRequest{
+			DishID:   item["dishId"].(string),
+			DishName: item["dishName"].(string),
+			Quantity: item["quantity"].(int),
+			Price:    item["price"].(float64),
+		}
+		rawOptions, _ := item["options"].([]interface{})
+		for _, rawOption := range rawOptions {
+			option := rawOption.(map[string]interface{})
+			items[i].Options = append(items[i].Options, application.OrderItemOptionRequest{
+				GroupID:  option["groupId"].(string),
+				OptionID: option["optionId"].(string),
+			})
+		}
+		rawComponents, _ := item["components"].([]interface{})
+		for _, rawComponent := range rawComponents {
+			component := rawComponent.(map[string]interface{})
+			items[i].Components = append(items[i].Components, application.ComboComponentRequest{
+				SlotID: component["slotId"].(string),
+				DishID: component["dishId"].(string),
+			})
+		}
+	}
+
+	delivery := input["deliveryInfo"].(map[string]interface{})
+	remark, _ := input["remark"].(string)
+	req := &application.CreateOrderRequest{
+		MerchantID: input["merchantId"].(string),
+		Items:      items,
+		DeliveryInfo: application.DeliveryInfoRequest{
+			RecipientName:  delivery["recipientName"].(string),
+			RecipientPhone: delivery["recipientPhone"].(string),
+			Address:        delivery["address"].(string),
+		},
+		Remark: remark,
+	}
+	if location, ok := delivery["location"].(map[string]interface{}); ok {
+		latitude, longitude := location["latitude"].(float64), location["longitude"].(float64)
+		req.DeliveryInfo.Location = &application.GeoPointRequest{Latitude: &latitude, Longitude: &longitude}
+	}
+	return req
+}
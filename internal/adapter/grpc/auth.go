@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"order-service/internal/adapter/web"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// userIDKey 用户ID在 context 中的键
+type userIDKey struct{}
+
+// AuthInterceptor JWT 认证拦截器
+// 从 metadata 的 authorization（Bearer <token>）中读取 Token，复用 HTTP 适配器的 Token 校验
+func AuthInterceptor(ctx context.Context, req interface{}, info *grpcgo.UnaryServerInfo, handler grpcgo.UnaryHandler) (interface{}, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization format")
+	}
+
+	claims, err := web.ValidateToken(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+
+	return handler(context.WithValue(ctx, userIDKey{}, claims.UserID), req)
+}
+
+// UserIDFromContext 获取认证后的用户ID
+func UserIDFromContext(ctx context.Context) (uint64, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(uint64)
+	return userID, ok
+}
+
+// requireUserID 获取认证后的用户ID，未认证时返回 Unauthenticated
+func requireUserID(ctx context.Context) (uint64, error) {
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	return userID, nil
+}
@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"context"
+
+	"order-service/internal/application"
+	"order-service/internal/logging"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain 错误详情中的错误域
+const errorDomain = "order-service"
+
+// fieldPaths 应用层字段名到 protobuf 字段路径的映射
+var fieldPaths = map[string]string{
+	"MerchantID":     "merchant_id",
+	"Items":          "items",
+	"DishID":         "items.dish_id",
+	"DishName":       "items.dish_name",
+	"Quantity":       "items.quantity",
+	"Price":          "items.price",
+	"DeliveryInfo":   "delivery_info",
+	"RecipientName":  "delivery_info.recipient_name",
+	"RecipientPhone": "delivery_info.recipient_phone",
+	"Address":        "delivery_info.address",
+	"Location":       "delivery_info.location",
+	"Latitude":       "delivery_info.location.latitude",
+	"Longitude":      "delivery_info.location.longitude",
+	"Remark":         "remark",
+	"Limit":          "page_size",
+	"Cursor":         "page_token",
+	"Status":         "status",
+}
+
+// toStatusError 将应用层错误映射为 gRPC 状态
+//   - ValidationError -> InvalidArgument（附带 BadRequest 字段错误）
+//   - BusinessError   -> FailedPrecondition（附带 ErrorInfo 业务错误码）
+//   - NotFoundError   -> NotFound
+//   - RateLimitError  -> ResourceExhausted（附带 RetryInfo 重试等待时间）
+//   - 其他错误        -> Internal（不暴露内部细节）
+func toStatusError(ctx context.Context, err error) error {
+	switch e := err.(type) {
+	case *application.ValidationError:
+		field := fieldPaths[e.Field]
+		if field == "" {
+			field = e.Field
+		}
+		return withDetails(codes.InvalidArgument, e.Message, &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: field, Description: e.Message},
+			},
+		})
+	case *application.BusinessError:
+		return withDetails(codes.FailedPrecondition, e.Message, &errdetails.ErrorInfo{
+			Reason: e.Code,
+			Domain: errorDomain,
+		})
+	case *application.NotFoundError:
+		return status.Error(codes.NotFound, e.Message)
+	case *application.RateLimitError:
+		return withDetails(codes.ResourceExhausted, e.Message, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(e.RetryAfter),
+		})
+	default:
+		// 记录详细错误日志（响应中不暴露内部细节）
+		logging.FromContext(ctx).Error("internal error", logging.KeyError, err)
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
+
+// withDetails 创建附带错误详情的 gRPC 状态（附加失败时返回不含详情的状态）
+func withDetails(code codes.Code, message string, details ...protoadapt.MessageV1) error {
+	st := status.New(code, message)
+	if detailed, err := st.WithDetails(details...); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
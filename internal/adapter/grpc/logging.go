@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"order-service/internal/logging"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDMetadataKey 携带请求ID的 metadata 键（gRPC metadata 键均为小写）
+var requestIDMetadataKey = strings.ToLower(logging.RequestIDHeader)
+
+// LoggingInterceptor 请求ID与访问日志拦截器（需位于认证拦截器之前）
+// 沿用调用方传入的合法 x-request-id，否则生成新的请求ID，通过响应 header 返回，
+// 并随 logger 放入 context；Internal/Unknown 等服务端错误记为 error，其余记为 info
+func LoggingInterceptor(ctx context.Context, req interface{}, info *grpcgo.UnaryServerInfo, handler grpcgo.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadataKey); len(values) > 0 && logging.ValidRequestID(values[0]) {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = logging.NewRequestID()
+	}
+	_ = grpcgo.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, requestID))
+	ctx = logging.WithRequestID(ctx, requestID)
+
+	resp, err := handler(ctx, req)
+
+	code := status.Code(err)
+	attrs := []slog.Attr{
+		slog.String("method", info.FullMethod),
+		slog.String("code", code.String()),
+		slog.Duration("latency", time.Since(start)),
+	}
+	level := slog.LevelInfo
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss:
+		level = slog.LevelError
+	}
+	logging.FromContext(ctx).LogAttrs(ctx, level, "grpc request", attrs...)
+	return resp, err
+}
@@ -0,0 +1,1179 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: order.proto
+
+// 订单服务 gRPC 接口（供内部服务调用）
+
+package orderpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CreateOrderRequest 创建订单请求
+type CreateOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId    string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Items         []*OrderItemInput      `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	DeliveryInfo  *DeliveryInfo          `protobuf:"bytes,3,opt,name=delivery_info,json=deliveryInfo,proto3" json:"delivery_info,omitempty"`
+	Remark        string                 `protobuf:"bytes,4,opt,name=remark,proto3" json:"remark,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateOrderRequest) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetItems() []*OrderItemInput {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetDeliveryInfo() *DeliveryInfo {
+	if x != nil {
+		return x.DeliveryInfo
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
+// OrderItemInput 订单项请求（price 为餐品基础单价；套餐的 dish_id 为套餐ID，名称和套餐价以菜单为准）
+type OrderItemInput struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	DishId        string                  `protobuf:"bytes,1,opt,name=dish_id,json=dishId,proto3" json:"dish_id,omitempty"`
+	DishName      string                  `protobuf:"bytes,2,opt,name=dish_name,json=dishName,proto3" json:"dish_name,omitempty"`
+	Quantity      int32                   `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         float64                 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Options       []*OrderItemOptionInput `protobuf:"bytes,5,rep,name=options,proto3" json:"options,omitempty"`
+	Components    []*ComboComponentInput  `protobuf:"bytes,6,rep,name=components,proto3" json:"components,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItemInput) Reset() {
+	*x = OrderItemInput{}
+	mi := &file_order_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItemInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItemInput) ProtoMessage() {}
+
+func (x *OrderItemInput) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItemInput.ProtoReflect.Descriptor instead.
+func (*OrderItemInput) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrderItemInput) GetDishId() string {
+	if x != nil {
+		return x.DishId
+	}
+	return ""
+}
+
+func (x *OrderItemInput) GetDishName() string {
+	if x != nil {
+		return x.DishName
+	}
+	return ""
+}
+
+func (x *OrderItemInput) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderItemInput) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *OrderItemInput) GetOptions() []*OrderItemOptionInput {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *OrderItemInput) GetComponents() []*ComboComponentInput {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+// OrderItemOptionInput 订单项规格选择
+type OrderItemOptionInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	OptionId      string                 `protobuf:"bytes,2,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItemOptionInput) Reset() {
+	*x = OrderItemOptionInput{}
+	mi := &file_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItemOptionInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItemOptionInput) ProtoMessage() {}
+
+func (x *OrderItemOptionInput) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItemOptionInput.ProtoReflect.Descriptor instead.
+func (*OrderItemOptionInput) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrderItemOptionInput) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *OrderItemOptionInput) GetOptionId() string {
+	if x != nil {
+		return x.OptionId
+	}
+	return ""
+}
+
+// ComboComponentInput 套餐组件位的替换选择
+type ComboComponentInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SlotId        string                 `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	DishId        string                 `protobuf:"bytes,2,opt,name=dish_id,json=dishId,proto3" json:"dish_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComboComponentInput) Reset() {
+	*x = ComboComponentInput{}
+	mi := &file_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComboComponentInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComboComponentInput) ProtoMessage() {}
+
+func (x *ComboComponentInput) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComboComponentInput.ProtoReflect.Descriptor instead.
+func (*ComboComponentInput) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ComboComponentInput) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *ComboComponentInput) GetDishId() string {
+	if x != nil {
+		return x.DishId
+	}
+	return ""
+}
+
+// DeliveryInfo 配送信息（商家配置了配送范围时 location 必填）
+type DeliveryInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RecipientName  string                 `protobuf:"bytes,1,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	RecipientPhone string                 `protobuf:"bytes,2,opt,name=recipient_phone,json=recipientPhone,proto3" json:"recipient_phone,omitempty"`
+	Address        string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Location       *GeoPoint              `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeliveryInfo) Reset() {
+	*x = DeliveryInfo{}
+	mi := &file_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryInfo) ProtoMessage() {}
+
+func (x *DeliveryInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryInfo.ProtoReflect.Descriptor instead.
+func (*DeliveryInfo) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeliveryInfo) GetRecipientName() string {
+	if x != nil {
+		return x.RecipientName
+	}
+	return ""
+}
+
+func (x *DeliveryInfo) GetRecipientPhone() string {
+	if x != nil {
+		return x.RecipientPhone
+	}
+	return ""
+}
+
+func (x *DeliveryInfo) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *DeliveryInfo) GetLocation() *GeoPoint {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// GeoPoint 地理坐标（WGS-84 经纬度）
+type GeoPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoPoint) Reset() {
+	*x = GeoPoint{}
+	mi := &file_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoPoint) ProtoMessage() {}
+
+func (x *GeoPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoPoint.ProtoReflect.Descriptor instead.
+func (*GeoPoint) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GeoPoint) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GeoPoint) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+// GetOrderRequest 查询订单请求
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderNumber   string                 `protobuf:"bytes,1,opt,name=order_number,json=orderNumber,proto3" json:"order_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetOrderRequest) GetOrderNumber() string {
+	if x != nil {
+		return x.OrderNumber
+	}
+	return ""
+}
+
+// ListOrdersRequest 订单列表请求
+type ListOrdersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 每页数量（1-100，默认 20）
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// 上一页返回的 next_page_token
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// 按订单状态过滤（为空表示全部）
+	Status        string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// ListOrdersResponse 订单列表响应
+type ListOrdersResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Orders []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// 为空表示没有下一页
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Order 订单
+type Order struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderNumber   string                 `protobuf:"bytes,1,opt,name=order_number,json=orderNumber,proto3" json:"order_number,omitempty"`
+	MerchantId    string                 `protobuf:"bytes,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Items         []*OrderItem           `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	Pricing       *Pricing               `protobuf:"bytes,5,opt,name=pricing,proto3" json:"pricing,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Order) GetOrderNumber() string {
+	if x != nil {
+		return x.OrderNumber
+	}
+	return ""
+}
+
+func (x *Order) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *Order) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Order) GetPricing() *Pricing {
+	if x != nil {
+		return x.Pricing
+	}
+	return nil
+}
+
+func (x *Order) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Order) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+// OrderItem 订单项（金额为两位小数的字符串，unit_price 含规格加价）
+type OrderItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DishId        string                 `protobuf:"bytes,1,opt,name=dish_id,json=dishId,proto3" json:"dish_id,omitempty"`
+	DishName      string                 `protobuf:"bytes,2,opt,name=dish_name,json=dishName,proto3" json:"dish_name,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         string                 `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	UnitPrice     string                 `protobuf:"bytes,5,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Subtotal      string                 `protobuf:"bytes,6,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Options       []*OrderItemOption     `protobuf:"bytes,7,rep,name=options,proto3" json:"options,omitempty"`
+	Components    []*OrderItemComponent  `protobuf:"bytes,8,rep,name=components,proto3" json:"components,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItem) ProtoMessage() {}
+
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OrderItem) GetDishId() string {
+	if x != nil {
+		return x.DishId
+	}
+	return ""
+}
+
+func (x *OrderItem) GetDishName() string {
+	if x != nil {
+		return x.DishName
+	}
+	return ""
+}
+
+func (x *OrderItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderItem) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *OrderItem) GetUnitPrice() string {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return ""
+}
+
+func (x *OrderItem) GetSubtotal() string {
+	if x != nil {
+		return x.Subtotal
+	}
+	return ""
+}
+
+func (x *OrderItem) GetOptions() []*OrderItemOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *OrderItem) GetComponents() []*OrderItemComponent {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+// OrderItemOption 订单项已选规格
+type OrderItemOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       string                 `protobuf:"bytes,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	GroupName     string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	OptionId      string                 `protobuf:"bytes,3,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+	OptionName    string                 `protobuf:"bytes,4,opt,name=option_name,json=optionName,proto3" json:"option_name,omitempty"`
+	PriceDelta    string                 `protobuf:"bytes,5,opt,name=price_delta,json=priceDelta,proto3" json:"price_delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItemOption) Reset() {
+	*x = OrderItemOption{}
+	mi := &file_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItemOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItemOption) ProtoMessage() {}
+
+func (x *OrderItemOption) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItemOption.ProtoReflect.Descriptor instead.
+func (*OrderItemOption) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OrderItemOption) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *OrderItemOption) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *OrderItemOption) GetOptionId() string {
+	if x != nil {
+		return x.OptionId
+	}
+	return ""
+}
+
+func (x *OrderItemOption) GetOptionName() string {
+	if x != nil {
+		return x.OptionName
+	}
+	return ""
+}
+
+func (x *OrderItemOption) GetPriceDelta() string {
+	if x != nil {
+		return x.PriceDelta
+	}
+	return ""
+}
+
+// OrderItemComponent 套餐组件（allocated_price 为每份套餐分摊到该组件的金额）
+type OrderItemComponent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SlotId         string                 `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	SlotName       string                 `protobuf:"bytes,2,opt,name=slot_name,json=slotName,proto3" json:"slot_name,omitempty"`
+	DishId         string                 `protobuf:"bytes,3,opt,name=dish_id,json=dishId,proto3" json:"dish_id,omitempty"`
+	DishName       string                 `protobuf:"bytes,4,opt,name=dish_name,json=dishName,proto3" json:"dish_name,omitempty"`
+	Quantity       int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Surcharge      string                 `protobuf:"bytes,6,opt,name=surcharge,proto3" json:"surcharge,omitempty"`
+	AllocatedPrice string                 `protobuf:"bytes,7,opt,name=allocated_price,json=allocatedPrice,proto3" json:"allocated_price,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OrderItemComponent) Reset() {
+	*x = OrderItemComponent{}
+	mi := &file_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItemComponent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItemComponent) ProtoMessage() {}
+
+func (x *OrderItemComponent) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItemComponent.ProtoReflect.Descriptor instead.
+func (*OrderItemComponent) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OrderItemComponent) GetSlotId() string {
+	if x != nil {
+		return x.SlotId
+	}
+	return ""
+}
+
+func (x *OrderItemComponent) GetSlotName() string {
+	if x != nil {
+		return x.SlotName
+	}
+	return ""
+}
+
+func (x *OrderItemComponent) GetDishId() string {
+	if x != nil {
+		return x.DishId
+	}
+	return ""
+}
+
+func (x *OrderItemComponent) GetDishName() string {
+	if x != nil {
+		return x.DishName
+	}
+	return ""
+}
+
+func (x *OrderItemComponent) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderItemComponent) GetSurcharge() string {
+	if x != nil {
+		return x.Surcharge
+	}
+	return ""
+}
+
+func (x *OrderItemComponent) GetAllocatedPrice() string {
+	if x != nil {
+		return x.AllocatedPrice
+	}
+	return ""
+}
+
+// Pricing 价格信息（金额为两位小数的字符串）
+type Pricing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemsTotal    string                 `protobuf:"bytes,1,opt,name=items_total,json=itemsTotal,proto3" json:"items_total,omitempty"`
+	PackagingFee  string                 `protobuf:"bytes,2,opt,name=packaging_fee,json=packagingFee,proto3" json:"packaging_fee,omitempty"`
+	DeliveryFee   string                 `protobuf:"bytes,3,opt,name=delivery_fee,json=deliveryFee,proto3" json:"delivery_fee,omitempty"`
+	FinalAmount   string                 `protobuf:"bytes,4,opt,name=final_amount,json=finalAmount,proto3" json:"final_amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pricing) Reset() {
+	*x = Pricing{}
+	mi := &file_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pricing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pricing) ProtoMessage() {}
+
+func (x *Pricing) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pricing.ProtoReflect.Descriptor instead.
+func (*Pricing) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Pricing) GetItemsTotal() string {
+	if x != nil {
+		return x.ItemsTotal
+	}
+	return ""
+}
+
+func (x *Pricing) GetPackagingFee() string {
+	if x != nil {
+		return x.PackagingFee
+	}
+	return ""
+}
+
+func (x *Pricing) GetDeliveryFee() string {
+	if x != nil {
+		return x.DeliveryFee
+	}
+	return ""
+}
+
+func (x *Pricing) GetFinalAmount() string {
+	if x != nil {
+		return x.FinalAmount
+	}
+	return ""
+}
+
+var File_order_proto protoreflect.FileDescriptor
+
+const file_order_proto_rawDesc = "" +
+	"\n" +
+	"\vorder.proto\x12\border.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xba\x01\n" +
+	"\x12CreateOrderRequest\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\tR\n" +
+	"merchantId\x12.\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.order.v1.OrderItemInputR\x05items\x12;\n" +
+	"\rdelivery_info\x18\x03 \x01(\v2\x16.order.v1.DeliveryInfoR\fdeliveryInfo\x12\x16\n" +
+	"\x06remark\x18\x04 \x01(\tR\x06remark\"\xf1\x01\n" +
+	"\x0eOrderItemInput\x12\x17\n" +
+	"\adish_id\x18\x01 \x01(\tR\x06dishId\x12\x1b\n" +
+	"\tdish_name\x18\x02 \x01(\tR\bdishName\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x128\n" +
+	"\aoptions\x18\x05 \x03(\v2\x1e.order.v1.OrderItemOptionInputR\aoptions\x12=\n" +
+	"\n" +
+	"components\x18\x06 \x03(\v2\x1d.order.v1.ComboComponentInputR\n" +
+	"components\"N\n" +
+	"\x14OrderItemOptionInput\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12\x1b\n" +
+	"\toption_id\x18\x02 \x01(\tR\boptionId\"G\n" +
+	"\x13ComboComponentInput\x12\x17\n" +
+	"\aslot_id\x18\x01 \x01(\tR\x06slotId\x12\x17\n" +
+	"\adish_id\x18\x02 \x01(\tR\x06dishId\"\xa8\x01\n" +
+	"\fDeliveryInfo\x12%\n" +
+	"\x0erecipient_name\x18\x01 \x01(\tR\rrecipientName\x12'\n" +
+	"\x0frecipient_phone\x18\x02 \x01(\tR\x0erecipientPhone\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12.\n" +
+	"\blocation\x18\x04 \x01(\v2\x12.order.v1.GeoPointR\blocation\"D\n" +
+	"\bGeoPoint\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"4\n" +
+	"\x0fGetOrderRequest\x12!\n" +
+	"\forder_number\x18\x01 \x01(\tR\vorderNumber\"g\n" +
+	"\x11ListOrdersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"e\n" +
+	"\x12ListOrdersResponse\x12'\n" +
+	"\x06orders\x18\x01 \x03(\v2\x0f.order.v1.OrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xb5\x02\n" +
+	"\x05Order\x12!\n" +
+	"\forder_number\x18\x01 \x01(\tR\vorderNumber\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\tR\n" +
+	"merchantId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12)\n" +
+	"\x05items\x18\x04 \x03(\v2\x13.order.v1.OrderItemR\x05items\x12+\n" +
+	"\apricing\x18\x05 \x01(\v2\x11.order.v1.PricingR\apricing\x12;\n" +
+	"\vcreate_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\"\xa1\x02\n" +
+	"\tOrderItem\x12\x17\n" +
+	"\adish_id\x18\x01 \x01(\tR\x06dishId\x12\x1b\n" +
+	"\tdish_name\x18\x02 \x01(\tR\bdishName\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\tR\x05price\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x05 \x01(\tR\tunitPrice\x12\x1a\n" +
+	"\bsubtotal\x18\x06 \x01(\tR\bsubtotal\x123\n" +
+	"\aoptions\x18\a \x03(\v2\x19.order.v1.OrderItemOptionR\aoptions\x12<\n" +
+	"\n" +
+	"components\x18\b \x03(\v2\x1c.order.v1.OrderItemComponentR\n" +
+	"components\"\xaa\x01\n" +
+	"\x0fOrderItemOption\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x02 \x01(\tR\tgroupName\x12\x1b\n" +
+	"\toption_id\x18\x03 \x01(\tR\boptionId\x12\x1f\n" +
+	"\voption_name\x18\x04 \x01(\tR\n" +
+	"optionName\x12\x1f\n" +
+	"\vprice_delta\x18\x05 \x01(\tR\n" +
+	"priceDelta\"\xe3\x01\n" +
+	"\x12OrderItemComponent\x12\x17\n" +
+	"\aslot_id\x18\x01 \x01(\tR\x06slotId\x12\x1b\n" +
+	"\tslot_name\x18\x02 \x01(\tR\bslotName\x12\x17\n" +
+	"\adish_id\x18\x03 \x01(\tR\x06dishId\x12\x1b\n" +
+	"\tdish_name\x18\x04 \x01(\tR\bdishName\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x1c\n" +
+	"\tsurcharge\x18\x06 \x01(\tR\tsurcharge\x12'\n" +
+	"\x0fallocated_price\x18\a \x01(\tR\x0eallocatedPrice\"\x95\x01\n" +
+	"\aPricing\x12\x1f\n" +
+	"\vitems_total\x18\x01 \x01(\tR\n" +
+	"itemsTotal\x12#\n" +
+	"\rpackaging_fee\x18\x02 \x01(\tR\fpackagingFee\x12!\n" +
+	"\fdelivery_fee\x18\x03 \x01(\tR\vdeliveryFee\x12!\n" +
+	"\ffinal_amount\x18\x04 \x01(\tR\vfinalAmount2\xcd\x01\n" +
+	"\fOrderService\x12<\n" +
+	"\vCreateOrder\x12\x1c.order.v1.CreateOrderRequest\x1a\x0f.order.v1.Order\x126\n" +
+	"\bGetOrder\x12\x19.order.v1.GetOrderRequest\x1a\x0f.order.v1.Order\x12G\n" +
+	"\n" +
+	"ListOrders\x12\x1b.order.v1.ListOrdersRequest\x1a\x1c.order.v1.ListOrdersResponseB5Z3order-service/internal/adapter/grpc/orderpb;orderpbb\x06proto3"
+
+var (
+	file_order_proto_rawDescOnce sync.Once
+	file_order_proto_rawDescData []byte
+)
+
+func file_order_proto_rawDescGZIP() []byte {
+	file_order_proto_rawDescOnce.Do(func() {
+		file_order_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_order_proto_rawDesc), len(file_order_proto_rawDesc)))
+	})
+	return file_order_proto_rawDescData
+}
+
+var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_order_proto_goTypes = []any{
+	(*CreateOrderRequest)(nil),    // 0: order.v1.CreateOrderRequest
+	(*OrderItemInput)(nil),        // 1: order.v1.OrderItemInput
+	(*OrderItemOptionInput)(nil),  // 2: order.v1.OrderItemOptionInput
+	(*ComboComponentInput)(nil),   // 3: order.v1.ComboComponentInput
+	(*DeliveryInfo)(nil),          // 4: order.v1.DeliveryInfo
+	(*GeoPoint)(nil),              // 5: order.v1.GeoPoint
+	(*GetOrderRequest)(nil),       // 6: order.v1.GetOrderRequest
+	(*ListOrdersRequest)(nil),     // 7: order.v1.ListOrdersRequest
+	(*ListOrdersResponse)(nil),    // 8: order.v1.ListOrdersResponse
+	(*Order)(nil),                 // 9: order.v1.Order
+	(*OrderItem)(nil),             // 10: order.v1.OrderItem
+	(*OrderItemOption)(nil),       // 11: order.v1.OrderItemOption
+	(*OrderItemComponent)(nil),    // 12: order.v1.OrderItemComponent
+	(*Pricing)(nil),               // 13: order.v1.Pricing
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+}
+var file_order_proto_depIdxs = []int32{
+	1,  // 0: order.v1.CreateOrderRequest.items:type_name -> order.v1.OrderItemInput
+	4,  // 1: order.v1.CreateOrderRequest.delivery_info:type_name -> order.v1.DeliveryInfo
+	2,  // 2: order.v1.OrderItemInput.options:type_name -> order.v1.OrderItemOptionInput
+	3,  // 3: order.v1.OrderItemInput.components:type_name -> order.v1.ComboComponentInput
+	5,  // 4: order.v1.DeliveryInfo.location:type_name -> order.v1.GeoPoint
+	9,  // 5: order.v1.ListOrdersResponse.orders:type_name -> order.v1.Order
+	10, // 6: order.v1.Order.items:type_name -> order.v1.OrderItem
+	13, // 7: order.v1.Order.pricing:type_name -> order.v1.Pricing
+	14, // 8: order.v1.Order.create_time:type_name -> google.protobuf.Timestamp
+	14, // 9: order.v1.Order.update_time:type_name -> google.protobuf.Timestamp
+	11, // 10: order.v1.OrderItem.options:type_name -> order.v1.OrderItemOption
+	12, // 11: order.v1.OrderItem.components:type_name -> order.v1.OrderItemComponent
+	0,  // 12: order.v1.OrderService.CreateOrder:input_type -> order.v1.CreateOrderRequest
+	6,  // 13: order.v1.OrderService.GetOrder:input_type -> order.v1.GetOrderRequest
+	7,  // 14: order.v1.OrderService.ListOrders:input_type -> order.v1.ListOrdersRequest
+	9,  // 15: order.v1.OrderService.CreateOrder:output_type -> order.v1.Order
+	9,  // 16: order.v1.OrderService.GetOrder:output_type -> order.v1.Order
+	8,  // 17: order.v1.OrderService.ListOrders:output_type -> order.v1.ListOrdersResponse
+	15, // [15:18] is the sub-list for method output_type
+	12, // [12:15] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_order_proto_init() }
+func file_order_proto_init() {
+	if File_order_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_proto_rawDesc), len(file_order_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_order_proto_goTypes,
+		DependencyIndexes: file_order_proto_depIdxs,
+		MessageInfos:      file_order_proto_msgTypes,
+	}.Build()
+	File_order_proto = out.File
+	file_order_proto_goTypes = nil
+	file_order_proto_depIdxs = nil
+}
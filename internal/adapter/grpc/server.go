@@ -0,0 +1,187 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"order-service/internal/adapter/grpc/orderpb"
+	"order-service/internal/application"
+
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// OrderServer 订单 gRPC 服务（与 Echo HTTP 适配器并列的驱动适配器）
+type OrderServer struct {
+	orderpb.UnimplementedOrderServiceServer
+	orderService application.OrderService
+}
+
+// NewOrderServer 创建订单 gRPC 服务
+func NewOrderServer(orderService application.OrderService) *OrderServer {
+	return &OrderServer{orderService: orderService}
+}
+
+// NewServer 创建注册了订单服务、日志和 JWT 认证拦截器的 gRPC 服务器
+// opts 中的拦截器（如链路追踪）位于最外层，先于日志和认证执行，认证失败的调用同样被追踪
+func NewServer(orderService application.OrderService, opts ...grpcgo.ServerOption) *grpcgo.Server {
+	opts = append(opts, grpcgo.ChainUnaryInterceptor(LoggingInterceptor, AuthInterceptor))
+	server := grpcgo.NewServer(opts...)
+	orderpb.RegisterOrderServiceServer(server, NewOrderServer(orderService))
+	return server
+}
+
+// CreateOrder 创建订单
+func (s *OrderServer) CreateOrder(ctx context.Context, req *orderpb.CreateOrderRequest) (*orderpb.Order, error) {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orderData, err := s.orderService.CreateOrder(ctx, userID, toCreateOrderRequest(req))
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+	return toOrderMessage(orderData), nil
+}
+
+// GetOrder 查询自己的订单
+func (s *OrderServer) GetOrder(ctx context.Context, req *orderpb.GetOrderRequest) (*orderpb.Order, error) {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orderData, err := s.orderService.GetOrder(ctx, userID, req.GetOrderNumber())
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+	return toOrderMessage(orderData), nil
+}
+
+// ListOrders 分页查询自己的订单
+func (s *OrderServer) ListOrders(ctx context.Context, req *orderpb.ListOrdersRequest) (*orderpb.ListOrdersResponse, error) {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.orderService.ListOrders(ctx, userID, &application.ListOrdersRequest{
+		Limit:  int(req.GetPageSize()),
+		Cursor: req.GetPageToken(),
+		Status: req.GetStatus(),
+	})
+	if err != nil {
+		return nil, toStatusError(ctx, err)
+	}
+
+	response := &orderpb.ListOrdersResponse{
+		Orders:        make([]*orderpb.Order, len(list.Orders)),
+		NextPageToken: list.NextCursor,
+	}
+	for i := range list.Orders {
+		response.Orders[i] = toOrderMessage(&list.Orders[i])
+	}
+	return response, nil
+}
+
+// toCreateOrderRequest 转换 protobuf 请求到应用层 DTO
+func toCreateOrderRequest(req *orderpb.CreateOrderRequest) *application.CreateOrderRequest {
+	items := make([]application.OrderItemRequest, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		items[i] = application.OrderItemRequest{
+			DishID:   item.GetDishId(),
+			DishName: item.GetDishName(),
+			Quantity: int(item.GetQuantity()),
+			Price:    item.GetPrice(),
+		}
+		for _, option := range item.GetOptions() {
+			items[i].Options = append(items[i].Options, application.OrderItemOptionRequest{
+				GroupID:  option.GetGroupId(),
+				OptionID: option.GetOptionId(),
+			})
+		}
+		for _, component := range item.GetComponents() {
+			items[i].Components = append(items[i].Components, application.ComboComponentRequest{
+				SlotID: component.GetSlotId(),
+				DishID: component.GetDishId(),
+			})
+		}
+	}
+
+	delivery := req.GetDeliveryInfo()
+	appReq := &application.CreateOrderRequest{
+		MerchantID: req.GetMerchantId(),
+		Items:      items,
+		DeliveryInfo: application.DeliveryInfoRequest{
+			RecipientName:  delivery.GetRecipientName(),
+			RecipientPhone: delivery.GetRecipientPhone(),
+			Address:        delivery.GetAddress(),
+		},
+		Remark: req.GetRemark(),
+	}
+	if location := delivery.GetLocation(); location != nil {
+		latitude, longitude := location.GetLatitude(), location.GetLongitude()
+		appReq.DeliveryInfo.Location = &application.GeoPointRequest{Latitude: &latitude, Longitude: &longitude}
+	}
+	return appReq
+}
+
+// toOrderMessage 转换应用层订单数据到 protobuf 消息
+func toOrderMessage(orderData *application.OrderData) *orderpb.Order {
+	items := make([]*orderpb.OrderItem, len(orderData.Items))
+	for i, item := range orderData.Items {
+		items[i] = &orderpb.OrderItem{
+			DishId:    item.DishID,
+			DishName:  item.DishName,
+			Quantity:  int32(item.Quantity),
+			Price:     item.Price,
+			UnitPrice: item.UnitPrice,
+			Subtotal:  item.Subtotal,
+		}
+		for _, option := range item.Options {
+			items[i].Options = append(items[i].Options, &orderpb.OrderItemOption{
+				GroupId:    option.GroupID,
+				GroupName:  option.GroupName,
+				OptionId:   option.OptionID,
+				OptionName: option.OptionName,
+				PriceDelta: option.PriceDelta,
+			})
+		}
+		for _, component := range item.Components {
+			items[i].Components = append(items[i].Components, &orderpb.OrderItemComponent{
+				SlotId:         component.SlotID,
+				SlotName:       component.SlotName,
+				DishId:         component.DishID,
+				DishName:       component.DishName,
+				Quantity:       int32(component.Quantity),
+				Surcharge:      component.Surcharge,
+				AllocatedPrice: component.AllocatedPrice,
+			})
+		}
+	}
+
+	return &orderpb.Order{
+		OrderNumber: orderData.OrderNumber,
+		MerchantId:  orderData.MerchantID,
+		Status:      orderData.Status,
+		Items:       items,
+		Pricing: &orderpb.Pricing{
+			ItemsTotal:   orderData.Pricing.ItemsTotal,
+			PackagingFee: orderData.Pricing.PackagingFee,
+			DeliveryFee:  orderData.Pricing.DeliveryFee,
+			FinalAmount:  orderData.Pricing.FinalAmount,
+		},
+		CreateTime: toTimestamp(orderData.CreatedAt),
+		UpdateTime: toTimestamp(orderData.UpdatedAt),
+	}
+}
+
+// toTimestamp 转换 RFC3339 时间字符串（为空或格式错误时返回 nil）
+func toTimestamp(value string) *timestamppb.Timestamp {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,235 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"order-service/internal/adapter/grpc/orderpb"
+	"order-service/internal/adapter/persistence"
+	"order-service/internal/adapter/web"
+	"order-service/internal/application"
+	"order-service/internal/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient 启动基于内存连接的 gRPC 服务器并返回客户端
+func newTestClient(t *testing.T, opts ...grpcgo.ServerOption) orderpb.OrderServiceClient {
+	lis := bufconn.Listen(1024 * 1024)
+	server := NewServer(application.NewOrderService(persistence.NewInMemoryOrderRepository()), opts...)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpcgo.NewClient("passthrough:///bufnet",
+		grpcgo.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpcgo.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return orderpb.NewOrderServiceClient(conn)
+}
+
+// authContext 创建携带用户 Token 的调用上下文
+func authContext(t *testing.T, userID uint64) context.Context {
+	token, err := web.GenerateToken(userID)
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// validCreateRequest 创建合法的下单请求
+func validCreateRequest() *orderpb.CreateOrderRequest {
+	return &orderpb.CreateOrderRequest{
+		MerchantId: "merchant_001",
+		Items: []*orderpb.OrderItemInput{
+			{DishId: "dish_001", DishName: "宫保鸡丁", Quantity: 2, Price: 28.00},
+		},
+		DeliveryInfo: &orderpb.DeliveryInfo{
+			RecipientName:  "张三",
+			RecipientPhone: "13800138000",
+			Address:        "北京市朝阳区xxx",
+		},
+	}
+}
+
+func TestOrderServer_CreateAndGetOrder(t *testing.T) {
+	// Arrange
+	client := newTestClient(t)
+	ctx := authContext(t, 1001)
+
+	// Act
+	created, err := client.CreateOrder(ctx, validCreateRequest())
+	require.NoError(t, err)
+	found, err := client.GetOrder(ctx, &orderpb.GetOrderRequest{OrderNumber: created.OrderNumber})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "PENDING_PAYMENT", found.Status)
+	assert.Equal(t, "merchant_001", found.MerchantId)
+	assert.Equal(t, "60.00", found.Pricing.FinalAmount)
+	require.Len(t, found.Items, 1)
+	assert.Equal(t, "28.00", found.Items[0].Price)
+	assert.NotNil(t, found.CreateTime)
+}
+
+func TestOrderServer_GetOrder_OtherUsersOrder(t *testing.T) {
+	client := newTestClient(t)
+	created, err := client.CreateOrder(authContext(t, 1001), validCreateRequest())
+	require.NoError(t, err)
+
+	_, err = client.GetOrder(authContext(t, 2002), &orderpb.GetOrderRequest{OrderNumber: created.OrderNumber})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestOrderServer_ListOrders(t *testing.T) {
+	client := newTestClient(t)
+	ctx := authContext(t, 1001)
+	for i := 0; i < 3; i++ {
+		_, err := client.CreateOrder(ctx, validCreateRequest())
+		require.NoError(t, err)
+	}
+
+	first, err := client.ListOrders(ctx, &orderpb.ListOrdersRequest{PageSize: 2})
+	require.NoError(t, err)
+	second, err := client.ListOrders(ctx, &orderpb.ListOrdersRequest{PageSize: 2, PageToken: first.NextPageToken})
+	require.NoError(t, err)
+
+	assert.Len(t, first.Orders, 2)
+	assert.NotEmpty(t, first.NextPageToken)
+	assert.Len(t, second.Orders, 1)
+	assert.Empty(t, second.NextPageToken)
+}
+
+func TestOrderServer_ValidationErrorHasFieldViolation(t *testing.T) {
+	client := newTestClient(t)
+	req := validCreateRequest()
+	req.DeliveryInfo.RecipientPhone = "12345"
+
+	_, err := client.CreateOrder(authContext(t, 1001), req)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	assert.Equal(t, "delivery_info.recipient_phone", badRequest.FieldViolations[0].Field)
+}
+
+func TestOrderServer_LocationValidationError(t *testing.T) {
+	client := newTestClient(t)
+	req := validCreateRequest()
+	req.DeliveryInfo.Location = &orderpb.GeoPoint{Latitude: 120, Longitude: 116.40}
+
+	_, err := client.CreateOrder(authContext(t, 1001), req)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	assert.Equal(t, "delivery_info.location.latitude", badRequest.FieldViolations[0].Field)
+}
+
+func TestOrderServer_RequiresToken(t *testing.T) {
+	client := newTestClient(t)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"missing token", context.Background()},
+		{"invalid format", metadata.AppendToOutgoingContext(context.Background(), "authorization", "Token abc")},
+		{"invalid token", metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer abc")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetOrder(tt.ctx, &orderpb.GetOrderRequest{OrderNumber: "x"})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+	}
+}
+
+func TestNewServer_CallerInterceptorsAreOutermost(t *testing.T) {
+	// Arrange - 调用方拦截器记录被调用时是否已有请求ID以及最终的状态码
+	var requestIDSeen bool
+	var code codes.Code
+	outer := func(ctx context.Context, req interface{}, info *grpcgo.UnaryServerInfo, handler grpcgo.UnaryHandler) (interface{}, error) {
+		requestIDSeen = logging.RequestIDFromContext(ctx) != ""
+		resp, err := handler(ctx, req)
+		code = status.Code(err)
+		return resp, err
+	}
+	client := newTestClient(t, grpcgo.ChainUnaryInterceptor(outer))
+
+	// Act - 未携带 Token 的调用
+	_, err := client.GetOrder(context.Background(), &orderpb.GetOrderRequest{OrderNumber: "x"})
+
+	// Assert - 调用方拦截器先于日志和认证执行，认证失败的调用同样经过它
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.False(t, requestIDSeen)
+	assert.Equal(t, codes.Unauthenticated, code)
+}
+
+func TestToStatusError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"validation", application.NewValidationError("Cursor", "invalid cursor"), codes.InvalidArgument},
+		{"business", application.NewBusinessError("INVALID_ORDER_STATUS", "not allowed"), codes.FailedPrecondition},
+		{"not found", application.NewNotFoundError("order not found"), codes.NotFound},
+		{"internal", application.NewInternalError("db down", nil), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(toStatusError(context.Background(), tt.err))
+			assert.Equal(t, tt.want, st.Code())
+		})
+	}
+
+	// 业务错误码通过 ErrorInfo 返回
+	st := status.Convert(toStatusError(context.Background(), application.NewBusinessError("INVALID_ORDER_STATUS", "not allowed")))
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.Equal(t, "INVALID_ORDER_STATUS", info.Reason)
+
+	// 限流错误通过 RetryInfo 返回重试等待时间
+	st = status.Convert(toStatusError(context.Background(), application.NewRateLimitError("too many orders, retry later", 3*time.Second)))
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Equal(t, 3*time.Second, st.Details()[0].(*errdetails.RetryInfo).RetryDelay.AsDuration())
+
+	// 内部错误不暴露细节
+	assert.Equal(t, "internal server error", status.Convert(toStatusError(context.Background(), application.NewInternalError("db down", nil))).Message())
+}
+
+func TestLoggingInterceptor_PropagatesRequestID(t *testing.T) {
+	// Arrange
+	client := newTestClient(t)
+	ctx := metadata.AppendToOutgoingContext(authContext(t, 1001), "x-request-id", "client-req-1")
+	var header metadata.MD
+
+	// Act - 未携带请求ID时由服务端生成
+	_, err := client.CreateOrder(ctx, validCreateRequest(), grpcgo.Header(&header))
+	require.NoError(t, err)
+	var generated metadata.MD
+	_, _ = client.GetOrder(context.Background(), &orderpb.GetOrderRequest{OrderNumber: "x"}, grpcgo.Header(&generated))
+
+	// Assert
+	assert.Equal(t, []string{"client-req-1"}, header.Get("x-request-id"))
+	require.Len(t, generated.Get("x-request-id"), 1)
+	assert.True(t, logging.ValidRequestID(generated.Get("x-request-id")[0]))
+}
@@ -0,0 +1,18 @@
+// Package httproute 提供 HTTP 请求的路由标签，供 Web、指标和链路追踪适配器共用
+package httproute
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// Unmatched 未匹配任何路由的请求的路由标签
+const Unmatched = "unmatched"
+
+// Label 请求匹配的路由模板，用作指标标签和 span 名（未匹配时返回 Unmatched，避免以原始路径导致基数失控）
+func Label(c echo.Context) string {
+	route := c.Path()
+	if route == "" || route == "/*" {
+		return Unmatched
+	}
+	return route
+}
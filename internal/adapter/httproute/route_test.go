@@ -0,0 +1,31 @@
+package httproute
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabel(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	e.GET("/api/v1/orders/:orderNumber", func(c echo.Context) error {
+		return c.String(http.StatusOK, Label(c))
+	})
+	e.RouteNotFound("/*", func(c echo.Context) error {
+		return c.String(http.StatusNotFound, Label(c))
+	})
+
+	// Act
+	matched := httptest.NewRecorder()
+	e.ServeHTTP(matched, httptest.NewRequest(http.MethodGet, "/api/v1/orders/ORD001", nil))
+	unmatched := httptest.NewRecorder()
+	e.ServeHTTP(unmatched, httptest.NewRequest(http.MethodGet, "/scan/ORD001", nil))
+
+	// Assert - 匹配时为路由模板，未匹配时不使用原始路径
+	assert.Equal(t, "/api/v1/orders/:orderNumber", matched.Body.String())
+	assert.Equal(t, Unmatched, unmatched.Body.String())
+}
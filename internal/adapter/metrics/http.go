@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"order-service/internal/adapter/httproute"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HTTPMiddleware 记录 HTTP 请求耗时（按路由模板而不是原始路径统计，控制标签基数）
+func (m *Metrics) HTTPMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+		if err != nil {
+			// 交给 Echo 的错误处理器写入响应，以便记录最终状态码（响应已提交后不会重复写入）
+			c.Error(err)
+		}
+
+		m.httpDuration.
+			WithLabelValues(c.Request().Method, httproute.Label(c), strconv.Itoa(c.Response().Status)).
+			Observe(time.Since(start).Seconds())
+		// 继续返回错误，外层中间件（如访问日志）仍能获取错误信息
+		return err
+	}
+}
@@ -0,0 +1,104 @@
+// Package metrics Prometheus 指标适配器：HTTP 请求、订单业务和仓储调用指标，通过 /metrics 以文本格式暴露
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace 指标名前缀
+const namespace = "order_service"
+
+// 校验失败来源
+const (
+	ValidationSourceRequestSchema = "request_schema" // HTTP 请求体未通过 OpenAPI Schema 校验
+	ValidationSourceApplication   = "application"    // 应用服务校验失败（所有接入方式）
+)
+
+// Metrics 服务指标
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpDuration       *prometheus.HistogramVec
+	ordersCreated      *prometheus.CounterVec
+	ordersPaid         *prometheus.CounterVec
+	ordersCancelled    *prometheus.CounterVec
+	gmv                *prometheus.CounterVec
+	validationFailures *prometheus.CounterVec
+	repositoryDuration *prometheus.HistogramVec
+}
+
+// New 创建指标并注册到独立的 Registry（包含 Go 运行时和进程指标）
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		ordersCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orders_created_total",
+			Help:      "Orders created, by merchant.",
+		}, []string{"merchant_id"}),
+		ordersPaid: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orders_paid_total",
+			Help:      "Orders paid, by merchant.",
+		}, []string{"merchant_id"}),
+		ordersCancelled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orders_cancelled_total",
+			Help:      "Orders cancelled by users, by merchant.",
+		}, []string{"merchant_id"}),
+		gmv: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "gmv_yuan_total",
+			Help:      "Gross merchandise value of paid orders (sum of final amount in yuan), by merchant.",
+		}, []string{"merchant_id"}),
+		validationFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "validation_failures_total",
+			Help:      "Rejected requests by validation source and field.",
+		}, []string{"source", "field"}),
+		repositoryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_operation_duration_seconds",
+			Help:      "Repository call latency by repository, operation and outcome.",
+			Buckets:   []float64{.0001, .0005, .001, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"repository", "operation", "outcome"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpDuration,
+		m.ordersCreated,
+		m.ordersPaid,
+		m.ordersCancelled,
+		m.gmv,
+		m.validationFailures,
+		m.repositoryDuration,
+	)
+	return m
+}
+
+// Handler 以 Prometheus 文本格式输出指标
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RecordValidationFailure 记录一次校验失败（field 需为有限取值，不能包含客户端输入）
+func (m *Metrics) RecordValidationFailure(source, field string) {
+	m.validationFailures.WithLabelValues(source, field).Inc()
+}
+
+// RecordRequestSchemaFailure 记录 HTTP 请求体 Schema 校验失败（供 web.WithValidationObserver 使用）
+func (m *Metrics) RecordRequestSchemaFailure(field string) {
+	m.RecordValidationFailure(ValidationSourceRequestSchema, field)
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"order-service/internal/adapter/persistence"
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scrape 抓取指标文本
+func scrape(t *testing.T, m *Metrics) string {
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+// validCreateRequest 合法的下单请求（最终金额 28.00 + 1.00 + 3.00 = 32.00）
+func validCreateRequest() *application.CreateOrderRequest {
+	return &application.CreateOrderRequest{
+		MerchantID: "merchant_001",
+		Items:      []application.OrderItemRequest{{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00}},
+		DeliveryInfo: application.DeliveryInfoRequest{
+			RecipientName:  "张三",
+			RecipientPhone: "13800138000",
+			Address:        "北京市朝阳区xxx",
+		},
+	}
+}
+
+func TestHTTPMiddleware_RecordsByRouteAndStatus(t *testing.T) {
+	// Arrange
+	m := New()
+	e := echo.New()
+	e.Use(m.HTTPMiddleware)
+	e.GET("/api/v1/orders/:orderNumber", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/fail", func(c echo.Context) error {
+		return errors.New("boom")
+	})
+
+	// Act
+	for _, path := range []string{"/api/v1/orders/1", "/api/v1/orders/2", "/fail", "/no/such/path"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	// Assert - 按路由模板统计，未匹配的路径不作为标签
+	out := scrape(t, m)
+	assert.Contains(t, out, `order_service_http_request_duration_seconds_count{method="GET",route="/api/v1/orders/:orderNumber",status="200"} 2`)
+	assert.Contains(t, out, `order_service_http_request_duration_seconds_count{method="GET",route="/fail",status="500"} 1`)
+	assert.Contains(t, out, `order_service_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, out, "/no/such/path")
+}
+
+func TestOrderService_RecordsBusinessMetrics(t *testing.T) {
+	// Arrange
+	m := New()
+	service := NewOrderService(application.NewOrderService(persistence.NewInMemoryOrderRepository()), m)
+	ctx := context.Background()
+
+	// Act - 两单：一单支付，一单取消；再提交一个手机号错误的请求
+	paid, err := service.CreateOrder(ctx, 1001, validCreateRequest())
+	require.NoError(t, err)
+	_, err = service.ConfirmPayment(ctx, &application.ConfirmPaymentRequest{
+		OrderNumber: paid.OrderNumber, PaymentID: "pay_001", Amount: paid.Pricing.FinalAmount,
+	})
+	require.NoError(t, err)
+	cancelled, err := service.CreateOrder(ctx, 1001, validCreateRequest())
+	require.NoError(t, err)
+	_, err = service.CancelOrder(ctx, 1001, cancelled.OrderNumber, &application.CancelOrderRequest{})
+	require.NoError(t, err)
+
+	invalid := validCreateRequest()
+	invalid.DeliveryInfo.RecipientPhone = "123"
+	_, err = service.CreateOrder(ctx, 1001, invalid)
+	require.Error(t, err)
+
+	// Assert
+	out := scrape(t, m)
+	assert.Contains(t, out, `order_service_orders_created_total{merchant_id="merchant_001"} 2`)
+	assert.Contains(t, out, `order_service_orders_paid_total{merchant_id="merchant_001"} 1`)
+	assert.Contains(t, out, `order_service_orders_cancelled_total{merchant_id="merchant_001"} 1`)
+	assert.Contains(t, out, `order_service_gmv_yuan_total{merchant_id="merchant_001"} 32`)
+	assert.Contains(t, out, `order_service_validation_failures_total{field="RecipientPhone",source="application"} 1`)
+}
+
+func TestOrderRepository_RecordsLatencyByOutcome(t *testing.T) {
+	// Arrange
+	m := New()
+	repo := NewOrderRepository(persistence.NewInMemoryOrderRepository(), m)
+
+	// Act
+	_, err := repo.FindByOrderNumber(context.Background(), "missing")
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, scrape(t, m), `order_service_repository_operation_duration_seconds_count{operation="find_by_order_number",outcome="not_found",repository="order"} 1`)
+}
+
+func TestRecordRequestSchemaFailure(t *testing.T) {
+	m := New()
+
+	m.RecordRequestSchemaFailure("$.items[].quantity")
+
+	assert.Contains(t, scrape(t, m), `order_service_validation_failures_total{field="$.items[].quantity",source="request_schema"} 1`)
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"order-service/internal/application"
+)
+
+// orderService 统计订单业务指标的 OrderService 装饰器
+// 只统计成功的操作；应用层校验失败按字段计数（覆盖 HTTP、gRPC、GraphQL 等所有接入方式）
+type orderService struct {
+	application.OrderService
+	metrics *Metrics
+}
+
+// NewOrderService 为 OrderService 增加业务指标统计
+func NewOrderService(inner application.OrderService, m *Metrics) application.OrderService {
+	return &orderService{OrderService: inner, metrics: m}
+}
+
+// CreateOrder 统计创建的订单数
+func (s *orderService) CreateOrder(ctx context.Context, userID uint64, req *application.CreateOrderRequest) (*application.OrderData, error) {
+	orderData, err := s.OrderService.CreateOrder(ctx, userID, req)
+	if err != nil {
+		return nil, s.observeError(err)
+	}
+	s.metrics.ordersCreated.WithLabelValues(orderData.MerchantID).Inc()
+	return orderData, nil
+}
+
+// QuoteOrder 统计校验失败
+func (s *orderService) QuoteOrder(ctx context.Context, userID uint64, req *application.CreateOrderRequest) (*application.QuoteData, error) {
+	quote, err := s.OrderService.QuoteOrder(ctx, userID, req)
+	return quote, s.observeError(err)
+}
+
+// GetOrder 统计校验失败
+func (s *orderService) GetOrder(ctx context.Context, userID uint64, orderNumber string) (*application.OrderData, error) {
+	orderData, err := s.OrderService.GetOrder(ctx, userID, orderNumber)
+	return orderData, s.observeError(err)
+}
+
+// ListOrders 统计校验失败
+func (s *orderService) ListOrders(ctx context.Context, userID uint64, req *application.ListOrdersRequest) (*application.OrderListData, error) {
+	list, err := s.OrderService.ListOrders(ctx, userID, req)
+	return list, s.observeError(err)
+}
+
+// ConfirmPayment 统计支付的订单数和 GMV
+func (s *orderService) ConfirmPayment(ctx context.Context, req *application.ConfirmPaymentRequest) (*application.OrderData, error) {
+	orderData, err := s.OrderService.ConfirmPayment(ctx, req)
+	if err != nil {
+		return nil, s.observeError(err)
+	}
+	s.metrics.ordersPaid.WithLabelValues(orderData.MerchantID).Inc()
+	if amount, err := strconv.ParseFloat(orderData.Pricing.FinalAmount, 64); err == nil {
+		s.metrics.gmv.WithLabelValues(orderData.MerchantID).Add(amount)
+	}
+	return orderData, nil
+}
+
+// CancelOrder 统计取消的订单数
+func (s *orderService) CancelOrder(ctx context.Context, userID uint64, orderNumber string, req *application.CancelOrderRequest) (*application.OrderData, error) {
+	orderData, err := s.OrderService.CancelOrder(ctx, userID, orderNumber, req)
+	if err != nil {
+		return nil, s.observeError(err)
+	}
+	s.metrics.ordersCancelled.WithLabelValues(orderData.MerchantID).Inc()
+	return orderData, nil
+}
+
+// RefundOrder 统计校验失败
+func (s *orderService) RefundOrder(ctx context.Context, userID uint64, orderNumber string, req *application.RefundOrderRequest) (*application.RefundData, error) {
+	refund, err := s.OrderService.RefundOrder(ctx, userID, orderNumber, req)
+	return refund, s.observeError(err)
+}
+
+// AcceptOrder 统计校验失败
+func (s *orderService) AcceptOrder(ctx context.Context, merchantID, orderNumber string) (*application.OrderData, error) {
+	orderData, err := s.OrderService.AcceptOrder(ctx, merchantID, orderNumber)
+	return orderData, s.observeError(err)
+}
+
+// RejectOrder 统计校验失败
+func (s *orderService) RejectOrder(ctx context.Context, merchantID, orderNumber string, req *application.RejectOrderRequest) (*application.OrderData, error) {
+	orderData, err := s.OrderService.RejectOrder(ctx, merchantID, orderNumber, req)
+	return orderData, s.observeError(err)
+}
+
+// observeError 应用层校验失败时按字段计数，原样返回错误
+func (s *orderService) observeError(err error) error {
+	var validationErr *application.ValidationError
+	if errors.As(err, &validationErr) {
+		s.metrics.RecordValidationFailure(ValidationSourceApplication, validationErr.Field)
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+)
+
+// 仓储调用结果
+const (
+	outcomeSuccess  = "success"
+	outcomeNotFound = "not_found"
+	outcomeError    = "error"
+)
+
+// orderRepository 记录调用耗时的 OrderRepository 装饰器
+type orderRepository struct {
+	inner   application.OrderRepository
+	metrics *Metrics
+}
+
+// NewOrderRepository 为 OrderRepository 增加调用耗时统计
+func NewOrderRepository(inner application.OrderRepository, m *Metrics) application.OrderRepository {
+	return &orderRepository{inner: inner, metrics: m}
+}
+
+// Create 创建订单
+func (r *orderRepository) Create(ctx context.Context, order *domain.Order) error {
+	start := time.Now()
+	err := r.inner.Create(ctx, order)
+	r.observe("create", start, err)
+	return err
+}
+
+// FindByOrderNumber 根据订单号查询订单
+func (r *orderRepository) FindByOrderNumber(ctx context.Context, orderNumber string) (*domain.Order, error) {
+	start := time.Now()
+	order, err := r.inner.FindByOrderNumber(ctx, orderNumber)
+	r.observe("find_by_order_number", start, err)
+	return order, err
+}
+
+// Update 更新订单
+func (r *orderRepository) Update(ctx context.Context, order *domain.Order) error {
+	start := time.Now()
+	err := r.inner.Update(ctx, order)
+	r.observe("update", start, err)
+	return err
+}
+
+// FindByUser 分页查询用户订单
+func (r *orderRepository) FindByUser(ctx context.Context, query application.OrderListQuery) ([]*domain.Order, error) {
+	start := time.Now()
+	orders, err := r.inner.FindByUser(ctx, query)
+	r.observe("find_by_user", start, err)
+	return orders, err
+}
+
+// CountActiveByMerchant 统计商家处理中的订单数
+func (r *orderRepository) CountActiveByMerchant(ctx context.Context, merchantID string) (int, error) {
+	start := time.Now()
+	count, err := r.inner.CountActiveByMerchant(ctx, merchantID)
+	r.observe("count_active_by_merchant", start, err)
+	return count, err
+}
+
+// FindDueScheduled 查询到期待推送的预订单
+func (r *orderRepository) FindDueScheduled(ctx context.Context, before time.Time, limit int) ([]*domain.Order, error) {
+	start := time.Now()
+	orders, err := r.inner.FindDueScheduled(ctx, before, limit)
+	r.observe("find_due_scheduled", start, err)
+	return orders, err
+}
+
+// FindUnpaidBefore 查询支付超时的订单
+func (r *orderRepository) FindUnpaidBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Order, error) {
+	start := time.Now()
+	orders, err := r.inner.FindUnpaidBefore(ctx, before, limit)
+	r.observe("find_unpaid_before", start, err)
+	return orders, err
+}
+
+// observe 记录一次调用的耗时和结果
+func (r *orderRepository) observe(operation string, start time.Time, err error) {
+	r.metrics.repositoryDuration.
+		WithLabelValues("order", operation, outcome(err)).
+		Observe(time.Since(start).Seconds())
+}
+
+// outcome 调用结果标签（记录不存在不视为错误）
+func outcome(err error) string {
+	var notFound *application.NotFoundError
+	switch {
+	case err == nil:
+		return outcomeSuccess
+	case errors.As(err, &notFound):
+		return outcomeNotFound
+	default:
+		return outcomeError
+	}
+}
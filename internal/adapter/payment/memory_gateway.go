@@ -0,0 +1,50 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"order-service/internal/application"
+)
+
+// InMemoryPaymentGateway 内存支付网关实现（本地开发和测试使用，退款直接成功）
+type InMemoryPaymentGateway struct {
+	mu      sync.Mutex
+	refunds map[string]application.PaymentRefundRequest // 按 RefundID 索引
+}
+
+// NewInMemoryPaymentGateway 创建内存支付网关实例
+func NewInMemoryPaymentGateway() *InMemoryPaymentGateway {
+	return &InMemoryPaymentGateway{
+		refunds: make(map[string]application.PaymentRefundRequest),
+	}
+}
+
+// Refund 提交退款（同一退款ID重复提交视为幂等）
+func (g *InMemoryPaymentGateway) Refund(ctx context.Context, req *application.PaymentRefundRequest) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if existing, exists := g.refunds[req.RefundID]; exists {
+		if !existing.Amount.Equal(req.Amount) {
+			return fmt.Errorf("refund %s already submitted with a different amount", req.RefundID)
+		}
+		return nil
+	}
+
+	g.refunds[req.RefundID] = *req
+	return nil
+}
+
+// Refunds 返回已提交的退款（按 RefundID 索引）
+func (g *InMemoryPaymentGateway) Refunds() map[string]application.PaymentRefundRequest {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	result := make(map[string]application.PaymentRefundRequest, len(g.refunds))
+	for id, refund := range g.refunds {
+		result[id] = refund
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+package payment
+
+import (
+	"context"
+	"testing"
+
+	"order-service/internal/application"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryPaymentGateway_Refund(t *testing.T) {
+	gateway := NewInMemoryPaymentGateway()
+	ctx := context.Background()
+
+	req := &application.PaymentRefundRequest{
+		OrderNumber: "20241117120000123456",
+		PaymentID:   "pay_001",
+		RefundID:    "20241117120000123456R01",
+		Amount:      decimal.NewFromFloat(28.41),
+	}
+
+	assert.NoError(t, gateway.Refund(ctx, req))
+	// 重复提交相同退款是幂等的
+	assert.NoError(t, gateway.Refund(ctx, req))
+	assert.Len(t, gateway.Refunds(), 1)
+	assert.Equal(t, "28.41", gateway.Refunds()[req.RefundID].Amount.StringFixed(2))
+}
+
+func TestInMemoryPaymentGateway_Refund_AmountMismatch(t *testing.T) {
+	gateway := NewInMemoryPaymentGateway()
+	ctx := context.Background()
+
+	req := &application.PaymentRefundRequest{RefundID: "R01", Amount: decimal.NewFromFloat(10)}
+	assert.NoError(t, gateway.Refund(ctx, req))
+
+	err := gateway.Refund(ctx, &application.PaymentRefundRequest{RefundID: "R01", Amount: decimal.NewFromFloat(20)})
+	assert.Error(t, err)
+}
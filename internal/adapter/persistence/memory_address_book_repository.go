@@ -0,0 +1,60 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+)
+
+// InMemoryAddressBookRepository 内存地址簿仓储实现
+// 读写时复制实体，避免调用方修改共享状态
+type InMemoryAddressBookRepository struct {
+	mu    sync.RWMutex
+	books map[uint64]domain.AddressBook // 按用户ID索引
+}
+
+// NewInMemoryAddressBookRepository 创建内存地址簿仓储实例
+func NewInMemoryAddressBookRepository() *InMemoryAddressBookRepository {
+	return &InMemoryAddressBookRepository{
+		books: make(map[uint64]domain.AddressBook),
+	}
+}
+
+// Find 查询用户地址簿
+func (r *InMemoryAddressBookRepository) Find(ctx context.Context, userID uint64) (*domain.AddressBook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	book, exists := r.books[userID]
+	if !exists {
+		return nil, application.NewNotFoundError(fmt.Sprintf("address book of user %d not found", userID))
+	}
+	result := cloneAddressBook(&book)
+	return &result, nil
+}
+
+// Save 保存用户地址簿（新增或替换）
+func (r *InMemoryAddressBookRepository) Save(ctx context.Context, book *domain.AddressBook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.books[book.UserID] = cloneAddressBook(book)
+	return nil
+}
+
+// cloneAddressBook 深拷贝地址簿
+func cloneAddressBook(book *domain.AddressBook) domain.AddressBook {
+	result := *book
+	result.Addresses = slices.Clone(book.Addresses)
+	for i, address := range result.Addresses {
+		if address.Delivery.Location != nil {
+			location := *address.Delivery.Location
+			result.Addresses[i].Delivery.Location = &location
+		}
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryAddressBookRepository_SaveAndFind(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryAddressBookRepository()
+	book := domain.NewAddressBook(1001)
+	_, err := book.Add(domain.AddressLabelHome, domain.DeliveryInfo{
+		RecipientName:  "张三",
+		RecipientPhone: "13800138000",
+		Address:        "北京市朝阳区xxx",
+		Location:       &domain.GeoPoint{Latitude: 39.9087, Longitude: 116.3975},
+	}, false, time.Now())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act
+	_, missing := repo.Find(ctx, 1001)
+	require.NoError(t, repo.Save(ctx, book))
+	book.Addresses[0].Delivery.Location.Latitude = 0
+	found, err := repo.Find(ctx, 1001)
+	require.NoError(t, err)
+	found.Addresses[0].Label = domain.AddressLabelOffice
+	again, _ := repo.Find(ctx, 1001)
+
+	// Assert - 读写都是副本
+	var notFound *application.NotFoundError
+	assert.ErrorAs(t, missing, &notFound)
+	assert.Equal(t, 39.9087, again.Addresses[0].Delivery.Location.Latitude)
+	assert.Equal(t, domain.AddressLabelHome, again.Addresses[0].Label)
+	assert.True(t, again.Addresses[0].IsDefault)
+}
@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+)
+
+// defaultCartSweepInterval 清理过期购物车的间隔
+const defaultCartSweepInterval = time.Minute
+
+// InMemoryCartRepository 内存购物车仓储实现
+// 读写时复制购物车，过期购物车视为不存在，并在写入时定期清理
+type InMemoryCartRepository struct {
+	mu        sync.Mutex
+	carts     map[string]domain.Cart // 按 "用户ID/商家ID" 索引
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+// CartRepositoryOption 内存购物车仓储可选配置
+type CartRepositoryOption func(*InMemoryCartRepository)
+
+// WithCartClock 配置时钟（测试使用）
+func WithCartClock(now func() time.Time) CartRepositoryOption {
+	return func(r *InMemoryCartRepository) {
+		r.now = now
+	}
+}
+
+// NewInMemoryCartRepository 创建内存购物车仓储实例
+func NewInMemoryCartRepository(opts ...CartRepositoryOption) *InMemoryCartRepository {
+	r := &InMemoryCartRepository{
+		carts: make(map[string]domain.Cart),
+		now:   time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.lastSweep = r.now()
+	return r
+}
+
+// Find 查询用户在商家下的购物车
+func (r *InMemoryCartRepository) Find(ctx context.Context, userID uint64, merchantID string) (*domain.Cart, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := cartKey(userID, merchantID)
+	cart, exists := r.carts[key]
+	if !exists || cart.IsExpired(r.now()) {
+		return nil, application.NewNotFoundError(fmt.Sprintf("cart of merchant %s not found", merchantID))
+	}
+	result := cloneCart(&cart)
+	return &result, nil
+}
+
+// Save 保存购物车（新增或覆盖）
+func (r *InMemoryCartRepository) Save(ctx context.Context, cart *domain.Cart) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sweep(r.now())
+	r.carts[cartKey(cart.UserID, cart.MerchantID)] = cloneCart(cart)
+	return nil
+}
+
+// Delete 删除购物车（不存在时忽略）
+func (r *InMemoryCartRepository) Delete(ctx context.Context, userID uint64, merchantID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.carts, cartKey(userID, merchantID))
+	return nil
+}
+
+// sweep 定期删除已过期的购物车，避免废弃购物车无限增长
+func (r *InMemoryCartRepository) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < defaultCartSweepInterval {
+		return
+	}
+	r.lastSweep = now
+	for key, cart := range r.carts {
+		if cart.IsExpired(now) {
+			delete(r.carts, key)
+		}
+	}
+}
+
+// cartKey 购物车索引键
+func cartKey(userID uint64, merchantID string) string {
+	return fmt.Sprintf("%d/%s", userID, merchantID)
+}
+
+// cloneCart 复制购物车（含购物车行及其规格和组件选择）
+func cloneCart(cart *domain.Cart) domain.Cart {
+	result := *cart
+	result.Items = slices.Clone(cart.Items)
+	for i := range result.Items {
+		result.Items[i].Options = slices.Clone(result.Items[i].Options)
+		result.Items[i].Components = slices.Clone(result.Items[i].Components)
+	}
+	return result
+}
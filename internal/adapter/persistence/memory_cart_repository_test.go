@@ -0,0 +1,63 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryCartRepository_SaveAndFind(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := NewInMemoryCartRepository(WithCartClock(func() time.Time { return now }))
+	cart := domain.NewCart(1001, "merchant_001", now, time.Hour)
+	_, err := cart.AddItem(domain.CartItem{
+		DishID:   "dish_101",
+		DishName: "牛肉面",
+		Quantity: 1,
+		Price:    decimal.NewFromInt(22),
+		Options:  []domain.OptionSelection{{GroupID: "size", OptionID: "large"}},
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Act
+	require.NoError(t, repo.Save(ctx, cart))
+	cart.Items[0].Options[0].OptionID = "changed"
+	found, err := repo.Find(ctx, 1001, "merchant_001")
+	require.NoError(t, err)
+	found.Items[0].Quantity = 9
+	again, _ := repo.Find(ctx, 1001, "merchant_001")
+	_, otherUserErr := repo.Find(ctx, 2002, "merchant_001")
+
+	// Assert - 读写都是副本，购物车按用户和商家隔离
+	assert.Equal(t, "large", again.Items[0].Options[0].OptionID)
+	assert.Equal(t, 1, again.Items[0].Quantity)
+	var notFound *application.NotFoundError
+	assert.ErrorAs(t, otherUserErr, &notFound)
+}
+
+func TestInMemoryCartRepository_ExpiredCartIsNotFoundAndSwept(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := NewInMemoryCartRepository(WithCartClock(func() time.Time { return now }))
+	ctx := context.Background()
+	require.NoError(t, repo.Save(ctx, domain.NewCart(1001, "merchant_001", now, time.Hour)))
+
+	// Act
+	now = now.Add(2 * time.Hour)
+	_, err := repo.Find(ctx, 1001, "merchant_001")
+	require.NoError(t, repo.Save(ctx, domain.NewCart(1001, "merchant_002", now, time.Hour)))
+
+	// Assert - 过期购物车视为不存在，写入时被清理
+	var notFound *application.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Len(t, repo.carts, 1)
+}
@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+)
+
+// InMemoryDeliveryAreaRepository 内存商家配送范围仓储实现
+// 读写时复制实体，避免调用方修改共享状态
+type InMemoryDeliveryAreaRepository struct {
+	mu    sync.RWMutex
+	areas map[string]domain.DeliveryArea // 按商家ID索引
+}
+
+// NewInMemoryDeliveryAreaRepository 创建内存商家配送范围仓储实例
+func NewInMemoryDeliveryAreaRepository() *InMemoryDeliveryAreaRepository {
+	return &InMemoryDeliveryAreaRepository{
+		areas: make(map[string]domain.DeliveryArea),
+	}
+}
+
+// Find 查询商家配送范围
+func (r *InMemoryDeliveryAreaRepository) Find(ctx context.Context, merchantID string) (*domain.DeliveryArea, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	area, exists := r.areas[merchantID]
+	if !exists {
+		return nil, application.NewNotFoundError(fmt.Sprintf("delivery area of merchant %s not found", merchantID))
+	}
+	result := cloneDeliveryArea(&area)
+	return &result, nil
+}
+
+// Save 保存商家配送范围（新增或替换）
+func (r *InMemoryDeliveryAreaRepository) Save(ctx context.Context, area *domain.DeliveryArea) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.areas[area.MerchantID] = cloneDeliveryArea(area)
+	return nil
+}
+
+// Delete 删除商家配送范围
+func (r *InMemoryDeliveryAreaRepository) Delete(ctx context.Context, merchantID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.areas[merchantID]; !exists {
+		return application.NewNotFoundError(fmt.Sprintf("delivery area of merchant %s not found", merchantID))
+	}
+	delete(r.areas, merchantID)
+	return nil
+}
+
+// cloneDeliveryArea 深拷贝商家配送范围
+func cloneDeliveryArea(area *domain.DeliveryArea) domain.DeliveryArea {
+	result := *area
+	result.Zones = make([]domain.DeliveryZone, len(area.Zones))
+	for i, zone := range area.Zones {
+		result.Zones[i] = domain.DeliveryZone{Name: zone.Name, Polygon: slices.Clone(zone.Polygon)}
+	}
+	result.FeeBands = slices.Clone(area.FeeBands)
+	return result
+}
@@ -0,0 +1,46 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryDeliveryAreaRepository_SaveFindAndDelete(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryDeliveryAreaRepository()
+	area := &domain.DeliveryArea{
+		MerchantID: "merchant_001",
+		Origin:     domain.GeoPoint{Latitude: 39.9087, Longitude: 116.3975},
+		Zones: []domain.DeliveryZone{{Name: "东城", Polygon: []domain.GeoPoint{
+			{Latitude: 39.90, Longitude: 116.38}, {Latitude: 39.90, Longitude: 116.42}, {Latitude: 39.93, Longitude: 116.40},
+		}}},
+		FeeBands: []domain.DeliveryFeeBand{{MaxDistance: 3000, Fee: decimal.NewFromInt(3)}},
+	}
+	ctx := context.Background()
+
+	// Act
+	require.NoError(t, repo.Save(ctx, area))
+	area.Zones[0].Polygon[0].Latitude = 0
+	found, err := repo.Find(ctx, "merchant_001")
+	require.NoError(t, err)
+	found.FeeBands[0].MaxDistance = 1
+	again, _ := repo.Find(ctx, "merchant_001")
+	deleteErr := repo.Delete(ctx, "merchant_001")
+	_, afterDelete := repo.Find(ctx, "merchant_001")
+	deleteAgain := repo.Delete(ctx, "merchant_001")
+
+	// Assert - 读写都是副本
+	require.NoError(t, deleteErr)
+	assert.Equal(t, 39.90, again.Zones[0].Polygon[0].Latitude)
+	assert.Equal(t, 3000, again.FeeBands[0].MaxDistance)
+	var notFound *application.NotFoundError
+	assert.ErrorAs(t, afterDelete, &notFound)
+	assert.ErrorAs(t, deleteAgain, &notFound)
+}
@@ -0,0 +1,69 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// InMemoryDeliverySlotRepository 内存配送时段预订仓储实现
+// 名额检查和占用在同一把锁内完成，模拟数据库的条件更新
+type InMemoryDeliverySlotRepository struct {
+	mu    sync.Mutex
+	slots map[slotKey]map[string]struct{} // 时段 -> 已预订的订单号
+}
+
+// slotKey 时段标识（商家ID + 时段开始的 Unix 时间，与时区无关）
+type slotKey struct {
+	merchantID string
+	start      int64
+}
+
+// NewInMemoryDeliverySlotRepository 创建内存配送时段预订仓储实例
+func NewInMemoryDeliverySlotRepository() *InMemoryDeliverySlotRepository {
+	return &InMemoryDeliverySlotRepository{
+		slots: make(map[slotKey]map[string]struct{}),
+	}
+}
+
+// Reserve 为订单预订时段
+func (r *InMemoryDeliverySlotRepository) Reserve(ctx context.Context, merchantID string, slot time.Time, orderNumber string, capacity int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := slotKey{merchantID: merchantID, start: slot.Unix()}
+	orders := r.slots[key]
+	if _, ok := orders[orderNumber]; ok {
+		return true, nil
+	}
+	if capacity > 0 && len(orders) >= capacity {
+		return false, nil
+	}
+	if orders == nil {
+		orders = make(map[string]struct{})
+		r.slots[key] = orders
+	}
+	orders[orderNumber] = struct{}{}
+	return true, nil
+}
+
+// Release 释放订单的时段预订
+func (r *InMemoryDeliverySlotRepository) Release(ctx context.Context, merchantID string, slot time.Time, orderNumber string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := slotKey{merchantID: merchantID, start: slot.Unix()}
+	delete(r.slots[key], orderNumber)
+	if len(r.slots[key]) == 0 {
+		delete(r.slots, key)
+	}
+	return nil
+}
+
+// Count 统计时段已预订的订单数
+func (r *InMemoryDeliverySlotRepository) Count(ctx context.Context, merchantID string, slot time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.slots[slotKey{merchantID: merchantID, start: slot.Unix()}]), nil
+}
@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryDeliverySlotRepository_ReserveAndRelease(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryDeliverySlotRepository()
+	slot := time.Date(2025, 6, 3, 12, 30, 0, 0, time.FixedZone("CST", 8*3600))
+	ctx := context.Background()
+
+	// Act
+	first, err := repo.Reserve(ctx, "merchant_001", slot, "order_1", 2)
+	require.NoError(t, err)
+	again, _ := repo.Reserve(ctx, "merchant_001", slot.UTC(), "order_1", 2)
+	second, _ := repo.Reserve(ctx, "merchant_001", slot, "order_2", 2)
+	full, _ := repo.Reserve(ctx, "merchant_001", slot, "order_3", 2)
+	other, _ := repo.Reserve(ctx, "merchant_002", slot, "order_4", 2)
+	require.NoError(t, repo.Release(ctx, "merchant_001", slot, "order_1"))
+	afterRelease, _ := repo.Reserve(ctx, "merchant_001", slot, "order_3", 2)
+	count, err := repo.Count(ctx, "merchant_001", slot)
+
+	// Assert - 同一订单重复预订不占用名额，时段按时刻而非时区区分
+	require.NoError(t, err)
+	assert.True(t, first)
+	assert.True(t, again)
+	assert.True(t, second)
+	assert.False(t, full)
+	assert.True(t, other)
+	assert.True(t, afterRelease)
+	assert.Equal(t, 2, count)
+}
+
+func TestInMemoryDeliverySlotRepository_UnlimitedCapacity(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryDeliverySlotRepository()
+	slot := time.Date(2025, 6, 3, 4, 30, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	// Act
+	for _, orderNumber := range []string{"order_1", "order_2", "order_3"} {
+		reserved, err := repo.Reserve(ctx, "merchant_001", slot, orderNumber, 0)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+	}
+	count, err := repo.Count(ctx, "merchant_001", slot)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
@@ -0,0 +1,78 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+)
+
+// InMemoryInventoryRepository 内存餐品库存仓储实现
+// 读写时复制实体，避免调用方修改共享状态；Update 持有写锁执行，同一进程内并发下单不会超卖
+type InMemoryInventoryRepository struct {
+	mu          sync.RWMutex
+	inventories map[string]domain.Inventory // 按商家ID索引
+}
+
+// NewInMemoryInventoryRepository 创建内存餐品库存仓储实例
+func NewInMemoryInventoryRepository() *InMemoryInventoryRepository {
+	return &InMemoryInventoryRepository{
+		inventories: make(map[string]domain.Inventory),
+	}
+}
+
+// Find 查询商家库存
+func (r *InMemoryInventoryRepository) Find(ctx context.Context, merchantID string) (*domain.Inventory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	inv, exists := r.inventories[merchantID]
+	if !exists {
+		return nil, application.NewNotFoundError(fmt.Sprintf("inventory of merchant %s not found", merchantID))
+	}
+	result := cloneInventory(&inv)
+	return &result, nil
+}
+
+// Update 加锁加载、修改并保存商家库存（update 返回错误时不保存）
+func (r *InMemoryInventoryRepository) Update(ctx context.Context, merchantID string, update func(inv *domain.Inventory) error) (*domain.Inventory, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	inv := domain.NewInventory(merchantID)
+	if stored, exists := r.inventories[merchantID]; exists {
+		cloned := cloneInventory(&stored)
+		inv = &cloned
+	}
+	if err := update(inv); err != nil {
+		return nil, err
+	}
+	r.inventories[merchantID] = cloneInventory(inv)
+	return inv, nil
+}
+
+// cloneInventory 深拷贝商家库存
+func cloneInventory(inv *domain.Inventory) domain.Inventory {
+	result := *inv
+	result.Dishes = slices.Clone(inv.Dishes)
+	for i, dish := range result.Dishes {
+		if dish.Stock != nil {
+			stock := *dish.Stock
+			result.Dishes[i].Stock = &stock
+		}
+	}
+	result.DailySold = make(map[string]map[string]int, len(inv.DailySold))
+	for day, sold := range inv.DailySold {
+		result.DailySold[day] = maps.Clone(sold)
+	}
+	result.Reservations = make(map[string]domain.StockReservation, len(inv.Reservations))
+	for orderNumber, reservation := range inv.Reservations {
+		reservation.Lines = slices.Clone(reservation.Lines)
+		result.Reservations[orderNumber] = reservation
+	}
+	return result
+}
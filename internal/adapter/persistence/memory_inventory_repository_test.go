@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryInventoryRepository_UpdateAndFind(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryInventoryRepository()
+	ctx := context.Background()
+	stock := 5
+
+	// Act
+	_, missing := repo.Find(ctx, "merchant_001")
+	saved, err := repo.Update(ctx, "merchant_001", func(inv *domain.Inventory) error {
+		inv.SetDishes([]domain.DishStock{{DishID: "dish_001", Stock: &stock}}, time.Now())
+		return inv.Reserve("ORD001", "2025-06-03", []domain.StockLine{{DishID: "dish_001", Quantity: 2}}, time.Now())
+	})
+	require.NoError(t, err)
+	*saved.Dishes[0].Stock = 0
+	saved.DailySold["2025-06-03"]["dish_001"] = 0
+	_, failed := repo.Update(ctx, "merchant_001", func(inv *domain.Inventory) error {
+		inv.Release("ORD001", time.Now())
+		return errors.New("rollback")
+	})
+	found, err := repo.Find(ctx, "merchant_001")
+	require.NoError(t, err)
+
+	// Assert - 读写都是副本，update 失败时不保存
+	var notFound *application.NotFoundError
+	assert.ErrorAs(t, missing, &notFound)
+	assert.EqualError(t, failed, "rollback")
+	assert.Equal(t, 3, *found.Dishes[0].Stock)
+	assert.Equal(t, 2, found.Sold("2025-06-03", "dish_001"))
+	assert.Contains(t, found.Reservations, "ORD001")
+}
+
+func TestInMemoryInventoryRepository_Update_Concurrent(t *testing.T) {
+	// Arrange - 剩余 5 份，20 个订单并发各占用 1 份
+	repo := NewInMemoryInventoryRepository()
+	ctx := context.Background()
+	stock := 5
+	_, err := repo.Update(ctx, "merchant_001", func(inv *domain.Inventory) error {
+		inv.SetDishes([]domain.DishStock{{DishID: "dish_001", Stock: &stock}}, time.Now())
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Act
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved := 0
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.Update(ctx, "merchant_001", func(inv *domain.Inventory) error {
+				return inv.Reserve(fmt.Sprintf("ORD%03d", i), "2025-06-03", []domain.StockLine{{DishID: "dish_001", Quantity: 1}}, time.Now())
+			})
+			if err == nil {
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	found, _ := repo.Find(ctx, "merchant_001")
+
+	// Assert - 恰好 5 个订单占用成功，不超卖
+	assert.Equal(t, 5, reserved)
+	assert.Equal(t, 0, *found.Dishes[0].Stock)
+	assert.Len(t, found.Reservations, 5)
+}
@@ -0,0 +1,94 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+)
+
+// InMemoryMenuCatalog 内存商家菜单实现
+// 读写时复制实体，避免调用方修改共享状态
+type InMemoryMenuCatalog struct {
+	mu     sync.RWMutex
+	dishes map[string]domain.Dish  // 按 "商家ID/餐品ID" 索引
+	combos map[string]domain.Combo // 按 "商家ID/套餐ID" 索引
+}
+
+// NewInMemoryMenuCatalog 创建内存商家菜单实例
+func NewInMemoryMenuCatalog() *InMemoryMenuCatalog {
+	return &InMemoryMenuCatalog{
+		dishes: make(map[string]domain.Dish),
+		combos: make(map[string]domain.Combo),
+	}
+}
+
+// SaveDish 保存商家菜单中的餐品（新增或更新）
+func (c *InMemoryMenuCatalog) SaveDish(merchantID string, dish *domain.Dish) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.dishes[menuKey(merchantID, dish.DishID)] = cloneDish(dish)
+}
+
+// FindDish 查询商家菜单中的餐品
+func (c *InMemoryMenuCatalog) FindDish(ctx context.Context, merchantID, dishID string) (*domain.Dish, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	dish, exists := c.dishes[menuKey(merchantID, dishID)]
+	if !exists {
+		return nil, application.NewNotFoundError(fmt.Sprintf("dish %s not found in menu of merchant %s", dishID, merchantID))
+	}
+	result := cloneDish(&dish)
+	return &result, nil
+}
+
+// SaveCombo 保存商家菜单中的套餐（新增或更新）
+func (c *InMemoryMenuCatalog) SaveCombo(merchantID string, combo *domain.Combo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.combos[menuKey(merchantID, combo.ComboID)] = cloneCombo(combo)
+}
+
+// FindCombo 查询商家菜单中的套餐
+func (c *InMemoryMenuCatalog) FindCombo(ctx context.Context, merchantID, comboID string) (*domain.Combo, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	combo, exists := c.combos[menuKey(merchantID, comboID)]
+	if !exists {
+		return nil, application.NewNotFoundError(fmt.Sprintf("combo %s not found in menu of merchant %s", comboID, merchantID))
+	}
+	result := cloneCombo(&combo)
+	return &result, nil
+}
+
+// menuKey 菜单索引键
+func menuKey(merchantID, dishID string) string {
+	return merchantID + "/" + dishID
+}
+
+// cloneDish 复制餐品（含选项组和选项）
+func cloneDish(dish *domain.Dish) domain.Dish {
+	result := *dish
+	result.OptionGroups = slices.Clone(dish.OptionGroups)
+	for i := range result.OptionGroups {
+		result.OptionGroups[i].Options = slices.Clone(result.OptionGroups[i].Options)
+	}
+	return result
+}
+
+// cloneCombo 复制套餐（含组件位和可选餐品）
+func cloneCombo(combo *domain.Combo) domain.Combo {
+	result := *combo
+	result.Slots = slices.Clone(combo.Slots)
+	for i := range result.Slots {
+		result.Slots[i].Choices = slices.Clone(result.Slots[i].Choices)
+	}
+	return result
+}
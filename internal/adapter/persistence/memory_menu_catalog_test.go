@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryMenuCatalog_FindDish(t *testing.T) {
+	// Arrange
+	catalog := NewInMemoryMenuCatalog()
+	catalog.SaveDish("merchant_001", &domain.Dish{
+		DishID: "dish_101",
+		Name:   "牛肉面",
+		Price:  decimal.NewFromInt(20),
+		OptionGroups: []domain.OptionGroup{{
+			GroupID: "size",
+			Name:    "份量",
+			Options: []domain.DishOption{{OptionID: "large", Name: "大份", PriceDelta: decimal.NewFromInt(3)}},
+		}},
+	})
+	ctx := context.Background()
+
+	// Act
+	found, err := catalog.FindDish(ctx, "merchant_001", "dish_101")
+	require.NoError(t, err)
+	found.OptionGroups[0].Options[0].Name = "changed"
+	again, _ := catalog.FindDish(ctx, "merchant_001", "dish_101")
+	_, otherMerchantErr := catalog.FindDish(ctx, "merchant_002", "dish_101")
+
+	// Assert - 查询结果是副本，餐品按商家隔离
+	assert.Equal(t, "大份", again.OptionGroups[0].Options[0].Name)
+	var notFound *application.NotFoundError
+	assert.ErrorAs(t, otherMerchantErr, &notFound)
+}
+
+func TestInMemoryMenuCatalog_FindCombo(t *testing.T) {
+	// Arrange
+	catalog := NewInMemoryMenuCatalog()
+	catalog.SaveCombo("merchant_001", &domain.Combo{
+		ComboID: "combo_201",
+		Name:    "牛肉面套餐",
+		Price:   decimal.NewFromInt(30),
+		Slots: []domain.ComboSlot{{
+			SlotID:   "drink",
+			Name:     "饮料",
+			Quantity: 1,
+			Choices:  []domain.ComboChoice{{DishID: "dish_104", DishName: "酸梅汤", ListPrice: decimal.NewFromInt(6)}},
+		}},
+	})
+	ctx := context.Background()
+
+	// Act
+	found, err := catalog.FindCombo(ctx, "merchant_001", "combo_201")
+	require.NoError(t, err)
+	found.Slots[0].Choices[0].DishName = "changed"
+	again, _ := catalog.FindCombo(ctx, "merchant_001", "combo_201")
+	_, dishErr := catalog.FindDish(ctx, "merchant_001", "combo_201")
+
+	// Assert - 查询结果是副本，套餐和餐品分别索引
+	assert.Equal(t, "酸梅汤", again.Slots[0].Choices[0].DishName)
+	var notFound *application.NotFoundError
+	assert.ErrorAs(t, dishErr, &notFound)
+}
@@ -0,0 +1,55 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+)
+
+// InMemoryMerchantProfileRepository 内存商家营业配置仓储实现
+// 读写时复制实体，避免调用方修改共享状态
+type InMemoryMerchantProfileRepository struct {
+	mu       sync.RWMutex
+	profiles map[string]domain.MerchantProfile // 按商家ID索引
+}
+
+// NewInMemoryMerchantProfileRepository 创建内存商家营业配置仓储实例
+func NewInMemoryMerchantProfileRepository() *InMemoryMerchantProfileRepository {
+	return &InMemoryMerchantProfileRepository{
+		profiles: make(map[string]domain.MerchantProfile),
+	}
+}
+
+// Find 查询商家营业配置
+func (r *InMemoryMerchantProfileRepository) Find(ctx context.Context, merchantID string) (*domain.MerchantProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profile, exists := r.profiles[merchantID]
+	if !exists {
+		return nil, application.NewNotFoundError(fmt.Sprintf("profile of merchant %s not found", merchantID))
+	}
+	result := cloneMerchantProfile(&profile)
+	return &result, nil
+}
+
+// Save 保存商家营业配置（新增或更新）
+func (r *InMemoryMerchantProfileRepository) Save(ctx context.Context, profile *domain.MerchantProfile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.profiles[profile.MerchantID] = cloneMerchantProfile(profile)
+	return nil
+}
+
+// cloneMerchantProfile 深拷贝商家营业配置
+func cloneMerchantProfile(profile *domain.MerchantProfile) domain.MerchantProfile {
+	result := *profile
+	result.OpeningHours = slices.Clone(profile.OpeningHours)
+	result.Holidays = slices.Clone(profile.Holidays)
+	return result
+}
@@ -0,0 +1,37 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryMerchantProfileRepository_SaveAndFind(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryMerchantProfileRepository()
+	profile := domain.NewMerchantProfile("merchant_001", time.UTC)
+	profile.OpeningHours = []domain.OpeningHours{{Weekday: time.Monday, Open: 600, Close: 840}}
+	profile.Holidays = []string{"2025-06-02"}
+	ctx := context.Background()
+
+	// Act
+	require.NoError(t, repo.Save(ctx, profile))
+	profile.OpeningHours[0].Close = 900
+	found, err := repo.Find(ctx, "merchant_001")
+	require.NoError(t, err)
+	found.Holidays[0] = "changed"
+	again, _ := repo.Find(ctx, "merchant_001")
+	_, otherErr := repo.Find(ctx, "merchant_002")
+
+	// Assert - 读写都是副本
+	assert.Equal(t, 840, again.OpeningHours[0].Close)
+	assert.Equal(t, []string{"2025-06-02"}, again.Holidays)
+	var notFound *application.NotFoundError
+	assert.ErrorAs(t, otherErr, &notFound)
+}
@@ -3,40 +3,256 @@ package persistence
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
 
 	"order-service/internal/application"
 	"order-service/internal/domain"
 )
 
-// InMemoryOrderRepository 内存订单仓储实现
+// InMemoryOrderRepository 内存订单仓储实现（同时实现事件 outbox）
+// 订单与 outbox 记录在同一把锁内写入，模拟数据库事务的原子性；
+// 读写时复制订单，Update 按版本号检测并发修改（乐观锁）
 type InMemoryOrderRepository struct {
-	orders map[string]*domain.Order // 按 OrderNumber 索引
+	mu          sync.RWMutex
+	orders      map[string]*domain.Order // 按 OrderNumber 索引
+	outbox      map[string]application.OutboxEntry
+	outboxOrder []string // outbox 记录写入顺序
 }
 
 // NewInMemoryOrderRepository 创建内存仓储实例
-func NewInMemoryOrderRepository() application.OrderRepository {
+func NewInMemoryOrderRepository() *InMemoryOrderRepository {
 	return &InMemoryOrderRepository{
 		orders: make(map[string]*domain.Order),
+		outbox: make(map[string]application.OutboxEntry),
 	}
 }
 
 // Create 创建订单
 func (r *InMemoryOrderRepository) Create(ctx context.Context, order *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	// 检查订单号唯一性
 	if _, exists := r.orders[order.OrderNumber]; exists {
 		return fmt.Errorf("order number %s already exists", order.OrderNumber)
 	}
 
-	r.orders[order.OrderNumber] = order
+	r.appendOutbox(order)
+	order.Version = 1
+	r.orders[order.OrderNumber] = cloneOrder(order)
 	return nil
 }
 
 // FindByOrderNumber 根据订单号查询订单
 func (r *InMemoryOrderRepository) FindByOrderNumber(ctx context.Context, orderNumber string) (*domain.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	order, exists := r.orders[orderNumber]
 	if !exists {
 		return nil, application.NewNotFoundError(fmt.Sprintf("order %s not found", orderNumber))
 	}
 
-	return order, nil
+	return cloneOrder(order), nil
+}
+
+// Update 更新订单（订单在加载后已被其他请求修改时返回 domain.ErrOrderConflict）
+func (r *InMemoryOrderRepository) Update(ctx context.Context, order *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.orders[order.OrderNumber]
+	if !exists {
+		return application.NewNotFoundError(fmt.Sprintf("order %s not found", order.OrderNumber))
+	}
+	if stored.Version != order.Version {
+		return fmt.Errorf("order %s version %d is stale (current %d): %w",
+			order.OrderNumber, order.Version, stored.Version, domain.ErrOrderConflict)
+	}
+
+	r.appendOutbox(order)
+	order.Version++
+	r.orders[order.OrderNumber] = cloneOrder(order)
+	return nil
+}
+
+// FindByUser 按创建时间倒序查询用户订单
+func (r *InMemoryOrderRepository) FindByUser(ctx context.Context, query application.OrderListQuery) ([]*domain.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.Order
+	for _, order := range r.orders {
+		if order.UserID != query.UserID {
+			continue
+		}
+		if query.Status != "" && order.Status != query.Status {
+			continue
+		}
+		if query.After != nil && !query.After.Precedes(order) {
+			continue
+		}
+		result = append(result, cloneOrder(order))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+		return result[i].OrderNumber > result[j].OrderNumber
+	})
+	if query.Limit > 0 && len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+	return result, nil
+}
+
+// CountActiveByMerchant 统计商家同时处理中的订单数
+func (r *InMemoryOrderRepository) CountActiveByMerchant(ctx context.Context, merchantID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, order := range r.orders {
+		if order.MerchantID == merchantID && order.Active() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// FindDueScheduled 按配送时段升序查询到期待推送的预订单
+func (r *InMemoryOrderRepository) FindDueScheduled(ctx context.Context, before time.Time, limit int) ([]*domain.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.Order
+	for _, order := range r.orders {
+		if order.IsScheduled() && order.ReleasedAt.IsZero() &&
+			order.Status == domain.OrderStatusPaid && !order.ScheduledFor.After(before) {
+			result = append(result, cloneOrder(order))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].ScheduledFor.Equal(result[j].ScheduledFor) {
+			return result[i].ScheduledFor.Before(result[j].ScheduledFor)
+		}
+		return result[i].OrderNumber < result[j].OrderNumber
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// FindUnpaidBefore 按创建时间升序查询支付超时的订单
+func (r *InMemoryOrderRepository) FindUnpaidBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.Order
+	for _, order := range r.orders {
+		if order.Status == domain.OrderStatusPendingPayment && order.CreatedAt.Before(before) {
+			result = append(result, cloneOrder(order))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.Before(result[j].CreatedAt)
+		}
+		return result[i].OrderNumber < result[j].OrderNumber
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// FetchPendingOutbox 按写入顺序查询到期的待投递 outbox 记录
+func (r *InMemoryOrderRepository) FetchPendingOutbox(ctx context.Context, now time.Time, limit int) ([]application.OutboxEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []application.OutboxEntry
+	for _, id := range r.outboxOrder {
+		entry := r.outbox[id]
+		if entry.Status != application.OutboxStatusPending || entry.NextAttemptAt.After(now) {
+			continue
+		}
+		result = append(result, entry)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// UpdateOutboxEntry 更新 outbox 记录投递状态
+func (r *InMemoryOrderRepository) UpdateOutboxEntry(ctx context.Context, entry application.OutboxEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.outbox[entry.ID]; !exists {
+		return application.NewNotFoundError(fmt.Sprintf("outbox entry %s not found", entry.ID))
+	}
+
+	r.outbox[entry.ID] = entry
+	return nil
+}
+
+// FindOutboxEntries 查询指定状态的 outbox 记录（按创建时间排序）
+func (r *InMemoryOrderRepository) FindOutboxEntries(ctx context.Context, status application.OutboxStatus) ([]application.OutboxEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []application.OutboxEntry
+	for _, id := range r.outboxOrder {
+		if entry := r.outbox[id]; entry.Status == status {
+			result = append(result, entry)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// HealthCheck 就绪检查：能在超时前获取读锁即视为可用（数据库实现中对应连接池 Ping）
+func (r *InMemoryOrderRepository) HealthCheck(ctx context.Context) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return ctx.Err()
+}
+
+// appendOutbox 将订单待发布的领域事件写入 outbox（调用方需持有写锁）
+func (r *InMemoryOrderRepository) appendOutbox(order *domain.Order) {
+	for _, event := range order.PullEvents() {
+		entry := application.NewOutboxEntry(event)
+		r.outbox[entry.ID] = entry
+		r.outboxOrder = append(r.outboxOrder, entry.ID)
+	}
+}
+
+// cloneOrder 深拷贝订单（保存的订单不含待发布事件，副本同样不含）
+func cloneOrder(order *domain.Order) *domain.Order {
+	result := *order
+	if order.Delivery.Location != nil {
+		location := *order.Delivery.Location
+		result.Delivery.Location = &location
+	}
+	result.Items = slices.Clone(order.Items)
+	for i, item := range result.Items {
+		result.Items[i].Options = slices.Clone(item.Options)
+		result.Items[i].Components = slices.Clone(item.Components)
+	}
+	result.Refunds = slices.Clone(order.Refunds)
+	for i, refund := range result.Refunds {
+		result.Refunds[i].Items = slices.Clone(refund.Items)
+	}
+	return &result
 }
@@ -2,12 +2,17 @@ package persistence
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
+	"order-service/internal/adapter/payment"
+	"order-service/internal/application"
 	"order-service/internal/domain"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInMemoryOrderRepository_Create(t *testing.T) {
@@ -51,7 +56,95 @@ func TestInMemoryOrderRepository_FindByOrderNumber_NotFound(t *testing.T) {
 	assert.Contains(t, err.Error(), "not found")
 }
 
+func TestInMemoryOrderRepository_Update(t *testing.T) {
+	repo := NewInMemoryOrderRepository()
+	ctx := context.Background()
+
+	order := createTestOrder("20241117120000123456")
+	assert.NoError(t, repo.Create(ctx, order))
+
+	assert.NoError(t, order.MarkPaid("pay_001"))
+	err := repo.Update(ctx, order)
+	assert.NoError(t, err)
+
+	found, err := repo.FindByOrderNumber(ctx, order.OrderNumber)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusPaid, found.Status)
+}
+
+func TestInMemoryOrderRepository_Update_NotFound(t *testing.T) {
+	repo := NewInMemoryOrderRepository()
+	ctx := context.Background()
+
+	err := repo.Update(ctx, createTestOrder("nonexistent"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestInMemoryOrderRepository_Update_VersionConflict(t *testing.T) {
+	// Arrange - 两个请求先后加载同一订单
+	repo := NewInMemoryOrderRepository()
+	ctx := context.Background()
+	require.NoError(t, repo.Create(ctx, createTestOrder("20241117120000123456")))
+	first, err := repo.FindByOrderNumber(ctx, "20241117120000123456")
+	require.NoError(t, err)
+	second, err := repo.FindByOrderNumber(ctx, "20241117120000123456")
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, first.MarkPaid("pay_001"))
+	firstErr := repo.Update(ctx, first)
+	require.NoError(t, second.Cancel("点错了"))
+	secondErr := repo.Update(ctx, second)
+	found, _ := repo.FindByOrderNumber(ctx, "20241117120000123456")
+	found.Items[0].Quantity = 99
+	again, _ := repo.FindByOrderNumber(ctx, "20241117120000123456")
+
+	// Assert - 后保存的请求基于过期版本，不会覆盖已支付状态；查询返回副本
+	assert.NoError(t, firstErr)
+	assert.ErrorIs(t, secondErr, domain.ErrOrderConflict)
+	assert.Equal(t, 2, first.Version)
+	assert.Equal(t, domain.OrderStatusPaid, again.Status)
+	assert.Equal(t, 2, again.Items[0].Quantity)
+}
 
+func TestInMemoryOrderRepository_ConcurrentRefundsRespectFinalAmount(t *testing.T) {
+	// Arrange - 实付 60 元的已支付订单，10 个请求并发全额退款
+	repo := NewInMemoryOrderRepository()
+	gateway := payment.NewInMemoryPaymentGateway()
+	service := application.NewOrderService(repo, application.WithPaymentGateway(gateway))
+	ctx := context.Background()
+	order := createTestOrder("20241117120000123456")
+	require.NoError(t, repo.Create(ctx, order))
+	require.NoError(t, order.MarkPaid("pay_001"))
+	require.NoError(t, repo.Update(ctx, order))
+
+	// Act
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = service.RefundOrder(ctx, 1001, order.OrderNumber, &application.RefundOrderRequest{
+				Items: []application.RefundItemRequest{{DishID: "dish1", Quantity: 2}},
+			})
+		}()
+	}
+	wg.Wait()
+	found, err := repo.FindByOrderNumber(ctx, order.OrderNumber)
+	require.NoError(t, err)
+
+	// Assert - 只有一个退款成功，退款总额不超过实付金额
+	refunded := decimal.Zero
+	for _, refund := range gateway.Refunds() {
+		refunded = refunded.Add(refund.Amount)
+	}
+	assert.Len(t, gateway.Refunds(), 1)
+	assert.Len(t, found.Refunds, 1)
+	assert.True(t, refunded.Equal(found.Pricing.FinalAmount), refunded.String())
+	assert.True(t, found.RefundedAmount().Equal(found.Pricing.FinalAmount))
+	assert.Equal(t, domain.OrderStatusRefunded, found.Status)
+}
 
 // createTestOrder 创建测试订单
 func createTestOrder(orderNumber string) *domain.Order {
@@ -75,3 +168,170 @@ func createTestOrder(orderNumber string) *domain.Order {
 	order.OrderNumber = orderNumber
 	return order
 }
+
+func TestInMemoryOrderRepository_WritesOutboxWithOrder(t *testing.T) {
+	repo := NewInMemoryOrderRepository()
+	ctx := context.Background()
+
+	order := createTestOrder("20241117120000123456")
+	assert.NoError(t, repo.Create(ctx, order))
+	assert.NoError(t, order.MarkPaid("pay_001"))
+	assert.NoError(t, repo.Update(ctx, order))
+
+	// 聚合中的事件已转移到 outbox
+	assert.Empty(t, order.PendingEvents())
+	entries, err := repo.FetchPendingOutbox(ctx, time.Now(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, domain.EventTypeOrderCreated, entries[0].Event.EventType())
+	assert.Equal(t, domain.EventTypeOrderPaid, entries[1].Event.EventType())
+	assert.Equal(t, application.OutboxStatusPending, entries[0].Status)
+}
+
+func TestInMemoryOrderRepository_FailedCreateWritesNoOutbox(t *testing.T) {
+	repo := NewInMemoryOrderRepository()
+	ctx := context.Background()
+
+	assert.NoError(t, repo.Create(ctx, createTestOrder("20241117120000123456")))
+	duplicate := createTestOrder("20241117120000123456")
+	assert.Error(t, repo.Create(ctx, duplicate))
+
+	// 保存失败时事件保留在聚合中，不写入 outbox
+	assert.Len(t, duplicate.PendingEvents(), 1)
+	entries, _ := repo.FetchPendingOutbox(ctx, time.Now(), 10)
+	assert.Len(t, entries, 1)
+}
+
+func TestInMemoryOrderRepository_FetchPendingOutbox_RespectsSchedule(t *testing.T) {
+	repo := NewInMemoryOrderRepository()
+	ctx := context.Background()
+	assert.NoError(t, repo.Create(ctx, createTestOrder("20241117120000123456")))
+
+	entries, _ := repo.FetchPendingOutbox(ctx, time.Now(), 10)
+	entry := entries[0]
+	entry.NextAttemptAt = time.Now().Add(time.Minute)
+	assert.NoError(t, repo.UpdateOutboxEntry(ctx, entry))
+
+	entries, _ = repo.FetchPendingOutbox(ctx, time.Now(), 10)
+	assert.Empty(t, entries)
+	entries, _ = repo.FetchPendingOutbox(ctx, time.Now().Add(2*time.Minute), 10)
+	assert.Len(t, entries, 1)
+
+	entry.Status = application.OutboxStatusDeadLetter
+	assert.NoError(t, repo.UpdateOutboxEntry(ctx, entry))
+	dead, _ := repo.FindOutboxEntries(ctx, application.OutboxStatusDeadLetter)
+	assert.Len(t, dead, 1)
+}
+
+func TestInMemoryOrderRepository_FindByUser(t *testing.T) {
+	// Arrange - 两个订单创建时间相同，按订单号倒序
+	repo := NewInMemoryOrderRepository()
+	ctx := context.Background()
+	createdAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, orderNumber := range []string{"20250101120000000001", "20250101120000000002", "20250101120100000003"} {
+		order := createTestOrder(orderNumber)
+		order.CreatedAt = createdAt
+		if orderNumber == "20250101120100000003" {
+			order.CreatedAt = createdAt.Add(time.Minute)
+		}
+		assert.NoError(t, repo.Create(ctx, order))
+	}
+
+	// Act
+	page, err := repo.FindByUser(ctx, application.OrderListQuery{UserID: 1001, Limit: 2})
+	assert.NoError(t, err)
+	cursor := &application.OrderCursor{CreatedAt: page[1].CreatedAt, OrderNumber: page[1].OrderNumber}
+	next, err := repo.FindByUser(ctx, application.OrderListQuery{UserID: 1001, After: cursor, Limit: 2})
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "20250101120100000003", page[0].OrderNumber)
+	assert.Equal(t, "20250101120000000002", page[1].OrderNumber)
+	assert.Len(t, next, 1)
+	assert.Equal(t, "20250101120000000001", next[0].OrderNumber)
+
+	other, _ := repo.FindByUser(ctx, application.OrderListQuery{UserID: 2002, Limit: 10})
+	assert.Empty(t, other)
+}
+
+func TestInMemoryOrderRepository_FindDueScheduled(t *testing.T) {
+	// Arrange - 立即配送、12:30 和 13:00 时段的已支付订单，以及一个未支付的 12:00 预订单
+	repo := NewInMemoryOrderRepository()
+	ctx := context.Background()
+	noon := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+	newOrder := func(slot time.Time, paid bool) *domain.Order {
+		order := domain.NewScheduledOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "", domain.DefaultFees(), slot)
+		if paid {
+			assert.NoError(t, order.MarkPaid("pay_001"))
+		}
+		assert.NoError(t, repo.Create(ctx, order))
+		return order
+	}
+	newOrder(time.Time{}, true)
+	late := newOrder(noon.Add(time.Hour), true)
+	early := newOrder(noon.Add(30*time.Minute), true)
+	newOrder(noon, false)
+
+	// Act
+	due, err := repo.FindDueScheduled(ctx, noon.Add(time.Hour), 10)
+	assert.NoError(t, err)
+	active, err := repo.CountActiveByMerchant(ctx, "merchant_001")
+	assert.NoError(t, err)
+	assert.NoError(t, early.Release(noon))
+	assert.NoError(t, repo.Update(ctx, early))
+	remaining, _ := repo.FindDueScheduled(ctx, noon.Add(time.Hour), 10)
+	activeAfterRelease, _ := repo.CountActiveByMerchant(ctx, "merchant_001")
+
+	// Assert - 按配送时段升序，已推送的预订单不再返回；未推送的预订单不计入处理中的订单
+	if assert.Len(t, due, 2) {
+		assert.Equal(t, early.OrderNumber, due[0].OrderNumber)
+		assert.Equal(t, late.OrderNumber, due[1].OrderNumber)
+	}
+	assert.Len(t, remaining, 1)
+	assert.Equal(t, 1, active)
+	assert.Equal(t, 2, activeAfterRelease)
+}
+
+func TestInMemoryOrderRepository_FindUnpaidBefore(t *testing.T) {
+	// Arrange - 11:00 和 11:30 下单的待支付订单、11:10 下单的已支付订单
+	repo := NewInMemoryOrderRepository()
+	ctx := context.Background()
+	noon := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+	newOrder := func(createdAt time.Time, paid bool) *domain.Order {
+		order := domain.NewOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "")
+		order.CreatedAt = createdAt
+		if paid {
+			assert.NoError(t, order.MarkPaid("pay_001"))
+		}
+		assert.NoError(t, repo.Create(ctx, order))
+		return order
+	}
+	late := newOrder(noon.Add(-30*time.Minute), false)
+	newOrder(noon.Add(-50*time.Minute), true)
+	early := newOrder(noon.Add(-time.Hour), false)
+
+	// Act
+	all, err := repo.FindUnpaidBefore(ctx, noon, 10)
+	assert.NoError(t, err)
+	expired, _ := repo.FindUnpaidBefore(ctx, noon.Add(-45*time.Minute), 10)
+
+	// Assert - 按创建时间升序，只返回待支付的订单
+	if assert.Len(t, all, 2) {
+		assert.Equal(t, early.OrderNumber, all[0].OrderNumber)
+		assert.Equal(t, late.OrderNumber, all[1].OrderNumber)
+	}
+	if assert.Len(t, expired, 1) {
+		assert.Equal(t, early.OrderNumber, expired[0].OrderNumber)
+	}
+}
+
+func TestInMemoryOrderRepository_HealthCheck(t *testing.T) {
+	repo := NewInMemoryOrderRepository()
+	var _ application.HealthChecker = repo
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, repo.HealthCheck(ctx))
+
+	cancel()
+	assert.ErrorIs(t, repo.HealthCheck(ctx), context.Canceled)
+}
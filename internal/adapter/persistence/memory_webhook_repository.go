@@ -0,0 +1,161 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+)
+
+// InMemoryWebhookRepository 内存 Webhook 仓储实现
+// 读写时复制实体，避免调用方修改共享状态
+type InMemoryWebhookRepository struct {
+	mu            sync.RWMutex
+	subscriptions map[string]domain.WebhookSubscription // 按订阅ID索引
+	deliveries    map[string]domain.WebhookDelivery     // 按投递ID索引
+}
+
+// NewInMemoryWebhookRepository 创建内存 Webhook 仓储实例
+func NewInMemoryWebhookRepository() application.WebhookRepository {
+	return &InMemoryWebhookRepository{
+		subscriptions: make(map[string]domain.WebhookSubscription),
+		deliveries:    make(map[string]domain.WebhookDelivery),
+	}
+}
+
+// SaveSubscription 保存订阅（新增或更新）
+func (r *InMemoryWebhookRepository) SaveSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscriptions[subscription.ID] = cloneSubscription(subscription)
+	return nil
+}
+
+// FindSubscription 根据订阅ID查询订阅
+func (r *InMemoryWebhookRepository) FindSubscription(ctx context.Context, subscriptionID string) (*domain.WebhookSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscription, exists := r.subscriptions[subscriptionID]
+	if !exists {
+		return nil, application.NewNotFoundError(fmt.Sprintf("webhook %s not found", subscriptionID))
+	}
+	result := cloneSubscription(&subscription)
+	return &result, nil
+}
+
+// FindSubscriptionsByMerchant 查询商家的全部订阅（按创建时间排序）
+func (r *InMemoryWebhookRepository) FindSubscriptionsByMerchant(ctx context.Context, merchantID string) ([]*domain.WebhookSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.WebhookSubscription
+	for _, subscription := range r.subscriptions {
+		if subscription.MerchantID == merchantID {
+			s := cloneSubscription(&subscription)
+			result = append(result, &s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// DeleteSubscription 删除订阅
+func (r *InMemoryWebhookRepository) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.subscriptions[subscriptionID]; !exists {
+		return application.NewNotFoundError(fmt.Sprintf("webhook %s not found", subscriptionID))
+	}
+	delete(r.subscriptions, subscriptionID)
+	return nil
+}
+
+// CreateDelivery 创建投递记录，ID 已存在时返回 false
+func (r *InMemoryWebhookRepository) CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.deliveries[delivery.ID]; exists {
+		return false, nil
+	}
+	r.deliveries[delivery.ID] = cloneDelivery(delivery)
+	return true, nil
+}
+
+// UpdateDelivery 更新投递记录
+func (r *InMemoryWebhookRepository) UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.deliveries[delivery.ID]; !exists {
+		return application.NewNotFoundError(fmt.Sprintf("webhook delivery %s not found", delivery.ID))
+	}
+	r.deliveries[delivery.ID] = cloneDelivery(delivery)
+	return nil
+}
+
+// FindDueDeliveries 查询到期的待投递记录（按下次投递时间排序）
+func (r *InMemoryWebhookRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.Status == domain.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+			d := cloneDelivery(&delivery)
+			result = append(result, &d)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NextAttemptAt.Before(result[j].NextAttemptAt)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// FindDeliveriesBySubscription 查询订阅的投递记录（按创建时间倒序）
+func (r *InMemoryWebhookRepository) FindDeliveriesBySubscription(ctx context.Context, subscriptionID string, limit int) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []*domain.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.SubscriptionID == subscriptionID {
+			d := cloneDelivery(&delivery)
+			result = append(result, &d)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// cloneSubscription 复制订阅
+func cloneSubscription(subscription *domain.WebhookSubscription) domain.WebhookSubscription {
+	result := *subscription
+	result.EventTypes = slices.Clone(subscription.EventTypes)
+	return result
+}
+
+// cloneDelivery 复制投递记录
+func cloneDelivery(delivery *domain.WebhookDelivery) domain.WebhookDelivery {
+	result := *delivery
+	result.Attempts = slices.Clone(delivery.Attempts)
+	return result
+}
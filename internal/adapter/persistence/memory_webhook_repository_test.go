@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryWebhookRepository_Subscriptions(t *testing.T) {
+	repo := NewInMemoryWebhookRepository()
+	ctx := context.Background()
+
+	subscription := domain.NewWebhookSubscription("merchant_001", "https://example.com/hook", []string{"*"}, "secret")
+	require.NoError(t, repo.SaveSubscription(ctx, subscription))
+	require.NoError(t, repo.SaveSubscription(ctx, domain.NewWebhookSubscription("merchant_002", "https://example.com/hook", []string{"*"}, "secret")))
+
+	// 查询结果是副本，修改不影响仓储
+	found, err := repo.FindSubscription(ctx, subscription.ID)
+	require.NoError(t, err)
+	found.Status = domain.WebhookStatusDisabled
+	again, _ := repo.FindSubscription(ctx, subscription.ID)
+	assert.Equal(t, domain.WebhookStatusActive, again.Status)
+
+	list, err := repo.FindSubscriptionsByMerchant(ctx, "merchant_001")
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	require.NoError(t, repo.DeleteSubscription(ctx, subscription.ID))
+	_, err = repo.FindSubscription(ctx, subscription.ID)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestInMemoryWebhookRepository_Deliveries(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryWebhookRepository()
+	ctx := context.Background()
+	subscription := domain.NewWebhookSubscription("merchant_001", "https://example.com/hook", []string{"*"}, "secret")
+	order := domain.NewOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "")
+	delivery := domain.NewWebhookDelivery(subscription, order.PendingEvents()[0], []byte(`{}`))
+
+	// Act & Assert - 相同投递ID只创建一次
+	created, err := repo.CreateDelivery(ctx, delivery)
+	require.NoError(t, err)
+	assert.True(t, created)
+	created, err = repo.CreateDelivery(ctx, delivery)
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	due, err := repo.FindDueDeliveries(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+
+	// 安排稍后重试后不再到期
+	due[0].NextAttemptAt = time.Now().Add(time.Minute)
+	require.NoError(t, repo.UpdateDelivery(ctx, due[0]))
+	due, _ = repo.FindDueDeliveries(ctx, time.Now(), 10)
+	assert.Empty(t, due)
+
+	deliveries, err := repo.FindDeliveriesBySubscription(ctx, subscription.ID, 10)
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+}
@@ -0,0 +1,123 @@
+// Package ratelimit 限流存储适配器
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"order-service/internal/application"
+)
+
+// defaultSweepInterval 清理已补满令牌桶的间隔
+const defaultSweepInterval = time.Minute
+
+// bucket 令牌桶状态（令牌数按经过的时间惰性补充）
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	limit     application.RateLimit
+}
+
+// InMemoryRateLimiter 内存令牌桶限流实现（单实例部署和测试使用，多实例部署时需替换为共享存储实现）
+type InMemoryRateLimiter struct {
+	mu            sync.Mutex
+	buckets       map[string]*bucket
+	now           func() time.Time
+	sweepInterval time.Duration
+	lastSweep     time.Time
+}
+
+// Option 内存限流可选配置
+type Option func(*InMemoryRateLimiter)
+
+// WithClock 配置时钟（测试使用）
+func WithClock(now func() time.Time) Option {
+	return func(l *InMemoryRateLimiter) {
+		l.now = now
+	}
+}
+
+// NewInMemoryRateLimiter 创建内存限流实例
+func NewInMemoryRateLimiter(opts ...Option) *InMemoryRateLimiter {
+	l := &InMemoryRateLimiter{
+		buckets:       make(map[string]*bucket),
+		now:           time.Now,
+		sweepInterval: defaultSweepInterval,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	l.lastSweep = l.now()
+	return l
+}
+
+// Allow 按令牌桶算法判定请求是否放行，放行时扣减一个令牌
+func (l *InMemoryRateLimiter) Allow(ctx context.Context, key string, limit application.RateLimit) (application.RateLimitDecision, error) {
+	if !limit.Enabled() {
+		return application.RateLimitDecision{Allowed: true}, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, exists := l.buckets[key]
+	if !exists || b.limit != limit {
+		// 新建或限额配置变化时从满桶开始
+		b = &bucket{tokens: float64(limit.Burst), updatedAt: now, limit: limit}
+		l.buckets[key] = b
+	}
+	b.refill(now)
+
+	decision := application.RateLimitDecision{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = b.durationFor(1 - b.tokens)
+	}
+	decision.Remaining = int(math.Floor(b.tokens))
+	decision.ResetAfter = b.durationFor(float64(limit.Burst) - b.tokens)
+	return decision, nil
+}
+
+// sweep 定期删除已补满的令牌桶（与新建的满桶等价），避免按 IP 等维度的 key 无限增长
+func (l *InMemoryRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// refill 按经过的时间补充令牌（不超过桶容量）
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updatedAt)
+	if elapsed <= 0 {
+		return
+	}
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed.Seconds()*b.ratePerSecond())
+	b.updatedAt = now
+}
+
+// durationFor 补充指定数量令牌所需的时间
+func (b *bucket) durationFor(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(tokens / b.ratePerSecond() * float64(time.Second)))
+}
+
+// ratePerSecond 每秒补充的令牌数
+func (b *bucket) ratePerSecond() float64 {
+	return float64(b.limit.Rate) / b.limit.Period.Seconds()
+}
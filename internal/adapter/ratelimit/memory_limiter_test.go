@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/application"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock 可手动推进的时钟
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestInMemoryRateLimiter_TokenBucket(t *testing.T) {
+	// Arrange - 每分钟 6 个令牌（10 秒一个），最多突发 2 个
+	clock := &fakeClock{now: time.Date(2024, 11, 17, 12, 0, 0, 0, time.UTC)}
+	limiter := NewInMemoryRateLimiter(WithClock(clock.Now))
+	limit := application.RateLimit{Rate: 6, Period: time.Minute, Burst: 2}
+	ctx := context.Background()
+
+	// Act
+	first, err := limiter.Allow(ctx, "user:1001", limit)
+	require.NoError(t, err)
+	second, _ := limiter.Allow(ctx, "user:1001", limit)
+	denied, _ := limiter.Allow(ctx, "user:1001", limit)
+	other, _ := limiter.Allow(ctx, "user:2002", limit)
+	clock.now = clock.now.Add(4 * time.Second)
+	stillDenied, _ := limiter.Allow(ctx, "user:1001", limit)
+	clock.now = clock.now.Add(6 * time.Second)
+	refilled, _ := limiter.Allow(ctx, "user:1001", limit)
+
+	// Assert
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 2, first.Limit)
+	assert.Equal(t, 1, first.Remaining)
+	assert.Equal(t, 10*time.Second, first.ResetAfter)
+
+	assert.True(t, second.Allowed)
+	assert.Equal(t, 0, second.Remaining)
+
+	assert.False(t, denied.Allowed)
+	assert.Equal(t, 10*time.Second, denied.RetryAfter)
+	assert.Equal(t, 20*time.Second, denied.ResetAfter)
+
+	assert.True(t, other.Allowed, "buckets are isolated by key")
+	assert.False(t, stillDenied.Allowed)
+	assert.Equal(t, 6*time.Second, stillDenied.RetryAfter)
+	assert.True(t, refilled.Allowed)
+}
+
+func TestInMemoryRateLimiter_DisabledLimitAlwaysAllows(t *testing.T) {
+	limiter := NewInMemoryRateLimiter()
+
+	decision, err := limiter.Allow(context.Background(), "ip:127.0.0.1", application.RateLimit{})
+
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
+
+func TestInMemoryRateLimiter_SweepsFullBuckets(t *testing.T) {
+	// Arrange
+	clock := &fakeClock{now: time.Date(2024, 11, 17, 12, 0, 0, 0, time.UTC)}
+	limiter := NewInMemoryRateLimiter(WithClock(clock.Now))
+	limit := application.RateLimit{Rate: 60, Period: time.Minute, Burst: 1}
+	_, _ = limiter.Allow(context.Background(), "ip:10.0.0.1", limit)
+
+	// Act - 超过清理间隔后，已补满的令牌桶被删除
+	clock.now = clock.now.Add(defaultSweepInterval)
+	_, _ = limiter.Allow(context.Background(), "ip:10.0.0.2", limit)
+
+	// Assert
+	assert.Len(t, limiter.buckets, 1)
+	assert.Contains(t, limiter.buckets, "ip:10.0.0.2")
+}
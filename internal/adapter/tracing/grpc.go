@@ -0,0 +1,67 @@
+package tracing
+
+import (
+	"context"
+
+	"order-service/internal/logging"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+	grpcgo "google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataCarrier 以 gRPC metadata 读取 traceparent 等传播字段
+type metadataCarrier metadata.MD
+
+// Get 返回键的第一个值
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Set 设置键的值
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys 返回所有键
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// UnaryServerInterceptor 为每个 RPC 创建服务端 span（按完整方法名命名）
+// 沿用 metadata 中 traceparent 的上游链路，并将 trace ID 追加到 context 携带的 logger
+func UnaryServerInterceptor(tracer trace.Tracer) grpcgo.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpcgo.UnaryServerInfo, handler grpcgo.UnaryHandler) (interface{}, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			ctx = propagator.Extract(ctx, metadataCarrier(md))
+		}
+		ctx, span := tracer.Start(ctx, info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.RPCSystemNameGRPC, semconv.RPCMethod(info.FullMethod)),
+		)
+		defer span.End()
+		ctx = logging.With(ctx, KeyTraceID, span.SpanContext().TraceID().String())
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		span.SetAttributes(semconv.RPCResponseStatusCode(code.String()))
+		switch code {
+		case grpccodes.Unknown, grpccodes.Internal, grpccodes.Unavailable, grpccodes.DataLoss:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, status.Convert(err).Message())
+		}
+		return resp, err
+	}
+}
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"net/http"
+
+	"order-service/internal/adapter/httproute"
+	"order-service/internal/logging"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HTTPMiddleware 为每个 HTTP 请求创建服务端 span（按路由模板命名）
+// 沿用请求 traceparent 头中的上游链路，并将 trace ID 追加到 context 携带的 logger（需位于 web.RequestID 之后）
+func HTTPMiddleware(tracer trace.Tracer) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			route := httproute.Label(c)
+
+			ctx := propagator.Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			ctx, span := tracer.Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.HTTPRoute(route),
+					semconv.URLPath(req.URL.Path),
+				),
+			)
+			defer span.End()
+			ctx = logging.With(ctx, KeyTraceID, span.SpanContext().TraceID().String())
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			if err != nil {
+				// 交给 Echo 的错误处理器写入响应，以便记录最终状态码（响应已提交后不会重复写入）
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+				if err != nil {
+					span.RecordError(err)
+				}
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package tracing
+
+import (
+	"context"
+	"strconv"
+
+	"order-service/internal/application"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// orderService 为每个用例创建 span 的 OrderService 装饰器
+// span 携带订单号和商户ID（下单时订单号在成功后补充）
+type orderService struct {
+	inner  application.OrderService
+	tracer trace.Tracer
+}
+
+// NewOrderService 为 OrderService 增加链路追踪
+func NewOrderService(inner application.OrderService, tracer trace.Tracer) application.OrderService {
+	return &orderService{inner: inner, tracer: tracer}
+}
+
+// CreateOrder 创建订单
+func (s *orderService) CreateOrder(ctx context.Context, userID uint64, req *application.CreateOrderRequest) (*application.OrderData, error) {
+	ctx, span := s.start(ctx, "CreateOrder", userAttr(userID), AttrMerchantID.String(req.MerchantID))
+	orderData, err := s.inner.CreateOrder(ctx, userID, req)
+	setOrderAttrs(span, orderData)
+	endSpan(span, err)
+	return orderData, err
+}
+
+// QuoteOrder 下单报价
+func (s *orderService) QuoteOrder(ctx context.Context, userID uint64, req *application.CreateOrderRequest) (*application.QuoteData, error) {
+	ctx, span := s.start(ctx, "QuoteOrder", userAttr(userID), AttrMerchantID.String(req.MerchantID))
+	quote, err := s.inner.QuoteOrder(ctx, userID, req)
+	endSpan(span, err)
+	return quote, err
+}
+
+// GetOrder 查询订单
+func (s *orderService) GetOrder(ctx context.Context, userID uint64, orderNumber string) (*application.OrderData, error) {
+	ctx, span := s.start(ctx, "GetOrder", userAttr(userID), AttrOrderNumber.String(orderNumber))
+	orderData, err := s.inner.GetOrder(ctx, userID, orderNumber)
+	setOrderAttrs(span, orderData)
+	endSpan(span, err)
+	return orderData, err
+}
+
+// ListOrders 分页查询订单
+func (s *orderService) ListOrders(ctx context.Context, userID uint64, req *application.ListOrdersRequest) (*application.OrderListData, error) {
+	ctx, span := s.start(ctx, "ListOrders", userAttr(userID))
+	list, err := s.inner.ListOrders(ctx, userID, req)
+	endSpan(span, err)
+	return list, err
+}
+
+// ConfirmPayment 确认支付结果
+func (s *orderService) ConfirmPayment(ctx context.Context, req *application.ConfirmPaymentRequest) (*application.OrderData, error) {
+	ctx, span := s.start(ctx, "ConfirmPayment", AttrOrderNumber.String(req.OrderNumber))
+	orderData, err := s.inner.ConfirmPayment(ctx, req)
+	setOrderAttrs(span, orderData)
+	endSpan(span, err)
+	return orderData, err
+}
+
+// CancelOrder 取消订单
+func (s *orderService) CancelOrder(ctx context.Context, userID uint64, orderNumber string, req *application.CancelOrderRequest) (*application.OrderData, error) {
+	ctx, span := s.start(ctx, "CancelOrder", userAttr(userID), AttrOrderNumber.String(orderNumber))
+	orderData, err := s.inner.CancelOrder(ctx, userID, orderNumber, req)
+	setOrderAttrs(span, orderData)
+	endSpan(span, err)
+	return orderData, err
+}
+
+// RefundOrder 申请退款
+func (s *orderService) RefundOrder(ctx context.Context, userID uint64, orderNumber string, req *application.RefundOrderRequest) (*application.RefundData, error) {
+	ctx, span := s.start(ctx, "RefundOrder", userAttr(userID), AttrOrderNumber.String(orderNumber))
+	refund, err := s.inner.RefundOrder(ctx, userID, orderNumber, req)
+	if refund != nil {
+		span.SetAttributes(AttrRefundID.String(refund.RefundID))
+	}
+	endSpan(span, err)
+	return refund, err
+}
+
+// AcceptOrder 商户接单
+func (s *orderService) AcceptOrder(ctx context.Context, merchantID, orderNumber string) (*application.OrderData, error) {
+	ctx, span := s.start(ctx, "AcceptOrder", AttrMerchantID.String(merchantID), AttrOrderNumber.String(orderNumber))
+	orderData, err := s.inner.AcceptOrder(ctx, merchantID, orderNumber)
+	endSpan(span, err)
+	return orderData, err
+}
+
+// RejectOrder 商户拒单
+func (s *orderService) RejectOrder(ctx context.Context, merchantID, orderNumber string, req *application.RejectOrderRequest) (*application.OrderData, error) {
+	ctx, span := s.start(ctx, "RejectOrder", AttrMerchantID.String(merchantID), AttrOrderNumber.String(orderNumber))
+	orderData, err := s.inner.RejectOrder(ctx, merchantID, orderNumber, req)
+	endSpan(span, err)
+	return orderData, err
+}
+
+// start 创建名为 OrderService.{operation} 的 span
+func (s *orderService) start(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "OrderService."+operation, trace.WithAttributes(attrs...))
+}
+
+// userAttr 用户ID属性
+func userAttr(userID uint64) attribute.KeyValue {
+	return AttrUserID.String(strconv.FormatUint(userID, 10))
+}
+
+// setOrderAttrs 以用例返回的订单补充订单号和商户ID
+func setOrderAttrs(span trace.Span, orderData *application.OrderData) {
+	if orderData == nil {
+		return
+	}
+	span.SetAttributes(
+		AttrOrderNumber.String(orderData.OrderNumber),
+		AttrMerchantID.String(orderData.MerchantID),
+	)
+}
@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"order-service/internal/application"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// transport 为出站 HTTP 请求创建客户端 span 并注入 traceparent 头的 RoundTripper
+type transport struct {
+	base   http.RoundTripper
+	tracer trace.Tracer
+}
+
+// NewTransport 包装出站 HTTP 请求（如 Webhook 投递），base 为空时使用 http.DefaultTransport
+func NewTransport(base http.RoundTripper, tracer trace.Tracer) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, tracer: tracer}
+}
+
+// RoundTrip 发送请求，下游通过 traceparent 头关联到本次调用
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.ServerAddress(req.URL.Hostname()),
+			semconv.URLPath(req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	// RoundTripper 不得修改原请求，注入头部前先复制
+	req = req.Clone(ctx)
+	propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	return resp, nil
+}
+
+// paymentGateway 为退款调用创建客户端 span 的 PaymentGateway 装饰器
+// 基于 HTTP 的网关实现应使用 NewTransport 发送请求，以便将 traceparent 传播到支付渠道
+type paymentGateway struct {
+	inner  application.PaymentGateway
+	tracer trace.Tracer
+}
+
+// NewPaymentGateway 为 PaymentGateway 增加链路追踪
+func NewPaymentGateway(inner application.PaymentGateway, tracer trace.Tracer) application.PaymentGateway {
+	return &paymentGateway{inner: inner, tracer: tracer}
+}
+
+// Refund 提交退款
+func (g *paymentGateway) Refund(ctx context.Context, req *application.PaymentRefundRequest) error {
+	ctx, span := g.tracer.Start(ctx, "PaymentGateway.Refund",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			AttrOrderNumber.String(req.OrderNumber),
+			AttrRefundID.String(req.RefundID),
+		),
+	)
+	err := g.inner.Refund(ctx, req)
+	endSpan(span, err)
+	return err
+}
@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// orderRepository 为每次调用创建 span 的 OrderRepository 装饰器
+type orderRepository struct {
+	inner  application.OrderRepository
+	tracer trace.Tracer
+}
+
+// NewOrderRepository 为 OrderRepository 增加链路追踪
+func NewOrderRepository(inner application.OrderRepository, tracer trace.Tracer) application.OrderRepository {
+	return &orderRepository{inner: inner, tracer: tracer}
+}
+
+// Create 创建订单
+func (r *orderRepository) Create(ctx context.Context, order *domain.Order) error {
+	ctx, span := r.start(ctx, "Create", orderAttrs(order)...)
+	err := r.inner.Create(ctx, order)
+	endSpan(span, err)
+	return err
+}
+
+// FindByOrderNumber 根据订单号查询订单
+func (r *orderRepository) FindByOrderNumber(ctx context.Context, orderNumber string) (*domain.Order, error) {
+	ctx, span := r.start(ctx, "FindByOrderNumber", AttrOrderNumber.String(orderNumber))
+	order, err := r.inner.FindByOrderNumber(ctx, orderNumber)
+	if order != nil {
+		span.SetAttributes(AttrMerchantID.String(order.MerchantID))
+	}
+	endSpan(span, err)
+	return order, err
+}
+
+// Update 更新订单
+func (r *orderRepository) Update(ctx context.Context, order *domain.Order) error {
+	ctx, span := r.start(ctx, "Update", orderAttrs(order)...)
+	err := r.inner.Update(ctx, order)
+	endSpan(span, err)
+	return err
+}
+
+// FindByUser 分页查询用户订单
+func (r *orderRepository) FindByUser(ctx context.Context, query application.OrderListQuery) ([]*domain.Order, error) {
+	ctx, span := r.start(ctx, "FindByUser", AttrUserID.String(strconv.FormatUint(query.UserID, 10)))
+	orders, err := r.inner.FindByUser(ctx, query)
+	endSpan(span, err)
+	return orders, err
+}
+
+// CountActiveByMerchant 统计商家处理中的订单数
+func (r *orderRepository) CountActiveByMerchant(ctx context.Context, merchantID string) (int, error) {
+	ctx, span := r.start(ctx, "CountActiveByMerchant", AttrMerchantID.String(merchantID))
+	count, err := r.inner.CountActiveByMerchant(ctx, merchantID)
+	endSpan(span, err)
+	return count, err
+}
+
+// FindDueScheduled 查询到期待推送的预订单
+func (r *orderRepository) FindDueScheduled(ctx context.Context, before time.Time, limit int) ([]*domain.Order, error) {
+	ctx, span := r.start(ctx, "FindDueScheduled")
+	orders, err := r.inner.FindDueScheduled(ctx, before, limit)
+	endSpan(span, err)
+	return orders, err
+}
+
+// FindUnpaidBefore 查询支付超时的订单
+func (r *orderRepository) FindUnpaidBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Order, error) {
+	ctx, span := r.start(ctx, "FindUnpaidBefore")
+	orders, err := r.inner.FindUnpaidBefore(ctx, before, limit)
+	endSpan(span, err)
+	return orders, err
+}
+
+// start 创建名为 OrderRepository.{operation} 的客户端 span
+func (r *orderRepository) start(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return r.tracer.Start(ctx, "OrderRepository."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// orderAttrs 订单号和商户ID属性
+func orderAttrs(order *domain.Order) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		AttrOrderNumber.String(order.OrderNumber),
+		AttrMerchantID.String(order.MerchantID),
+	}
+}
@@ -0,0 +1,136 @@
+// Package tracing OpenTelemetry 链路追踪适配器：HTTP/gRPC 入口、应用服务、仓储和出站调用的 span，
+// 以 W3C traceparent 头在服务间传播，支持 OTLP/HTTP 导出和本地使用的 stdout/文件导出
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"order-service/internal/application"
+	"order-service/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName 本服务创建 span 使用的 Tracer 名称
+const InstrumentationName = "order-service"
+
+// span 属性键
+const (
+	AttrOrderNumber = attribute.Key("order.number")
+	AttrMerchantID  = attribute.Key("merchant.id")
+	AttrUserID      = attribute.Key("user.id")
+	AttrRefundID    = attribute.Key("refund.id")
+	// attrErrorKind 调用方错误（校验/业务/不存在）的类型，这类错误不将 span 标记为失败
+	attrErrorKind = attribute.Key("error.kind")
+)
+
+// KeyTraceID 日志中的 trace ID 字段（用于关联日志与链路）
+const KeyTraceID = "trace_id"
+
+// propagator W3C Trace Context + Baggage 传播器
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// Setup 按配置创建 TracerProvider，并设置为全局 TracerProvider 和传播器
+// exporter 为 none 时仍生成 span（trace ID 可用于日志关联和向下游传播），只是不导出；
+// 返回的 shutdown 在停机时导出剩余 span 并关闭导出器
+func Setup(ctx context.Context, cfg config.TracingConfig) (*sdktrace.TracerProvider, func(context.Context) error, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, nil, fmt.Errorf("build tracing resource: %w", err)
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}
+	exporter, closeOutput, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+
+	shutdown := func(ctx context.Context) error {
+		return errors.Join(provider.Shutdown(ctx), closeOutput())
+	}
+	return provider, shutdown, nil
+}
+
+// newExporter 创建导出器，返回的 closeOutput 关闭导出文件（无文件时为空操作）
+func newExporter(ctx context.Context, cfg config.TracingConfig) (sdktrace.SpanExporter, func() error, error) {
+	noop := func() error { return nil }
+
+	switch cfg.Exporter {
+	case config.TracingExporterNone:
+		return nil, noop, nil
+	case config.TracingExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		if err != nil {
+			return nil, nil, fmt.Errorf("create OTLP trace exporter: %w", err)
+		}
+		return exporter, noop, nil
+	case config.TracingExporterStdout:
+		var w io.Writer = os.Stdout
+		closeOutput := noop
+		if cfg.File != "" {
+			file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, nil, fmt.Errorf("open trace file: %w", err)
+			}
+			w, closeOutput = file, file.Close
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+		if err != nil {
+			return nil, nil, fmt.Errorf("create stdout trace exporter: %w", err)
+		}
+		return exporter, closeOutput, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported tracing exporter %q", cfg.Exporter)
+	}
+}
+
+// endSpan 记录错误并结束 span
+// 校验失败、业务规则冲突和记录不存在属于调用方错误，只记录错误类型，不将 span 标记为失败
+func endSpan(span trace.Span, err error) {
+	defer span.End()
+	if err == nil {
+		return
+	}
+
+	var (
+		validationErr *application.ValidationError
+		businessErr   *application.BusinessError
+		notFoundErr   *application.NotFoundError
+	)
+	switch {
+	case errors.As(err, &validationErr):
+		span.SetAttributes(attrErrorKind.String("validation"))
+	case errors.As(err, &businessErr):
+		span.SetAttributes(attrErrorKind.String("business"))
+	case errors.As(err, &notFoundErr):
+		span.SetAttributes(attrErrorKind.String("not_found"))
+	default:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,219 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"order-service/internal/adapter/payment"
+	"order-service/internal/adapter/persistence"
+	"order-service/internal/application"
+	"order-service/internal/config"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// upstreamTraceparent 上游服务传入的 traceparent（已采样）
+const (
+	upstreamTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	upstreamTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+)
+
+// newTestTracer 创建记录全部 span 的 Tracer
+func newTestTracer(t *testing.T) (trace.Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return provider.Tracer(InstrumentationName), recorder
+}
+
+// findSpan 按名称查找已结束的 span
+func findSpan(t *testing.T, recorder *tracetest.SpanRecorder, name string) sdktrace.ReadOnlySpan {
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	require.Failf(t, "span not found", "span %q", name)
+	return nil
+}
+
+// attrValue 返回 span 属性的字符串值
+func attrValue(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+// validCreateRequest 合法的下单请求
+func validCreateRequest() *application.CreateOrderRequest {
+	return &application.CreateOrderRequest{
+		MerchantID: "merchant_001",
+		Items:      []application.OrderItemRequest{{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00}},
+		DeliveryInfo: application.DeliveryInfoRequest{
+			RecipientName:  "张三",
+			RecipientPhone: "13800138000",
+			Address:        "北京市朝阳区xxx",
+		},
+	}
+}
+
+func TestHTTPMiddleware_ContinuesUpstreamTrace(t *testing.T) {
+	// Arrange
+	tracer, recorder := newTestTracer(t)
+	e := echo.New()
+	e.Use(HTTPMiddleware(tracer))
+	e.GET("/api/v1/orders/:orderNumber", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/fail", func(c echo.Context) error {
+		return errors.New("boom")
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1", nil)
+	req.Header.Set("traceparent", upstreamTraceparent)
+
+	// Act
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+
+	// Assert - 按路由模板命名，沿用上游 trace ID
+	span := findSpan(t, recorder, "GET /api/v1/orders/:orderNumber")
+	assert.Equal(t, upstreamTraceID, span.SpanContext().TraceID().String())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.Equal(t, "200", attrValue(span, "http.response.status_code"))
+	assert.Equal(t, codes.Unset, span.Status().Code)
+
+	failed := findSpan(t, recorder, "GET /fail")
+	assert.Equal(t, "500", attrValue(failed, "http.response.status_code"))
+	assert.Equal(t, codes.Error, failed.Status().Code)
+}
+
+func TestOrderService_SpansCarryOrderAttributes(t *testing.T) {
+	// Arrange
+	tracer, recorder := newTestTracer(t)
+	repo := NewOrderRepository(persistence.NewInMemoryOrderRepository(), tracer)
+	service := NewOrderService(application.NewOrderService(repo), tracer)
+
+	// Act
+	created, err := service.CreateOrder(context.Background(), 1001, validCreateRequest())
+	require.NoError(t, err)
+	_, getErr := service.GetOrder(context.Background(), 2002, created.OrderNumber)
+
+	// Assert - 仓储 span 是用例 span 的子 span
+	createSpan := findSpan(t, recorder, "OrderService.CreateOrder")
+	assert.Equal(t, created.OrderNumber, attrValue(createSpan, AttrOrderNumber))
+	assert.Equal(t, "merchant_001", attrValue(createSpan, AttrMerchantID))
+	assert.Equal(t, "1001", attrValue(createSpan, AttrUserID))
+
+	repoSpan := findSpan(t, recorder, "OrderRepository.Create")
+	assert.Equal(t, createSpan.SpanContext().SpanID(), repoSpan.Parent().SpanID())
+	assert.Equal(t, created.OrderNumber, attrValue(repoSpan, AttrOrderNumber))
+	assert.Equal(t, "merchant_001", attrValue(repoSpan, AttrMerchantID))
+
+	// 查询他人订单属于调用方错误，不将 span 标记为失败
+	var notFound *application.NotFoundError
+	require.ErrorAs(t, getErr, &notFound)
+	getSpan := findSpan(t, recorder, "OrderService.GetOrder")
+	assert.Equal(t, created.OrderNumber, attrValue(getSpan, AttrOrderNumber))
+	assert.Equal(t, "not_found", attrValue(getSpan, attrErrorKind))
+	assert.Equal(t, codes.Unset, getSpan.Status().Code)
+}
+
+func TestTransport_InjectsTraceparent(t *testing.T) {
+	// Arrange
+	tracer, recorder := newTestTracer(t)
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: NewTransport(nil, tracer)}
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/hooks", nil)
+	require.NoError(t, err)
+
+	// Act
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	// Assert - 下游收到的 traceparent 指向出站请求的客户端 span
+	span := findSpan(t, recorder, "HTTP POST")
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, "00-"+span.SpanContext().TraceID().String()+"-"+span.SpanContext().SpanID().String()+"-01", received)
+	assert.Empty(t, req.Header.Get("traceparent"), "original request must not be modified")
+}
+
+func TestPaymentGateway_RecordsRefundSpan(t *testing.T) {
+	tracer, recorder := newTestTracer(t)
+	gateway := NewPaymentGateway(payment.NewInMemoryPaymentGateway(), tracer)
+
+	err := gateway.Refund(context.Background(), &application.PaymentRefundRequest{
+		OrderNumber: "20241117120000123456",
+		RefundID:    "20241117120000123456R01",
+	})
+
+	require.NoError(t, err)
+	span := findSpan(t, recorder, "PaymentGateway.Refund")
+	assert.Equal(t, "20241117120000123456", attrValue(span, AttrOrderNumber))
+	assert.Equal(t, "20241117120000123456R01", attrValue(span, AttrRefundID))
+}
+
+func TestUnaryServerInterceptor_ContinuesUpstreamTrace(t *testing.T) {
+	// Arrange
+	tracer, recorder := newTestTracer(t)
+	interceptor := UnaryServerInterceptor(tracer)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", upstreamTraceparent))
+	info := &grpcgo.UnaryServerInfo{FullMethod: "/order.v1.OrderService/GetOrder"}
+
+	// Act
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+
+	// Assert
+	require.NoError(t, err)
+	span := findSpan(t, recorder, info.FullMethod)
+	assert.Equal(t, upstreamTraceID, span.SpanContext().TraceID().String())
+	assert.Equal(t, "OK", attrValue(span, "rpc.response.status_code"))
+}
+
+func TestSetup_StdoutFileExporter(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "traces.json")
+	cfg := config.Default().Tracing
+	cfg.Exporter = config.TracingExporterStdout
+	cfg.File = path
+
+	// Act
+	provider, shutdown, err := Setup(context.Background(), cfg)
+	require.NoError(t, err)
+	_, span := provider.Tracer(InstrumentationName).Start(context.Background(), "local-span")
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	// Assert - 停机时导出剩余 span
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "local-span")
+	assert.Contains(t, string(content), "order-service")
+}
@@ -0,0 +1,38 @@
+package web
+
+// SaveAddressRequest Web 层保存地址请求（label 为空时为 OTHER，isDefault 为 true 时设为默认地址）
+type SaveAddressRequest struct {
+	Label          string           `json:"label,omitempty"`
+	RecipientName  string           `json:"recipientName"`
+	RecipientPhone string           `json:"recipientPhone"`
+	Address        string           `json:"address"`
+	Location       *GeoPointRequest `json:"location,omitempty"`
+	IsDefault      bool             `json:"isDefault,omitempty"`
+}
+
+// AddressResponse 地址响应
+type AddressResponse struct {
+	Code    int          `json:"code"`
+	Message string       `json:"message"`
+	Data    *AddressData `json:"data,omitempty"`
+}
+
+// AddressListResponse 地址簿响应（默认地址在前）
+type AddressListResponse struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Data    []AddressData `json:"data"`
+}
+
+// AddressData 地址簿地址（下单时用 addressId 引用）
+type AddressData struct {
+	AddressID      string        `json:"addressId"`
+	Label          string        `json:"label"`
+	RecipientName  string        `json:"recipientName"`
+	RecipientPhone string        `json:"recipientPhone"`
+	Address        string        `json:"address"`
+	Location       *GeoPointData `json:"location,omitempty"`
+	IsDefault      bool          `json:"isDefault"`
+	CreatedAt      string        `json:"createdAt"`
+	UpdatedAt      string        `json:"updatedAt"`
+}
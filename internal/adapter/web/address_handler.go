@@ -0,0 +1,187 @@
+package web
+
+import (
+	"net/http"
+
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AddressBookHandler 用户地址簿 HTTP 处理器
+type AddressBookHandler struct {
+	bookService application.AddressBookService
+}
+
+// NewAddressBookHandler 创建地址簿处理器
+func NewAddressBookHandler(bookService application.AddressBookService) *AddressBookHandler {
+	return &AddressBookHandler{
+		bookService: bookService,
+	}
+}
+
+// ListAddresses 查询地址簿
+func (h *AddressBookHandler) ListAddresses(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	addresses, err := h.bookService.ListAddresses(c.Request().Context(), userID)
+	if err != nil {
+		return handleError(c, err)
+	}
+	data := make([]AddressData, len(addresses))
+	for i := range addresses {
+		data[i] = *toAddressData(&addresses[i])
+	}
+	return c.JSON(http.StatusOK, AddressListResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    data,
+	})
+}
+
+// GetAddress 查询地址
+func (h *AddressBookHandler) GetAddress(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	address, err := h.bookService.GetAddress(c.Request().Context(), userID, c.Param("addressId"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return addressResponse(c, http.StatusOK, "success", address)
+}
+
+// CreateAddress 新增地址
+func (h *AddressBookHandler) CreateAddress(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	var webReq SaveAddressRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	address, err := h.bookService.CreateAddress(c.Request().Context(), userID, toApplicationSaveAddress(webReq))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return addressResponse(c, http.StatusCreated, "address created", address)
+}
+
+// UpdateAddress 修改地址
+func (h *AddressBookHandler) UpdateAddress(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	var webReq SaveAddressRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	address, err := h.bookService.UpdateAddress(c.Request().Context(), userID, c.Param("addressId"), toApplicationSaveAddress(webReq))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return addressResponse(c, http.StatusOK, "address updated", address)
+}
+
+// DeleteAddress 删除地址（删除默认地址时最早添加的地址成为默认地址）
+func (h *AddressBookHandler) DeleteAddress(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	if err := h.bookService.DeleteAddress(c.Request().Context(), userID, c.Param("addressId")); err != nil {
+		return handleError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SetDefault 设为默认地址
+func (h *AddressBookHandler) SetDefault(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	address, err := h.bookService.SetDefault(c.Request().Context(), userID, c.Param("addressId"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return addressResponse(c, http.StatusOK, "default address updated", address)
+}
+
+// toApplicationSaveAddress 转换保存地址请求
+func toApplicationSaveAddress(webReq SaveAddressRequest) *application.SaveAddressRequest {
+	return &application.SaveAddressRequest{
+		DeliveryInfoRequest: toApplicationDeliveryInfo(DeliveryInfoRequest{
+			RecipientName:  webReq.RecipientName,
+			RecipientPhone: webReq.RecipientPhone,
+			Address:        webReq.Address,
+			Location:       webReq.Location,
+		}),
+		Label:     webReq.Label,
+		IsDefault: webReq.IsDefault,
+	}
+}
+
+// addressResponse 返回地址响应
+func addressResponse(c echo.Context, status int, message string, data *application.AddressData) error {
+	return c.JSON(status, AddressResponse{
+		Code:    status,
+		Message: message,
+		Data:    toAddressData(data),
+	})
+}
+
+// toAddressData 转换应用层地址数据到 Web DTO
+func toAddressData(data *application.AddressData) *AddressData {
+	result := &AddressData{
+		AddressID:      data.AddressID,
+		Label:          data.Label,
+		RecipientName:  data.DeliveryInfo.RecipientName,
+		RecipientPhone: data.DeliveryInfo.RecipientPhone,
+		Address:        data.DeliveryInfo.Address,
+		IsDefault:      data.IsDefault,
+		CreatedAt:      data.CreatedAt,
+		UpdatedAt:      data.UpdatedAt,
+	}
+	if location := data.DeliveryInfo.Location; location != nil {
+		result.Location = &GeoPointData{Latitude: location.Latitude, Longitude: location.Longitude}
+	}
+	return result
+}
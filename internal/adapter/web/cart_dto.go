@@ -0,0 +1,37 @@
+package web
+
+// UpdateCartItemRequest Web 层修改购物车行数量请求
+type UpdateCartItemRequest struct {
+	Quantity int `json:"quantity"`
+}
+
+// CheckoutRequest Web 层购物车结算请求
+type CheckoutRequest struct {
+	DeliveryInfo DeliveryInfoRequest `json:"deliveryInfo"`
+	AddressID    string              `json:"addressId,omitempty"` // 地址簿中的地址ID（与 deliveryInfo 二选一）
+	Remark       string              `json:"remark"`
+	ScheduledFor string              `json:"scheduledFor,omitempty"`
+}
+
+// CartResponse 购物车响应
+type CartResponse struct {
+	Code    int       `json:"code"`
+	Message string    `json:"message"`
+	Data    *CartData `json:"data,omitempty"`
+}
+
+// CartData 购物车数据（pricing 为按当前菜单计算的价格预览，不含不可下单的行）
+type CartData struct {
+	MerchantID string         `json:"merchantId"`
+	Items      []CartItemData `json:"items"`
+	Pricing    PricingInfo    `json:"pricing"`
+	UpdatedAt  string         `json:"updatedAt"`
+	ExpiresAt  string         `json:"expiresAt"`
+}
+
+// CartItemData 购物车行（unavailable 为该行无法下单的业务错误码，如菜单下架了所选规格）
+type CartItemData struct {
+	LineID      string        `json:"lineId"`
+	Item        OrderItemData `json:"item"`
+	Unavailable string        `json:"unavailable,omitempty"`
+}
@@ -0,0 +1,183 @@
+package web
+
+import (
+	"net/http"
+
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CartHandler 购物车 HTTP 处理器（购物车按路径中的 merchantId 区分）
+type CartHandler struct {
+	cartService application.CartService
+}
+
+// NewCartHandler 创建购物车处理器
+func NewCartHandler(cartService application.CartService) *CartHandler {
+	return &CartHandler{
+		cartService: cartService,
+	}
+}
+
+// GetCart 查询购物车及价格预览
+func (h *CartHandler) GetCart(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	cart, err := h.cartService.GetCart(c.Request().Context(), userID, c.Param("merchantId"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return cartResponse(c, http.StatusOK, "success", cart)
+}
+
+// AddItem 加入购物车
+func (h *CartHandler) AddItem(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	var webReq OrderItemRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	appReq := toApplicationOrderItem(webReq)
+	cart, err := h.cartService.AddItem(c.Request().Context(), userID, c.Param("merchantId"), &appReq)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return cartResponse(c, http.StatusOK, "item added to cart", cart)
+}
+
+// UpdateItem 修改购物车行数量
+func (h *CartHandler) UpdateItem(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	var webReq UpdateCartItemRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	appReq := &application.UpdateCartItemRequest{Quantity: webReq.Quantity}
+	cart, err := h.cartService.UpdateItem(c.Request().Context(), userID, c.Param("merchantId"), c.Param("lineId"), appReq)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return cartResponse(c, http.StatusOK, "cart item updated", cart)
+}
+
+// RemoveItem 移除购物车行
+func (h *CartHandler) RemoveItem(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	cart, err := h.cartService.RemoveItem(c.Request().Context(), userID, c.Param("merchantId"), c.Param("lineId"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return cartResponse(c, http.StatusOK, "cart item removed", cart)
+}
+
+// ClearCart 清空购物车
+func (h *CartHandler) ClearCart(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	if err := h.cartService.ClearCart(c.Request().Context(), userID, c.Param("merchantId")); err != nil {
+		return handleError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Checkout 购物车结算下单
+func (h *CartHandler) Checkout(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	var webReq CheckoutRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	appReq := &application.CheckoutRequest{
+		DeliveryInfo: toApplicationDeliveryInfo(webReq.DeliveryInfo),
+		AddressID:    webReq.AddressID,
+		Remark:       webReq.Remark,
+		ScheduledFor: webReq.ScheduledFor,
+	}
+	orderData, err := h.cartService.Checkout(c.Request().Context(), userID, c.Param("merchantId"), appReq)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, CreateOrderResponse{
+		Code:    http.StatusCreated,
+		Message: "order created successfully",
+		Data:    convertToWebDTO(orderData),
+	})
+}
+
+// cartResponse 返回购物车响应
+func cartResponse(c echo.Context, status int, message string, cart *application.CartData) error {
+	items := make([]CartItemData, len(cart.Items))
+	for i, item := range cart.Items {
+		items[i] = CartItemData{
+			LineID:      item.LineID,
+			Item:        toOrderItemData(item.Item),
+			Unavailable: item.Unavailable,
+		}
+	}
+
+	return c.JSON(status, CartResponse{
+		Code:    status,
+		Message: message,
+		Data: &CartData{
+			MerchantID: cart.MerchantID,
+			Items:      items,
+			Pricing:    toPricingInfo(cart.Pricing),
+			UpdatedAt:  cart.UpdatedAt,
+			ExpiresAt:  cart.ExpiresAt,
+		},
+	})
+}
@@ -0,0 +1,99 @@
+package web
+
+import (
+	"net/http"
+
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DeliveryAreaHandler 商家配送范围 HTTP 处理器
+type DeliveryAreaHandler struct {
+	areaService application.DeliveryAreaService
+}
+
+// NewDeliveryAreaHandler 创建商家配送范围处理器
+func NewDeliveryAreaHandler(areaService application.DeliveryAreaService) *DeliveryAreaHandler {
+	return &DeliveryAreaHandler{
+		areaService: areaService,
+	}
+}
+
+// GetArea 查询商家配送范围（用户和商家均可查询）
+func (h *DeliveryAreaHandler) GetArea(c echo.Context) error {
+	data, err := h.areaService.GetArea(c.Request().Context(), c.Param("merchantId"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return deliveryAreaResponse(c, "success", data)
+}
+
+// UpdateArea 更新商家配送范围
+func (h *DeliveryAreaHandler) UpdateArea(c echo.Context) error {
+	var webReq UpdateDeliveryAreaRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	appReq := &application.UpdateDeliveryAreaRequest{
+		Origin:   toApplicationGeoPoint(webReq.Origin),
+		Zones:    make([]application.DeliveryZoneRequest, len(webReq.Zones)),
+		FeeBands: make([]application.DeliveryFeeBandRequest, len(webReq.FeeBands)),
+	}
+	for i, zone := range webReq.Zones {
+		appReq.Zones[i] = application.DeliveryZoneRequest{
+			Name:    zone.Name,
+			Polygon: make([]application.GeoPointRequest, len(zone.Polygon)),
+		}
+		for j, point := range zone.Polygon {
+			appReq.Zones[i].Polygon[j] = toApplicationGeoPoint(point)
+		}
+	}
+	for i, band := range webReq.FeeBands {
+		appReq.FeeBands[i] = application.DeliveryFeeBandRequest{MaxDistance: band.MaxDistance, Fee: band.Fee}
+	}
+	data, err := h.areaService.UpdateArea(c.Request().Context(), c.Param("merchantId"), appReq)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return deliveryAreaResponse(c, "delivery area updated", data)
+}
+
+// DeleteArea 删除商家配送范围（恢复固定配送费）
+func (h *DeliveryAreaHandler) DeleteArea(c echo.Context) error {
+	if err := h.areaService.DeleteArea(c.Request().Context(), c.Param("merchantId")); err != nil {
+		return handleError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// deliveryAreaResponse 返回商家配送范围响应
+func deliveryAreaResponse(c echo.Context, message string, data *application.DeliveryAreaData) error {
+	zones := make([]DeliveryZoneData, len(data.Zones))
+	for i, zone := range data.Zones {
+		zones[i] = DeliveryZoneData{Name: zone.Name, Polygon: make([]GeoPointData, len(zone.Polygon))}
+		for j, point := range zone.Polygon {
+			zones[i].Polygon[j] = GeoPointData{Latitude: point.Latitude, Longitude: point.Longitude}
+		}
+	}
+	bands := make([]DeliveryFeeBandData, len(data.FeeBands))
+	for i, band := range data.FeeBands {
+		bands[i] = DeliveryFeeBandData{MaxDistance: band.MaxDistance, Fee: band.Fee}
+	}
+
+	return c.JSON(http.StatusOK, DeliveryAreaResponse{
+		Code:    http.StatusOK,
+		Message: message,
+		Data: &DeliveryAreaData{
+			MerchantID: data.MerchantID,
+			Origin:     GeoPointData{Latitude: data.Origin.Latitude, Longitude: data.Origin.Longitude},
+			Zones:      zones,
+			FeeBands:   bands,
+			UpdatedAt:  data.UpdatedAt,
+		},
+	})
+}
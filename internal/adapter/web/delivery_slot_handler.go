@@ -0,0 +1,46 @@
+package web
+
+import (
+	"net/http"
+
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DeliverySlotHandler 预订配送时段 HTTP 处理器
+type DeliverySlotHandler struct {
+	slotService application.DeliverySlotService
+}
+
+// NewDeliverySlotHandler 创建预订配送时段处理器
+func NewDeliverySlotHandler(slotService application.DeliverySlotService) *DeliverySlotHandler {
+	return &DeliverySlotHandler{
+		slotService: slotService,
+	}
+}
+
+// ListSlots 查询商家某天可预订的配送时段（?date=YYYY-MM-DD，默认商家时区的今天）
+func (h *DeliverySlotHandler) ListSlots(c echo.Context) error {
+	appReq := &application.ListDeliverySlotsRequest{Date: c.QueryParam("date")}
+	slots, err := h.slotService.ListSlots(c.Request().Context(), c.Param("merchantId"), appReq)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	data := make([]DeliverySlotData, len(slots))
+	for i, slot := range slots {
+		data[i] = DeliverySlotData{
+			Start:     slot.Start,
+			End:       slot.End,
+			Capacity:  slot.Capacity,
+			Booked:    slot.Booked,
+			Available: slot.Available,
+		}
+	}
+	return c.JSON(http.StatusOK, DeliverySlotListResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    data,
+	})
+}
@@ -5,37 +5,111 @@ type CreateOrderRequest struct {
 	MerchantID   string              `json:"merchantId"`
 	Items        []OrderItemRequest  `json:"items"`
 	DeliveryInfo DeliveryInfoRequest `json:"deliveryInfo"`
+	AddressID    string              `json:"addressId,omitempty"` // 地址簿中的地址ID（与 deliveryInfo 二选一）
 	Remark       string              `json:"remark"`
+	QuoteToken   string              `json:"quoteToken,omitempty"`
+	ScheduledFor string              `json:"scheduledFor,omitempty"` // 预订配送时段的开始时间（RFC 3339），为空表示立即配送
 }
 
 // OrderItemRequest Web 层订单项请求
 type OrderItemRequest struct {
-	DishID   string  `json:"dishId"`
-	DishName string  `json:"dishName"`
-	Quantity int     `json:"quantity"`
-	Price    float64 `json:"price"`
+	DishID     string                   `json:"dishId"`
+	DishName   string                   `json:"dishName"`
+	Quantity   int                      `json:"quantity"`
+	Price      float64                  `json:"price"`
+	Options    []OrderItemOptionRequest `json:"options,omitempty"`
+	Components []ComboComponentRequest  `json:"components,omitempty"`
 }
 
-// DeliveryInfoRequest Web 层配送信息请求
+// ComboComponentRequest Web 层套餐组件位替换选择
+type ComboComponentRequest struct {
+	SlotID string `json:"slotId"`
+	DishID string `json:"dishId"`
+}
+
+// OrderItemOptionRequest Web 层订单项规格选择
+type OrderItemOptionRequest struct {
+	GroupID  string `json:"groupId"`
+	OptionID string `json:"optionId"`
+}
+
+// DeliveryInfoRequest Web 层配送信息请求（location 为收货坐标，商家配置了配送范围时必填）
 type DeliveryInfoRequest struct {
-	RecipientName  string `json:"recipientName"`
-	RecipientPhone string `json:"recipientPhone"`
-	Address        string `json:"address"`
+	RecipientName  string           `json:"recipientName"`
+	RecipientPhone string           `json:"recipientPhone"`
+	Address        string           `json:"address"`
+	Location       *GeoPointRequest `json:"location,omitempty"`
+}
+
+// GeoPointRequest Web 层地理坐标（WGS-84 经纬度）
+type GeoPointRequest struct {
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
 }
 
 // CreateOrderResponse 创建订单响应
 type CreateOrderResponse struct {
-	Code    int         `json:"code"`
-	Message string      `json:"message"`
-	Data    *OrderData  `json:"data,omitempty"`
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    *OrderData `json:"data,omitempty"`
+}
+
+// QuoteResponse 下单报价响应
+type QuoteResponse struct {
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    *QuoteData `json:"data,omitempty"`
+}
+
+// QuoteData 报价数据（quoteToken 在 expiresAt 前随相同的商家和订单项下单时，实付金额与报价一致）
+type QuoteData struct {
+	MerchantID string          `json:"merchantId"`
+	Items      []OrderItemData `json:"items"`
+	Pricing    PricingInfo     `json:"pricing"`
+	QuoteToken string          `json:"quoteToken,omitempty"`
+	ExpiresAt  string          `json:"expiresAt,omitempty"`
 }
 
 // OrderData 订单数据
 type OrderData struct {
-	OrderNumber string      `json:"orderNumber"`
-	Status      string      `json:"status"`
-	Pricing     PricingInfo `json:"pricing"`
-	CreatedAt   string      `json:"createdAt"`
+	OrderNumber  string          `json:"orderNumber"`
+	Status       string          `json:"status"`
+	Items        []OrderItemData `json:"items"`
+	Pricing      PricingInfo     `json:"pricing"`
+	ScheduledFor string          `json:"scheduledFor,omitempty"`
+	CreatedAt    string          `json:"createdAt"`
+}
+
+// OrderItemData 订单项数据（unitPrice 为含规格加价的单价）
+type OrderItemData struct {
+	DishID     string                   `json:"dishId"`
+	DishName   string                   `json:"dishName"`
+	Quantity   int                      `json:"quantity"`
+	Price      string                   `json:"price"`
+	UnitPrice  string                   `json:"unitPrice"`
+	Subtotal   string                   `json:"subtotal"`
+	Options    []OrderItemOptionData    `json:"options,omitempty"`
+	Components []OrderItemComponentData `json:"components,omitempty"`
+}
+
+// OrderItemComponentData 套餐组件（allocatedPrice 为每份套餐分摊到该组件的金额）
+type OrderItemComponentData struct {
+	SlotID         string `json:"slotId"`
+	SlotName       string `json:"slotName"`
+	DishID         string `json:"dishId"`
+	DishName       string `json:"dishName"`
+	Quantity       int    `json:"quantity"`
+	Surcharge      string `json:"surcharge"`
+	AllocatedPrice string `json:"allocatedPrice"`
+}
+
+// OrderItemOptionData 订单项已选规格
+type OrderItemOptionData struct {
+	GroupID    string `json:"groupId"`
+	GroupName  string `json:"groupName"`
+	OptionID   string `json:"optionId"`
+	OptionName string `json:"optionName"`
+	PriceDelta string `json:"priceDelta"`
 }
 
 // PricingInfo 价格信息
@@ -46,9 +120,78 @@ type PricingInfo struct {
 	FinalAmount  string `json:"finalAmount"`
 }
 
+// PaymentCallbackRequest 支付回调请求（Amount 为实付金额，两位小数的字符串）
+type PaymentCallbackRequest struct {
+	OrderNumber string `json:"orderNumber"`
+	PaymentID   string `json:"paymentId"`
+	Amount      string `json:"amount"`
+}
+
+// CancelOrderRequest Web 层取消订单请求
+type CancelOrderRequest struct {
+	Reason string `json:"reason"`
+}
+
+// OrderResponse 订单操作响应
+type OrderResponse struct {
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    *OrderData `json:"data,omitempty"`
+}
+
+// RefundOrderRequest Web 层退款请求（items 为空表示全额退款）
+type RefundOrderRequest struct {
+	Items  []RefundItemRequest `json:"items"`
+	Reason string              `json:"reason"`
+}
+
+// RefundItemRequest Web 层退款项请求（退套餐中的单个组件时 slotId 为组件位ID）
+type RefundItemRequest struct {
+	DishID   string `json:"dishId"`
+	SlotID   string `json:"slotId,omitempty"`
+	Quantity int    `json:"quantity"`
+}
+
+// RefundOrderResponse 退款响应
+type RefundOrderResponse struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    *RefundData `json:"data,omitempty"`
+}
+
+// RefundData 退款数据
+type RefundData struct {
+	RefundID     string           `json:"refundId"`
+	OrderNumber  string           `json:"orderNumber"`
+	OrderStatus  string           `json:"orderStatus"`
+	Status       string           `json:"status"`
+	Items        []RefundItemData `json:"items"`
+	PackagingFee string           `json:"packagingFee"`
+	DeliveryFee  string           `json:"deliveryFee"`
+	Amount       string           `json:"amount"`
+	CreatedAt    string           `json:"createdAt"`
+}
+
+// RefundItemData 退款项数据
+type RefundItemData struct {
+	DishID   string `json:"dishId"`
+	SlotID   string `json:"slotId,omitempty"`
+	Quantity int    `json:"quantity"`
+	Amount   string `json:"amount"`
+}
+
 // ErrorResponse 错误响应
 type ErrorResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Field   string `json:"field,omitempty"`
+	Code      int    `json:"code"`
+	Message   string `json:"message"`
+	Field     string `json:"field,omitempty"`
+	ErrorCode string `json:"errorCode,omitempty"`
+}
+
+// OrderStatusEvent SSE 推送的订单状态数据
+type OrderStatusEvent struct {
+	OrderNumber string `json:"orderNumber"`
+	EventType   string `json:"eventType,omitempty"`
+	Status      string `json:"status,omitempty"`
+	OccurredAt  string `json:"occurredAt,omitempty"`
 }
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"order-service/internal/application"
+	"order-service/internal/logging"
 
 	"github.com/labstack/echo/v4"
 )
@@ -46,77 +47,330 @@ func (h *OrderHandler) CreateOrder(c echo.Context) error {
 	// 4. 调用应用服务（验证在应用层完成）
 	orderData, err := h.orderService.CreateOrder(c.Request().Context(), userID, appReq)
 	if err != nil {
-		return h.handleError(c, err)
+		return handleError(c, err)
 	}
 
 	// 5. 返回成功响应
 	return c.JSON(http.StatusCreated, CreateOrderResponse{
 		Code:    http.StatusCreated,
 		Message: "order created successfully",
-		Data: &OrderData{
-			OrderNumber: orderData.OrderNumber,
-			Status:      orderData.Status,
-			Pricing: PricingInfo{
-				ItemsTotal:   orderData.Pricing.ItemsTotal,
-				PackagingFee: orderData.Pricing.PackagingFee,
-				DeliveryFee:  orderData.Pricing.DeliveryFee,
-				FinalAmount:  orderData.Pricing.FinalAmount,
-			},
-			CreatedAt: orderData.CreatedAt,
+		Data:    convertToWebDTO(orderData),
+	})
+}
+
+// QuoteOrder 下单报价 HTTP 处理器（按下单流程试算价格，不创建订单）
+func (h *OrderHandler) QuoteOrder(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	var webReq CreateOrderRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	quote, err := h.orderService.QuoteOrder(c.Request().Context(), userID, h.convertToApplicationDTO(&webReq))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	items := make([]OrderItemData, len(quote.Items))
+	for i, item := range quote.Items {
+		items[i] = toOrderItemData(item)
+	}
+	return c.JSON(http.StatusOK, QuoteResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data: &QuoteData{
+			MerchantID: quote.MerchantID,
+			Items:      items,
+			Pricing:    toPricingInfo(quote.Pricing),
+			QuoteToken: quote.QuoteToken,
+			ExpiresAt:  quote.ExpiresAt,
 		},
 	})
 }
 
-// convertToApplicationDTO 转换 Web DTO 到应用层 DTO
-func (h *OrderHandler) convertToApplicationDTO(webReq *CreateOrderRequest) *application.CreateOrderRequest {
-	items := make([]application.OrderItemRequest, len(webReq.Items))
+// CancelOrder 取消订单 HTTP 处理器
+func (h *OrderHandler) CancelOrder(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	var webReq CancelOrderRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	appReq := &application.CancelOrderRequest{Reason: webReq.Reason}
+	orderData, err := h.orderService.CancelOrder(c.Request().Context(), userID, c.Param("orderNumber"), appReq)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, OrderResponse{
+		Code:    http.StatusOK,
+		Message: "order cancelled successfully",
+		Data:    convertToWebDTO(orderData),
+	})
+}
+
+// RefundOrder 订单退款 HTTP 处理器
+func (h *OrderHandler) RefundOrder(c echo.Context) error {
+	// 1. 从 Context 获取用户ID
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	// 2. 解析请求体
+	var webReq RefundOrderRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	// 3. 转换 Web DTO 到应用层 DTO
+	items := make([]application.RefundItemRequest, len(webReq.Items))
 	for i, item := range webReq.Items {
-		items[i] = application.OrderItemRequest{
+		items[i] = application.RefundItemRequest{
 			DishID:   item.DishID,
-			DishName: item.DishName,
+			SlotID:   item.SlotID,
 			Quantity: item.Quantity,
-			Price:    item.Price,
 		}
 	}
+	appReq := &application.RefundOrderRequest{
+		Items:  items,
+		Reason: webReq.Reason,
+	}
 
-	return &application.CreateOrderRequest{
-		MerchantID: webReq.MerchantID,
-		Items:      items,
-		DeliveryInfo: application.DeliveryInfoRequest{
-			RecipientName:  webReq.DeliveryInfo.RecipientName,
-			RecipientPhone: webReq.DeliveryInfo.RecipientPhone,
-			Address:        webReq.DeliveryInfo.Address,
+	// 4. 调用应用服务
+	refundData, err := h.orderService.RefundOrder(c.Request().Context(), userID, c.Param("orderNumber"), appReq)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	// 5. 返回成功响应
+	refundItems := make([]RefundItemData, len(refundData.Items))
+	for i, item := range refundData.Items {
+		refundItems[i] = RefundItemData{
+			DishID:   item.DishID,
+			SlotID:   item.SlotID,
+			Quantity: item.Quantity,
+			Amount:   item.Amount,
+		}
+	}
+	return c.JSON(http.StatusCreated, RefundOrderResponse{
+		Code:    http.StatusCreated,
+		Message: "refund created successfully",
+		Data: &RefundData{
+			RefundID:     refundData.RefundID,
+			OrderNumber:  refundData.OrderNumber,
+			OrderStatus:  refundData.OrderStatus,
+			Status:       refundData.Status,
+			Items:        refundItems,
+			PackagingFee: refundData.PackagingFee,
+			DeliveryFee:  refundData.DeliveryFee,
+			Amount:       refundData.Amount,
+			CreatedAt:    refundData.CreatedAt,
 		},
-		Remark: webReq.Remark,
+	})
+}
+
+// convertToApplicationDTO 转换 Web DTO 到应用层 DTO
+func (h *OrderHandler) convertToApplicationDTO(webReq *CreateOrderRequest) *application.CreateOrderRequest {
+	items := make([]application.OrderItemRequest, len(webReq.Items))
+	for i, item := range webReq.Items {
+		items[i] = toApplicationOrderItem(item)
+	}
+
+	return &application.CreateOrderRequest{
+		MerchantID:   webReq.MerchantID,
+		Items:        items,
+		DeliveryInfo: toApplicationDeliveryInfo(webReq.DeliveryInfo),
+		AddressID:    webReq.AddressID,
+		Remark:       webReq.Remark,
+		QuoteToken:   webReq.QuoteToken,
+		ScheduledFor: webReq.ScheduledFor,
 	}
 }
 
+// toApplicationOrderItem 转换订单项请求
+func toApplicationOrderItem(item OrderItemRequest) application.OrderItemRequest {
+	result := application.OrderItemRequest{
+		DishID:   item.DishID,
+		DishName: item.DishName,
+		Quantity: item.Quantity,
+		Price:    item.Price,
+	}
+	for _, option := range item.Options {
+		result.Options = append(result.Options, application.OrderItemOptionRequest{
+			GroupID:  option.GroupID,
+			OptionID: option.OptionID,
+		})
+	}
+	for _, component := range item.Components {
+		result.Components = append(result.Components, application.ComboComponentRequest{
+			SlotID: component.SlotID,
+			DishID: component.DishID,
+		})
+	}
+	return result
+}
+
+// toApplicationDeliveryInfo 转换配送信息请求
+func toApplicationDeliveryInfo(info DeliveryInfoRequest) application.DeliveryInfoRequest {
+	result := application.DeliveryInfoRequest{
+		RecipientName:  info.RecipientName,
+		RecipientPhone: info.RecipientPhone,
+		Address:        info.Address,
+	}
+	if info.Location != nil {
+		location := toApplicationGeoPoint(*info.Location)
+		result.Location = &location
+	}
+	return result
+}
+
+// toApplicationGeoPoint 转换地理坐标请求
+func toApplicationGeoPoint(point GeoPointRequest) application.GeoPointRequest {
+	return application.GeoPointRequest{Latitude: point.Latitude, Longitude: point.Longitude}
+}
+
+// convertToWebDTO 转换应用层订单数据到 Web DTO
+func convertToWebDTO(orderData *application.OrderData) *OrderData {
+	items := make([]OrderItemData, len(orderData.Items))
+	for i, item := range orderData.Items {
+		items[i] = toOrderItemData(item)
+	}
+
+	return &OrderData{
+		OrderNumber:  orderData.OrderNumber,
+		Status:       orderData.Status,
+		Items:        items,
+		Pricing:      toPricingInfo(orderData.Pricing),
+		ScheduledFor: orderData.ScheduledFor,
+		CreatedAt:    orderData.CreatedAt,
+	}
+}
+
+// toOrderItemData 转换订单项
+func toOrderItemData(item application.OrderItemData) OrderItemData {
+	return OrderItemData{
+		DishID:     item.DishID,
+		DishName:   item.DishName,
+		Quantity:   item.Quantity,
+		Price:      item.Price,
+		UnitPrice:  item.UnitPrice,
+		Subtotal:   item.Subtotal,
+		Options:    toOrderItemOptionData(item.Options),
+		Components: toOrderItemComponentData(item.Components),
+	}
+}
+
+// toPricingInfo 转换价格信息
+func toPricingInfo(pricing application.PricingInfo) PricingInfo {
+	return PricingInfo{
+		ItemsTotal:   pricing.ItemsTotal,
+		PackagingFee: pricing.PackagingFee,
+		DeliveryFee:  pricing.DeliveryFee,
+		FinalAmount:  pricing.FinalAmount,
+	}
+}
+
+// toOrderItemOptionData 转换订单项已选规格
+func toOrderItemOptionData(options []application.OrderItemOptionData) []OrderItemOptionData {
+	var result []OrderItemOptionData
+	for _, option := range options {
+		result = append(result, OrderItemOptionData{
+			GroupID:    option.GroupID,
+			GroupName:  option.GroupName,
+			OptionID:   option.OptionID,
+			OptionName: option.OptionName,
+			PriceDelta: option.PriceDelta,
+		})
+	}
+	return result
+}
+
+// toOrderItemComponentData 转换套餐组件
+func toOrderItemComponentData(components []application.OrderItemComponentData) []OrderItemComponentData {
+	var result []OrderItemComponentData
+	for _, component := range components {
+		result = append(result, OrderItemComponentData{
+			SlotID:         component.SlotID,
+			SlotName:       component.SlotName,
+			DishID:         component.DishID,
+			DishName:       component.DishName,
+			Quantity:       component.Quantity,
+			Surcharge:      component.Surcharge,
+			AllocatedPrice: component.AllocatedPrice,
+		})
+	}
+	return result
+}
+
 // handleError 处理不同类型的错误
-func (h *OrderHandler) handleError(c echo.Context, err error) error {
+func handleError(c echo.Context, err error) error {
+	status, response := errorResponse(err)
+	if status == http.StatusInternalServerError {
+		// 记录详细错误日志（响应中不暴露内部细节）
+		logging.FromContext(c.Request().Context()).Error("internal error", logging.KeyError, err)
+	}
+	if rateLimitErr, ok := err.(*application.RateLimitError); ok {
+		setRateLimitErrorHeaders(c.Response().Header(), rateLimitErr)
+	}
+	return c.JSON(status, response)
+}
+
+// errorResponse 将应用层错误映射为 HTTP 状态码和错误响应
+func errorResponse(err error) (int, ErrorResponse) {
 	switch e := err.(type) {
 	case *application.ValidationError:
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
+		return http.StatusBadRequest, ErrorResponse{
 			Code:    http.StatusBadRequest,
 			Message: e.Message,
 			Field:   e.Field,
-		})
+		}
+	case *application.BusinessError:
+		return http.StatusUnprocessableEntity, ErrorResponse{
+			Code:      http.StatusUnprocessableEntity,
+			Message:   e.Message,
+			ErrorCode: e.Code,
+		}
 	case *application.NotFoundError:
-		return c.JSON(http.StatusNotFound, ErrorResponse{
+		return http.StatusNotFound, ErrorResponse{
 			Code:    http.StatusNotFound,
 			Message: e.Message,
-		})
-	case *application.InternalError:
-		// 记录详细错误日志（生产环境应使用日志库）
-		c.Logger().Error(e)
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Code:    http.StatusInternalServerError,
-			Message: "internal server error",
-		})
+		}
+	case *application.RateLimitError:
+		return http.StatusTooManyRequests, ErrorResponse{
+			Code:    http.StatusTooManyRequests,
+			Message: e.Message,
+		}
 	default:
-		c.Logger().Error(err)
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		return http.StatusInternalServerError, ErrorResponse{
 			Code:    http.StatusInternalServerError,
 			Message: "internal server error",
-		})
+		}
 	}
 }
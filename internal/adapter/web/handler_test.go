@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"order-service/internal/application"
 
@@ -35,6 +36,70 @@ func (m *MockOrderService) CreateOrder(ctx context.Context, userID uint64, req *
 	return args.Get(0).(*application.OrderData), args.Error(1)
 }
 
+func (m *MockOrderService) QuoteOrder(ctx context.Context, userID uint64, req *application.CreateOrderRequest) (*application.QuoteData, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.QuoteData), args.Error(1)
+}
+
+func (m *MockOrderService) GetOrder(ctx context.Context, userID uint64, orderNumber string) (*application.OrderData, error) {
+	args := m.Called(ctx, userID, orderNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.OrderData), args.Error(1)
+}
+
+func (m *MockOrderService) ListOrders(ctx context.Context, userID uint64, req *application.ListOrdersRequest) (*application.OrderListData, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.OrderListData), args.Error(1)
+}
+
+func (m *MockOrderService) AcceptOrder(ctx context.Context, merchantID, orderNumber string) (*application.OrderData, error) {
+	args := m.Called(ctx, merchantID, orderNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.OrderData), args.Error(1)
+}
+
+func (m *MockOrderService) RejectOrder(ctx context.Context, merchantID, orderNumber string, req *application.RejectOrderRequest) (*application.OrderData, error) {
+	args := m.Called(ctx, merchantID, orderNumber, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.OrderData), args.Error(1)
+}
+
+func (m *MockOrderService) ConfirmPayment(ctx context.Context, req *application.ConfirmPaymentRequest) (*application.OrderData, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.OrderData), args.Error(1)
+}
+
+func (m *MockOrderService) CancelOrder(ctx context.Context, userID uint64, orderNumber string, req *application.CancelOrderRequest) (*application.OrderData, error) {
+	args := m.Called(ctx, userID, orderNumber, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.OrderData), args.Error(1)
+}
+
+func (m *MockOrderService) RefundOrder(ctx context.Context, userID uint64, orderNumber string, req *application.RefundOrderRequest) (*application.RefundData, error) {
+	args := m.Called(ctx, userID, orderNumber, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.RefundData), args.Error(1)
+}
+
 func TestOrderHandler_CreateOrder_Success(t *testing.T) {
 	e := echo.New()
 	e.Validator = &testValidator{}
@@ -154,6 +219,36 @@ func TestOrderHandler_CreateOrder_Unauthorized(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 }
 
+func TestOrderHandler_CreateOrder_RateLimited(t *testing.T) {
+	e := echo.New()
+	e.Validator = &testValidator{}
+	mockService := new(MockOrderService)
+	handler := NewOrderHandler(mockService)
+
+	body, _ := json.Marshal(CreateOrderRequest{MerchantID: "merchant1"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set(UserIDKey, uint64(1001))
+
+	mockService.On("CreateOrder", mock.Anything, uint64(1001), mock.AnythingOfType("*application.CreateOrderRequest")).
+		Return(nil, application.NewRateLimitErrorFromDecision("too many orders, retry later", application.RateLimitDecision{
+			Limit: 5, RetryAfter: 1500 * time.Millisecond, ResetAfter: 7500 * time.Millisecond,
+		}))
+
+	err := handler.CreateOrder(c)
+
+	// 应用层限流返回 429，Retry-After 向上取整，RateLimit-* 为拒绝的规则
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get(echo.HeaderRetryAfter))
+	assert.Equal(t, "5", rec.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "0", rec.Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "8", rec.Header().Get(HeaderRateLimitReset))
+	assert.Contains(t, rec.Body.String(), "too many orders")
+}
+
 func TestOrderHandler_CreateOrder_InternalError(t *testing.T) {
 	e := echo.New()
 	e.Validator = &testValidator{}
@@ -195,3 +290,97 @@ func TestOrderHandler_CreateOrder_InternalError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 }
+
+func TestOrderHandler_RefundOrder_Success(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockOrderService)
+	handler := NewOrderHandler(mockService)
+
+	body, _ := json.Marshal(RefundOrderRequest{
+		Items:  []RefundItemRequest{{DishID: "dish1", Quantity: 1}},
+		Reason: "少送了一份",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/20241117120000123456/refunds", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("orderNumber")
+	c.SetParamValues("20241117120000123456")
+	c.Set(UserIDKey, uint64(1001))
+
+	// 设置 mock 期望
+	expectedReq := &application.RefundOrderRequest{
+		Items:  []application.RefundItemRequest{{DishID: "dish1", Quantity: 1}},
+		Reason: "少送了一份",
+	}
+	mockService.On("RefundOrder", mock.Anything, uint64(1001), "20241117120000123456", expectedReq).Return(&application.RefundData{
+		RefundID:    "20241117120000123456R01",
+		OrderNumber: "20241117120000123456",
+		OrderStatus: "PAID",
+		Status:      "REFUNDED",
+		Items:       []application.RefundItemData{{DishID: "dish1", Quantity: 1, Amount: "28.00"}},
+		Amount:      "28.41",
+	}, nil)
+
+	// 执行
+	err := handler.RefundOrder(c)
+
+	// 验证
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var response RefundOrderResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "REFUNDED", response.Data.Status)
+	assert.Equal(t, "28.41", response.Data.Amount)
+	assert.Len(t, response.Data.Items, 1)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_RefundOrder_BusinessError(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockOrderService)
+	handler := NewOrderHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/20241117120000123456/refunds", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("orderNumber")
+	c.SetParamValues("20241117120000123456")
+	c.Set(UserIDKey, uint64(1001))
+
+	mockService.On("RefundOrder", mock.Anything, uint64(1001), "20241117120000123456", mock.Anything).
+		Return(nil, application.NewBusinessError("ORDER_NOT_PAID", "order is not paid"))
+
+	err := handler.RefundOrder(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var response ErrorResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "ORDER_NOT_PAID", response.ErrorCode)
+}
+
+func TestOrderHandler_CancelOrder_InvalidStatus(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockOrderService)
+	handler := NewOrderHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/20241117120000123456/cancel", bytes.NewReader([]byte(`{"reason":"点错了"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("orderNumber")
+	c.SetParamValues("20241117120000123456")
+	c.Set(UserIDKey, uint64(1001))
+
+	mockService.On("CancelOrder", mock.Anything, uint64(1001), "20241117120000123456", &application.CancelOrderRequest{Reason: "点错了"}).
+		Return(nil, application.NewBusinessError("INVALID_ORDER_STATUS", "operation not allowed in current order status"))
+
+	err := handler.CancelOrder(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
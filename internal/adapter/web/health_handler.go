@@ -0,0 +1,67 @@
+package web
+
+import (
+	"net/http"
+
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 健康检查状态
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusReady    = "ready"
+	HealthStatusNotReady = "not_ready"
+	HealthStatusDraining = "draining"
+)
+
+// HealthResponse 健康检查响应（Checks 为各检查项的结果，通过时为 ok，否则为错误信息）
+type HealthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// HealthHandler 存活/就绪检查处理器
+type HealthHandler struct {
+	health *application.Health
+}
+
+// NewHealthHandler 创建健康检查处理器
+func NewHealthHandler(health *application.Health) *HealthHandler {
+	return &HealthHandler{health: health}
+}
+
+// Liveness 存活检查：进程能够响应即返回 200（停机排空期间仍然存活，避免被提前重启）
+func (h *HealthHandler) Liveness(c echo.Context) error {
+	return c.JSON(http.StatusOK, HealthResponse{Status: HealthStatusOK})
+}
+
+// Readiness 就绪检查：全部检查项通过且未开始停机时返回 200，否则返回 503
+func (h *HealthHandler) Readiness(c echo.Context) error {
+	report := h.health.Readiness(c.Request().Context())
+
+	resp := HealthResponse{Status: HealthStatusReady, Checks: make(map[string]string, len(report.Checks))}
+	for _, check := range report.Checks {
+		resp.Checks[check.Name] = HealthStatusOK
+		if check.Error != nil {
+			resp.Checks[check.Name] = check.Error.Error()
+		}
+	}
+
+	switch {
+	case report.Draining:
+		resp.Status = HealthStatusDraining
+	case !report.Ready:
+		resp.Status = HealthStatusNotReady
+	default:
+		return c.JSON(http.StatusOK, resp)
+	}
+	return c.JSON(http.StatusServiceUnavailable, resp)
+}
+
+// RegisterHealthRoutes 注册 /healthz 和 /readyz（根路径、无需认证，供负载均衡和编排系统探测）
+func RegisterHealthRoutes(e *echo.Echo, h *HealthHandler) {
+	e.GET("/healthz", h.Liveness)
+	e.GET("/readyz", h.Readiness)
+}
@@ -0,0 +1,86 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newHealthServer 创建注册了健康检查路由的测试服务
+func newHealthServer(health *application.Health) *echo.Echo {
+	e := echo.New()
+	RegisterHealthRoutes(e, NewHealthHandler(health))
+	return e
+}
+
+// getHealth 请求健康检查接口并解析响应
+func getHealth(t *testing.T, e *echo.Echo, path string) (int, HealthResponse) {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	var resp HealthResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return rec.Code, resp
+}
+
+func TestHealthHandler_Liveness(t *testing.T) {
+	// Arrange - 停机排空期间仍然存活
+	health := application.NewHealth()
+	health.StartDraining()
+	e := newHealthServer(health)
+
+	// Act
+	code, resp := getHealth(t, e, "/healthz")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, HealthStatusOK, resp.Status)
+}
+
+func TestHealthHandler_Readiness(t *testing.T) {
+	failing := application.HealthCheckFunc(func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+	passing := application.HealthCheckFunc(func(ctx context.Context) error { return nil })
+
+	tests := []struct {
+		name     string
+		checker  application.HealthChecker
+		draining bool
+		code     int
+		status   string
+		check    string
+	}{
+		{name: "就绪", checker: passing, code: http.StatusOK, status: HealthStatusReady, check: HealthStatusOK},
+		{name: "检查项失败", checker: failing, code: http.StatusServiceUnavailable, status: HealthStatusNotReady, check: "connection refused"},
+		{name: "停机中", checker: passing, draining: true, code: http.StatusServiceUnavailable, status: HealthStatusDraining, check: HealthStatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			health := application.NewHealth()
+			health.Register("orderRepository", tt.checker)
+			if tt.draining {
+				health.StartDraining()
+			}
+			e := newHealthServer(health)
+
+			// Act
+			code, resp := getHealth(t, e, "/readyz")
+
+			// Assert
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.status, resp.Status)
+			assert.Equal(t, map[string]string{"orderRepository": tt.check}, resp.Checks)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package web
+
+import (
+	"net/http"
+
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// InventoryHandler 商家餐品库存 HTTP 处理器
+type InventoryHandler struct {
+	inventoryService application.InventoryService
+}
+
+// NewInventoryHandler 创建商家餐品库存处理器
+func NewInventoryHandler(inventoryService application.InventoryService) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryService: inventoryService,
+	}
+}
+
+// GetInventory 查询商家餐品库存（用户和商家均可查询）
+func (h *InventoryHandler) GetInventory(c echo.Context) error {
+	data, err := h.inventoryService.GetInventory(c.Request().Context(), c.Param("merchantId"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return inventoryResponse(c, "success", data)
+}
+
+// UpdateInventory 更新商家餐品库存
+func (h *InventoryHandler) UpdateInventory(c echo.Context) error {
+	var webReq UpdateInventoryRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	appReq := &application.UpdateInventoryRequest{
+		Dishes: make([]application.DishStockRequest, len(webReq.Dishes)),
+	}
+	for i, dish := range webReq.Dishes {
+		appReq.Dishes[i] = application.DishStockRequest{DishID: dish.DishID, Stock: dish.Stock, DailyLimit: dish.DailyLimit}
+	}
+	data, err := h.inventoryService.UpdateInventory(c.Request().Context(), c.Param("merchantId"), appReq)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return inventoryResponse(c, "inventory updated", data)
+}
+
+// inventoryResponse 返回商家餐品库存响应
+func inventoryResponse(c echo.Context, message string, data *application.InventoryData) error {
+	dishes := make([]DishStockData, len(data.Dishes))
+	for i, dish := range data.Dishes {
+		dishes[i] = DishStockData{
+			DishID:     dish.DishID,
+			Stock:      dish.Stock,
+			DailyLimit: dish.DailyLimit,
+			SoldToday:  dish.SoldToday,
+			Available:  dish.Available,
+		}
+	}
+
+	return c.JSON(http.StatusOK, InventoryResponse{
+		Code:    http.StatusOK,
+		Message: message,
+		Data: &InventoryData{
+			MerchantID: data.MerchantID,
+			Dishes:     dishes,
+			UpdatedAt:  data.UpdatedAt,
+		},
+	})
+}
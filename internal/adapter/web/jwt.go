@@ -4,34 +4,55 @@ import (
 	"fmt"
 	"time"
 
+	"order-service/internal/config"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
 const (
-	// JWTSecret JWT 密钥（生产环境应从配置读取）
-	JWTSecret = "interview-demo-secret-key"
-	// TokenExpiration token 过期时间
-	TokenExpiration = 24 * time.Hour
+	// DefaultJWTSecret 开发环境默认 JWT 密钥（生产环境通过配置覆盖）
+	DefaultJWTSecret = config.DemoJWTSecret
+	// DefaultTokenExpiration 默认 token 过期时间
+	DefaultTokenExpiration = 24 * time.Hour
+)
+
+// JWT 密钥和 token 过期时间（启动时通过 ConfigureJWT 设置）
+var (
+	jwtSecret       = []byte(DefaultJWTSecret)
+	tokenExpiration = DefaultTokenExpiration
 )
 
-// Claims JWT Claims
+// ConfigureJWT 配置 JWT 密钥和 token 过期时间（需在处理请求前调用）
+func ConfigureJWT(secret string, expiration time.Duration) {
+	jwtSecret = []byte(secret)
+	tokenExpiration = expiration
+}
+
+// Claims JWT Claims（MerchantID 仅商家账号的 token 携带）
 type Claims struct {
-	UserID uint64 `json:"userId"`
+	UserID     uint64 `json:"userId"`
+	MerchantID string `json:"merchantId,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // GenerateToken 生成 JWT token（用于测试）
 func GenerateToken(userID uint64) (string, error) {
+	return GenerateMerchantToken(userID, "")
+}
+
+// GenerateMerchantToken 生成商家账号的 JWT token（用于测试）
+func GenerateMerchantToken(userID uint64, merchantID string) (string, error) {
 	claims := Claims{
-		UserID: userID,
+		UserID:     userID,
+		MerchantID: merchantID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(TokenExpiration)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tokenExpiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(JWTSecret))
+	return token.SignedString(jwtSecret)
 }
 
 // ValidateToken 验证 token 有效性
@@ -41,7 +62,7 @@ func ValidateToken(tokenString string) (*Claims, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(JWTSecret), nil
+		return jwtSecret, nil
 	})
 
 	if err != nil {
@@ -2,6 +2,7 @@ package web
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -45,3 +46,32 @@ func TestExtractUserID_InvalidToken(t *testing.T) {
 	_, err := ExtractUserID("invalid-token")
 	assert.Error(t, err)
 }
+
+func TestGenerateMerchantToken(t *testing.T) {
+	token, err := GenerateMerchantToken(2001, "merchant_001")
+	assert.NoError(t, err)
+
+	claims, err := ValidateToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2001), claims.UserID)
+	assert.Equal(t, "merchant_001", claims.MerchantID)
+}
+
+func TestConfigureJWT(t *testing.T) {
+	// Arrange - 用默认密钥签发的 token
+	oldToken, err := GenerateToken(1001)
+	assert.NoError(t, err)
+	ConfigureJWT("another-secret-key-for-tests", time.Hour)
+	t.Cleanup(func() { ConfigureJWT(DefaultJWTSecret, DefaultTokenExpiration) })
+
+	// Act
+	newToken, err := GenerateToken(1001)
+	assert.NoError(t, err)
+	claims, newErr := ValidateToken(newToken)
+	_, oldErr := ValidateToken(oldToken)
+
+	// Assert - 更换密钥后旧 token 失效，新 token 按配置的有效期签发
+	assert.NoError(t, newErr)
+	assert.Error(t, oldErr)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt.Time, time.Minute)
+}
@@ -0,0 +1,68 @@
+package web
+
+import (
+	"log/slog"
+	"time"
+
+	"order-service/internal/logging"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequestIDKey Context 中存储请求ID的键
+const RequestIDKey = "requestID"
+
+// RequestID 请求ID中间件（需注册在最外层）
+// 沿用调用方传入的合法 X-Request-ID，否则生成新的请求ID；请求ID写回响应头，
+// 并随 logger 放入请求 context，下游（包括应用服务）的日志都会携带该请求ID
+func RequestID(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		requestID := req.Header.Get(logging.RequestIDHeader)
+		if !logging.ValidRequestID(requestID) {
+			requestID = logging.NewRequestID()
+		}
+
+		c.Set(RequestIDKey, requestID)
+		c.Response().Header().Set(logging.RequestIDHeader, requestID)
+		c.SetRequest(req.WithContext(logging.WithRequestID(req.Context(), requestID)))
+		return next(c)
+	}
+}
+
+// AccessLog 访问日志中间件（需注册在 RequestID 之后），5xx 记为 error，其余记为 info
+func AccessLog(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+		if err != nil {
+			// 交给 Echo 的错误处理器写入响应，以便记录最终状态码
+			c.Error(err)
+		}
+
+		req := c.Request()
+		res := c.Response()
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.Path),
+			slog.String("route", c.Path()),
+			slog.Int("status", res.Status),
+			slog.Duration("latency", time.Since(start)),
+			slog.Int64("bytes_out", res.Size),
+			slog.String("remote_ip", c.RealIP()),
+		}
+		if userID, ok := c.Get(UserIDKey).(uint64); ok {
+			attrs = append(attrs, slog.Uint64(logging.KeyUserID, userID))
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String(logging.KeyError, err.Error()))
+		}
+
+		level := slog.LevelInfo
+		if res.Status >= 500 {
+			level = slog.LevelError
+		}
+		logging.FromContext(req.Context()).LogAttrs(req.Context(), level, "http request", attrs...)
+		return nil
+	}
+}
@@ -0,0 +1,104 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"order-service/internal/logging"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLoggingServer 创建挂载请求ID和访问日志中间件的测试服务
+func newLoggingServer() *echo.Echo {
+	e := echo.New()
+	e.Use(RequestID, AccessLog)
+	e.GET("/orders/:orderNumber", func(c echo.Context) error {
+		c.Set(UserIDKey, uint64(1001))
+		logging.FromContext(c.Request().Context()).Info("handler")
+		return c.String(http.StatusOK, c.Get(RequestIDKey).(string))
+	})
+	e.GET("/fail", func(c echo.Context) error {
+		return errors.New("boom")
+	})
+	return e
+}
+
+// serveWithLogger 发送请求，日志写入返回的缓冲区（每行一条 JSON）
+func serveWithLogger(e *echo.Echo, req *http.Request) (*httptest.ResponseRecorder, []map[string]interface{}) {
+	var buf bytes.Buffer
+	req = req.WithContext(logging.WithLogger(req.Context(), logging.New(&buf, slog.LevelInfo)))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if json.Unmarshal([]byte(line), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return rec, entries
+}
+
+func TestRequestID_HonoursIncomingHeader(t *testing.T) {
+	// Arrange
+	e := newLoggingServer()
+	req := httptest.NewRequest(http.MethodGet, "/orders/20250101", nil)
+	req.Header.Set(logging.RequestIDHeader, "client-req-1")
+
+	// Act
+	rec, entries := serveWithLogger(e, req)
+
+	// Assert - 处理器日志和访问日志都携带请求ID
+	assert.Equal(t, "client-req-1", rec.Header().Get(logging.RequestIDHeader))
+	assert.Equal(t, "client-req-1", rec.Body.String())
+	require.Len(t, entries, 2)
+	assert.Equal(t, "handler", entries[0]["msg"])
+	assert.Equal(t, "client-req-1", entries[0][logging.KeyRequestID])
+
+	access := entries[1]
+	assert.Equal(t, "http request", access["msg"])
+	assert.Equal(t, "client-req-1", access[logging.KeyRequestID])
+	assert.Equal(t, "/orders/:orderNumber", access["route"])
+	assert.Equal(t, float64(http.StatusOK), access["status"])
+	assert.Equal(t, float64(1001), access[logging.KeyUserID])
+}
+
+func TestRequestID_ReplacesInvalidHeader(t *testing.T) {
+	// Arrange
+	e := newLoggingServer()
+	req := httptest.NewRequest(http.MethodGet, "/orders/20250101", nil)
+	req.Header.Set(logging.RequestIDHeader, "bad id\twith spaces")
+
+	// Act
+	rec, _ := serveWithLogger(e, req)
+
+	// Assert
+	requestID := rec.Header().Get(logging.RequestIDHeader)
+	assert.NotEqual(t, "bad id\twith spaces", requestID)
+	assert.True(t, logging.ValidRequestID(requestID))
+}
+
+func TestAccessLog_LogsServerErrors(t *testing.T) {
+	// Arrange
+	e := newLoggingServer()
+
+	// Act
+	rec, entries := serveWithLogger(e, httptest.NewRequest(http.MethodGet, "/fail", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "ERROR", entries[0]["level"])
+	assert.Equal(t, float64(http.StatusInternalServerError), entries[0]["status"])
+	assert.Equal(t, "boom", entries[0][logging.KeyError])
+}
@@ -0,0 +1,79 @@
+package web
+
+// 商家接单 WebSocket 消息类型
+const (
+	// 客户端 -> 服务端
+	IntakeCommandAck    = "ack"
+	IntakeCommandAccept = "accept"
+	IntakeCommandReject = "reject"
+
+	// 服务端 -> 客户端
+	IntakeMessageOrder  = "order"
+	IntakeMessageResult = "result"
+	IntakeMessageError  = "error"
+)
+
+// IntakeCommand 商家终端发送的指令
+type IntakeCommand struct {
+	Type        string `json:"type"`
+	RequestID   string `json:"requestId,omitempty"`
+	DeliveryID  string `json:"deliveryId,omitempty"`
+	OrderNumber string `json:"orderNumber,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// IntakeMessage 推送给商家终端的消息
+type IntakeMessage struct {
+	Type        string         `json:"type"`
+	DeliveryID  string         `json:"deliveryId,omitempty"`
+	Order       *IntakeOrder   `json:"order,omitempty"`
+	RequestID   string         `json:"requestId,omitempty"`
+	OrderNumber string         `json:"orderNumber,omitempty"`
+	Status      string         `json:"status,omitempty"`
+	Error       *ErrorResponse `json:"error,omitempty"`
+}
+
+// IntakeOrder 推送的订单数据
+type IntakeOrder struct {
+	OrderNumber  string            `json:"orderNumber"`
+	EventType    string            `json:"eventType"`
+	Status       string            `json:"status"`
+	Items        []IntakeOrderItem `json:"items,omitempty"`
+	FinalAmount  string            `json:"finalAmount"`
+	ScheduledFor string            `json:"scheduledFor,omitempty"` // 预订配送时段的开始时间（仅预订单）
+	OccurredAt   string            `json:"occurredAt"`
+}
+
+// IntakeOrderItem 推送的订单项（options 和 components 为出餐需要的规格和套餐组件）
+type IntakeOrderItem struct {
+	DishID     string                   `json:"dishId"`
+	DishName   string                   `json:"dishName"`
+	Quantity   int                      `json:"quantity"`
+	Price      string                   `json:"price"`
+	UnitPrice  string                   `json:"unitPrice"`
+	Options    []OrderItemOptionData    `json:"options,omitempty"`
+	Components []OrderItemComponentData `json:"components,omitempty"`
+}
+
+// MerchantPresenceResponse 商家在线状态响应
+type MerchantPresenceResponse struct {
+	Code    int                  `json:"code"`
+	Message string               `json:"message"`
+	Data    MerchantPresenceData `json:"data"`
+}
+
+// OnlineMerchantsResponse 在线商家列表响应
+type OnlineMerchantsResponse struct {
+	Code    int                    `json:"code"`
+	Message string                 `json:"message"`
+	Data    []MerchantPresenceData `json:"data"`
+}
+
+// MerchantPresenceData 商家在线状态
+type MerchantPresenceData struct {
+	MerchantID  string `json:"merchantId"`
+	Online      bool   `json:"online"`
+	Connections int    `json:"connections"`
+	ConnectedAt string `json:"connectedAt,omitempty"`
+	LastSeenAt  string `json:"lastSeenAt,omitempty"`
+}
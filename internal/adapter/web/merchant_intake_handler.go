@@ -0,0 +1,283 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"order-service/internal/application"
+	"order-service/internal/logging"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+)
+
+// 默认 WebSocket 心跳间隔（超过两个间隔未收到 pong 视为断线）
+const DefaultPingInterval = 30 * time.Second
+
+// 单次写入超时
+const wsWriteTimeout = 10 * time.Second
+
+// MerchantIntakeHandler 商家接单 WebSocket 处理器
+type MerchantIntakeHandler struct {
+	orderService application.OrderService
+	hub          *application.MerchantIntakeHub
+	upgrader     websocket.Upgrader
+	pingInterval time.Duration
+}
+
+// IntakeHandlerOption 商家接单处理器可选配置
+type IntakeHandlerOption func(*MerchantIntakeHandler)
+
+// WithPingInterval 配置 WebSocket 心跳间隔
+func WithPingInterval(interval time.Duration) IntakeHandlerOption {
+	return func(h *MerchantIntakeHandler) {
+		h.pingInterval = interval
+	}
+}
+
+// NewMerchantIntakeHandler 创建商家接单处理器
+func NewMerchantIntakeHandler(orderService application.OrderService, hub *application.MerchantIntakeHub, opts ...IntakeHandlerOption) *MerchantIntakeHandler {
+	h := &MerchantIntakeHandler{
+		orderService: orderService,
+		hub:          hub,
+		pingInterval: DefaultPingInterval,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Connect 商家终端 WebSocket 连接处理器
+// 连接建立后先重新投递未确认的推送，之后实时推送新订单和已支付订单；
+// 终端通过 ack 确认送达，通过 accept/reject 接单或拒单
+func (h *MerchantIntakeHandler) Connect(c echo.Context) error {
+	merchantID := c.Param("merchantId")
+
+	conn, err := h.upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		// Upgrader 已写入错误响应
+		return nil
+	}
+	defer conn.Close()
+
+	session, pending := h.hub.Connect(merchantID)
+	defer session.Close()
+
+	ctx := logging.With(c.Request().Context(), "merchant_id", merchantID)
+	logger := logging.FromContext(ctx)
+	logger.Info("merchant intake connected", "pending", len(pending))
+	defer logger.Info("merchant intake disconnected")
+
+	outbound := make(chan IntakeMessage, 16)
+	writerDone := make(chan struct{})
+	readerDone := make(chan struct{})
+	defer close(writerDone)
+
+	go func() {
+		defer close(readerDone)
+		h.readLoop(ctx, conn, session, merchantID, outbound, writerDone)
+	}()
+
+	for _, push := range pending {
+		if err := writeJSON(conn, toIntakeOrderMessage(push)); err != nil {
+			return nil
+		}
+	}
+
+	ticker := time.NewTicker(h.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		select {
+		case <-readerDone:
+			return nil
+		case push, ok := <-session.Pushes():
+			if !ok {
+				// 会话被断开（终端消费过慢），终端重连后重新投递
+				return nil
+			}
+			err = writeJSON(conn, toIntakeOrderMessage(push))
+		case msg := <-outbound:
+			err = writeJSON(conn, msg)
+		case <-ticker.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+		}
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+// readLoop 读取并处理终端指令，直到连接断开
+func (h *MerchantIntakeHandler) readLoop(ctx context.Context, conn *websocket.Conn, session *application.MerchantSession, merchantID string, outbound chan<- IntakeMessage, writerDone <-chan struct{}) {
+	pongWait := 2 * h.pingInterval
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		session.Touch()
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+		session.Touch()
+
+		var cmd IntakeCommand
+		if err := json.Unmarshal(data, &cmd); err != nil {
+			if !sendMessage(outbound, writerDone, intakeError("", http.StatusBadRequest, "invalid command")) {
+				return
+			}
+			continue
+		}
+
+		reply, ok := h.handleCommand(ctx, merchantID, &cmd)
+		if ok && !sendMessage(outbound, writerDone, reply) {
+			return
+		}
+	}
+}
+
+// handleCommand 处理单条指令，返回需要回复的消息（ack 无需回复）
+func (h *MerchantIntakeHandler) handleCommand(ctx context.Context, merchantID string, cmd *IntakeCommand) (IntakeMessage, bool) {
+	var (
+		orderData *application.OrderData
+		err       error
+	)
+	switch cmd.Type {
+	case IntakeCommandAck:
+		h.hub.Ack(merchantID, cmd.DeliveryID)
+		return IntakeMessage{}, false
+	case IntakeCommandAccept:
+		orderData, err = h.orderService.AcceptOrder(ctx, merchantID, cmd.OrderNumber)
+	case IntakeCommandReject:
+		orderData, err = h.orderService.RejectOrder(ctx, merchantID, cmd.OrderNumber, &application.RejectOrderRequest{Reason: cmd.Reason})
+	default:
+		return intakeError(cmd.RequestID, http.StatusBadRequest, "unknown command type"), true
+	}
+
+	if err != nil {
+		status, response := errorResponse(err)
+		if status == http.StatusInternalServerError {
+			logging.FromContext(ctx).Error("internal error", logging.KeyError, err, "command", cmd.Type)
+		}
+		return IntakeMessage{
+			Type:        IntakeMessageResult,
+			RequestID:   cmd.RequestID,
+			OrderNumber: cmd.OrderNumber,
+			Error:       &response,
+		}, true
+	}
+
+	// 已接单或拒单的订单无需再推送
+	h.hub.AckOrder(merchantID, cmd.OrderNumber)
+	return IntakeMessage{
+		Type:        IntakeMessageResult,
+		RequestID:   cmd.RequestID,
+		OrderNumber: orderData.OrderNumber,
+		Status:      orderData.Status,
+	}, true
+}
+
+// GetPresence 查询商家在线状态
+func (h *MerchantIntakeHandler) GetPresence(c echo.Context) error {
+	presence := h.hub.Presence(c.Param("merchantId"))
+	return c.JSON(http.StatusOK, MerchantPresenceResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    toPresenceData(presence),
+	})
+}
+
+// ListOnlineMerchants 查询当前在线的商家
+func (h *MerchantIntakeHandler) ListOnlineMerchants(c echo.Context) error {
+	online := h.hub.OnlineMerchants()
+	data := make([]MerchantPresenceData, len(online))
+	for i, presence := range online {
+		data[i] = toPresenceData(presence)
+	}
+	return c.JSON(http.StatusOK, OnlineMerchantsResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    data,
+	})
+}
+
+// sendMessage 将回复交给写协程，写协程已退出时返回 false
+func sendMessage(outbound chan<- IntakeMessage, writerDone <-chan struct{}, msg IntakeMessage) bool {
+	select {
+	case outbound <- msg:
+		return true
+	case <-writerDone:
+		return false
+	}
+}
+
+// writeJSON 带超时写入 JSON 消息
+func writeJSON(conn *websocket.Conn, v interface{}) error {
+	_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return conn.WriteJSON(v)
+}
+
+// intakeError 创建指令错误消息
+func intakeError(requestID string, code int, message string) IntakeMessage {
+	return IntakeMessage{
+		Type:      IntakeMessageError,
+		RequestID: requestID,
+		Error:     &ErrorResponse{Code: code, Message: message},
+	}
+}
+
+// toIntakeOrderMessage 转换推送到 WebSocket 消息
+func toIntakeOrderMessage(push application.MerchantOrderPush) IntakeMessage {
+	items := make([]IntakeOrderItem, len(push.Items))
+	for i, item := range push.Items {
+		items[i] = IntakeOrderItem{
+			DishID:     item.DishID,
+			DishName:   item.DishName,
+			Quantity:   item.Quantity,
+			Price:      item.Price,
+			UnitPrice:  item.UnitPrice,
+			Options:    toOrderItemOptionData(item.Options),
+			Components: toOrderItemComponentData(item.Components),
+		}
+	}
+	order := &IntakeOrder{
+		OrderNumber: push.OrderNumber,
+		EventType:   push.EventType,
+		Status:      push.Status,
+		Items:       items,
+		FinalAmount: push.FinalAmount,
+		OccurredAt:  push.OccurredAt.Format(time.RFC3339),
+	}
+	if !push.ScheduledFor.IsZero() {
+		order.ScheduledFor = push.ScheduledFor.Format(time.RFC3339)
+	}
+	return IntakeMessage{
+		Type:       IntakeMessageOrder,
+		DeliveryID: push.DeliveryID,
+		Order:      order,
+	}
+}
+
+// toPresenceData 转换在线状态
+func toPresenceData(presence application.MerchantPresence) MerchantPresenceData {
+	data := MerchantPresenceData{
+		MerchantID:  presence.MerchantID,
+		Online:      presence.Online,
+		Connections: presence.Connections,
+	}
+	if !presence.ConnectedAt.IsZero() {
+		data.ConnectedAt = presence.ConnectedAt.Format(time.RFC3339)
+	}
+	if !presence.LastSeenAt.IsZero() {
+		data.LastSeenAt = presence.LastSeenAt.Format(time.RFC3339)
+	}
+	return data
+}
@@ -0,0 +1,181 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newIntakeServer 启动带认证的商家接单测试服务器
+func newIntakeServer(t *testing.T, service application.OrderService, hub *application.MerchantIntakeHub) *httptest.Server {
+	e := echo.New()
+	handler := NewMerchantIntakeHandler(service, hub)
+	e.GET("/api/v1/merchants/:merchantId/intake", handler.Connect, AuthMiddleware, RequireMerchant)
+	e.GET("/api/v1/merchants/:merchantId/presence", handler.GetPresence, AuthMiddleware)
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// dialIntake 以商家身份建立 WebSocket 连接
+func dialIntake(t *testing.T, server *httptest.Server, merchantID string) *websocket.Conn {
+	token, err := GenerateMerchantToken(2001, merchantID)
+	require.NoError(t, err)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/merchants/" + merchantID + "/intake"
+	header := http.Header{"Authorization": []string{"Bearer " + token}}
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// readIntakeMessage 读取一条服务端消息
+func readIntakeMessage(t *testing.T, conn *websocket.Conn) IntakeMessage {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	var msg IntakeMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+// publishNewPaidOrder 创建并支付订单，将事件交给接单推送中心
+func publishNewPaidOrder(t *testing.T, hub *application.MerchantIntakeHub, merchantID string) []domain.Event {
+	order := domain.NewOrder(1001, merchantID, nil, domain.DeliveryInfo{}, "")
+	require.NoError(t, order.MarkPaid("pay_001"))
+	events := order.PullEvents()
+	for _, event := range events {
+		require.NoError(t, hub.HandleEvent(context.Background(), event))
+	}
+	return events
+}
+
+func TestMerchantIntakeHandler_RedeliversAndPushes(t *testing.T) {
+	// Arrange - 连接前已有未确认的推送
+	hub := application.NewMerchantIntakeHub()
+	server := newIntakeServer(t, new(MockOrderService), hub)
+	offline := publishNewPaidOrder(t, hub, "merchant_001")
+
+	// Act
+	conn := dialIntake(t, server, "merchant_001")
+
+	// Assert - 先重新投递未确认推送
+	first := readIntakeMessage(t, conn)
+	second := readIntakeMessage(t, conn)
+	assert.Equal(t, IntakeMessageOrder, first.Type)
+	assert.Equal(t, offline[0].EventID(), first.DeliveryID)
+	assert.Equal(t, "order.created", first.Order.EventType)
+	assert.Equal(t, offline[1].EventID(), second.DeliveryID)
+	assert.Equal(t, "PAID", second.Order.Status)
+
+	// 确认后实时推送新订单
+	require.NoError(t, conn.WriteJSON(IntakeCommand{Type: IntakeCommandAck, DeliveryID: first.DeliveryID}))
+	live := publishNewPaidOrder(t, hub, "merchant_001")
+	assert.Equal(t, live[0].EventID(), readIntakeMessage(t, conn).DeliveryID)
+	assert.Eventually(t, func() bool { return len(hub.Pending("merchant_001")) == 3 }, time.Second, time.Millisecond)
+}
+
+func TestMerchantIntakeHandler_AcceptCommand(t *testing.T) {
+	// Arrange
+	hub := application.NewMerchantIntakeHub()
+	mockService := new(MockOrderService)
+	server := newIntakeServer(t, mockService, hub)
+	conn := dialIntake(t, server, "merchant_001")
+
+	events := publishNewPaidOrder(t, hub, "merchant_001")
+	orderNumber := events[0].AggregateID()
+	readIntakeMessage(t, conn)
+	readIntakeMessage(t, conn)
+
+	mockService.On("AcceptOrder", mock.Anything, "merchant_001", orderNumber).
+		Return(&application.OrderData{OrderNumber: orderNumber, Status: "ACCEPTED"}, nil)
+
+	// Act
+	require.NoError(t, conn.WriteJSON(IntakeCommand{Type: IntakeCommandAccept, RequestID: "req_1", OrderNumber: orderNumber}))
+
+	// Assert - 接单成功后该订单的推送全部确认
+	result := readIntakeMessage(t, conn)
+	assert.Equal(t, IntakeMessageResult, result.Type)
+	assert.Equal(t, "req_1", result.RequestID)
+	assert.Equal(t, "ACCEPTED", result.Status)
+	assert.Nil(t, result.Error)
+	assert.Empty(t, hub.Pending("merchant_001"))
+}
+
+func TestMerchantIntakeHandler_RejectCommandError(t *testing.T) {
+	hub := application.NewMerchantIntakeHub()
+	mockService := new(MockOrderService)
+	server := newIntakeServer(t, mockService, hub)
+	conn := dialIntake(t, server, "merchant_001")
+
+	mockService.On("RejectOrder", mock.Anything, "merchant_001", "ORDER_001", &application.RejectOrderRequest{Reason: "打烊了"}).
+		Return(nil, application.NewBusinessError("INVALID_ORDER_STATUS", "operation not allowed in current order status"))
+
+	require.NoError(t, conn.WriteJSON(IntakeCommand{Type: IntakeCommandReject, RequestID: "req_2", OrderNumber: "ORDER_001", Reason: "打烊了"}))
+
+	result := readIntakeMessage(t, conn)
+	assert.Equal(t, IntakeMessageResult, result.Type)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, http.StatusUnprocessableEntity, result.Error.Code)
+	assert.Equal(t, "INVALID_ORDER_STATUS", result.Error.ErrorCode)
+}
+
+func TestMerchantIntakeHandler_InvalidCommand(t *testing.T) {
+	hub := application.NewMerchantIntakeHub()
+	server := newIntakeServer(t, new(MockOrderService), hub)
+	conn := dialIntake(t, server, "merchant_001")
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("not json")))
+	assert.Equal(t, IntakeMessageError, readIntakeMessage(t, conn).Type)
+
+	require.NoError(t, conn.WriteJSON(IntakeCommand{Type: "ship", RequestID: "req_3"}))
+	msg := readIntakeMessage(t, conn)
+	assert.Equal(t, IntakeMessageError, msg.Type)
+	assert.Equal(t, "req_3", msg.RequestID)
+}
+
+func TestMerchantIntakeHandler_RequiresMatchingMerchant(t *testing.T) {
+	hub := application.NewMerchantIntakeHub()
+	server := newIntakeServer(t, new(MockOrderService), hub)
+	token, _ := GenerateMerchantToken(2001, "merchant_002")
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/merchants/merchant_001/intake"
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Authorization": []string{"Bearer " + token}})
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestMerchantIntakeHandler_Presence(t *testing.T) {
+	hub := application.NewMerchantIntakeHub()
+	server := newIntakeServer(t, new(MockOrderService), hub)
+	conn := dialIntake(t, server, "merchant_001")
+
+	token, _ := GenerateToken(1001)
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v1/merchants/merchant_001/presence", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var response MerchantPresenceResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.True(t, response.Data.Online)
+	assert.Equal(t, 1, response.Data.Connections)
+
+	// 断开后变为离线
+	conn.Close()
+	assert.Eventually(t, func() bool { return !hub.Presence("merchant_001").Online }, time.Second, 5*time.Millisecond)
+}
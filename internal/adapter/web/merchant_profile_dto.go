@@ -0,0 +1,155 @@
+package web
+
+// UpdateMerchantProfileRequest Web 层更新商家营业配置请求（整体替换，openingHours 为空表示全天营业）
+type UpdateMerchantProfileRequest struct {
+	Timezone        string                `json:"timezone"`
+	OpeningHours    []OpeningHoursRequest `json:"openingHours"`
+	Holidays        []string              `json:"holidays"`
+	MinOrderAmount  float64               `json:"minOrderAmount"`
+	MaxActiveOrders int                   `json:"maxActiveOrders"`
+	SlotCapacity    int                   `json:"slotCapacity"`
+}
+
+// OpeningHoursRequest Web 层营业时段（HH:MM，close 最大为 24:00）
+type OpeningHoursRequest struct {
+	Weekday string `json:"weekday"`
+	Open    string `json:"open"`
+	Close   string `json:"close"`
+}
+
+// PauseMerchantRequest Web 层忙碌暂停接单请求
+type PauseMerchantRequest struct {
+	Minutes int `json:"minutes"`
+}
+
+// MerchantProfileResponse 商家营业配置响应
+type MerchantProfileResponse struct {
+	Code    int                  `json:"code"`
+	Message string               `json:"message"`
+	Data    *MerchantProfileData `json:"data,omitempty"`
+}
+
+// MerchantProfileData 商家营业配置（status 为当前接单状态：OPEN、CLOSED、HOLIDAY、PAUSED）
+type MerchantProfileData struct {
+	MerchantID      string             `json:"merchantId"`
+	Timezone        string             `json:"timezone"`
+	OpeningHours    []OpeningHoursData `json:"openingHours"`
+	Holidays        []string           `json:"holidays"`
+	PausedUntil     string             `json:"pausedUntil,omitempty"`
+	MinOrderAmount  string             `json:"minOrderAmount"`
+	MaxActiveOrders int                `json:"maxActiveOrders"`
+	SlotCapacity    int                `json:"slotCapacity"`
+	Status          string             `json:"status"`
+	UpdatedAt       string             `json:"updatedAt"`
+}
+
+// OpeningHoursData 营业时段
+type OpeningHoursData struct {
+	Weekday string `json:"weekday"`
+	Open    string `json:"open"`
+	Close   string `json:"close"`
+}
+
+// DeliverySlotListResponse 配送时段列表响应
+type DeliverySlotListResponse struct {
+	Code    int                `json:"code"`
+	Message string             `json:"message"`
+	Data    []DeliverySlotData `json:"data"`
+}
+
+// DeliverySlotData 可预订的配送时段（capacity 为 0 表示不限，available 为是否还有名额）
+type DeliverySlotData struct {
+	Start     string `json:"start"`
+	End       string `json:"end"`
+	Capacity  int    `json:"capacity"`
+	Booked    int    `json:"booked"`
+	Available bool   `json:"available"`
+}
+
+// UpdateDeliveryAreaRequest Web 层更新配送范围请求（整体替换，zones 为空表示只按距离档位限制配送范围）
+type UpdateDeliveryAreaRequest struct {
+	Origin   GeoPointRequest          `json:"origin"`
+	Zones    []DeliveryZoneRequest    `json:"zones"`
+	FeeBands []DeliveryFeeBandRequest `json:"feeBands"`
+}
+
+// DeliveryZoneRequest Web 层配送区域（polygon 顶点按顺序排列，首尾自动闭合）
+type DeliveryZoneRequest struct {
+	Name    string            `json:"name"`
+	Polygon []GeoPointRequest `json:"polygon"`
+}
+
+// DeliveryFeeBandRequest Web 层距离档位（配送距离不超过 maxDistance 米时收取 fee）
+type DeliveryFeeBandRequest struct {
+	MaxDistance int     `json:"maxDistance"`
+	Fee         float64 `json:"fee"`
+}
+
+// DeliveryAreaResponse 配送范围响应
+type DeliveryAreaResponse struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Data    *DeliveryAreaData `json:"data,omitempty"`
+}
+
+// DeliveryAreaData 商家配送范围（feeBands 按距离升序，超出最后一档不配送）
+type DeliveryAreaData struct {
+	MerchantID string                `json:"merchantId"`
+	Origin     GeoPointData          `json:"origin"`
+	Zones      []DeliveryZoneData    `json:"zones"`
+	FeeBands   []DeliveryFeeBandData `json:"feeBands"`
+	UpdatedAt  string                `json:"updatedAt"`
+}
+
+// GeoPointData 地理坐标
+type GeoPointData struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// DeliveryZoneData 配送区域
+type DeliveryZoneData struct {
+	Name    string         `json:"name"`
+	Polygon []GeoPointData `json:"polygon"`
+}
+
+// DeliveryFeeBandData 距离档位（maxDistance 单位为米）
+type DeliveryFeeBandData struct {
+	MaxDistance int    `json:"maxDistance"`
+	Fee         string `json:"fee"`
+}
+
+// UpdateInventoryRequest Web 层更新餐品库存请求（整体替换，不在列表中的餐品不限量）
+type UpdateInventoryRequest struct {
+	Dishes []DishStockRequest `json:"dishes"`
+}
+
+// DishStockRequest Web 层餐品库存（stock 为空表示不限库存，dailyLimit 为 0 表示不限每日份数）
+type DishStockRequest struct {
+	DishID     string `json:"dishId"`
+	Stock      *int   `json:"stock,omitempty"`
+	DailyLimit int    `json:"dailyLimit"`
+}
+
+// InventoryResponse 餐品库存响应
+type InventoryResponse struct {
+	Code    int            `json:"code"`
+	Message string         `json:"message"`
+	Data    *InventoryData `json:"data,omitempty"`
+}
+
+// InventoryData 商家餐品库存（dishes 按 dishId 升序，updatedAt 未配置时为空）
+type InventoryData struct {
+	MerchantID string          `json:"merchantId"`
+	Dishes     []DishStockData `json:"dishes"`
+	UpdatedAt  string          `json:"updatedAt,omitempty"`
+}
+
+// DishStockData 餐品库存（soldToday 为商家时区今天的已售份数，含未支付订单的占用；available 为今天还可下单的份数）
+type DishStockData struct {
+	DishID     string `json:"dishId"`
+	Stock      *int   `json:"stock,omitempty"`
+	DailyLimit int    `json:"dailyLimit"`
+	SoldToday  int    `json:"soldToday"`
+	Available  int    `json:"available"`
+}
@@ -0,0 +1,118 @@
+package web
+
+import (
+	"net/http"
+
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MerchantProfileHandler 商家营业配置 HTTP 处理器
+type MerchantProfileHandler struct {
+	profileService application.MerchantProfileService
+}
+
+// NewMerchantProfileHandler 创建商家营业配置处理器
+func NewMerchantProfileHandler(profileService application.MerchantProfileService) *MerchantProfileHandler {
+	return &MerchantProfileHandler{
+		profileService: profileService,
+	}
+}
+
+// GetProfile 查询商家营业配置和当前接单状态（用户和商家均可查询）
+func (h *MerchantProfileHandler) GetProfile(c echo.Context) error {
+	data, err := h.profileService.GetProfile(c.Request().Context(), c.Param("merchantId"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return profileResponse(c, "success", data)
+}
+
+// UpdateProfile 更新商家营业配置
+func (h *MerchantProfileHandler) UpdateProfile(c echo.Context) error {
+	var webReq UpdateMerchantProfileRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	appReq := &application.UpdateMerchantProfileRequest{
+		Timezone:        webReq.Timezone,
+		OpeningHours:    make([]application.OpeningHoursRequest, len(webReq.OpeningHours)),
+		Holidays:        webReq.Holidays,
+		MinOrderAmount:  webReq.MinOrderAmount,
+		MaxActiveOrders: webReq.MaxActiveOrders,
+		SlotCapacity:    webReq.SlotCapacity,
+	}
+	for i, hours := range webReq.OpeningHours {
+		appReq.OpeningHours[i] = application.OpeningHoursRequest{
+			Weekday: hours.Weekday,
+			Open:    hours.Open,
+			Close:   hours.Close,
+		}
+	}
+	data, err := h.profileService.UpdateProfile(c.Request().Context(), c.Param("merchantId"), appReq)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return profileResponse(c, "merchant profile updated", data)
+}
+
+// Pause 忙碌暂停接单
+func (h *MerchantProfileHandler) Pause(c echo.Context) error {
+	var webReq PauseMerchantRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	appReq := &application.PauseMerchantRequest{Minutes: webReq.Minutes}
+	data, err := h.profileService.Pause(c.Request().Context(), c.Param("merchantId"), appReq)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return profileResponse(c, "merchant paused", data)
+}
+
+// Resume 结束忙碌暂停
+func (h *MerchantProfileHandler) Resume(c echo.Context) error {
+	data, err := h.profileService.Resume(c.Request().Context(), c.Param("merchantId"))
+	if err != nil {
+		return handleError(c, err)
+	}
+	return profileResponse(c, "merchant resumed", data)
+}
+
+// profileResponse 返回商家营业配置响应
+func profileResponse(c echo.Context, message string, data *application.MerchantProfileData) error {
+	hours := make([]OpeningHoursData, len(data.OpeningHours))
+	for i, h := range data.OpeningHours {
+		hours[i] = OpeningHoursData{Weekday: h.Weekday, Open: h.Open, Close: h.Close}
+	}
+	holidays := data.Holidays
+	if holidays == nil {
+		holidays = []string{}
+	}
+
+	return c.JSON(http.StatusOK, MerchantProfileResponse{
+		Code:    http.StatusOK,
+		Message: message,
+		Data: &MerchantProfileData{
+			MerchantID:      data.MerchantID,
+			Timezone:        data.Timezone,
+			OpeningHours:    hours,
+			Holidays:        holidays,
+			PausedUntil:     data.PausedUntil,
+			MinOrderAmount:  data.MinOrderAmount,
+			MaxActiveOrders: data.MaxActiveOrders,
+			SlotCapacity:    data.SlotCapacity,
+			Status:          data.Status,
+			UpdatedAt:       data.UpdatedAt,
+		},
+	})
+}
@@ -10,6 +10,8 @@ import (
 const (
 	// UserIDKey Context 中存储用户ID的键
 	UserIDKey = "userID"
+	// MerchantIDKey Context 中存储商家ID的键（仅商家账号）
+	MerchantIDKey = "merchantID"
 )
 
 // AuthMiddleware JWT 认证中间件
@@ -46,6 +48,25 @@ func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 
 		// 将用户ID存入 Context
 		c.Set(UserIDKey, claims.UserID)
+		if claims.MerchantID != "" {
+			c.Set(MerchantIDKey, claims.MerchantID)
+		}
+
+		return next(c)
+	}
+}
+
+// RequireMerchant 商家权限中间件（需在 AuthMiddleware 之后使用）
+// 要求 token 中的商家ID与路径参数 merchantId 一致
+func RequireMerchant(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		merchantID, ok := c.Get(MerchantIDKey).(string)
+		if !ok || merchantID == "" || merchantID != c.Param("merchantId") {
+			return c.JSON(http.StatusForbidden, ErrorResponse{
+				Code:    http.StatusForbidden,
+				Message: "merchant access denied",
+			})
+		}
 
 		return next(c)
 	}
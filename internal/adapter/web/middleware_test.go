@@ -95,3 +95,57 @@ func TestAuthMiddleware_InvalidToken(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 	assert.Contains(t, rec.Body.String(), "invalid or expired token")
 }
+
+func TestAuthMiddleware_SetsMerchantID(t *testing.T) {
+	e := echo.New()
+	token, err := GenerateMerchantToken(2001, "merchant_001")
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	h := AuthMiddleware(func(c echo.Context) error {
+		assert.Equal(t, uint64(2001), c.Get(UserIDKey))
+		assert.Equal(t, "merchant_001", c.Get(MerchantIDKey))
+		return c.String(http.StatusOK, "success")
+	})
+
+	assert.NoError(t, h(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRequireMerchant(t *testing.T) {
+	tests := []struct {
+		name       string
+		merchantID interface{}
+		param      string
+		wantStatus int
+	}{
+		{"matching merchant", "merchant_001", "merchant_001", http.StatusOK},
+		{"other merchant", "merchant_002", "merchant_001", http.StatusForbidden},
+		{"not a merchant account", nil, "merchant_001", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("merchantId")
+			c.SetParamValues(tt.param)
+			if tt.merchantID != nil {
+				c.Set(MerchantIDKey, tt.merchantID)
+			}
+
+			h := RequireMerchant(func(c echo.Context) error {
+				return c.String(http.StatusOK, "success")
+			})
+
+			assert.NoError(t, h(c))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,785 @@
+package web
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// OpenAPIVersion 生成文档使用的 OpenAPI 版本
+const OpenAPIVersion = "3.1.0"
+
+// apiVersion 接口版本（与路由前缀一致）
+const apiVersion = "v1"
+
+//go:embed openapi_docs.html
+var apiDocsPage []byte
+
+// authRequirement 接口认证要求
+type authRequirement int
+
+const (
+	authNone             authRequirement = iota
+	authUser                             // 需要 Bearer Token
+	authMerchant                         // 需要商家 Token，且 merchantId 与路径一致
+	authPaymentSignature                 // 需要支付回调签名（X-Payment-Signature）
+)
+
+// apiParameter 查询参数
+type apiParameter struct {
+	Name        string
+	Description string
+	Schema      *Schema
+}
+
+// apiResponse 接口响应（Body 为 nil 表示无响应体）
+type apiResponse struct {
+	Status      int
+	Description string
+	Body        interface{}
+	ContentType string // 为空表示 application/json
+}
+
+// apiOperation 接口描述
+// Request 为 Web 层请求 DTO，Validation 为对应的应用层 DTO（提供 validate 标签约束）
+type apiOperation struct {
+	Method      string
+	Path        string // Echo 路由格式，路径参数为 :name
+	OperationID string
+	Summary     string
+	Tag         string
+	Auth        authRequirement
+	Query       []apiParameter
+	Request     interface{}
+	Validation  interface{}
+	Responses   []apiResponse
+}
+
+// apiOperations RegisterRoutes 中全部路由的接口描述（路径相对 /api/v1）
+var apiOperations = []apiOperation{
+	{
+		Method: http.MethodPost, Path: "/orders", OperationID: "createOrder", Summary: "创建订单", Tag: "orders", Auth: authUser,
+		Request: CreateOrderRequest{}, Validation: application.CreateOrderRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusCreated, Description: "订单创建成功", Body: CreateOrderResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+			{Status: http.StatusUnprocessableEntity, Description: "业务规则不满足、餐品已售罄、引用的地址不存在或报价无效、过期、不匹配、价格已变化", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/orders/quote", OperationID: "quoteOrder", Summary: "下单报价（试算价格并签发报价 token，不创建订单）", Tag: "orders", Auth: authUser,
+		Request: CreateOrderRequest{}, Validation: application.CreateOrderRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "报价成功", Body: QuoteResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+			{Status: http.StatusUnprocessableEntity, Description: "业务规则不满足、商家不在接单状态或餐品已售罄", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/orders/:orderNumber/cancel", OperationID: "cancelOrder", Summary: "取消待支付订单", Tag: "orders", Auth: authUser,
+		Request: CancelOrderRequest{}, Validation: application.CancelOrderRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "取消成功", Body: OrderResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+			{Status: http.StatusNotFound, Description: "订单不存在", Body: ErrorResponse{}},
+			{Status: http.StatusUnprocessableEntity, Description: "订单状态不允许取消", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/orders/:orderNumber/refunds", OperationID: "refundOrder", Summary: "申请退款（items 为空表示全额退款）", Tag: "orders", Auth: authUser,
+		Request: RefundOrderRequest{}, Validation: application.RefundOrderRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusCreated, Description: "退款已提交", Body: RefundOrderResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+			{Status: http.StatusNotFound, Description: "订单不存在", Body: ErrorResponse{}},
+			{Status: http.StatusUnprocessableEntity, Description: "退款规则不满足", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/orders/:orderNumber/events", OperationID: "streamOrderEvents", Summary: "订阅订单状态变更（Server-Sent Events）", Tag: "orders", Auth: authUser,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "SSE 事件流，snapshot/status 事件的 data 为 OrderStatusEvent", Body: OrderStatusEvent{}, ContentType: "text/event-stream"},
+			{Status: http.StatusNotFound, Description: "订单不存在", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/carts/:merchantId", OperationID: "getCart", Summary: "查询购物车及价格预览", Tag: "carts", Auth: authUser,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "购物车（不存在或已过期时为空购物车）", Body: CartResponse{}},
+		},
+	},
+	{
+		Method: http.MethodDelete, Path: "/carts/:merchantId", OperationID: "clearCart", Summary: "清空购物车", Tag: "carts", Auth: authUser,
+		Responses: []apiResponse{
+			{Status: http.StatusNoContent, Description: "清空成功"},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/carts/:merchantId/items", OperationID: "addCartItem", Summary: "加入购物车（与已有行选择相同时合并数量）", Tag: "carts", Auth: authUser,
+		Request: OrderItemRequest{}, Validation: application.OrderItemRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "加入成功，返回购物车", Body: CartResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+			{Status: http.StatusUnprocessableEntity, Description: "规格或套餐组件不合法、购物车已满", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPatch, Path: "/carts/:merchantId/items/:lineId", OperationID: "updateCartItem", Summary: "修改购物车行数量", Tag: "carts", Auth: authUser,
+		Request: UpdateCartItemRequest{}, Validation: application.UpdateCartItemRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "修改成功，返回购物车", Body: CartResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+			{Status: http.StatusUnprocessableEntity, Description: "购物车行不存在", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodDelete, Path: "/carts/:merchantId/items/:lineId", OperationID: "removeCartItem", Summary: "移除购物车行", Tag: "carts", Auth: authUser,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "移除成功，返回购物车", Body: CartResponse{}},
+			{Status: http.StatusUnprocessableEntity, Description: "购物车行不存在", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/carts/:merchantId/checkout", OperationID: "checkoutCart", Summary: "购物车结算下单（成功后清空购物车）", Tag: "carts", Auth: authUser,
+		Request: CheckoutRequest{}, Validation: application.CheckoutRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusCreated, Description: "订单创建成功", Body: CreateOrderResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+			{Status: http.StatusUnprocessableEntity, Description: "购物车为空、业务规则不满足或餐品已售罄", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/addresses", OperationID: "listAddresses", Summary: "查询地址簿（默认地址在前）", Tag: "addresses", Auth: authUser,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "地址列表", Body: AddressListResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/addresses", OperationID: "createAddress", Summary: "新增地址（第一个地址自动成为默认地址）", Tag: "addresses", Auth: authUser,
+		Request: SaveAddressRequest{}, Validation: application.SaveAddressRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusCreated, Description: "新增成功", Body: AddressResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+			{Status: http.StatusUnprocessableEntity, Description: "地址簿已满", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/addresses/:addressId", OperationID: "getAddress", Summary: "查询地址", Tag: "addresses", Auth: authUser,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "地址", Body: AddressResponse{}},
+			{Status: http.StatusNotFound, Description: "地址不存在", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPut, Path: "/addresses/:addressId", OperationID: "updateAddress", Summary: "修改地址（整体替换，不影响已下单的订单）", Tag: "addresses", Auth: authUser,
+		Request: SaveAddressRequest{}, Validation: application.SaveAddressRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "修改成功", Body: AddressResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+			{Status: http.StatusNotFound, Description: "地址不存在", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodDelete, Path: "/addresses/:addressId", OperationID: "deleteAddress", Summary: "删除地址（删除默认地址时最早添加的地址成为默认地址）", Tag: "addresses", Auth: authUser,
+		Responses: []apiResponse{
+			{Status: http.StatusNoContent, Description: "删除成功"},
+			{Status: http.StatusNotFound, Description: "地址不存在", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/addresses/:addressId/default", OperationID: "setDefaultAddress", Summary: "设为默认地址", Tag: "addresses", Auth: authUser,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "设置成功", Body: AddressResponse{}},
+			{Status: http.StatusNotFound, Description: "地址不存在", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/merchants/online", OperationID: "listOnlineMerchants", Summary: "查询在线商家", Tag: "merchants", Auth: authUser,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "在线商家列表", Body: OnlineMerchantsResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/merchants/:merchantId/presence", OperationID: "getMerchantPresence", Summary: "查询商家在线状态", Tag: "merchants", Auth: authUser,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "商家在线状态", Body: MerchantPresenceResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/merchants/:merchantId/intake", OperationID: "connectMerchantIntake", Summary: "商家接单 WebSocket（消息格式见 README）", Tag: "merchants", Auth: authMerchant,
+		Responses: []apiResponse{
+			{Status: http.StatusSwitchingProtocols, Description: "升级为 WebSocket 连接"},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/merchants/:merchantId/profile", OperationID: "getMerchantProfile", Summary: "查询商家营业配置和接单状态", Tag: "merchants", Auth: authUser,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "商家营业配置（未配置时为全天营业）", Body: MerchantProfileResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/merchants/:merchantId/slots", OperationID: "listDeliverySlots", Summary: "查询可预订的配送时段", Tag: "merchants", Auth: authUser,
+		Query: []apiParameter{
+			{Name: "date", Description: "商家时区的日期（YYYY-MM-DD），默认今天", Schema: &Schema{Type: "string", Format: "date"}},
+		},
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "在可预订范围内且商家营业的时段（含已约满的时段）", Body: DeliverySlotListResponse{}},
+			{Status: http.StatusBadRequest, Description: "日期格式错误", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/merchants/:merchantId/delivery-area", OperationID: "getDeliveryArea", Summary: "查询商家配送范围和距离配送费", Tag: "merchants", Auth: authUser,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "商家配送范围", Body: DeliveryAreaResponse{}},
+			{Status: http.StatusNotFound, Description: "商家未配置配送范围（不限收货地址，按固定配送费计价）", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/merchants/:merchantId/inventory", OperationID: "getInventory", Summary: "查询餐品库存和今天的已售份数", Tag: "merchants", Auth: authUser,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "商家餐品库存（未配置时为空列表，所有餐品不限量）", Body: InventoryResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/payments/callback", OperationID: "paymentCallback", Summary: "支付结果回调（由支付渠道调用，请求体须带签名）", Tag: "payments", Auth: authPaymentSignature,
+		Request: PaymentCallbackRequest{}, Validation: application.ConfirmPaymentRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "支付确认成功", Body: OrderResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+			{Status: http.StatusNotFound, Description: "订单不存在", Body: ErrorResponse{}},
+			{Status: http.StatusUnprocessableEntity, Description: "订单状态不允许支付或实付金额与订单金额不一致", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPut, Path: "/merchants/:merchantId/profile", OperationID: "updateMerchantProfile", Summary: "更新商家营业配置（整体替换）", Tag: "merchants", Auth: authMerchant,
+		Request: UpdateMerchantProfileRequest{}, Validation: application.UpdateMerchantProfileRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "更新成功", Body: MerchantProfileResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/merchants/:merchantId/pause", OperationID: "pauseMerchant", Summary: "忙碌暂停接单", Tag: "merchants", Auth: authMerchant,
+		Request: PauseMerchantRequest{}, Validation: application.PauseMerchantRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "暂停成功", Body: MerchantProfileResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/merchants/:merchantId/resume", OperationID: "resumeMerchant", Summary: "结束忙碌暂停", Tag: "merchants", Auth: authMerchant,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "已恢复接单", Body: MerchantProfileResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPut, Path: "/merchants/:merchantId/delivery-area", OperationID: "updateDeliveryArea", Summary: "更新商家配送范围（整体替换）", Tag: "merchants", Auth: authMerchant,
+		Request: UpdateDeliveryAreaRequest{}, Validation: application.UpdateDeliveryAreaRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "更新成功", Body: DeliveryAreaResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodDelete, Path: "/merchants/:merchantId/delivery-area", OperationID: "deleteDeliveryArea", Summary: "删除商家配送范围（恢复固定配送费）", Tag: "merchants", Auth: authMerchant,
+		Responses: []apiResponse{
+			{Status: http.StatusNoContent, Description: "删除成功"},
+			{Status: http.StatusNotFound, Description: "商家未配置配送范围", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPut, Path: "/merchants/:merchantId/inventory", OperationID: "updateInventory", Summary: "更新餐品库存和每日限售份数（整体替换）", Tag: "merchants", Auth: authMerchant,
+		Request: UpdateInventoryRequest{}, Validation: application.UpdateInventoryRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "更新成功", Body: InventoryResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误、验证失败或餐品重复", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/merchants/:merchantId/webhooks", OperationID: "createWebhook", Summary: "创建 Webhook 订阅", Tag: "webhooks", Auth: authMerchant,
+		Request: CreateWebhookRequest{}, Validation: application.CreateWebhookRequest{},
+		Responses: []apiResponse{
+			{Status: http.StatusCreated, Description: "订阅创建成功（secret 仅在此返回）", Body: WebhookResponse{}},
+			{Status: http.StatusBadRequest, Description: "请求体格式错误或验证失败", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/merchants/:merchantId/webhooks", OperationID: "listWebhooks", Summary: "查询 Webhook 订阅", Tag: "webhooks", Auth: authMerchant,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "订阅列表", Body: WebhookListResponse{}},
+		},
+	},
+	{
+		Method: http.MethodDelete, Path: "/merchants/:merchantId/webhooks/:webhookId", OperationID: "deleteWebhook", Summary: "删除 Webhook 订阅", Tag: "webhooks", Auth: authMerchant,
+		Responses: []apiResponse{
+			{Status: http.StatusNoContent, Description: "删除成功"},
+			{Status: http.StatusNotFound, Description: "订阅不存在", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodPost, Path: "/merchants/:merchantId/webhooks/:webhookId/enable", OperationID: "enableWebhook", Summary: "重新启用 Webhook 订阅", Tag: "webhooks", Auth: authMerchant,
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "启用成功", Body: WebhookResponse{}},
+			{Status: http.StatusNotFound, Description: "订阅不存在", Body: ErrorResponse{}},
+		},
+	},
+	{
+		Method: http.MethodGet, Path: "/merchants/:merchantId/webhooks/:webhookId/deliveries", OperationID: "listWebhookDeliveries", Summary: "查询 Webhook 投递记录", Tag: "webhooks", Auth: authMerchant,
+		Query: []apiParameter{
+			{Name: "limit", Description: "返回条数", Schema: &Schema{Type: "integer", Minimum: floatPtr(1), Maximum: floatPtr(500), Default: defaultDeliveryLimit}},
+		},
+		Responses: []apiResponse{
+			{Status: http.StatusOK, Description: "投递记录（最新在前）", Body: WebhookDeliveryListResponse{}},
+			{Status: http.StatusBadRequest, Description: "limit 不合法", Body: ErrorResponse{}},
+			{Status: http.StatusNotFound, Description: "订阅不存在", Body: ErrorResponse{}},
+		},
+	},
+}
+
+// OpenAPIDocument OpenAPI 文档
+type OpenAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       OpenAPIInfo                             `json:"info"`
+	Servers    []OpenAPIServer                         `json:"servers"`
+	Paths      map[string]map[string]*OpenAPIOperation `json:"paths"`
+	Components OpenAPIComponents                       `json:"components"`
+}
+
+// OpenAPIInfo 文档信息
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPIServer 服务地址
+type OpenAPIServer struct {
+	URL string `json:"url"`
+}
+
+// OpenAPIComponents 可复用组件
+type OpenAPIComponents struct {
+	Schemas         map[string]*Schema           `json:"schemas"`
+	SecuritySchemes map[string]map[string]string `json:"securitySchemes"`
+}
+
+// OpenAPIOperation 接口操作
+type OpenAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary"`
+	Tags        []string                    `json:"tags"`
+	Parameters  []OpenAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+	Security    []map[string][]string       `json:"security,omitempty"`
+}
+
+// OpenAPIParameter 路径或查询参数
+type OpenAPIParameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Required    bool    `json:"required"`
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// OpenAPIRequestBody 请求体
+type OpenAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]OpenAPIMediaType `json:"content"`
+}
+
+// OpenAPIResponse 响应
+type OpenAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+// OpenAPIMediaType 媒体类型
+type OpenAPIMediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema JSON Schema（OpenAPI 3.1 子集）
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+}
+
+// schemaRefPrefix 组件引用前缀
+const schemaRefPrefix = "#/components/schemas/"
+
+// pathParamPattern Echo 路径参数
+var pathParamPattern = regexp.MustCompile(`:([A-Za-z]+)`)
+
+// validationFormats 自定义 validate 标签对应的 Schema 约束
+var validationFormats = map[string]func(*Schema){
+	"phone": func(s *Schema) {
+		s.Pattern = application.PhonePattern
+	},
+	"http_url": func(s *Schema) {
+		s.Format = "uri"
+	},
+	"webhook_url": func(s *Schema) {
+		s.Pattern = application.WebhookURLPattern
+	},
+	"event_type": func(s *Schema) {
+		s.Enum = append([]string{domain.WebhookEventAll}, domain.EventTypes...)
+	},
+	"clock": func(s *Schema) {
+		s.Pattern = application.ClockPattern
+	},
+	"date": func(s *Schema) {
+		s.Format = "date"
+	},
+	"rfc3339": func(s *Schema) {
+		s.Format = "date-time"
+	},
+}
+
+// MaxRequestItems 请求体中数组元素个数上限（validate 标签未指定 max 时）
+const MaxRequestItems = 100
+
+// openAPIDocument 文档在首次使用时生成并缓存（请求体校验中间件与文档共用）
+var openAPIDocument = sync.OnceValue(buildOpenAPIDocument)
+
+// openAPISpec 序列化后的文档
+var openAPISpec = sync.OnceValues(func() ([]byte, error) {
+	return json.MarshalIndent(openAPIDocument(), "", "  ")
+})
+
+// ServeOpenAPISpec 返回 OpenAPI 文档
+func ServeOpenAPISpec(c echo.Context) error {
+	spec, err := openAPISpec()
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSONBlob(http.StatusOK, spec)
+}
+
+// ServeAPIDocs 返回接口文档页面（Swagger UI）
+func ServeAPIDocs(c echo.Context) error {
+	return c.HTMLBlob(http.StatusOK, apiDocsPage)
+}
+
+// buildOpenAPIDocument 根据 apiOperations 和 Web 层 DTO 生成 OpenAPI 文档
+func buildOpenAPIDocument() *OpenAPIDocument {
+	g := &schemaGenerator{components: make(map[string]*Schema)}
+	doc := &OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info: OpenAPIInfo{
+			Title:       "Order Service API",
+			Version:     apiVersion,
+			Description: "订单服务 REST 接口。金额字段为两位小数的字符串，时间为 RFC 3339 格式。",
+		},
+		Servers: []OpenAPIServer{{URL: "/api/" + apiVersion}},
+		Paths:   make(map[string]map[string]*OpenAPIOperation),
+		Components: OpenAPIComponents{
+			Schemas: g.components,
+			SecuritySchemes: map[string]map[string]string{
+				"bearerAuth": {"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+				"paymentSignature": {"type": "apiKey", "in": "header", "name": HeaderPaymentSignature,
+					"description": "请求体的 HMAC-SHA256 签名（十六进制），密钥为 payment.callbackSecret"},
+			},
+		},
+	}
+
+	for _, op := range apiOperations {
+		path := openAPIPath(op.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*OpenAPIOperation)
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = g.operation(op)
+	}
+	return doc
+}
+
+// openAPIPath 将 Echo 路径参数（:name）转换为 OpenAPI 格式（{name}）
+func openAPIPath(path string) string {
+	return pathParamPattern.ReplaceAllString(path, "{$1}")
+}
+
+// schemaGenerator 通过反射生成 Schema，结构体注册为可复用组件
+type schemaGenerator struct {
+	components map[string]*Schema
+}
+
+// operation 生成接口操作
+func (g *schemaGenerator) operation(op apiOperation) *OpenAPIOperation {
+	operation := &OpenAPIOperation{
+		OperationID: op.OperationID,
+		Summary:     op.Summary,
+		Tags:        []string{op.Tag},
+		Responses:   make(map[string]*OpenAPIResponse),
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(op.Path, -1) {
+		operation.Parameters = append(operation.Parameters, OpenAPIParameter{
+			Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"},
+		})
+	}
+	for _, param := range op.Query {
+		operation.Parameters = append(operation.Parameters, OpenAPIParameter{
+			Name: param.Name, In: "query", Description: param.Description, Schema: param.Schema,
+		})
+	}
+
+	if op.Request != nil {
+		var validation reflect.Type
+		if op.Validation != nil {
+			validation = reflect.TypeOf(op.Validation)
+		}
+		operation.RequestBody = &OpenAPIRequestBody{
+			Required: true,
+			Content: map[string]OpenAPIMediaType{
+				echo.MIMEApplicationJSON: {Schema: g.schema(reflect.TypeOf(op.Request), validation)},
+			},
+		}
+	}
+
+	responses := slices.Clone(op.Responses)
+	switch op.Auth {
+	case authNone:
+	case authPaymentSignature:
+		operation.Security = []map[string][]string{{"paymentSignature": {}}}
+		responses = append(responses, apiResponse{Status: http.StatusUnauthorized, Description: "签名缺失或无效", Body: ErrorResponse{}})
+	default:
+		operation.Security = []map[string][]string{{"bearerAuth": {}}}
+		responses = append(responses, apiResponse{Status: http.StatusUnauthorized, Description: "未认证或 Token 无效", Body: ErrorResponse{}})
+	}
+	if op.Auth != authNone {
+		responses = append(responses, apiResponse{Status: http.StatusTooManyRequests, Description: "请求过于频繁（Retry-After 响应头为需等待的秒数）", Body: ErrorResponse{}})
+	}
+	if op.Request != nil {
+		responses = append(responses, apiResponse{Status: http.StatusRequestEntityTooLarge, Description: "请求体超过大小上限", Body: ErrorResponse{}})
+	}
+	if op.Auth == authMerchant {
+		responses = append(responses, apiResponse{Status: http.StatusForbidden, Description: "非该商家账号", Body: ErrorResponse{}})
+	}
+	for _, resp := range responses {
+		response := &OpenAPIResponse{Description: resp.Description}
+		if resp.Body != nil {
+			contentType := resp.ContentType
+			if contentType == "" {
+				contentType = echo.MIMEApplicationJSON
+			}
+			response.Content = map[string]OpenAPIMediaType{
+				contentType: {Schema: g.schema(reflect.TypeOf(resp.Body), nil)},
+			}
+		}
+		operation.Responses[strconv.Itoa(resp.Status)] = response
+	}
+	return operation
+}
+
+// schema 生成类型的 Schema；validation 为提供 validate 标签的对应类型（可为 nil）
+func (g *schemaGenerator) schema(t reflect.Type, validation reflect.Type) *Schema {
+	t = derefType(t)
+	if validation != nil {
+		validation = derefType(validation)
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return g.component(t, validation)
+	case reflect.Slice, reflect.Array:
+		var elem reflect.Type
+		if validation != nil && (validation.Kind() == reflect.Slice || validation.Kind() == reflect.Array) {
+			elem = validation.Elem()
+		}
+		schema := &Schema{Type: "array", Items: g.schema(t.Elem(), elem)}
+		if validation != nil {
+			maxItems := MaxRequestItems
+			schema.MaxItems = &maxItems
+		}
+		return schema
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Map:
+		return &Schema{Type: "object"}
+	default:
+		return &Schema{}
+	}
+}
+
+// component 将结构体注册为组件并返回引用
+// 有 validate 来源时（请求 DTO）按 required 标签确定必填字段并禁止未声明的字段，
+// 否则未标记 omitempty 的字段为必填（响应 DTO）
+func (g *schemaGenerator) component(t reflect.Type, validation reflect.Type) *Schema {
+	ref := &Schema{Ref: schemaRefPrefix + t.Name()}
+	if _, ok := g.components[t.Name()]; ok {
+		return ref
+	}
+
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	if validation != nil {
+		closed := false
+		schema.AdditionalProperties = &closed
+	}
+	// 先占位，防止递归类型无限展开
+	g.components[t.Name()] = schema
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitEmpty := jsonFieldName(field)
+		if name == "" {
+			continue
+		}
+
+		var (
+			validationType reflect.Type
+			rules          string
+		)
+		if validation != nil {
+			if vf, ok := validation.FieldByName(field.Name); ok {
+				validationType = vf.Type
+				rules = vf.Tag.Get("validate")
+			}
+		}
+
+		property := g.schema(field.Type, validationType)
+		required := applyValidationRules(property, derefType(field.Type), rules)
+		if validation == nil {
+			required = !omitEmpty
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+	return ref
+}
+
+// applyValidationRules 将 validate 标签转换为 Schema 约束，返回字段是否必填
+// dive 之后的规则作用于数组元素；omitempty 字段允许空字符串，不输出最小长度
+func applyValidationRules(s *Schema, t reflect.Type, rules string) bool {
+	if rules == "" {
+		return false
+	}
+
+	required, omitEmpty := false, false
+	target, kind := s, t.Kind()
+	for _, rule := range strings.Split(rules, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			if target == s {
+				required = true
+			}
+		case "omitempty":
+			omitEmpty = target == s
+		case "min":
+			if !(omitEmpty && target == s && kind == reflect.String) {
+				applyBound(target, kind, name, param)
+			}
+		case "dive":
+			if target.Items != nil {
+				target, kind = target.Items, derefType(t.Elem()).Kind()
+			}
+		case "max":
+			applyBound(target, kind, name, param)
+		case "gt":
+			if n, err := strconv.ParseFloat(param, 64); err == nil {
+				target.ExclusiveMinimum = &n
+			}
+		case "oneof":
+			target.Enum = strings.Fields(param)
+		default:
+			if apply, ok := validationFormats[name]; ok {
+				apply(target)
+			}
+		}
+	}
+	return required
+}
+
+// applyBound 按字段类型将 min/max 转换为长度、元素个数或数值范围
+func applyBound(s *Schema, kind reflect.Kind, name, param string) {
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return
+	}
+	isMin := name == "min"
+	switch kind {
+	case reflect.String:
+		if isMin {
+			s.MinLength = &n
+		} else {
+			s.MaxLength = &n
+		}
+	case reflect.Slice, reflect.Array:
+		if isMin {
+			s.MinItems = &n
+		} else {
+			s.MaxItems = &n
+		}
+	default:
+		if isMin {
+			s.Minimum = floatPtr(float64(n))
+		} else {
+			s.Maximum = floatPtr(float64(n))
+		}
+	}
+}
+
+// jsonFieldName 读取 json 标签中的字段名（不导出或忽略的字段返回空）
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(options, "omitempty")
+}
+
+// derefType 去掉指针
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
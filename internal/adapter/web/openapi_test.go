@@ -0,0 +1,393 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"order-service/internal/adapter/payment"
+	"order-service/internal/adapter/persistence"
+	"order-service/internal/application"
+	"order-service/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// specServer 挂载全部路由的测试服务（使用内存实现的应用服务）
+type specServer struct {
+	e              *echo.Echo
+	doc            *OpenAPIDocument
+	webhookService application.WebhookService
+}
+
+// specCallbackSecret 测试服务的支付回调签名密钥
+const specCallbackSecret = "spec-callback-secret"
+
+// newSpecServer 创建与 main 相同路由的测试服务
+func newSpecServer() *specServer {
+	areaRepo := persistence.NewInMemoryDeliveryAreaRepository()
+	addressRepo := persistence.NewInMemoryAddressBookRepository()
+	inventoryRepo := persistence.NewInMemoryInventoryRepository()
+	orderService := application.NewOrderService(persistence.NewInMemoryOrderRepository(),
+		application.WithPaymentGateway(payment.NewInMemoryPaymentGateway()),
+		application.WithQuoteSigner(application.NewQuoteSigner([]byte("spec-secret"))),
+		application.WithDeliveryAreas(areaRepo),
+		application.WithAddressBook(addressRepo),
+		application.WithInventory(inventoryRepo))
+	webhookService := application.NewWebhookService(persistence.NewInMemoryWebhookRepository())
+	cartService := application.NewCartService(persistence.NewInMemoryCartRepository(), orderService)
+	profileRepo := persistence.NewInMemoryMerchantProfileRepository()
+	profileService := application.NewMerchantProfileService(profileRepo)
+	slotService := application.NewDeliverySlotService(profileRepo, persistence.NewInMemoryDeliverySlotRepository(), application.DefaultSlotPolicy())
+
+	e := echo.New()
+	RegisterRoutes(e.Group("/api/v1"), Handlers{
+		Order:     NewOrderHandler(orderService),
+		Cart:      NewCartHandler(cartService),
+		Webhook:   NewWebhookHandler(webhookService),
+		Stream:    NewOrderStreamHandler(orderService, application.NewOrderStatusBroker()),
+		Intake:    NewMerchantIntakeHandler(orderService, application.NewMerchantIntakeHub()),
+		Profile:   NewMerchantProfileHandler(profileService),
+		Slots:     NewDeliverySlotHandler(slotService),
+		Areas:     NewDeliveryAreaHandler(application.NewDeliveryAreaService(areaRepo)),
+		Addresses: NewAddressBookHandler(application.NewAddressBookService(addressRepo)),
+		Inventory: NewInventoryHandler(application.NewInventoryService(profileRepo, inventoryRepo)),
+		Payment:   NewPaymentHandler(orderService, specCallbackSecret),
+	})
+	return &specServer{e: e, doc: buildOpenAPIDocument(), webhookService: webhookService}
+}
+
+// call 以 Bearer Token 发送请求并校验响应符合文档
+func (s *specServer) call(t *testing.T, method, route, path, token string, body interface{}) map[string]interface{} {
+	t.Helper()
+
+	var raw []byte
+	if body != nil {
+		raw, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, "/api/v1"+path, bytes.NewReader(raw))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return s.serve(t, method, route, req)
+}
+
+// paymentCallback 发送以 secret 签名的支付回调请求并校验响应符合文档
+func (s *specServer) paymentCallback(t *testing.T, secret string, body PaymentCallbackRequest) map[string]interface{} {
+	t.Helper()
+
+	raw, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/payments/callback", bytes.NewReader(raw))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(HeaderPaymentSignature, PaymentSignature(secret, raw))
+	return s.serve(t, http.MethodPost, "/payments/callback", req)
+}
+
+// serve 处理请求并校验响应状态码和响应体符合文档中对应接口的描述
+func (s *specServer) serve(t *testing.T, method, route string, req *http.Request) map[string]interface{} {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	s.e.ServeHTTP(rec, req)
+
+	operation := s.doc.Paths[openAPIPath(route)][strings.ToLower(method)]
+	require.NotNilf(t, operation, "%s %s is not documented", method, route)
+	response, ok := operation.Responses[strconv.Itoa(rec.Code)]
+	require.Truef(t, ok, "%s %s returned undocumented status %d: %s", method, route, rec.Code, rec.Body.String())
+
+	media, hasBody := response.Content[echo.MIMEApplicationJSON]
+	if !hasBody {
+		assert.Empty(t, rec.Body.String())
+		return nil
+	}
+	var decoded interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+	assertMatchesSchema(t, s.doc, media.Schema, decoded, method+" "+route)
+	result, _ := decoded.(map[string]interface{})
+	return result
+}
+
+// assertMatchesSchema 校验 JSON 值符合 Schema：类型一致、必填字段存在、没有未声明的字段
+func assertMatchesSchema(t *testing.T, doc *OpenAPIDocument, schema *Schema, value interface{}, path string) {
+	t.Helper()
+	if schema.Ref != "" {
+		schema = doc.Components.Schemas[strings.TrimPrefix(schema.Ref, schemaRefPrefix)]
+		require.NotNilf(t, schema, "%s: unresolved reference", path)
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !assert.Truef(t, ok, "%s: expected object, got %T", path, value) || schema.Properties == nil {
+			return
+		}
+		for _, name := range schema.Required {
+			assert.Containsf(t, object, name, "%s: missing required field", path)
+		}
+		for name, fieldValue := range object {
+			property, ok := schema.Properties[name]
+			if assert.Truef(t, ok, "%s.%s is not documented", path, name) {
+				assertMatchesSchema(t, doc, property, fieldValue, path+"."+name)
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if assert.Truef(t, ok, "%s: expected array, got %T", path, value) {
+			for i, item := range items {
+				assertMatchesSchema(t, doc, schema.Items, item, path+"["+strconv.Itoa(i)+"]")
+			}
+		}
+	case "string":
+		_, ok := value.(string)
+		assert.Truef(t, ok, "%s: expected string, got %T", path, value)
+	case "integer":
+		n, ok := value.(float64)
+		assert.Truef(t, ok && n == math.Trunc(n), "%s: expected integer, got %v", path, value)
+	case "number":
+		_, ok := value.(float64)
+		assert.Truef(t, ok, "%s: expected number, got %T", path, value)
+	case "boolean":
+		_, ok := value.(bool)
+		assert.Truef(t, ok, "%s: expected boolean, got %T", path, value)
+	}
+}
+
+func TestOpenAPISpec_CoversAllRoutes(t *testing.T) {
+	// Arrange
+	server := newSpecServer()
+	routes := map[string]bool{}
+	for _, route := range server.e.Routes() {
+		// 带中间件的路由组会注册兜底的 404 路由
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		routes[route.Method+" "+route.Path] = true
+	}
+
+	documented := map[string]bool{}
+	for path, operations := range server.doc.Paths {
+		for method := range operations {
+			documented[strings.ToUpper(method)+" /api/v1"+path] = true
+		}
+	}
+
+	// Assert - 路由与文档一一对应
+	for route := range routes {
+		parts := strings.SplitN(route, " ", 2)
+		assert.Truef(t, documented[parts[0]+" "+openAPIPath(parts[1])], "route %s is not documented", route)
+	}
+	for _, op := range apiOperations {
+		assert.Truef(t, routes[op.Method+" /api/v1"+op.Path], "documented operation %s %s has no route", op.Method, op.Path)
+	}
+	assert.Len(t, documented, len(routes))
+}
+
+func TestOpenAPISpec_ResponsesMatchHandlers(t *testing.T) {
+	// Arrange
+	server := newSpecServer()
+	userToken, err := GenerateToken(1001)
+	require.NoError(t, err)
+	merchantToken, err := GenerateMerchantToken(2001, "merchant_001")
+	require.NoError(t, err)
+	createOrder := CreateOrderRequest{
+		MerchantID: "merchant_001",
+		Items:      []OrderItemRequest{{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 2, Price: 28.00}},
+		DeliveryInfo: DeliveryInfoRequest{
+			RecipientName:  "张三",
+			RecipientPhone: "13800138000",
+			Address:        "北京市朝阳区xxx",
+		},
+	}
+
+	// Act & Assert - 订单
+	quote := server.call(t, http.MethodPost, "/orders/quote", "/orders/quote", userToken, createOrder)
+	quoted := createOrder
+	quoted.QuoteToken = quote["data"].(map[string]interface{})["quoteToken"].(string)
+	created := server.call(t, http.MethodPost, "/orders", "/orders", userToken, quoted)
+	assert.Equal(t, "60.00", created["data"].(map[string]interface{})["pricing"].(map[string]interface{})["finalAmount"])
+	orderNumber := created["data"].(map[string]interface{})["orderNumber"].(string)
+	server.paymentCallback(t, "forged-callback-secret", PaymentCallbackRequest{OrderNumber: orderNumber, PaymentID: "pay_001", Amount: "60.00"})
+	server.paymentCallback(t, specCallbackSecret, PaymentCallbackRequest{OrderNumber: orderNumber, PaymentID: "pay_001", Amount: "0.01"})
+	server.paymentCallback(t, specCallbackSecret, PaymentCallbackRequest{OrderNumber: orderNumber, PaymentID: "pay_001", Amount: "60.00"})
+	server.call(t, http.MethodPost, "/orders/:orderNumber/refunds", "/orders/"+orderNumber+"/refunds", userToken,
+		RefundOrderRequest{Items: []RefundItemRequest{{DishID: "dish_001", Quantity: 1}}, Reason: "少送了一份"})
+	server.call(t, http.MethodPost, "/orders/:orderNumber/cancel", "/orders/"+orderNumber+"/cancel", userToken, CancelOrderRequest{})
+
+	invalid := createOrder
+	invalid.DeliveryInfo.RecipientPhone = "123"
+	server.call(t, http.MethodPost, "/orders", "/orders", userToken, invalid)
+	server.call(t, http.MethodPost, "/orders", "/orders", "", createOrder)
+	server.call(t, http.MethodPost, "/orders/quote", "/orders/quote", userToken, invalid)
+	quoted.Items = []OrderItemRequest{{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 3, Price: 28.00}}
+	server.call(t, http.MethodPost, "/orders", "/orders", userToken, quoted)
+	server.paymentCallback(t, specCallbackSecret, PaymentCallbackRequest{OrderNumber: "unknown", PaymentID: "pay_002", Amount: "60.00"})
+	server.paymentCallback(t, specCallbackSecret, PaymentCallbackRequest{OrderNumber: orderNumber})
+
+	// Act & Assert - 购物车
+	server.call(t, http.MethodGet, "/carts/:merchantId", "/carts/merchant_001", userToken, nil)
+	server.call(t, http.MethodPost, "/carts/:merchantId/checkout", "/carts/merchant_001/checkout", userToken,
+		CheckoutRequest{DeliveryInfo: createOrder.DeliveryInfo})
+	server.call(t, http.MethodPost, "/carts/:merchantId/items", "/carts/merchant_001/items", userToken, createOrder.Items[0])
+	server.call(t, http.MethodPost, "/carts/:merchantId/items", "/carts/merchant_001/items", userToken, OrderItemRequest{DishID: "dish_002"})
+	cart := server.call(t, http.MethodPatch, "/carts/:merchantId/items/:lineId", "/carts/merchant_001/items/L1", userToken, UpdateCartItemRequest{Quantity: 3})
+	assert.Equal(t, "84.00", cart["data"].(map[string]interface{})["pricing"].(map[string]interface{})["itemsTotal"])
+	server.call(t, http.MethodPatch, "/carts/:merchantId/items/:lineId", "/carts/merchant_001/items/L9", userToken, UpdateCartItemRequest{Quantity: 1})
+	server.call(t, http.MethodPost, "/carts/:merchantId/checkout", "/carts/merchant_001/checkout", userToken,
+		CheckoutRequest{DeliveryInfo: createOrder.DeliveryInfo})
+	server.call(t, http.MethodPost, "/carts/:merchantId/items", "/carts/merchant_001/items", userToken, createOrder.Items[0])
+	server.call(t, http.MethodDelete, "/carts/:merchantId/items/:lineId", "/carts/merchant_001/items/L1", userToken, nil)
+	server.call(t, http.MethodDelete, "/carts/:merchantId", "/carts/merchant_001", userToken, nil)
+
+	// Act & Assert - 地址簿
+	server.call(t, http.MethodGet, "/addresses", "/addresses", userToken, nil)
+	home := server.call(t, http.MethodPost, "/addresses", "/addresses", userToken, SaveAddressRequest{
+		Label:          "HOME",
+		RecipientName:  "张三",
+		RecipientPhone: "13800138000",
+		Address:        "北京市朝阳区xxx",
+	})
+	assert.Equal(t, true, home["data"].(map[string]interface{})["isDefault"])
+	server.call(t, http.MethodPost, "/addresses", "/addresses", userToken, SaveAddressRequest{Label: "SCHOOL", RecipientName: "张三"})
+	server.call(t, http.MethodPut, "/addresses/:addressId", "/addresses/A1", userToken, SaveAddressRequest{
+		Label:          "OFFICE",
+		RecipientName:  "张三",
+		RecipientPhone: "13800138000",
+		Address:        "北京市海淀区xxx",
+	})
+	server.call(t, http.MethodPut, "/addresses/:addressId", "/addresses/A9", userToken, SaveAddressRequest{
+		RecipientName:  "张三",
+		RecipientPhone: "13800138000",
+		Address:        "北京市海淀区xxx",
+	})
+	server.call(t, http.MethodPost, "/addresses/:addressId/default", "/addresses/A1/default", userToken, nil)
+	server.call(t, http.MethodPost, "/addresses/:addressId/default", "/addresses/A9/default", userToken, nil)
+	server.call(t, http.MethodGet, "/addresses/:addressId", "/addresses/A1", userToken, nil)
+	server.call(t, http.MethodGet, "/addresses/:addressId", "/addresses/A9", userToken, nil)
+	addressOrder := server.call(t, http.MethodPost, "/orders", "/orders", userToken, map[string]interface{}{
+		"merchantId": "merchant_001",
+		"items":      createOrder.Items,
+		"addressId":  "A1",
+	})
+	assert.Equal(t, "order created successfully", addressOrder["message"])
+	server.call(t, http.MethodDelete, "/addresses/:addressId", "/addresses/A1", userToken, nil)
+	server.call(t, http.MethodDelete, "/addresses/:addressId", "/addresses/A1", userToken, nil)
+
+	// Act & Assert - 商家
+	server.call(t, http.MethodGet, "/merchants/online", "/merchants/online", userToken, nil)
+	server.call(t, http.MethodGet, "/merchants/:merchantId/presence", "/merchants/merchant_001/presence", userToken, nil)
+	server.call(t, http.MethodGet, "/merchants/:merchantId/profile", "/merchants/merchant_001/profile", userToken, nil)
+	server.call(t, http.MethodGet, "/merchants/:merchantId/slots", "/merchants/merchant_001/slots", userToken, nil)
+	server.call(t, http.MethodGet, "/merchants/:merchantId/slots", "/merchants/merchant_001/slots?date=tomorrow", userToken, nil)
+	server.call(t, http.MethodPut, "/merchants/:merchantId/profile", "/merchants/merchant_001/profile", merchantToken, UpdateMerchantProfileRequest{
+		Timezone:       "Asia/Shanghai",
+		OpeningHours:   []OpeningHoursRequest{{Weekday: "MONDAY", Open: "10:00", Close: "24:00"}},
+		Holidays:       []string{"2025-10-01"},
+		MinOrderAmount: 20,
+	})
+	server.call(t, http.MethodPut, "/merchants/:merchantId/profile", "/merchants/merchant_001/profile", merchantToken, UpdateMerchantProfileRequest{
+		Timezone:     "Asia/Shanghai",
+		OpeningHours: []OpeningHoursRequest{{Weekday: "MONDAY", Open: "14:00", Close: "10:00"}},
+	})
+	server.call(t, http.MethodPost, "/merchants/:merchantId/pause", "/merchants/merchant_001/pause", merchantToken, PauseMerchantRequest{Minutes: 15})
+	server.call(t, http.MethodPost, "/merchants/:merchantId/pause", "/merchants/merchant_001/pause", merchantToken, PauseMerchantRequest{})
+	server.call(t, http.MethodPost, "/merchants/:merchantId/resume", "/merchants/merchant_001/resume", merchantToken, nil)
+	server.call(t, http.MethodGet, "/merchants/:merchantId/delivery-area", "/merchants/merchant_001/delivery-area", userToken, nil)
+	south, north, west, east := 39.88, 39.92, 116.37, 116.43
+	area := server.call(t, http.MethodPut, "/merchants/:merchantId/delivery-area", "/merchants/merchant_001/delivery-area", merchantToken, UpdateDeliveryAreaRequest{
+		Origin: GeoPointRequest{Latitude: &south, Longitude: &west},
+		Zones: []DeliveryZoneRequest{{Name: "东城", Polygon: []GeoPointRequest{
+			{Latitude: &south, Longitude: &west}, {Latitude: &south, Longitude: &east}, {Latitude: &north, Longitude: &west},
+		}}},
+		FeeBands: []DeliveryFeeBandRequest{{MaxDistance: 3000, Fee: 5}},
+	})
+	assert.Equal(t, "delivery area updated", area["message"])
+	server.call(t, http.MethodPut, "/merchants/:merchantId/delivery-area", "/merchants/merchant_001/delivery-area", merchantToken, UpdateDeliveryAreaRequest{
+		Origin: GeoPointRequest{Latitude: &south, Longitude: &west},
+	})
+	server.call(t, http.MethodGet, "/merchants/:merchantId/delivery-area", "/merchants/merchant_001/delivery-area", userToken, nil)
+	server.call(t, http.MethodDelete, "/merchants/:merchantId/delivery-area", "/merchants/merchant_001/delivery-area", merchantToken, nil)
+	server.call(t, http.MethodDelete, "/merchants/:merchantId/delivery-area", "/merchants/merchant_001/delivery-area", merchantToken, nil)
+	server.call(t, http.MethodGet, "/merchants/:merchantId/inventory", "/merchants/merchant_001/inventory", userToken, nil)
+	stock := 1
+	inventory := server.call(t, http.MethodPut, "/merchants/:merchantId/inventory", "/merchants/merchant_001/inventory", merchantToken, UpdateInventoryRequest{
+		Dishes: []DishStockRequest{{DishID: "dish_001", Stock: &stock}, {DishID: "dish_101", DailyLimit: 50}},
+	})
+	assert.Equal(t, "inventory updated", inventory["message"])
+	server.call(t, http.MethodPut, "/merchants/:merchantId/inventory", "/merchants/merchant_001/inventory", merchantToken, UpdateInventoryRequest{
+		Dishes: []DishStockRequest{{DishID: "dish_001"}, {DishID: "dish_001", DailyLimit: 10}},
+	})
+	soldOut := server.call(t, http.MethodPost, "/orders", "/orders", userToken, createOrder)
+	assert.Equal(t, "DISH_SOLD_OUT", soldOut["errorCode"])
+
+	webhook := server.call(t, http.MethodPost, "/merchants/:merchantId/webhooks", "/merchants/merchant_001/webhooks", merchantToken,
+		CreateWebhookRequest{URL: "https://merchant.example.com/hooks", EventTypes: []string{domain.EventTypeOrderCreated}})
+	webhookID := webhook["data"].(map[string]interface{})["id"].(string)
+	order := domain.NewOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "")
+	require.NoError(t, server.webhookService.HandleEvent(context.Background(), order.PendingEvents()[0]))
+
+	server.call(t, http.MethodGet, "/merchants/:merchantId/webhooks", "/merchants/merchant_001/webhooks", merchantToken, nil)
+	server.call(t, http.MethodPost, "/merchants/:merchantId/webhooks/:webhookId/enable", "/merchants/merchant_001/webhooks/"+webhookID+"/enable", merchantToken, nil)
+	deliveries := server.call(t, http.MethodGet, "/merchants/:merchantId/webhooks/:webhookId/deliveries", "/merchants/merchant_001/webhooks/"+webhookID+"/deliveries", merchantToken, nil)
+	assert.Len(t, deliveries["data"], 1)
+	server.call(t, http.MethodGet, "/merchants/:merchantId/webhooks/:webhookId/deliveries", "/merchants/merchant_001/webhooks/"+webhookID+"/deliveries?limit=0", merchantToken, nil)
+	server.call(t, http.MethodDelete, "/merchants/:merchantId/webhooks/:webhookId", "/merchants/merchant_001/webhooks/"+webhookID, merchantToken, nil)
+	server.call(t, http.MethodDelete, "/merchants/:merchantId/webhooks/:webhookId", "/merchants/merchant_001/webhooks/"+webhookID, merchantToken, nil)
+	server.call(t, http.MethodGet, "/merchants/:merchantId/webhooks", "/merchants/merchant_002/webhooks", merchantToken, nil)
+}
+
+func TestOpenAPISpec_RequestConstraintsFromValidateTags(t *testing.T) {
+	// Arrange
+	doc := buildOpenAPIDocument()
+	schemas := doc.Components.Schemas
+
+	// Assert
+	createOrder := schemas["CreateOrderRequest"]
+	assert.ElementsMatch(t, []string{"merchantId", "items"}, createOrder.Required)
+	assert.Equal(t, 32, *createOrder.Properties["addressId"].MaxLength)
+	assert.Equal(t, 1, *createOrder.Properties["items"].MinItems)
+	assert.Equal(t, 200, *createOrder.Properties["remark"].MaxLength)
+
+	assert.Equal(t, application.PhonePattern, schemas["DeliveryInfoRequest"].Properties["recipientPhone"].Pattern)
+	assert.Equal(t, 0.0, *schemas["OrderItemRequest"].Properties["price"].ExclusiveMinimum)
+
+	webhook := schemas["CreateWebhookRequest"]
+	assert.Equal(t, "uri", webhook.Properties["url"].Format)
+	assert.Contains(t, webhook.Properties["eventTypes"].Items.Enum, domain.EventTypeOrderPaid)
+	assert.Nil(t, webhook.Properties["secret"].MinLength)
+	assert.Equal(t, 128, *webhook.Properties["secret"].MaxLength)
+
+	// 响应 DTO：未标记 omitempty 的字段为必填
+	assert.ElementsMatch(t, []string{"code", "message"}, schemas["ErrorResponse"].Required)
+}
+
+func TestServeOpenAPISpec(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	RegisterDocsRoutes(e.Group("/api/v1"))
+
+	// Act
+	specRec := httptest.NewRecorder()
+	e.ServeHTTP(specRec, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	docsRec := httptest.NewRecorder()
+	e.ServeHTTP(docsRec, httptest.NewRequest(http.MethodGet, "/api/v1/docs", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, specRec.Code)
+	var doc OpenAPIDocument
+	require.NoError(t, json.Unmarshal(specRec.Body.Bytes(), &doc))
+	assert.Equal(t, OpenAPIVersion, doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/payments/callback")
+	assert.NotContains(t, doc.Paths, "/orders/{orderNumber}/pay")
+
+	assert.Equal(t, http.StatusOK, docsRec.Code)
+	assert.Contains(t, docsRec.Body.String(), "/api/v1/openapi.json")
+}
@@ -0,0 +1,155 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 默认心跳间隔（防止代理因空闲断开连接）
+const DefaultHeartbeatInterval = 15 * time.Second
+
+// SSE 客户端断线后的重连间隔（毫秒）
+const sseRetryMillis = 3000
+
+// OrderStreamHandler 订单状态 SSE 处理器
+type OrderStreamHandler struct {
+	orderService application.OrderService
+	broker       *application.OrderStatusBroker
+	heartbeat    time.Duration
+}
+
+// StreamOption 状态推送处理器可选配置
+type StreamOption func(*OrderStreamHandler)
+
+// WithHeartbeatInterval 配置心跳间隔
+func WithHeartbeatInterval(interval time.Duration) StreamOption {
+	return func(h *OrderStreamHandler) {
+		h.heartbeat = interval
+	}
+}
+
+// NewOrderStreamHandler 创建订单状态 SSE 处理器
+func NewOrderStreamHandler(orderService application.OrderService, broker *application.OrderStatusBroker, opts ...StreamOption) *OrderStreamHandler {
+	h := &OrderStreamHandler{
+		orderService: orderService,
+		broker:       broker,
+		heartbeat:    DefaultHeartbeatInterval,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// StreamOrderEvents 订单状态变更 SSE 处理器
+// 首次连接先推送 snapshot（当前状态），之后推送 status 事件；
+// 携带 Last-Event-ID 重连时补发其后的变更
+func (h *OrderStreamHandler) StreamOrderEvents(c echo.Context) error {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "user not authenticated",
+		})
+	}
+
+	orderNumber := c.Param("orderNumber")
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("lastEventId")
+	}
+
+	// 先校验订单归属，只有订单属于当前用户才订阅其状态变更
+	ctx := c.Request().Context()
+	orderData, err := h.orderService.GetOrder(ctx, userID, orderNumber)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	sub := h.broker.Subscribe(orderNumber, lastEventID)
+	defer sub.Close()
+
+	// 未续传时订阅后重新查询当前状态，避免校验与订阅之间的变更丢失（重复的变更对客户端无害）
+	if !sub.Resumed {
+		if orderData, err = h.orderService.GetOrder(ctx, userID, orderNumber); err != nil {
+			return handleError(c, err)
+		}
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(res, "retry: %d\n\n", sseRetryMillis); err != nil {
+		return nil
+	}
+	if !sub.Resumed {
+		snapshot := OrderStatusEvent{OrderNumber: orderData.OrderNumber, Status: orderData.Status}
+		if err := writeSSE(res, "", "snapshot", snapshot); err != nil {
+			return nil
+		}
+	}
+	for _, update := range sub.Replay {
+		if err := writeStatusUpdate(res, update); err != nil {
+			return nil
+		}
+	}
+	res.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-sub.Updates():
+			if !ok {
+				// 订阅被断开（客户端消费过慢），客户端将携带 Last-Event-ID 重连
+				return nil
+			}
+			if err := writeStatusUpdate(res, update); err != nil {
+				return nil
+			}
+		case <-ticker.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
+
+// writeStatusUpdate 写入状态变更事件（事件ID用于断线续传）
+func writeStatusUpdate(res *echo.Response, update application.OrderStatusUpdate) error {
+	return writeSSE(res, update.EventID, "status", OrderStatusEvent{
+		OrderNumber: update.OrderNumber,
+		EventType:   update.EventType,
+		Status:      update.Status,
+		OccurredAt:  update.OccurredAt.Format(time.RFC3339),
+	})
+}
+
+// writeSSE 按 SSE 格式写入一条事件
+func writeSSE(res *echo.Response, id, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(res, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
@@ -0,0 +1,122 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newStreamContext 创建带可取消请求上下文的 SSE 请求
+func newStreamContext(e *echo.Echo, orderNumber string, ctx context.Context) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/"+orderNumber+"/events", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("orderNumber")
+	c.SetParamValues(orderNumber)
+	c.Set(UserIDKey, uint64(1001))
+	return c, rec
+}
+
+func TestOrderStreamHandler_StreamsStatusUpdates(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	mockService := new(MockOrderService)
+	broker := application.NewOrderStatusBroker()
+	handler := NewOrderStreamHandler(mockService, broker, WithHeartbeatInterval(10*time.Millisecond))
+
+	order := domain.NewOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "")
+	require.NoError(t, order.MarkPaid("pay_001"))
+	events := order.PullEvents()
+
+	mockService.On("GetOrder", mock.Anything, uint64(1001), order.OrderNumber).
+		Return(&application.OrderData{OrderNumber: order.OrderNumber, Status: "PENDING_PAYMENT"}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c, rec := newStreamContext(e, order.OrderNumber, ctx)
+
+	// Act
+	done := make(chan error)
+	go func() { done <- handler.StreamOrderEvents(c) }()
+	assert.Eventually(t, func() bool { return broker.Subscribers(order.OrderNumber) == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, broker.HandleEvent(context.Background(), events[1]))
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	// Assert
+	body := rec.Body.String()
+	assert.Equal(t, "text/event-stream", rec.Header().Get(echo.HeaderContentType))
+	assert.Contains(t, body, "event: snapshot\ndata: {\"orderNumber\":\""+order.OrderNumber+"\",\"status\":\"PENDING_PAYMENT\"}")
+	assert.Contains(t, body, "id: "+events[1].EventID()+"\nevent: status\n")
+	assert.Contains(t, body, `"eventType":"order.paid","status":"PAID"`)
+	assert.Contains(t, body, ": heartbeat")
+	assert.Equal(t, 0, broker.Subscribers(order.OrderNumber))
+}
+
+func TestOrderStreamHandler_ResumesFromLastEventID(t *testing.T) {
+	// Arrange
+	e := echo.New()
+	mockService := new(MockOrderService)
+	broker := application.NewOrderStatusBroker()
+	handler := NewOrderStreamHandler(mockService, broker)
+
+	order := domain.NewOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "")
+	require.NoError(t, order.MarkPaid("pay_001"))
+	events := order.PullEvents()
+	for _, event := range events {
+		require.NoError(t, broker.HandleEvent(context.Background(), event))
+	}
+
+	mockService.On("GetOrder", mock.Anything, uint64(1001), order.OrderNumber).
+		Return(&application.OrderData{OrderNumber: order.OrderNumber, Status: "PAID"}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c, rec := newStreamContext(e, order.OrderNumber, ctx)
+	c.Request().Header.Set("Last-Event-ID", events[0].EventID())
+
+	// Act
+	err := handler.StreamOrderEvents(c)
+
+	// Assert - 续传时不发送 snapshot，只补发 Last-Event-ID 之后的变更
+	require.NoError(t, err)
+	body := rec.Body.String()
+	assert.NotContains(t, body, "event: snapshot")
+	assert.NotContains(t, body, events[0].EventID())
+	assert.Equal(t, 1, strings.Count(body, "event: status"))
+	assert.Contains(t, body, "id: "+events[1].EventID())
+}
+
+func TestOrderStreamHandler_OtherUsersOrder(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockOrderService)
+	broker := application.NewOrderStatusBroker()
+	handler := NewOrderStreamHandler(mockService, broker)
+
+	subscribersDuringCheck := -1
+	mockService.On("GetOrder", mock.Anything, uint64(1001), "ORDER_999").
+		Run(func(mock.Arguments) { subscribersDuringCheck = broker.Subscribers("ORDER_999") }).
+		Return(nil, application.NewNotFoundError("order ORDER_999 not found"))
+
+	c, rec := newStreamContext(e, "ORDER_999", context.Background())
+
+	err := handler.StreamOrderEvents(c)
+
+	// 归属校验失败时从未订阅该订单的状态变更
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, 0, subscribersDuringCheck)
+	assert.Equal(t, 0, broker.Subscribers("ORDER_999"))
+	mockService.AssertNumberOfCalls(t, "GetOrder", 1)
+}
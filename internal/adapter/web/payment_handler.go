@@ -0,0 +1,97 @@
+package web
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderPaymentSignature 支付回调签名请求头（请求体的 HMAC-SHA256，十六进制）
+const HeaderPaymentSignature = "X-Payment-Signature"
+
+// PaymentHandler 支付回调 HTTP 处理器
+type PaymentHandler struct {
+	orderService   application.OrderService
+	callbackSecret []byte
+}
+
+// NewPaymentHandler 创建支付回调处理器，callbackSecret 为与支付渠道约定的签名密钥（为空时拒绝全部回调）
+func NewPaymentHandler(orderService application.OrderService, callbackSecret string) *PaymentHandler {
+	return &PaymentHandler{
+		orderService:   orderService,
+		callbackSecret: []byte(callbackSecret),
+	}
+}
+
+// PaymentSignature 计算支付回调请求体的签名
+func PaymentSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature 支付回调验签中间件：签名与请求体不一致时返回 401
+func (h *PaymentHandler) VerifySignature(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		signature, err := hex.DecodeString(c.Request().Header.Get(HeaderPaymentSignature))
+		if len(h.callbackSecret) == 0 || err != nil || len(signature) == 0 {
+			return c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Code:    http.StatusUnauthorized,
+				Message: "missing or invalid payment signature",
+			})
+		}
+
+		body, err := io.ReadAll(io.LimitReader(c.Request().Body, DefaultMaxBodySize+1))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid request body",
+			})
+		}
+		mac := hmac.New(sha256.New, h.callbackSecret)
+		mac.Write(body)
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return c.JSON(http.StatusUnauthorized, ErrorResponse{
+				Code:    http.StatusUnauthorized,
+				Message: "missing or invalid payment signature",
+			})
+		}
+
+		c.Request().Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request().Body))
+		return next(c)
+	}
+}
+
+// PaymentCallback 支付结果回调（需在 VerifySignature 之后使用），确认支付后订单变为已支付
+func (h *PaymentHandler) PaymentCallback(c echo.Context) error {
+	var webReq PaymentCallbackRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	appReq := &application.ConfirmPaymentRequest{
+		OrderNumber: webReq.OrderNumber,
+		PaymentID:   webReq.PaymentID,
+		Amount:      webReq.Amount,
+	}
+	orderData, err := h.orderService.ConfirmPayment(c.Request().Context(), appReq)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, OrderResponse{
+		Code:    http.StatusOK,
+		Message: "order paid successfully",
+		Data:    convertToWebDTO(orderData),
+	})
+}
@@ -0,0 +1,82 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testCallbackSecret = "payment-callback-secret"
+
+// postPaymentCallback 发送支付回调请求，signature 为空时不带签名头
+func postPaymentCallback(e *echo.Echo, body, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/payments/callback", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if signature != "" {
+		req.Header.Set(HeaderPaymentSignature, signature)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPaymentHandler_PaymentCallback(t *testing.T) {
+	// Arrange
+	mockService := new(MockOrderService)
+	handler := NewPaymentHandler(mockService, testCallbackSecret)
+	e := echo.New()
+	e.POST("/api/v1/payments/callback", handler.PaymentCallback, handler.VerifySignature)
+	body := `{"orderNumber":"20241117120000123456","paymentId":"pay_001","amount":"60.00"}`
+	mockService.On("ConfirmPayment", mock.Anything, &application.ConfirmPaymentRequest{
+		OrderNumber: "20241117120000123456", PaymentID: "pay_001", Amount: "60.00",
+	}).Return(&application.OrderData{OrderNumber: "20241117120000123456", Status: "PAID"}, nil)
+
+	// Act
+	rec := postPaymentCallback(e, body, PaymentSignature(testCallbackSecret, []byte(body)))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var response OrderResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "PAID", response.Data.Status)
+	mockService.AssertExpectations(t)
+}
+
+func TestPaymentHandler_VerifySignature_Rejects(t *testing.T) {
+	body := `{"orderNumber":"20241117120000123456","paymentId":"pay_001","amount":"60.00"}`
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+	}{
+		{name: "缺少签名", secret: testCallbackSecret},
+		{name: "签名格式错误", secret: testCallbackSecret, signature: "not-hex"},
+		{name: "其他密钥签名", secret: testCallbackSecret, signature: PaymentSignature("another-callback-secret", []byte(body))},
+		{name: "请求体被篡改", secret: testCallbackSecret, signature: PaymentSignature(testCallbackSecret, []byte(body+" "))},
+		{name: "未配置密钥", signature: PaymentSignature("", []byte(body))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := new(MockOrderService)
+			handler := NewPaymentHandler(mockService, tt.secret)
+			e := echo.New()
+			e.POST("/api/v1/payments/callback", handler.PaymentCallback, handler.VerifySignature)
+
+			// Act
+			rec := postPaymentCallback(e, body, tt.signature)
+
+			// Assert - 验签失败时不确认支付
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			mockService.AssertNotCalled(t, "ConfirmPayment", mock.Anything, mock.Anything)
+		})
+	}
+}
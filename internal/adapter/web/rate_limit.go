@@ -0,0 +1,161 @@
+package web
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"order-service/internal/application"
+	"order-service/internal/logging"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 限流响应头（IETF RateLimit 头字段草案）
+const (
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+)
+
+// RateLimitKey 从请求中提取限流维度的值，返回 false 时规则不适用于本次请求
+type RateLimitKey func(c echo.Context) (string, bool)
+
+// RateLimitRule 限流规则：Routes 中的路由按 Key 分别计数，同名规则在各路由间共享令牌桶
+type RateLimitRule struct {
+	Name   string
+	Routes []string // "METHOD 路由路径"，路径相对 /api/v1，如 "POST /orders"
+	Key    RateLimitKey
+	Limit  application.RateLimit
+}
+
+// RateLimitPolicy 限流策略：按路由生效的限流规则及令牌桶存储
+type RateLimitPolicy struct {
+	limiter application.RateLimiter
+	routes  map[string][]RateLimitRule // "METHOD 路由路径" -> 规则
+}
+
+// NewRateLimitPolicy 创建限流策略，限额未生效的规则被忽略
+func NewRateLimitPolicy(limiter application.RateLimiter, rules ...RateLimitRule) *RateLimitPolicy {
+	p := &RateLimitPolicy{limiter: limiter, routes: make(map[string][]RateLimitRule)}
+	for _, rule := range rules {
+		if !rule.Limit.Enabled() {
+			continue
+		}
+		for _, route := range rule.Routes {
+			p.routes[route] = append(p.routes[route], rule)
+		}
+	}
+	return p
+}
+
+// AuthenticatedRoutes 需认证的全部路由（"METHOD 路由路径"，路径相对 /api/v1），用于配置作用于全部接口的规则
+func AuthenticatedRoutes() []string {
+	var routes []string
+	for _, op := range apiOperations {
+		if op.Auth != authNone {
+			routes = append(routes, op.Method+" "+op.Path)
+		}
+	}
+	return routes
+}
+
+// RateLimitByUser 按用户ID限流（需在 AuthMiddleware 之后使用）
+func RateLimitByUser(c echo.Context) (string, bool) {
+	userID, ok := c.Get(UserIDKey).(uint64)
+	if !ok {
+		return "", false
+	}
+	return strconv.FormatUint(userID, 10), true
+}
+
+// RateLimitByIP 按客户端 IP 限流（IP 的获取方式由 Echo 的 IPExtractor 决定；在 AuthMiddleware 之前使用，无效 token 同样消耗令牌）
+func RateLimitByIP(c echo.Context) (string, bool) {
+	ip := c.RealIP()
+	return ip, ip != ""
+}
+
+// RateLimit 限流中间件（按 IP 的规则在 AuthMiddleware 之前使用，按用户的规则在其之后、请求体校验之前使用），policy 为空时不限流
+// 请求依次经过所在路由的规则，遇到第一个拒绝的规则即返回 429 和 Retry-After，其后的规则不再消耗令牌；
+// 响应头 RateLimit-* 取剩余令牌最少（被拒绝时为拒绝）的规则，并保留前一个限流中间件写入的更紧的值；限流存储出错时放行请求
+func RateLimit(policy *RateLimitPolicy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if policy == nil {
+			return next
+		}
+		return func(c echo.Context) error {
+			rules := policy.routes[c.Request().Method+" "+strings.TrimPrefix(c.Path(), "/api/"+apiVersion)]
+			if len(rules) == 0 {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			var reported *application.RateLimitDecision
+			for i := range rules {
+				rule := &rules[i]
+				key, ok := rule.Key(c)
+				if !ok {
+					continue
+				}
+				decision, err := policy.limiter.Allow(ctx, rule.Name+":"+key, rule.Limit)
+				if err != nil {
+					logging.FromContext(ctx).Warn("rate limiter unavailable, allowing request",
+						"rule", rule.Name, logging.KeyError, err)
+					continue
+				}
+
+				if !decision.Allowed {
+					logging.FromContext(ctx).Info("request rate limited", "rule", rule.Name)
+					header := c.Response().Header()
+					setRateLimitHeaders(header, &decision)
+					header.Set(echo.HeaderRetryAfter, strconv.Itoa(ceilSeconds(decision.RetryAfter, 1)))
+					return c.JSON(http.StatusTooManyRequests, ErrorResponse{
+						Code:    http.StatusTooManyRequests,
+						Message: "too many requests, retry later",
+					})
+				}
+				if reported == nil || decision.Remaining < reported.Remaining {
+					reported = &decision
+				}
+			}
+
+			header := c.Response().Header()
+			if reported != nil && !tighterHeaderSet(header, reported.Remaining) {
+				setRateLimitHeaders(header, reported)
+			}
+			return next(c)
+		}
+	}
+}
+
+// tighterHeaderSet 前一个限流中间件是否已写入剩余令牌不多于 remaining 的 RateLimit-* 响应头
+func tighterHeaderSet(header http.Header, remaining int) bool {
+	existing, err := strconv.Atoi(header.Get(HeaderRateLimitRemaining))
+	return err == nil && existing <= remaining
+}
+
+// setRateLimitHeaders 写入 RateLimit-* 响应头（Reset 为令牌桶补满所需秒数）
+func setRateLimitHeaders(header http.Header, decision *application.RateLimitDecision) {
+	header.Set(HeaderRateLimitLimit, strconv.Itoa(decision.Limit))
+	header.Set(HeaderRateLimitRemaining, strconv.Itoa(decision.Remaining))
+	header.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(decision.ResetAfter, 0)))
+}
+
+// setRateLimitErrorHeaders 为应用层返回的限流错误写入 Retry-After 和拒绝规则的 RateLimit-* 响应头（规则未知时只写 Retry-After）
+func setRateLimitErrorHeaders(header http.Header, err *application.RateLimitError) {
+	if err.Limit > 0 {
+		setRateLimitHeaders(header, &application.RateLimitDecision{
+			Limit:      err.Limit,
+			Remaining:  err.Remaining,
+			ResetAfter: err.ResetAfter,
+		})
+	}
+	header.Set(echo.HeaderRetryAfter, strconv.Itoa(err.RetryAfterSeconds()))
+}
+
+// ceilSeconds 向上取整的秒数，不小于 atLeast
+func ceilSeconds(d time.Duration, atLeast int) int {
+	return max(atLeast, int(math.Ceil(d.Seconds())))
+}
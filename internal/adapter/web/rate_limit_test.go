@@ -0,0 +1,193 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"order-service/internal/adapter/ratelimit"
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingRateLimiter 始终返回错误的限流存储
+type failingRateLimiter struct{}
+
+func (failingRateLimiter) Allow(context.Context, string, application.RateLimit) (application.RateLimitDecision, error) {
+	return application.RateLimitDecision{}, errors.New("store unavailable")
+}
+
+// recordingRateLimiter 记录每次判定的 key 的限流存储
+type recordingRateLimiter struct {
+	application.RateLimiter
+	keys []string
+}
+
+func (l *recordingRateLimiter) Allow(ctx context.Context, key string, limit application.RateLimit) (application.RateLimitDecision, error) {
+	l.keys = append(l.keys, key)
+	return l.RateLimiter.Allow(ctx, key, limit)
+}
+
+// newRateLimitServer 创建挂载下单路由的测试服务（处理器回显请求体）
+func newRateLimitServer(policy *RateLimitPolicy) *echo.Echo {
+	e := echo.New()
+	e.IPExtractor = echo.ExtractIPDirect()
+	e.POST("/api/v1/orders", func(c echo.Context) error {
+		body, _ := io.ReadAll(c.Request().Body)
+		return c.String(http.StatusOK, string(body))
+	}, AuthMiddleware, RateLimit(policy))
+	return e
+}
+
+// postOrder 以指定用户下单
+func postOrder(t *testing.T, e *echo.Echo, userID uint64, merchantID string) *httptest.ResponseRecorder {
+	token, err := GenerateToken(userID)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(`{"merchantId":"`+merchantID+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimit_PerUserReturns429WithHeaders(t *testing.T) {
+	// Arrange - 每个用户每分钟 2 个令牌，最多突发 2 个
+	policy := NewRateLimitPolicy(ratelimit.NewInMemoryRateLimiter(), RateLimitRule{
+		Name:   "create_order_user",
+		Routes: []string{"POST /orders"},
+		Key:    RateLimitByUser,
+		Limit:  application.RateLimit{Rate: 2, Period: time.Minute, Burst: 2},
+	})
+	e := newRateLimitServer(policy)
+
+	// Act
+	first := postOrder(t, e, 1001, "merchant_001")
+	postOrder(t, e, 1001, "merchant_001")
+	limited := postOrder(t, e, 1001, "merchant_001")
+	otherUser := postOrder(t, e, 2002, "merchant_001")
+
+	// Assert - 限流中间件读取请求体后处理器仍能读到完整请求体
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, `{"merchantId":"merchant_001"}`, first.Body.String())
+	assert.Equal(t, "2", first.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "1", first.Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "30", first.Header().Get(HeaderRateLimitReset))
+
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "30", limited.Header().Get(echo.HeaderRetryAfter))
+	assert.Equal(t, "0", limited.Header().Get(HeaderRateLimitRemaining))
+	assert.Contains(t, limited.Body.String(), "too many requests")
+
+	assert.Equal(t, http.StatusOK, otherUser.Code)
+}
+
+func TestRateLimit_ReportsTightestRule(t *testing.T) {
+	// Arrange
+	policy := NewRateLimitPolicy(ratelimit.NewInMemoryRateLimiter(),
+		RateLimitRule{Name: "loose", Routes: []string{"POST /orders"}, Key: RateLimitByUser,
+			Limit: application.RateLimit{Rate: 100, Period: time.Minute, Burst: 100}},
+		RateLimitRule{Name: "strict", Routes: []string{"POST /orders"}, Key: RateLimitByUser,
+			Limit: application.RateLimit{Rate: 2, Period: time.Minute, Burst: 2}},
+		RateLimitRule{Name: "disabled", Routes: []string{"POST /orders"}, Key: RateLimitByIP},
+	)
+	e := newRateLimitServer(policy)
+
+	// Act
+	first := postOrder(t, e, 1001, "merchant_001")
+
+	// Assert - 响应头取剩余令牌最少的规则，未配置限额的规则不生效
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "1", first.Header().Get(HeaderRateLimitRemaining))
+}
+
+func TestRateLimit_StopsAtFirstDeny(t *testing.T) {
+	// Arrange
+	limiter := &recordingRateLimiter{RateLimiter: ratelimit.NewInMemoryRateLimiter()}
+	policy := NewRateLimitPolicy(limiter,
+		RateLimitRule{Name: "strict", Routes: []string{"POST /orders"}, Key: RateLimitByUser,
+			Limit: application.RateLimit{Rate: 1, Period: time.Minute, Burst: 1}},
+		RateLimitRule{Name: "loose", Routes: []string{"POST /orders"}, Key: RateLimitByUser,
+			Limit: application.RateLimit{Rate: 5, Period: time.Minute, Burst: 5}},
+	)
+	e := newRateLimitServer(policy)
+
+	// Act
+	postOrder(t, e, 1001, "merchant_001")
+	limited := postOrder(t, e, 1001, "merchant_001")
+
+	// Assert - 被拒绝的请求不再消耗其后规则的令牌，响应头为拒绝的规则
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "1", limited.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, []string{"strict:1001", "loose:1001", "strict:1001"}, limiter.keys)
+}
+
+func TestRateLimit_PreAuthIPLimit(t *testing.T) {
+	// Arrange - 认证前按 IP 限 3 次，认证后按用户限 5 次
+	limiter := ratelimit.NewInMemoryRateLimiter()
+	ipPolicy := NewRateLimitPolicy(limiter, RateLimitRule{Name: "ip", Routes: []string{"POST /orders"}, Key: RateLimitByIP,
+		Limit: application.RateLimit{Rate: 3, Period: time.Minute, Burst: 3}})
+	userPolicy := NewRateLimitPolicy(limiter, RateLimitRule{Name: "user", Routes: []string{"POST /orders"}, Key: RateLimitByUser,
+		Limit: application.RateLimit{Rate: 5, Period: time.Minute, Burst: 5}})
+	e := echo.New()
+	e.IPExtractor = echo.ExtractIPDirect()
+	e.POST("/api/v1/orders", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, RateLimit(ipPolicy), AuthMiddleware, RateLimit(userPolicy))
+	postInvalidToken := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil)
+		req.Header.Set("Authorization", "Bearer invalid")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Act
+	allowed := postOrder(t, e, 1001, "merchant_001")
+	unauthorized := postInvalidToken()
+	postInvalidToken()
+	limited := postInvalidToken()
+	validAfterLimit := postOrder(t, e, 2002, "merchant_001")
+
+	// Assert - 无效 token 同样消耗 IP 令牌；响应头保留 IP 规则更紧的剩余令牌数
+	assert.Equal(t, http.StatusOK, allowed.Code)
+	assert.Equal(t, "3", allowed.Header().Get(HeaderRateLimitLimit))
+	assert.Equal(t, "2", allowed.Header().Get(HeaderRateLimitRemaining))
+	assert.Equal(t, http.StatusUnauthorized, unauthorized.Code)
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, http.StatusTooManyRequests, validAfterLimit.Code)
+}
+
+func TestRateLimit_AllowsWhenStoreFailsOrRouteHasNoRules(t *testing.T) {
+	limit := application.RateLimit{Rate: 1, Period: time.Minute, Burst: 1}
+	failing := newRateLimitServer(NewRateLimitPolicy(failingRateLimiter{},
+		RateLimitRule{Name: "user", Routes: []string{"POST /orders"}, Key: RateLimitByUser, Limit: limit}))
+	otherRoute := newRateLimitServer(NewRateLimitPolicy(ratelimit.NewInMemoryRateLimiter(),
+		RateLimitRule{Name: "user", Routes: []string{"POST /orders/:orderNumber/cancel"}, Key: RateLimitByUser, Limit: limit}))
+	unlimited := newRateLimitServer(nil)
+
+	for _, e := range []*echo.Echo{failing, otherRoute, unlimited} {
+		postOrder(t, e, 1001, "merchant_001")
+		rec := postOrder(t, e, 1001, "merchant_001")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(HeaderRateLimitLimit))
+	}
+}
+
+func TestAuthenticatedRoutes(t *testing.T) {
+	routes := AuthenticatedRoutes()
+
+	assert.Contains(t, routes, "POST /orders")
+	assert.Contains(t, routes, "GET /merchants/:merchantId/webhooks")
+	assert.NotContains(t, routes, "GET /openapi.json")
+}
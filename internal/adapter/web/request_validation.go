@@ -0,0 +1,330 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DefaultMaxBodySize 请求体默认大小上限（1 MiB）
+const DefaultMaxBodySize int64 = 1 << 20
+
+// requestValidator 按 OpenAPI 文档校验请求体
+type requestValidator struct {
+	doc         *OpenAPIDocument
+	maxBodySize int64
+	bodies      map[string]*Schema // "METHOD 路由路径" -> 请求体 Schema
+	patterns    sync.Map           // 正则缓存
+	observe     ValidationObserver
+}
+
+// ValidationObserver 请求体校验失败回调（用于统计），field 为去掉数组下标的字段路径，
+// 如 $.items[].quantity；未声明的字段名来自客户端输入，统一报告为所在对象路径加 .*
+type ValidationObserver func(field string)
+
+// RequestValidationOption 请求体校验可选配置
+type RequestValidationOption func(*requestValidator)
+
+// WithMaxBodySize 配置请求体大小上限（字节）
+func WithMaxBodySize(size int64) RequestValidationOption {
+	return func(v *requestValidator) {
+		v.maxBodySize = size
+	}
+}
+
+// WithValidationObserver 配置请求体校验失败回调
+func WithValidationObserver(observe ValidationObserver) RequestValidationOption {
+	return func(v *requestValidator) {
+		v.observe = observe
+	}
+}
+
+// schemaViolation 请求体不符合 Schema 的位置和原因
+type schemaViolation struct {
+	Path    string // JSON 路径，如 $.items[0].quantity
+	Message string
+	unknown bool // 未声明的字段
+}
+
+// arrayIndex 匹配 JSON 路径中的数组下标
+var arrayIndex = regexp.MustCompile(`\[\d+\]`)
+
+// field 用于统计的字段路径（去掉数组下标，未声明的字段名替换为 *）
+func (s *schemaViolation) field() string {
+	path := s.Path
+	if s.unknown {
+		path = path[:strings.LastIndex(path, ".")] + ".*"
+	}
+	return arrayIndex.ReplaceAllString(path, "[]")
+}
+
+// ValidateRequestBody 请求体校验中间件（在认证中间件之后使用）
+// 在处理器执行前按已发布的 OpenAPI 文档校验 JSON 请求体：
+// 拒绝未声明的字段，类型、必填、长度、取值范围和数组元素个数错误时返回 400 并附带 JSON 路径，
+// 超过大小上限时返回 413；路由没有请求体描述时直接放行
+func ValidateRequestBody(opts ...RequestValidationOption) echo.MiddlewareFunc {
+	v := &requestValidator{
+		doc:         openAPIDocument(),
+		maxBodySize: DefaultMaxBodySize,
+		bodies:      make(map[string]*Schema),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	for path, operations := range v.doc.Paths {
+		for method, operation := range operations {
+			if operation.RequestBody == nil {
+				continue
+			}
+			key := strings.ToUpper(method) + " " + echoPath(path)
+			v.bodies[key] = operation.RequestBody.Content[echo.MIMEApplicationJSON].Schema
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			schema, ok := v.bodies[c.Request().Method+" "+strings.TrimPrefix(c.Path(), "/api/"+apiVersion)]
+			if !ok {
+				return next(c)
+			}
+
+			body, err := io.ReadAll(io.LimitReader(c.Request().Body, v.maxBodySize+1))
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, ErrorResponse{
+					Code:    http.StatusBadRequest,
+					Message: "failed to read request body",
+				})
+			}
+			if int64(len(body)) > v.maxBodySize {
+				return c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+					Code:    http.StatusRequestEntityTooLarge,
+					Message: fmt.Sprintf("request body exceeds %d bytes", v.maxBodySize),
+				})
+			}
+
+			if violation := v.validateBody(schema, body); violation != nil {
+				if v.observe != nil {
+					v.observe(violation.field())
+				}
+				return c.JSON(http.StatusBadRequest, ErrorResponse{
+					Code:    http.StatusBadRequest,
+					Message: violation.Message,
+					Field:   violation.Path,
+				})
+			}
+
+			// 处理器重新读取已校验的请求体
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+			return next(c)
+		}
+	}
+}
+
+// validateBody 解析并校验请求体（空请求体视为空对象）
+func (v *requestValidator) validateBody(schema *Schema, body []byte) *schemaViolation {
+	if len(bytes.TrimSpace(body)) == 0 {
+		body = []byte("{}")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return &schemaViolation{Path: "$", Message: "malformed JSON: " + jsonErrorDetail(err)}
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return &schemaViolation{Path: "$", Message: "unexpected data after JSON value"}
+	}
+	return v.validate(schema, value, "$")
+}
+
+// validate 按 Schema 递归校验 JSON 值，返回第一个不符合的位置
+func (v *requestValidator) validate(schema *Schema, value interface{}, path string) *schemaViolation {
+	if schema.Ref != "" {
+		schema = v.doc.Components.Schemas[strings.TrimPrefix(schema.Ref, schemaRefPrefix)]
+	}
+
+	switch schema.Type {
+	case "object":
+		return v.validateObject(schema, value, path)
+	case "array":
+		return v.validateArray(schema, value, path)
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return typeMismatch(path, "string", value)
+		}
+		return v.validateString(schema, s, path)
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return typeMismatch(path, "integer", value)
+		}
+		i, err := strconv.ParseInt(n.String(), 10, 64)
+		if err != nil {
+			return &schemaViolation{Path: path, Message: "expected integer, got " + n.String()}
+		}
+		return validateRange(schema, float64(i), path)
+	case "number":
+		n, ok := value.(json.Number)
+		if !ok {
+			return typeMismatch(path, "number", value)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return &schemaViolation{Path: path, Message: "expected number, got " + n.String()}
+		}
+		return validateRange(schema, f, path)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return typeMismatch(path, "boolean", value)
+		}
+	}
+	return nil
+}
+
+// validateObject 校验对象：必填字段、未声明字段和各字段取值
+func (v *requestValidator) validateObject(schema *Schema, value interface{}, path string) *schemaViolation {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return typeMismatch(path, "object", value)
+	}
+
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			return &schemaViolation{Path: path + "." + name, Message: "required field is missing"}
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		property, ok := schema.Properties[name]
+		if !ok {
+			if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+				return &schemaViolation{Path: path + "." + name, Message: "unknown field", unknown: true}
+			}
+			continue
+		}
+		if violation := v.validate(property, object[name], path+"."+name); violation != nil {
+			return violation
+		}
+	}
+	return nil
+}
+
+// validateArray 校验数组：元素个数和各元素取值
+func (v *requestValidator) validateArray(schema *Schema, value interface{}, path string) *schemaViolation {
+	items, ok := value.([]interface{})
+	if !ok {
+		return typeMismatch(path, "array", value)
+	}
+	if schema.MinItems != nil && len(items) < *schema.MinItems {
+		return &schemaViolation{Path: path, Message: fmt.Sprintf("must contain at least %d items", *schema.MinItems)}
+	}
+	if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+		return &schemaViolation{Path: path, Message: fmt.Sprintf("must contain at most %d items", *schema.MaxItems)}
+	}
+	for i, item := range items {
+		if violation := v.validate(schema.Items, item, path+"["+strconv.Itoa(i)+"]"); violation != nil {
+			return violation
+		}
+	}
+	return nil
+}
+
+// validateString 校验字符串长度（按字符计）、格式和枚举值
+func (v *requestValidator) validateString(schema *Schema, s, path string) *schemaViolation {
+	length := utf8.RuneCountInString(s)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		return &schemaViolation{Path: path, Message: fmt.Sprintf("must be at least %d characters", *schema.MinLength)}
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		return &schemaViolation{Path: path, Message: fmt.Sprintf("must be at most %d characters", *schema.MaxLength)}
+	}
+	if schema.Pattern != "" && !v.pattern(schema.Pattern).MatchString(s) {
+		return &schemaViolation{Path: path, Message: "does not match pattern " + schema.Pattern}
+	}
+	if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, s) {
+		return &schemaViolation{Path: path, Message: "must be one of: " + strings.Join(schema.Enum, ", ")}
+	}
+	return nil
+}
+
+// pattern 获取编译后的正则（文档中的正则在生成时已确定，按需编译并缓存）
+func (v *requestValidator) pattern(expr string) *regexp.Regexp {
+	if cached, ok := v.patterns.Load(expr); ok {
+		return cached.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(expr)
+	v.patterns.Store(expr, re)
+	return re
+}
+
+// validateRange 校验数值范围
+func validateRange(schema *Schema, n float64, path string) *schemaViolation {
+	if schema.ExclusiveMinimum != nil && n <= *schema.ExclusiveMinimum {
+		return &schemaViolation{Path: path, Message: fmt.Sprintf("must be greater than %v", *schema.ExclusiveMinimum)}
+	}
+	if schema.Minimum != nil && n < *schema.Minimum {
+		return &schemaViolation{Path: path, Message: fmt.Sprintf("must be at least %v", *schema.Minimum)}
+	}
+	if schema.Maximum != nil && n > *schema.Maximum {
+		return &schemaViolation{Path: path, Message: fmt.Sprintf("must be at most %v", *schema.Maximum)}
+	}
+	return nil
+}
+
+// typeMismatch 创建类型错误
+func typeMismatch(path, expected string, value interface{}) *schemaViolation {
+	return &schemaViolation{Path: path, Message: fmt.Sprintf("expected %s, got %s", expected, jsonTypeName(value))}
+}
+
+// jsonTypeName JSON 值的类型名
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// jsonErrorDetail 提取 JSON 语法错误的位置
+func jsonErrorDetail(err error) string {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Sprintf("%s at offset %d", syntaxErr.Error(), syntaxErr.Offset)
+	}
+	return err.Error()
+}
+
+// echoPath 将 OpenAPI 路径参数（{name}）转换为 Echo 格式（:name）
+func echoPath(path string) string {
+	return openAPIPathParam.ReplaceAllString(path, ":$1")
+}
+
+// openAPIPathParam OpenAPI 路径参数
+var openAPIPathParam = regexp.MustCompile(`\{([A-Za-z]+)\}`)
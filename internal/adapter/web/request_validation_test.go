@@ -0,0 +1,177 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newValidationServer 创建挂载请求体校验中间件的测试服务，处理器回显收到的请求体
+func newValidationServer(opts ...RequestValidationOption) *echo.Echo {
+	e := echo.New()
+	echoBody := func(c echo.Context) error {
+		body, _ := io.ReadAll(c.Request().Body)
+		return c.String(http.StatusOK, string(body))
+	}
+	validate := ValidateRequestBody(opts...)
+	e.POST("/api/v1/orders", echoBody, validate)
+	e.POST("/api/v1/orders/:orderNumber/cancel", echoBody, validate)
+	e.GET("/api/v1/merchants/online", echoBody, validate)
+	return e
+}
+
+// postJSON 发送 JSON 请求
+func postJSON(e *echo.Echo, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+const validCreateOrderBody = `{
+  "merchantId": "merchant_001",
+  "items": [{"dishId": "dish_001", "dishName": "宫保鸡丁", "quantity": 2, "price": 28.00}],
+  "deliveryInfo": {"recipientName": "张三", "recipientPhone": "13800138000", "address": "北京市朝阳区xxx"}
+}`
+
+func TestValidateRequestBody_PassesValidBody(t *testing.T) {
+	// Arrange
+	e := newValidationServer()
+
+	// Act
+	rec := postJSON(e, "/api/v1/orders", validCreateOrderBody)
+
+	// Assert - 处理器能重新读取请求体
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, validCreateOrderBody, rec.Body.String())
+}
+
+func TestValidateRequestBody_Violations(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		field string
+	}{
+		{
+			name:  "未声明的字段",
+			body:  strings.Replace(validCreateOrderBody, `"merchantId"`, `"couponId": "c1", "merchantId"`, 1),
+			field: "$.couponId",
+		},
+		{
+			name:  "嵌套字段类型错误",
+			body:  strings.Replace(validCreateOrderBody, `"quantity": 2`, `"quantity": "2"`, 1),
+			field: "$.items[0].quantity",
+		},
+		{
+			name:  "整数字段为小数",
+			body:  strings.Replace(validCreateOrderBody, `"quantity": 2`, `"quantity": 2.5`, 1),
+			field: "$.items[0].quantity",
+		},
+		{
+			name:  "缺少必填字段",
+			body:  strings.Replace(validCreateOrderBody, `"address": "北京市朝阳区xxx"`, `"address2": "x"`, 1),
+			field: "$.deliveryInfo.address",
+		},
+		{
+			name:  "手机号格式错误",
+			body:  strings.Replace(validCreateOrderBody, "13800138000", "12345", 1),
+			field: "$.deliveryInfo.recipientPhone",
+		},
+		{
+			name:  "空餐品列表",
+			body:  `{"merchantId": "m", "items": [], "deliveryInfo": {"recipientName": "a", "recipientPhone": "13800138000", "address": "b"}}`,
+			field: "$.items",
+		},
+		{
+			name:  "根节点不是对象",
+			body:  `[1, 2]`,
+			field: "$",
+		},
+		{
+			name:  "JSON 格式错误",
+			body:  `{"merchantId": `,
+			field: "$",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			e := newValidationServer()
+
+			// Act
+			rec := postJSON(e, "/api/v1/orders", tt.body)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			var resp ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tt.field, resp.Field)
+			assert.NotEmpty(t, resp.Message)
+		})
+	}
+}
+
+func TestValidateRequestBody_TooManyItems(t *testing.T) {
+	// Arrange
+	e := newValidationServer()
+	item := `{"dishId": "d", "dishName": "n", "quantity": 1, "price": 1}`
+	items := strings.TrimSuffix(strings.Repeat(item+",", MaxRequestItems+1), ",")
+	body := `{"merchantId": "m", "items": [` + items + `], "deliveryInfo": {"recipientName": "a", "recipientPhone": "13800138000", "address": "b"}}`
+
+	// Act
+	rec := postJSON(e, "/api/v1/orders", body)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"$.items"`)
+}
+
+func TestValidateRequestBody_BodyTooLarge(t *testing.T) {
+	// Arrange
+	e := newValidationServer(WithMaxBodySize(64))
+
+	// Act
+	rec := postJSON(e, "/api/v1/orders", validCreateOrderBody)
+
+	// Assert
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestValidateRequestBody_EmptyBodyAndRoutesWithoutBody(t *testing.T) {
+	// Arrange
+	e := newValidationServer()
+
+	// Act - 取消订单的字段均为可选，空请求体视为空对象
+	cancelRec := postJSON(e, "/api/v1/orders/20250101/cancel", "")
+	getRec := httptest.NewRecorder()
+	e.ServeHTTP(getRec, httptest.NewRequest(http.MethodGet, "/api/v1/merchants/online", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, cancelRec.Code)
+	assert.Equal(t, http.StatusOK, getRec.Code)
+}
+
+func TestValidateRequestBody_ObserverReceivesNormalizedField(t *testing.T) {
+	// Arrange
+	var fields []string
+	e := newValidationServer(WithValidationObserver(func(field string) {
+		fields = append(fields, field)
+	}))
+
+	// Act - 数组下标和未声明的字段名不会出现在统计字段中
+	postJSON(e, "/api/v1/orders", strings.Replace(validCreateOrderBody, `"quantity": 2`, `"quantity": "2"`, 1))
+	postJSON(e, "/api/v1/orders", strings.Replace(validCreateOrderBody, `"dishId"`, `"secret": 1, "dishId"`, 1))
+	postJSON(e, "/api/v1/orders", validCreateOrderBody)
+
+	// Assert
+	assert.Equal(t, []string{"$.items[].quantity", "$.items[].*"}, fields)
+}
@@ -0,0 +1,113 @@
+package web
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// Handlers Web 适配器的 HTTP 处理器
+type Handlers struct {
+	Order     *OrderHandler
+	Cart      *CartHandler
+	Webhook   *WebhookHandler
+	Stream    *OrderStreamHandler
+	Intake    *MerchantIntakeHandler
+	Profile   *MerchantProfileHandler
+	Slots     *DeliverySlotHandler
+	Areas     *DeliveryAreaHandler
+	Addresses *AddressBookHandler
+	Inventory *InventoryHandler
+	Payment   *PaymentHandler
+}
+
+// RouteOption 路由注册可选配置
+type RouteOption func(*routeOptions)
+
+// routeOptions 路由注册配置
+type routeOptions struct {
+	validation       []RequestValidationOption
+	rateLimit        *RateLimitPolicy
+	preAuthRateLimit *RateLimitPolicy
+}
+
+// WithRequestValidation 配置请求体校验
+func WithRequestValidation(opts ...RequestValidationOption) RouteOption {
+	return func(o *routeOptions) {
+		o.validation = append(o.validation, opts...)
+	}
+}
+
+// WithRateLimit 配置限流策略（默认不限流）
+func WithRateLimit(policy *RateLimitPolicy) RouteOption {
+	return func(o *routeOptions) {
+		o.rateLimit = policy
+	}
+}
+
+// WithPreAuthRateLimit 配置在认证之前执行的限流策略（默认不限流）
+// 用于按 IP 等不依赖用户身份的规则，在校验 JWT 或支付签名之前拒绝过量请求
+func WithPreAuthRateLimit(policy *RateLimitPolicy) RouteOption {
+	return func(o *routeOptions) {
+		o.preAuthRateLimit = policy
+	}
+}
+
+// RegisterRoutes 注册 /api/v1 下的 REST 路由，请求先按 IP 限流、再认证，认证后按用户限流、再按 OpenAPI 文档校验请求体
+// 新增或修改路由时需同步更新 apiOperations，否则 OpenAPI 一致性测试会失败
+func RegisterRoutes(api *echo.Group, h Handlers, opts ...RouteOption) {
+	o := &routeOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	validate := ValidateRequestBody(o.validation...)
+	limit := RateLimit(o.rateLimit)
+	preLimit := RateLimit(o.preAuthRateLimit)
+
+	api.POST("/orders", h.Order.CreateOrder, preLimit, AuthMiddleware, limit, validate)
+	api.POST("/orders/quote", h.Order.QuoteOrder, preLimit, AuthMiddleware, limit, validate)
+	api.POST("/orders/:orderNumber/cancel", h.Order.CancelOrder, preLimit, AuthMiddleware, limit, validate)
+	api.POST("/orders/:orderNumber/refunds", h.Order.RefundOrder, preLimit, AuthMiddleware, limit, validate)
+	api.GET("/orders/:orderNumber/events", h.Stream.StreamOrderEvents, preLimit, AuthMiddleware, limit)
+
+	api.GET("/carts/:merchantId", h.Cart.GetCart, preLimit, AuthMiddleware, limit)
+	api.DELETE("/carts/:merchantId", h.Cart.ClearCart, preLimit, AuthMiddleware, limit)
+	api.POST("/carts/:merchantId/items", h.Cart.AddItem, preLimit, AuthMiddleware, limit, validate)
+	api.PATCH("/carts/:merchantId/items/:lineId", h.Cart.UpdateItem, preLimit, AuthMiddleware, limit, validate)
+	api.DELETE("/carts/:merchantId/items/:lineId", h.Cart.RemoveItem, preLimit, AuthMiddleware, limit)
+	api.POST("/carts/:merchantId/checkout", h.Cart.Checkout, preLimit, AuthMiddleware, limit, validate)
+
+	api.GET("/addresses", h.Addresses.ListAddresses, preLimit, AuthMiddleware, limit)
+	api.POST("/addresses", h.Addresses.CreateAddress, preLimit, AuthMiddleware, limit, validate)
+	api.GET("/addresses/:addressId", h.Addresses.GetAddress, preLimit, AuthMiddleware, limit)
+	api.PUT("/addresses/:addressId", h.Addresses.UpdateAddress, preLimit, AuthMiddleware, limit, validate)
+	api.DELETE("/addresses/:addressId", h.Addresses.DeleteAddress, preLimit, AuthMiddleware, limit)
+	api.POST("/addresses/:addressId/default", h.Addresses.SetDefault, preLimit, AuthMiddleware, limit)
+
+	api.GET("/merchants/online", h.Intake.ListOnlineMerchants, preLimit, AuthMiddleware, limit)
+	api.GET("/merchants/:merchantId/presence", h.Intake.GetPresence, preLimit, AuthMiddleware, limit)
+	api.GET("/merchants/:merchantId/profile", h.Profile.GetProfile, preLimit, AuthMiddleware, limit)
+	api.GET("/merchants/:merchantId/slots", h.Slots.ListSlots, preLimit, AuthMiddleware, limit)
+	api.GET("/merchants/:merchantId/delivery-area", h.Areas.GetArea, preLimit, AuthMiddleware, limit)
+	api.GET("/merchants/:merchantId/inventory", h.Inventory.GetInventory, preLimit, AuthMiddleware, limit)
+
+	api.POST("/payments/callback", h.Payment.PaymentCallback, preLimit, h.Payment.VerifySignature, limit, validate)
+
+	merchant := api.Group("/merchants/:merchantId", preLimit, AuthMiddleware, RequireMerchant, limit, validate)
+	merchant.GET("/intake", h.Intake.Connect)
+	merchant.PUT("/profile", h.Profile.UpdateProfile)
+	merchant.POST("/pause", h.Profile.Pause)
+	merchant.POST("/resume", h.Profile.Resume)
+	merchant.PUT("/delivery-area", h.Areas.UpdateArea)
+	merchant.DELETE("/delivery-area", h.Areas.DeleteArea)
+	merchant.PUT("/inventory", h.Inventory.UpdateInventory)
+	merchant.POST("/webhooks", h.Webhook.CreateWebhook)
+	merchant.GET("/webhooks", h.Webhook.ListWebhooks)
+	merchant.DELETE("/webhooks/:webhookId", h.Webhook.DeleteWebhook)
+	merchant.POST("/webhooks/:webhookId/enable", h.Webhook.EnableWebhook)
+	merchant.GET("/webhooks/:webhookId/deliveries", h.Webhook.ListDeliveries)
+}
+
+// RegisterDocsRoutes 注册 OpenAPI 文档和文档页面路由（无需认证）
+func RegisterDocsRoutes(api *echo.Group) {
+	api.GET("/openapi.json", ServeOpenAPISpec)
+	api.GET("/docs", ServeAPIDocs)
+}
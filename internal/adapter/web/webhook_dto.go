@@ -0,0 +1,61 @@
+package web
+
+// CreateWebhookRequest Web 层创建 Webhook 订阅请求
+type CreateWebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+	Secret     string   `json:"secret"`
+}
+
+// WebhookResponse Webhook 订阅响应
+type WebhookResponse struct {
+	Code    int          `json:"code"`
+	Message string       `json:"message"`
+	Data    *WebhookData `json:"data,omitempty"`
+}
+
+// WebhookListResponse Webhook 订阅列表响应
+type WebhookListResponse struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Data    []WebhookData `json:"data"`
+}
+
+// WebhookData Webhook 订阅数据（secret 仅在创建时返回）
+type WebhookData struct {
+	ID                  string   `json:"id"`
+	MerchantID          string   `json:"merchantId"`
+	URL                 string   `json:"url"`
+	EventTypes          []string `json:"eventTypes"`
+	Secret              string   `json:"secret,omitempty"`
+	Status              string   `json:"status"`
+	ConsecutiveFailures int      `json:"consecutiveFailures"`
+	DisabledReason      string   `json:"disabledReason,omitempty"`
+	CreatedAt           string   `json:"createdAt"`
+}
+
+// WebhookDeliveryListResponse Webhook 投递记录列表响应
+type WebhookDeliveryListResponse struct {
+	Code    int                   `json:"code"`
+	Message string                `json:"message"`
+	Data    []WebhookDeliveryData `json:"data"`
+}
+
+// WebhookDeliveryData Webhook 投递记录
+type WebhookDeliveryData struct {
+	ID            string               `json:"id"`
+	EventID       string               `json:"eventId"`
+	EventType     string               `json:"eventType"`
+	Status        string               `json:"status"`
+	Attempts      []WebhookAttemptData `json:"attempts"`
+	NextAttemptAt string               `json:"nextAttemptAt,omitempty"`
+	CreatedAt     string               `json:"createdAt"`
+}
+
+// WebhookAttemptData 投递尝试
+type WebhookAttemptData struct {
+	AttemptedAt string `json:"attemptedAt"`
+	StatusCode  int    `json:"statusCode,omitempty"`
+	Error       string `json:"error,omitempty"`
+	DurationMs  int64  `json:"durationMs"`
+}
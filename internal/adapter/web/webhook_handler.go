@@ -0,0 +1,155 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	"order-service/internal/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// defaultDeliveryLimit 投递记录默认查询条数
+const defaultDeliveryLimit = 50
+
+// WebhookHandler 商家 Webhook HTTP 处理器
+type WebhookHandler struct {
+	webhookService application.WebhookService
+}
+
+// NewWebhookHandler 创建 Webhook 处理器
+func NewWebhookHandler(webhookService application.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhook 创建 Webhook 订阅
+func (h *WebhookHandler) CreateWebhook(c echo.Context) error {
+	var webReq CreateWebhookRequest
+	if err := c.Bind(&webReq); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+	}
+
+	appReq := &application.CreateWebhookRequest{
+		URL:        webReq.URL,
+		EventTypes: webReq.EventTypes,
+		Secret:     webReq.Secret,
+	}
+	data, err := h.webhookService.CreateSubscription(c.Request().Context(), c.Param("merchantId"), appReq)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, WebhookResponse{
+		Code:    http.StatusCreated,
+		Message: "webhook created successfully",
+		Data:    convertToWebhookData(data),
+	})
+}
+
+// ListWebhooks 查询商家的 Webhook 订阅
+func (h *WebhookHandler) ListWebhooks(c echo.Context) error {
+	subscriptions, err := h.webhookService.ListSubscriptions(c.Request().Context(), c.Param("merchantId"))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	result := make([]WebhookData, len(subscriptions))
+	for i := range subscriptions {
+		result[i] = *convertToWebhookData(&subscriptions[i])
+	}
+	return c.JSON(http.StatusOK, WebhookListResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    result,
+	})
+}
+
+// DeleteWebhook 删除 Webhook 订阅
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) error {
+	if err := h.webhookService.DeleteSubscription(c.Request().Context(), c.Param("merchantId"), c.Param("webhookId")); err != nil {
+		return handleError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// EnableWebhook 重新启用被停用的 Webhook 订阅
+func (h *WebhookHandler) EnableWebhook(c echo.Context) error {
+	data, err := h.webhookService.EnableSubscription(c.Request().Context(), c.Param("merchantId"), c.Param("webhookId"))
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, WebhookResponse{
+		Code:    http.StatusOK,
+		Message: "webhook enabled successfully",
+		Data:    convertToWebhookData(data),
+	})
+}
+
+// ListDeliveries 查询 Webhook 投递记录（?limit=N，默认 50）
+func (h *WebhookHandler) ListDeliveries(c echo.Context) error {
+	limit := defaultDeliveryLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 || parsed > 500 {
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "limit must be between 1 and 500",
+				Field:   "limit",
+			})
+		}
+		limit = parsed
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.Request().Context(), c.Param("merchantId"), c.Param("webhookId"), limit)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	result := make([]WebhookDeliveryData, len(deliveries))
+	for i, delivery := range deliveries {
+		attempts := make([]WebhookAttemptData, len(delivery.Attempts))
+		for j, attempt := range delivery.Attempts {
+			attempts[j] = WebhookAttemptData{
+				AttemptedAt: attempt.AttemptedAt,
+				StatusCode:  attempt.StatusCode,
+				Error:       attempt.Error,
+				DurationMs:  attempt.DurationMs,
+			}
+		}
+		result[i] = WebhookDeliveryData{
+			ID:            delivery.ID,
+			EventID:       delivery.EventID,
+			EventType:     delivery.EventType,
+			Status:        delivery.Status,
+			Attempts:      attempts,
+			NextAttemptAt: delivery.NextAttemptAt,
+			CreatedAt:     delivery.CreatedAt,
+		}
+	}
+	return c.JSON(http.StatusOK, WebhookDeliveryListResponse{
+		Code:    http.StatusOK,
+		Message: "success",
+		Data:    result,
+	})
+}
+
+// convertToWebhookData 转换应用层订阅数据到 Web DTO
+func convertToWebhookData(data *application.WebhookSubscriptionData) *WebhookData {
+	return &WebhookData{
+		ID:                  data.ID,
+		MerchantID:          data.MerchantID,
+		URL:                 data.URL,
+		EventTypes:          data.EventTypes,
+		Secret:              data.Secret,
+		Status:              data.Status,
+		ConsecutiveFailures: data.ConsecutiveFailures,
+		DisabledReason:      data.DisabledReason,
+		CreatedAt:           data.CreatedAt,
+	}
+}
@@ -0,0 +1,165 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"order-service/internal/application"
+	"order-service/internal/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWebhookService 模拟 WebhookService
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) CreateSubscription(ctx context.Context, merchantID string, req *application.CreateWebhookRequest) (*application.WebhookSubscriptionData, error) {
+	args := m.Called(ctx, merchantID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.WebhookSubscriptionData), args.Error(1)
+}
+
+func (m *MockWebhookService) ListSubscriptions(ctx context.Context, merchantID string) ([]application.WebhookSubscriptionData, error) {
+	args := m.Called(ctx, merchantID)
+	return args.Get(0).([]application.WebhookSubscriptionData), args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteSubscription(ctx context.Context, merchantID, subscriptionID string) error {
+	args := m.Called(ctx, merchantID, subscriptionID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) EnableSubscription(ctx context.Context, merchantID, subscriptionID string) (*application.WebhookSubscriptionData, error) {
+	args := m.Called(ctx, merchantID, subscriptionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.WebhookSubscriptionData), args.Error(1)
+}
+
+func (m *MockWebhookService) ListDeliveries(ctx context.Context, merchantID, subscriptionID string, limit int) ([]application.WebhookDeliveryData, error) {
+	args := m.Called(ctx, merchantID, subscriptionID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]application.WebhookDeliveryData), args.Error(1)
+}
+
+func (m *MockWebhookService) HandleEvent(ctx context.Context, event domain.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func TestWebhookHandler_CreateWebhook_Success(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService)
+
+	body, _ := json.Marshal(CreateWebhookRequest{
+		URL:        "https://merchant.example.com/hooks",
+		EventTypes: []string{"order.created"},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/merchants/merchant_001/webhooks", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("merchantId")
+	c.SetParamValues("merchant_001")
+
+	mockService.On("CreateSubscription", mock.Anything, "merchant_001", &application.CreateWebhookRequest{
+		URL:        "https://merchant.example.com/hooks",
+		EventTypes: []string{"order.created"},
+	}).Return(&application.WebhookSubscriptionData{
+		ID:         "whk_001",
+		MerchantID: "merchant_001",
+		URL:        "https://merchant.example.com/hooks",
+		EventTypes: []string{"order.created"},
+		Secret:     "whsec_001",
+		Status:     "ACTIVE",
+	}, nil)
+
+	err := handler.CreateWebhook(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var response WebhookResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Equal(t, "whk_001", response.Data.ID)
+	assert.Equal(t, "whsec_001", response.Data.Secret)
+	mockService.AssertExpectations(t)
+}
+
+func TestWebhookHandler_ListDeliveries(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/merchants/merchant_001/webhooks/whk_001/deliveries?limit=20", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("merchantId", "webhookId")
+	c.SetParamValues("merchant_001", "whk_001")
+
+	mockService.On("ListDeliveries", mock.Anything, "merchant_001", "whk_001", 20).Return([]application.WebhookDeliveryData{
+		{
+			ID:        "whk_001:evt_001",
+			EventID:   "evt_001",
+			EventType: "order.created",
+			Status:    "SUCCEEDED",
+			Attempts:  []application.WebhookAttemptData{{StatusCode: 200, DurationMs: 12}},
+		},
+	}, nil)
+
+	err := handler.ListDeliveries(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var response WebhookDeliveryListResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, 200, response.Data[0].Attempts[0].StatusCode)
+}
+
+func TestWebhookHandler_ListDeliveries_InvalidLimit(t *testing.T) {
+	e := echo.New()
+	handler := NewWebhookHandler(new(MockWebhookService))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/merchants/merchant_001/webhooks/whk_001/deliveries?limit=abc", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := handler.ListDeliveries(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebhookHandler_DeleteWebhook_NotFound(t *testing.T) {
+	e := echo.New()
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/merchants/merchant_001/webhooks/whk_999", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("merchantId", "webhookId")
+	c.SetParamValues("merchant_001", "whk_999")
+
+	mockService.On("DeleteSubscription", mock.Anything, "merchant_001", "whk_999").
+		Return(application.NewNotFoundError("webhook whk_999 not found"))
+
+	err := handler.DeleteWebhook(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,126 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"order-service/internal/application"
+)
+
+// Webhook 请求头
+const (
+	HeaderWebhookID        = "X-Webhook-Id"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookEventID   = "X-Webhook-Event-Id"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+
+	// signatureVersion 签名版本前缀
+	signatureVersion = "v1"
+	// DefaultTimeout 单次投递超时时间
+	DefaultTimeout = 10 * time.Second
+)
+
+// HTTPSender 基于 HTTP 的 Webhook 发送器
+// 以 POST 发送 JSON 负载，签名为 HMAC-SHA256(secret, "{timestamp}.{body}")
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender 创建 HTTP Webhook 发送器
+// client 为空时使用只连接公网地址、不跟随重定向的客户端；自定义 client 时应使用 NewPublicTransport 和 NoRedirect
+func NewHTTPSender(client *http.Client) *HTTPSender {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout, Transport: NewPublicTransport(), CheckRedirect: NoRedirect}
+	}
+	return &HTTPSender{client: client}
+}
+
+// NewPublicTransport 创建只连接公网地址的 Transport
+// 在 DNS 解析后、建立连接前检查目标地址（见 application.IsPublicAddr），防止 SSRF 和 DNS 重绑定；
+// 不使用环境变量中的代理，否则检查的是代理地址
+func NewPublicTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   allowPublicAddr,
+	}).DialContext
+	return transport
+}
+
+// allowPublicAddr 拒绝连接非公网地址
+func allowPublicAddr(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid webhook address %q: %w", address, err)
+	}
+	if !application.IsPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("webhook address %s is not a public address", addrPort.Addr())
+	}
+	return nil
+}
+
+// NoRedirect 不跟随重定向（3xx 响应视为投递失败），避免订阅地址被重定向到其他地址
+func NoRedirect(req *http.Request, via []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
+// Send 发送 Webhook，非 2xx 响应视为失败
+func (s *HTTPSender) Send(ctx context.Context, req *application.WebhookRequest) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build webhook request: %w", err)
+	}
+
+	timestamp := req.Timestamp.Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "order-service-webhook/1.0")
+	httpReq.Header.Set(HeaderWebhookID, req.DeliveryID)
+	httpReq.Header.Set(HeaderWebhookEvent, req.EventType)
+	httpReq.Header.Set(HeaderWebhookEventID, req.EventID)
+	httpReq.Header.Set(HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderWebhookSignature, Sign(req.Secret, timestamp, req.Payload))
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign 计算 Webhook 签名（格式：v1=hex(HMAC-SHA256(secret, "{timestamp}.{body}"))）
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 校验 Webhook 签名，并拒绝超出容忍时间的请求（防重放）
+func Verify(secret string, timestamp int64, body []byte, signature string, tolerance time.Duration, now time.Time) bool {
+	age := now.Sub(time.Unix(timestamp, 0))
+	if age > tolerance || age < -tolerance {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,234 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"order-service/internal/adapter/persistence"
+	"order-service/internal/application"
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receivedWebhook 测试服务器收到的 Webhook
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+// merchantWebhookURL 端到端测试中商家订阅的地址（由 merchantClient 连接到测试服务器）
+const merchantWebhookURL = "https://merchant.example.com/hooks"
+
+// newMerchantServer 创建模拟商家的 HTTPS Webhook 接收服务器（按顺序返回预设状态码）
+func newMerchantServer(t *testing.T, statusCodes ...int) (*httptest.Server, func() []receivedWebhook) {
+	var mu sync.Mutex
+	var received []receivedWebhook
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, receivedWebhook{header: r.Header.Clone(), body: body})
+
+		status := http.StatusOK
+		if len(statusCodes) > 0 {
+			status = statusCodes[0]
+			statusCodes = statusCodes[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []receivedWebhook {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]receivedWebhook(nil), received...)
+	}
+}
+
+// merchantClient 信任测试服务器证书、把所有请求连接到测试服务器的客户端（模拟商家域名解析到该服务器）
+func merchantClient(server *httptest.Server) *http.Client {
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	return &http.Client{Transport: transport}
+}
+
+func TestHTTPSender_Send_SignsPayload(t *testing.T) {
+	// Arrange
+	server, received := newMerchantServer(t)
+	sender := NewHTTPSender(server.Client())
+	timestamp := time.Now()
+	payload := []byte(`{"id":"evt_001","type":"order.created"}`)
+
+	// Act
+	status, err := sender.Send(context.Background(), &application.WebhookRequest{
+		DeliveryID: "whk_001:evt_001",
+		URL:        server.URL,
+		Secret:     "merchant-secret-0001",
+		EventID:    "evt_001",
+		EventType:  domain.EventTypeOrderCreated,
+		Payload:    payload,
+		Timestamp:  timestamp,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	require.Len(t, received(), 1)
+
+	got := received()[0]
+	assert.Equal(t, payload, got.body)
+	assert.Equal(t, "application/json", got.header.Get("Content-Type"))
+	assert.Equal(t, "whk_001:evt_001", got.header.Get(HeaderWebhookID))
+	assert.Equal(t, domain.EventTypeOrderCreated, got.header.Get(HeaderWebhookEvent))
+	assert.Equal(t, "evt_001", got.header.Get(HeaderWebhookEventID))
+
+	ts, err := strconv.ParseInt(got.header.Get(HeaderWebhookTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, timestamp.Unix(), ts)
+	assert.True(t, Verify("merchant-secret-0001", ts, got.body, got.header.Get(HeaderWebhookSignature), 5*time.Minute, time.Now()))
+	assert.False(t, Verify("wrong-secret", ts, got.body, got.header.Get(HeaderWebhookSignature), 5*time.Minute, time.Now()))
+}
+
+func TestHTTPSender_Send_Non2xxIsFailure(t *testing.T) {
+	server, _ := newMerchantServer(t, http.StatusServiceUnavailable)
+
+	status, err := NewHTTPSender(server.Client()).Send(context.Background(), &application.WebhookRequest{
+		URL:       server.URL,
+		Timestamp: time.Now(),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
+
+func TestHTTPSender_Send_RefusesNonPublicAddress(t *testing.T) {
+	// Arrange - 测试服务监听在回环地址，域名 localhost 在建立连接时才解析为回环地址
+	server, received := newMerchantServer(t)
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	sender := NewHTTPSender(nil)
+
+	for _, url := range []string{server.URL, "https://localhost:" + port} {
+		// Act
+		status, err := sender.Send(context.Background(), &application.WebhookRequest{URL: url, Timestamp: time.Now()})
+
+		// Assert - 建立连接前拒绝，请求不会到达内网服务
+		assert.ErrorContains(t, err, "is not a public address")
+		assert.Zero(t, status)
+	}
+	assert.Empty(t, received())
+}
+
+func TestHTTPSender_Send_DoesNotFollowRedirects(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.RedirectHandler("http://169.254.169.254/latest/meta-data", http.StatusFound))
+	t.Cleanup(server.Close)
+	client := server.Client()
+	client.CheckRedirect = NoRedirect
+
+	// Act
+	status, err := NewHTTPSender(client).Send(context.Background(), &application.WebhookRequest{URL: server.URL, Timestamp: time.Now()})
+
+	// Assert - 3xx 视为投递失败
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusFound, status)
+}
+
+func TestVerify_RejectsStaleTimestamp(t *testing.T) {
+	body := []byte(`{}`)
+	old := time.Now().Add(-10 * time.Minute).Unix()
+
+	signature := Sign("secret", old, body)
+
+	assert.False(t, Verify("secret", old, body, signature, 5*time.Minute, time.Now()))
+}
+
+func TestWebhookDelivery_EndToEnd(t *testing.T) {
+	// Arrange - 商家服务器先失败一次后恢复
+	server, received := newMerchantServer(t, http.StatusInternalServerError, http.StatusOK)
+	repo := persistence.NewInMemoryWebhookRepository()
+	service := application.NewWebhookService(repo)
+	ctx := context.Background()
+
+	subscription, err := service.CreateSubscription(ctx, "merchant_001", &application.CreateWebhookRequest{
+		URL:        merchantWebhookURL,
+		EventTypes: []string{domain.EventTypeOrderCreated},
+	})
+	require.NoError(t, err)
+
+	order := domain.NewOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "")
+	require.NoError(t, service.HandleEvent(ctx, order.PendingEvents()[0]))
+
+	now := time.Now()
+	clock := func() time.Time { return now }
+	dispatcher := application.NewWebhookDispatcher(repo, NewHTTPSender(merchantClient(server)),
+		application.WithWebhookClock(clock), application.WithWebhookBackoff(time.Second, time.Minute))
+
+	// Act
+	_, err = dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+	now = now.Add(time.Second)
+	succeeded, err := dispatcher.DispatchOnce(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, succeeded)
+	require.Len(t, received(), 2)
+	got := received()[1]
+	ts, _ := strconv.ParseInt(got.header.Get(HeaderWebhookTimestamp), 10, 64)
+	assert.True(t, Verify(subscription.Secret, ts, got.body, got.header.Get(HeaderWebhookSignature), time.Minute, now))
+
+	deliveries, err := service.ListDeliveries(ctx, "merchant_001", subscription.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "SUCCEEDED", deliveries[0].Status)
+	assert.Len(t, deliveries[0].Attempts, 2)
+}
+
+func TestWebhookDelivery_EndToEnd_AutoDisable(t *testing.T) {
+	// Arrange - 商家服务器持续不可用
+	server, received := newMerchantServer(t, 500, 500, 500, 500, 500)
+	repo := persistence.NewInMemoryWebhookRepository()
+	service := application.NewWebhookService(repo)
+	ctx := context.Background()
+
+	subscription, err := service.CreateSubscription(ctx, "merchant_001", &application.CreateWebhookRequest{
+		URL:        merchantWebhookURL,
+		EventTypes: []string{"*"},
+	})
+	require.NoError(t, err)
+
+	order := domain.NewOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "")
+	require.NoError(t, service.HandleEvent(ctx, order.PendingEvents()[0]))
+
+	now := time.Now()
+	dispatcher := application.NewWebhookDispatcher(repo, NewHTTPSender(merchantClient(server)),
+		application.WithWebhookClock(func() time.Time { return now }),
+		application.WithWebhookDisableThreshold(3),
+		application.WithWebhookBackoff(time.Second, time.Second))
+
+	// Act
+	for i := 0; i < 5; i++ {
+		_, _ = dispatcher.DispatchOnce(ctx)
+		now = now.Add(time.Second)
+	}
+
+	// Assert
+	assert.Len(t, received(), 3)
+	list, err := service.ListSubscriptions(ctx, "merchant_001")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, subscription.ID, list[0].ID)
+	assert.Equal(t, "DISABLED", list[0].Status)
+}
@@ -0,0 +1,44 @@
+package application
+
+import (
+	"context"
+
+	"order-service/internal/domain"
+)
+
+// AddressBookService 定义用户地址簿接口（输入端口）
+// 地址簿不为空时有且只有一个默认地址，下单时可以用 AddressID 引用地址簿中的地址
+type AddressBookService interface {
+	// ListAddresses 查询地址簿（默认地址在前，其余按添加顺序）
+	ListAddresses(ctx context.Context, userID uint64) ([]AddressData, error)
+	GetAddress(ctx context.Context, userID uint64, addressID string) (*AddressData, error)
+	CreateAddress(ctx context.Context, userID uint64, req *SaveAddressRequest) (*AddressData, error)
+	// UpdateAddress 整体替换地址内容（IsDefault 为 false 时不改变默认地址）
+	UpdateAddress(ctx context.Context, userID uint64, addressID string, req *SaveAddressRequest) (*AddressData, error)
+	DeleteAddress(ctx context.Context, userID uint64, addressID string) error
+	SetDefault(ctx context.Context, userID uint64, addressID string) (*AddressData, error)
+}
+
+// AddressBookRepository 定义地址簿持久化接口（输出端口）
+// 用户没有地址簿时 Find 返回 NotFoundError
+type AddressBookRepository interface {
+	Find(ctx context.Context, userID uint64) (*domain.AddressBook, error)
+	Save(ctx context.Context, book *domain.AddressBook) error
+}
+
+// SaveAddressRequest 保存地址请求（收件人、电话、地址和坐标的校验与下单的配送信息相同，Label 为空时为 OTHER）
+type SaveAddressRequest struct {
+	DeliveryInfoRequest
+	Label     string `validate:"omitempty,oneof=HOME OFFICE OTHER"`
+	IsDefault bool
+}
+
+// AddressData 地址簿地址数据
+type AddressData struct {
+	AddressID    string
+	Label        string
+	DeliveryInfo DeliveryInfoData
+	IsDefault    bool
+	CreatedAt    string
+	UpdatedAt    string
+}
@@ -0,0 +1,199 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"order-service/internal/domain"
+)
+
+// addressBookService 地址簿应用服务实现
+type addressBookService struct {
+	repo AddressBookRepository
+	now  func() time.Time
+}
+
+// AddressBookOption 地址簿服务可选配置
+type AddressBookOption func(*addressBookService)
+
+// WithAddressBookClock 配置时钟（测试使用）
+func WithAddressBookClock(now func() time.Time) AddressBookOption {
+	return func(s *addressBookService) {
+		s.now = now
+	}
+}
+
+// NewAddressBookService 创建地址簿服务实例
+func NewAddressBookService(repo AddressBookRepository, opts ...AddressBookOption) AddressBookService {
+	s := &addressBookService{repo: repo, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ListAddresses 实现 AddressBookService 接口
+func (s *addressBookService) ListAddresses(ctx context.Context, userID uint64) ([]AddressData, error) {
+	book, err := loadAddressBook(ctx, s.repo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := slices.Clone(book.Addresses)
+	slices.SortStableFunc(addresses, func(a, b domain.SavedAddress) int {
+		return cmp.Compare(boolRank(a.IsDefault), boolRank(b.IsDefault))
+	})
+	result := make([]AddressData, len(addresses))
+	for i := range addresses {
+		result[i] = *convertToAddressDTO(&addresses[i])
+	}
+	return result, nil
+}
+
+// GetAddress 实现 AddressBookService 接口
+func (s *addressBookService) GetAddress(ctx context.Context, userID uint64, addressID string) (*AddressData, error) {
+	book, err := loadAddressBook(ctx, s.repo, userID)
+	if err != nil {
+		return nil, err
+	}
+	address, err := book.Find(addressID)
+	if err != nil {
+		return nil, toAddressError(err)
+	}
+	return convertToAddressDTO(address), nil
+}
+
+// CreateAddress 实现 AddressBookService 接口
+func (s *addressBookService) CreateAddress(ctx context.Context, userID uint64, req *SaveAddressRequest) (*AddressData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	book, err := loadAddressBook(ctx, s.repo, userID)
+	if err != nil {
+		return nil, err
+	}
+	address, err := book.Add(addressLabel(req.Label), toDeliveryInfo(req.DeliveryInfoRequest), req.IsDefault, s.now())
+	if err != nil {
+		return nil, toApplicationError(err)
+	}
+	return s.save(ctx, book, address)
+}
+
+// UpdateAddress 实现 AddressBookService 接口
+func (s *addressBookService) UpdateAddress(ctx context.Context, userID uint64, addressID string, req *SaveAddressRequest) (*AddressData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	book, err := loadAddressBook(ctx, s.repo, userID)
+	if err != nil {
+		return nil, err
+	}
+	address, err := book.Update(addressID, addressLabel(req.Label), toDeliveryInfo(req.DeliveryInfoRequest), req.IsDefault, s.now())
+	if err != nil {
+		return nil, toAddressError(err)
+	}
+	return s.save(ctx, book, address)
+}
+
+// DeleteAddress 实现 AddressBookService 接口
+func (s *addressBookService) DeleteAddress(ctx context.Context, userID uint64, addressID string) error {
+	book, err := loadAddressBook(ctx, s.repo, userID)
+	if err != nil {
+		return err
+	}
+	if err := book.Remove(addressID, s.now()); err != nil {
+		return toAddressError(err)
+	}
+	if err := s.repo.Save(ctx, book); err != nil {
+		return NewInternalError("failed to save address book", err)
+	}
+	return nil
+}
+
+// SetDefault 实现 AddressBookService 接口
+func (s *addressBookService) SetDefault(ctx context.Context, userID uint64, addressID string) (*AddressData, error) {
+	book, err := loadAddressBook(ctx, s.repo, userID)
+	if err != nil {
+		return nil, err
+	}
+	address, err := book.SetDefault(addressID, s.now())
+	if err != nil {
+		return nil, toAddressError(err)
+	}
+	return s.save(ctx, book, address)
+}
+
+// save 保存地址簿并返回地址 DTO
+func (s *addressBookService) save(ctx context.Context, book *domain.AddressBook, address *domain.SavedAddress) (*AddressData, error) {
+	if err := s.repo.Save(ctx, book); err != nil {
+		return nil, NewInternalError("failed to save address book", err)
+	}
+	return convertToAddressDTO(address), nil
+}
+
+// loadAddressBook 加载用户地址簿，不存在时返回新的空地址簿（尚未保存）
+func loadAddressBook(ctx context.Context, repo AddressBookRepository, userID uint64) (*domain.AddressBook, error) {
+	book, err := repo.Find(ctx, userID)
+	if err == nil {
+		return book, nil
+	}
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		return nil, NewInternalError("failed to find address book", err)
+	}
+	return domain.NewAddressBook(userID), nil
+}
+
+// toAddressError 将地址簿领域错误转换为应用层错误（地址不存在时为 NotFoundError）
+func toAddressError(err error) error {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) && errors.Is(err, domain.ErrAddressNotFound) {
+		return NewNotFoundError(domainErr.Message)
+	}
+	return toApplicationError(err)
+}
+
+// addressLabel 转换已校验的地址标签（为空时为 OTHER）
+func addressLabel(label string) domain.AddressLabel {
+	if label == "" {
+		return domain.AddressLabelOther
+	}
+	return domain.AddressLabel(label)
+}
+
+// boolRank 排序时 true 在前
+func boolRank(b bool) int {
+	if b {
+		return 0
+	}
+	return 1
+}
+
+// toDeliveryInfoRequest 将保存的配送信息转换为下单使用的配送信息请求
+func toDeliveryInfoRequest(delivery domain.DeliveryInfo) DeliveryInfoRequest {
+	req := DeliveryInfoRequest{
+		RecipientName:  delivery.RecipientName,
+		RecipientPhone: delivery.RecipientPhone,
+		Address:        delivery.Address,
+	}
+	if delivery.Location != nil {
+		latitude, longitude := delivery.Location.Latitude, delivery.Location.Longitude
+		req.Location = &GeoPointRequest{Latitude: &latitude, Longitude: &longitude}
+	}
+	return req
+}
+
+// convertToAddressDTO 转换地址到 DTO
+func convertToAddressDTO(address *domain.SavedAddress) *AddressData {
+	return &AddressData{
+		AddressID:    address.AddressID,
+		Label:        string(address.Label),
+		DeliveryInfo: toDeliveryInfoData(address.Delivery),
+		IsDefault:    address.IsDefault,
+		CreatedAt:    address.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    address.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,242 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAddressBookRepository 模拟地址簿仓储（保存副本，模拟持久化）
+type MockAddressBookRepository struct {
+	books map[uint64]domain.AddressBook
+}
+
+func NewMockAddressBookRepository() *MockAddressBookRepository {
+	return &MockAddressBookRepository{books: make(map[uint64]domain.AddressBook)}
+}
+
+func (m *MockAddressBookRepository) Find(ctx context.Context, userID uint64) (*domain.AddressBook, error) {
+	book, ok := m.books[userID]
+	if !ok {
+		return nil, NewNotFoundError("address book not found")
+	}
+	book.Addresses = append([]domain.SavedAddress(nil), book.Addresses...)
+	return &book, nil
+}
+
+func (m *MockAddressBookRepository) Save(ctx context.Context, book *domain.AddressBook) error {
+	saved := *book
+	saved.Addresses = append([]domain.SavedAddress(nil), book.Addresses...)
+	m.books[book.UserID] = saved
+	return nil
+}
+
+// newSaveAddressRequest 创建保存地址请求
+func newSaveAddressRequest(label, address string) *SaveAddressRequest {
+	return &SaveAddressRequest{
+		DeliveryInfoRequest: DeliveryInfoRequest{
+			RecipientName:  "张三",
+			RecipientPhone: "13800138000",
+			Address:        address,
+		},
+		Label: label,
+	}
+}
+
+func TestAddressBookService_CreateAndList(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 6, 3, 11, 0, 0, 0, time.UTC)
+	service := NewAddressBookService(NewMockAddressBookRepository(), WithAddressBookClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	// Act
+	empty, emptyErr := service.ListAddresses(ctx, 1001)
+	home, err := service.CreateAddress(ctx, 1001, newSaveAddressRequest("HOME", "北京市朝阳区xxx"))
+	require.NoError(t, err)
+	office := newSaveAddressRequest("", "北京市海淀区xxx")
+	office.IsDefault = true
+	_, err = service.CreateAddress(ctx, 1001, office)
+	require.NoError(t, err)
+	addresses, err := service.ListAddresses(ctx, 1001)
+	require.NoError(t, err)
+
+	// Assert - 第一个地址自动成为默认地址，列表中默认地址在前
+	require.NoError(t, emptyErr)
+	assert.Empty(t, empty)
+	assert.Equal(t, "A1", home.AddressID)
+	assert.True(t, home.IsDefault)
+	assert.Equal(t, "2025-06-03T11:00:00Z", home.CreatedAt)
+	require.Len(t, addresses, 2)
+	assert.Equal(t, "A2", addresses[0].AddressID)
+	assert.Equal(t, "OTHER", addresses[0].Label)
+	assert.True(t, addresses[0].IsDefault)
+	assert.False(t, addresses[1].IsDefault)
+}
+
+func TestAddressBookService_CreateAddress_ValidationError(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(req *SaveAddressRequest)
+		wantField string
+	}{
+		{"手机号格式错误", func(req *SaveAddressRequest) { req.RecipientPhone = "123" }, "RecipientPhone"},
+		{"缺少地址", func(req *SaveAddressRequest) { req.Address = "" }, "Address"},
+		{"坐标超出范围", func(req *SaveAddressRequest) { req.Location = geoPoint(91, 116.40) }, "Latitude"},
+		{"未知标签", func(req *SaveAddressRequest) { req.Label = "SCHOOL" }, "Label"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service := NewAddressBookService(NewMockAddressBookRepository())
+			req := newSaveAddressRequest("HOME", "北京市朝阳区xxx")
+			tt.modify(req)
+
+			// Act
+			address, err := service.CreateAddress(context.Background(), 1001, req)
+
+			// Assert
+			assert.Nil(t, address)
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantField, validationErr.Field)
+		})
+	}
+}
+
+func TestAddressBookService_UpdateSetDefaultAndDelete(t *testing.T) {
+	// Arrange
+	service := NewAddressBookService(NewMockAddressBookRepository())
+	ctx := context.Background()
+	_, err := service.CreateAddress(ctx, 1001, newSaveAddressRequest("HOME", "北京市朝阳区xxx"))
+	require.NoError(t, err)
+	_, err = service.CreateAddress(ctx, 1001, newSaveAddressRequest("OFFICE", "北京市海淀区xxx"))
+	require.NoError(t, err)
+
+	// Act
+	updated, updateErr := service.UpdateAddress(ctx, 1001, "A2", newSaveAddressRequest("OFFICE", "北京市西城区xxx"))
+	defaulted, defaultErr := service.SetDefault(ctx, 1001, "A2")
+	deleteErr := service.DeleteAddress(ctx, 1001, "A2")
+	remaining, getErr := service.GetAddress(ctx, 1001, "A1")
+	_, missingErr := service.GetAddress(ctx, 1001, "A2")
+	deleteAgain := service.DeleteAddress(ctx, 1001, "A2")
+
+	// Assert - 删除默认地址后剩余地址成为默认地址
+	require.NoError(t, updateErr)
+	require.NoError(t, defaultErr)
+	require.NoError(t, deleteErr)
+	require.NoError(t, getErr)
+	assert.Equal(t, "北京市西城区xxx", updated.DeliveryInfo.Address)
+	assert.False(t, updated.IsDefault)
+	assert.True(t, defaulted.IsDefault)
+	assert.True(t, remaining.IsDefault)
+	assert.IsType(t, &NotFoundError{}, missingErr)
+	assert.IsType(t, &NotFoundError{}, deleteAgain)
+}
+
+// newAddressBookOrderService 创建配置了地址簿的应用服务，用户 1001 的地址簿中有地址 A1
+func newAddressBookOrderService(t *testing.T, opts ...ServiceOption) (OrderService, AddressBookService) {
+	book := NewMockAddressBookRepository()
+	addresses := NewAddressBookService(book)
+	req := newSaveAddressRequest("HOME", "北京市朝阳区xxx")
+	req.Location = geoPoint(39.905, 116.40)
+	_, err := addresses.CreateAddress(context.Background(), 1001, req)
+	require.NoError(t, err)
+	return NewOrderService(NewMockOrderRepository(), append(opts, WithAddressBook(book))...), addresses
+}
+
+func TestOrderService_CreateOrder_WithSavedAddress(t *testing.T) {
+	// Arrange
+	service, addresses := newAddressBookOrderService(t)
+	req := newOptionsOrderRequest(OrderItemRequest{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00})
+	req.DeliveryInfo = DeliveryInfoRequest{}
+	req.AddressID = "A1"
+
+	// Act
+	orderData, err := service.CreateOrder(context.Background(), 1001, req)
+	require.NoError(t, err)
+	_, updateErr := addresses.UpdateAddress(context.Background(), 1001, "A1", newSaveAddressRequest("HOME", "北京市海淀区xxx"))
+	require.NoError(t, updateErr)
+	found, err := service.GetOrder(context.Background(), 1001, orderData.OrderNumber)
+	require.NoError(t, err)
+
+	// Assert - 订单保存下单时的地址副本，之后修改地址不影响订单
+	assert.Equal(t, "北京市朝阳区xxx", orderData.DeliveryInfo.Address)
+	assert.Equal(t, &GeoPointData{Latitude: 39.905, Longitude: 116.40}, orderData.DeliveryInfo.Location)
+	assert.Equal(t, "北京市朝阳区xxx", found.DeliveryInfo.Address)
+}
+
+func TestOrderService_CreateOrder_WithSavedAddress_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    uint64
+		addressID string
+		keepInfo  bool
+		noBook    bool
+		wantCode  *domain.DomainError
+		wantField string
+	}{
+		{name: "地址不存在", userID: 1001, addressID: "A9", wantCode: domain.ErrAddressNotFound},
+		{name: "其他用户的地址", userID: 1002, addressID: "A1", wantCode: domain.ErrAddressNotFound},
+		{name: "同时提供配送信息", userID: 1001, addressID: "A1", keepInfo: true, wantField: "AddressID"},
+		{name: "未配置地址簿", userID: 1001, addressID: "A1", noBook: true, wantField: "AddressID"},
+		{name: "未提供地址和配送信息", userID: 1001, wantField: "DeliveryInfo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service, _ := newAddressBookOrderService(t)
+			if tt.noBook {
+				service = NewOrderService(NewMockOrderRepository())
+			}
+			req := newOptionsOrderRequest(OrderItemRequest{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00})
+			if !tt.keepInfo {
+				req.DeliveryInfo = DeliveryInfoRequest{}
+			}
+			req.AddressID = tt.addressID
+
+			// Act
+			orderData, err := service.CreateOrder(context.Background(), tt.userID, req)
+
+			// Assert
+			assert.Nil(t, orderData)
+			if tt.wantCode != nil {
+				assertBusinessCode(t, err, tt.wantCode)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantField, validationErr.Field)
+		})
+	}
+}
+
+func TestOrderService_QuoteOrder_WithSavedAddress(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	areas := NewMockDeliveryAreaRepository()
+	_, err := NewDeliveryAreaService(areas).UpdateArea(context.Background(), "merchant_001", newDeliveryAreaRequest())
+	require.NoError(t, err)
+	service, _ := newAddressBookOrderService(t, WithDeliveryAreas(areas),
+		WithQuoteSigner(NewQuoteSigner([]byte("test-secret"), WithQuoteClock(func() time.Time { return now }))))
+	req := newOptionsOrderRequest(OrderItemRequest{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00})
+	req.DeliveryInfo = DeliveryInfoRequest{}
+	req.AddressID = "A1"
+
+	// Act
+	quote, err := service.QuoteOrder(context.Background(), 1001, req)
+	require.NoError(t, err)
+	req.QuoteToken = quote.QuoteToken
+	orderData, err := service.CreateOrder(context.Background(), 1001, req)
+
+	// Assert - 按地址簿地址的坐标计算距离配送费，报价绑定该坐标
+	require.NoError(t, err)
+	assert.Equal(t, "3.00", quote.Pricing.DeliveryFee)
+	assert.Equal(t, quote.Pricing, orderData.Pricing)
+}
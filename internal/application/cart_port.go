@@ -0,0 +1,57 @@
+package application
+
+import (
+	"context"
+
+	"order-service/internal/domain"
+)
+
+// CartService 定义购物车接口（输入端口）
+// 每个用户在每个商家下一个购物车，价格按与下单相同的规则实时计算
+type CartService interface {
+	GetCart(ctx context.Context, userID uint64, merchantID string) (*CartData, error)
+	AddItem(ctx context.Context, userID uint64, merchantID string, req *OrderItemRequest) (*CartData, error)
+	UpdateItem(ctx context.Context, userID uint64, merchantID, lineID string, req *UpdateCartItemRequest) (*CartData, error)
+	RemoveItem(ctx context.Context, userID uint64, merchantID, lineID string) (*CartData, error)
+	ClearCart(ctx context.Context, userID uint64, merchantID string) error
+	// Checkout 将购物车转换为订单，下单成功后清空购物车
+	Checkout(ctx context.Context, userID uint64, merchantID string, req *CheckoutRequest) (*OrderData, error)
+}
+
+// CartRepository 定义购物车持久化接口（输出端口）
+// 购物车不存在或已过期时 Find 返回 NotFoundError，过期购物车由存储实现清理
+type CartRepository interface {
+	Find(ctx context.Context, userID uint64, merchantID string) (*domain.Cart, error)
+	Save(ctx context.Context, cart *domain.Cart) error
+	Delete(ctx context.Context, userID uint64, merchantID string) error
+}
+
+// UpdateCartItemRequest 修改购物车行数量请求
+type UpdateCartItemRequest struct {
+	Quantity int `validate:"required,gt=0"`
+}
+
+// CheckoutRequest 购物车结算请求（订单项取自购物车）
+type CheckoutRequest struct {
+	DeliveryInfo DeliveryInfoRequest `validate:"required_without=AddressID"` // 与 AddressID 二选一
+	AddressID    string              `validate:"omitempty,max=32"`           // 地址簿中的地址ID
+	Remark       string              `validate:"omitempty,max=200"`
+	ScheduledFor string              `validate:"omitempty,rfc3339"` // 预订配送时段的开始时间，为空表示立即配送
+}
+
+// CartData 购物车数据（Pricing 为按当前菜单计算的价格预览，不含不可下单的行）
+type CartData struct {
+	MerchantID string
+	Items      []CartItemData
+	Pricing    PricingInfo
+	UpdatedAt  string
+	ExpiresAt  string
+}
+
+// CartItemData 购物车行数据
+// 菜单变更导致该行无法下单时 Unavailable 为对应的业务错误码，Item 只包含购物车中保存的餐品、数量和单价
+type CartItemData struct {
+	LineID      string
+	Item        OrderItemData
+	Unavailable string
+}
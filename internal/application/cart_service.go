@@ -0,0 +1,270 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"order-service/internal/domain"
+	"order-service/internal/logging"
+)
+
+// DefaultCartTTL 购物车默认有效期（每次修改后顺延）
+const DefaultCartTTL = 72 * time.Hour
+
+// cartService 购物车应用服务实现
+type cartService struct {
+	repo   CartRepository
+	orders OrderService
+	menu   menuResolver
+	fees   domain.Fees
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// CartOption 购物车服务可选配置
+type CartOption func(*cartService)
+
+// WithCartMenuCatalog 配置商家菜单（应与订单服务使用同一菜单，保证预览价格与下单一致）
+func WithCartMenuCatalog(catalog MenuCatalog) CartOption {
+	return func(s *cartService) {
+		s.menu = menuResolver{catalog: catalog}
+	}
+}
+
+// WithCartFees 配置价格预览使用的固定费用（应与订单服务一致）
+func WithCartFees(fees domain.Fees) CartOption {
+	return func(s *cartService) {
+		s.fees = fees
+	}
+}
+
+// WithCartTTL 配置购物车有效期
+func WithCartTTL(ttl time.Duration) CartOption {
+	return func(s *cartService) {
+		s.ttl = ttl
+	}
+}
+
+// WithCartClock 配置时钟（测试使用）
+func WithCartClock(now func() time.Time) CartOption {
+	return func(s *cartService) {
+		s.now = now
+	}
+}
+
+// NewCartService 创建购物车服务实例，结算时通过 orders 下单
+func NewCartService(repo CartRepository, orders OrderService, opts ...CartOption) CartService {
+	s := &cartService{
+		repo:   repo,
+		orders: orders,
+		fees:   domain.DefaultFees(),
+		ttl:    DefaultCartTTL,
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetCart 实现 CartService 接口（购物车不存在时返回空购物车）
+func (s *cartService) GetCart(ctx context.Context, userID uint64, merchantID string) (*CartData, error) {
+	cart, err := s.loadCart(ctx, userID, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	return s.preview(ctx, cart)
+}
+
+// AddItem 实现 CartService 接口（加入前按商家菜单校验规格和套餐组件）
+func (s *cartService) AddItem(ctx context.Context, userID uint64, merchantID string, req *OrderItemRequest) (*CartData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	resolved, err := s.menu.resolveItems(ctx, merchantID, []OrderItemRequest{*req})
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.loadCart(ctx, userID, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := cart.AddItem(toCartItem(req, resolved[0])); err != nil {
+		return nil, toApplicationError(err)
+	}
+	return s.saveCart(ctx, cart)
+}
+
+// UpdateItem 实现 CartService 接口
+func (s *cartService) UpdateItem(ctx context.Context, userID uint64, merchantID, lineID string, req *UpdateCartItemRequest) (*CartData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	cart, err := s.loadCart(ctx, userID, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := cart.UpdateItemQuantity(lineID, req.Quantity); err != nil {
+		return nil, toApplicationError(err)
+	}
+	return s.saveCart(ctx, cart)
+}
+
+// RemoveItem 实现 CartService 接口
+func (s *cartService) RemoveItem(ctx context.Context, userID uint64, merchantID, lineID string) (*CartData, error) {
+	cart, err := s.loadCart(ctx, userID, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := cart.RemoveItem(lineID); err != nil {
+		return nil, toApplicationError(err)
+	}
+	return s.saveCart(ctx, cart)
+}
+
+// ClearCart 实现 CartService 接口
+func (s *cartService) ClearCart(ctx context.Context, userID uint64, merchantID string) error {
+	if err := s.repo.Delete(ctx, userID, merchantID); err != nil {
+		return NewInternalError("failed to delete cart", err)
+	}
+	return nil
+}
+
+// Checkout 实现 CartService 接口
+// 订单项和价格由订单服务按下单规则重新校验和计算；下单成功后删除购物车，删除失败不影响下单结果
+func (s *cartService) Checkout(ctx context.Context, userID uint64, merchantID string, req *CheckoutRequest) (*OrderData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	cart, err := s.loadCart(ctx, userID, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, toApplicationError(domain.ErrCartEmpty)
+	}
+
+	items := make([]OrderItemRequest, len(cart.Items))
+	for i, item := range cart.Items {
+		items[i] = toOrderItemRequest(item)
+	}
+	order, err := s.orders.CreateOrder(ctx, userID, &CreateOrderRequest{
+		MerchantID:   merchantID,
+		Items:        items,
+		DeliveryInfo: req.DeliveryInfo,
+		AddressID:    req.AddressID,
+		Remark:       req.Remark,
+		ScheduledFor: req.ScheduledFor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Delete(ctx, userID, merchantID); err != nil {
+		logging.FromContext(ctx).Warn("failed to delete cart after checkout",
+			"merchant_id", merchantID, "order_number", order.OrderNumber, logging.KeyError, err)
+	}
+	return order, nil
+}
+
+// loadCart 加载购物车，不存在或已过期时返回新的空购物车（尚未保存）
+func (s *cartService) loadCart(ctx context.Context, userID uint64, merchantID string) (*domain.Cart, error) {
+	cart, err := s.repo.Find(ctx, userID, merchantID)
+	if err == nil && !cart.IsExpired(s.now()) {
+		return cart, nil
+	}
+	var notFound *NotFoundError
+	if err != nil && !errors.As(err, &notFound) {
+		return nil, NewInternalError("failed to find cart", err)
+	}
+	return domain.NewCart(userID, merchantID, s.now(), s.ttl), nil
+}
+
+// saveCart 顺延有效期后保存购物车，返回价格预览
+func (s *cartService) saveCart(ctx context.Context, cart *domain.Cart) (*CartData, error) {
+	cart.Touch(s.now(), s.ttl)
+	if err := s.repo.Save(ctx, cart); err != nil {
+		return nil, NewInternalError("failed to save cart", err)
+	}
+	return s.preview(ctx, cart)
+}
+
+// preview 按当前商家菜单逐行计算价格预览，与下单使用相同的计价规则
+// 菜单变更导致无法下单的行标记为不可用，不计入价格；没有可下单的行时价格全部为 0
+func (s *cartService) preview(ctx context.Context, cart *domain.Cart) (*CartData, error) {
+	data := &CartData{
+		MerchantID: cart.MerchantID,
+		Items:      make([]CartItemData, len(cart.Items)),
+		UpdatedAt:  cart.UpdatedAt.Format(time.RFC3339),
+		ExpiresAt:  cart.ExpiresAt.Format(time.RFC3339),
+	}
+
+	var available []domain.OrderItem
+	for i, item := range cart.Items {
+		req := toOrderItemRequest(item)
+		data.Items[i].LineID = item.LineID
+
+		resolved, err := s.menu.resolveItems(ctx, cart.MerchantID, []OrderItemRequest{req})
+		var businessErr *BusinessError
+		switch {
+		case err == nil:
+			data.Items[i].Item = toOrderItemData(resolved[0])
+			available = append(available, resolved[0])
+		case errors.As(err, &businessErr):
+			data.Items[i].Item = OrderItemData{
+				DishID:   item.DishID,
+				DishName: item.DishName,
+				Quantity: item.Quantity,
+				Price:    item.Price.StringFixed(2),
+			}
+			data.Items[i].Unavailable = businessErr.Code
+		default:
+			return nil, err
+		}
+	}
+
+	if len(available) == 0 {
+		data.Pricing = toPricingInfo(domain.Pricing{})
+	} else {
+		data.Pricing = toPricingInfo(domain.CalculatePricing(available, s.fees))
+	}
+	return data, nil
+}
+
+// toCartItem 转换订单项请求为购物车行（名称和单价取自菜单解析结果）
+func toCartItem(req *OrderItemRequest, resolved domain.OrderItem) domain.CartItem {
+	item := domain.CartItem{
+		DishID:   req.DishID,
+		DishName: resolved.DishName,
+		Quantity: req.Quantity,
+		Price:    resolved.Price,
+	}
+	for _, option := range req.Options {
+		item.Options = append(item.Options, domain.OptionSelection{GroupID: option.GroupID, OptionID: option.OptionID})
+	}
+	for _, component := range req.Components {
+		item.Components = append(item.Components, domain.ComboSelection{SlotID: component.SlotID, DishID: component.DishID})
+	}
+	return item
+}
+
+// toOrderItemRequest 转换购物车行为订单项请求
+func toOrderItemRequest(item domain.CartItem) OrderItemRequest {
+	req := OrderItemRequest{
+		DishID:   item.DishID,
+		DishName: item.DishName,
+		Quantity: item.Quantity,
+		Price:    item.Price.InexactFloat64(),
+	}
+	for _, option := range item.Options {
+		req.Options = append(req.Options, OrderItemOptionRequest{GroupID: option.GroupID, OptionID: option.OptionID})
+	}
+	for _, component := range item.Components {
+		req.Components = append(req.Components, ComboComponentRequest{SlotID: component.SlotID, DishID: component.DishID})
+	}
+	return req
+}
@@ -0,0 +1,206 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockCartRepository 模拟购物车仓储
+type MockCartRepository struct {
+	carts map[string]*domain.Cart
+}
+
+func NewMockCartRepository() *MockCartRepository {
+	return &MockCartRepository{carts: make(map[string]*domain.Cart)}
+}
+
+func (m *MockCartRepository) Find(ctx context.Context, userID uint64, merchantID string) (*domain.Cart, error) {
+	cart, ok := m.carts[fmt.Sprintf("%d/%s", userID, merchantID)]
+	if !ok {
+		return nil, NewNotFoundError("cart not found")
+	}
+	return cart, nil
+}
+
+func (m *MockCartRepository) Save(ctx context.Context, cart *domain.Cart) error {
+	m.carts[fmt.Sprintf("%d/%s", cart.UserID, cart.MerchantID)] = cart
+	return nil
+}
+
+func (m *MockCartRepository) Delete(ctx context.Context, userID uint64, merchantID string) error {
+	delete(m.carts, fmt.Sprintf("%d/%s", userID, merchantID))
+	return nil
+}
+
+// newTestCartService 创建使用测试菜单的购物车服务
+func newTestCartService(repo CartRepository, menu MenuCatalog, opts ...CartOption) CartService {
+	orders := NewOrderService(NewMockOrderRepository(), WithMenuCatalog(menu))
+	return NewCartService(repo, orders, append([]CartOption{WithCartMenuCatalog(menu)}, opts...)...)
+}
+
+// largeNoodle 大份牛肉面加卤蛋（单价 28.00）
+func largeNoodle(quantity int) *OrderItemRequest {
+	return &OrderItemRequest{DishID: "dish_101", DishName: "牛肉面", Quantity: quantity, Price: 22.00, Options: []OrderItemOptionRequest{
+		{GroupID: "size", OptionID: "large"},
+		{GroupID: "addons", OptionID: "egg"},
+	}}
+}
+
+func TestCartService_AddItem_MergesAndPreviewsPricing(t *testing.T) {
+	// Arrange
+	service := newTestCartService(NewMockCartRepository(), newNoodleMenu())
+	ctx := context.Background()
+
+	// Act - 相同选择合并数量，不同餐品新增一行
+	_, err := service.AddItem(ctx, 1001, "merchant_001", largeNoodle(1))
+	require.NoError(t, err)
+	_, err = service.AddItem(ctx, 1001, "merchant_001", largeNoodle(1))
+	require.NoError(t, err)
+	cart, err := service.AddItem(ctx, 1001, "merchant_001", &OrderItemRequest{DishID: "combo_201", DishName: "套餐", Quantity: 1, Price: 30.00})
+
+	// Assert - 28.00 × 2 + 30.00 = 86.00，加打包费和配送费 90.00
+	require.NoError(t, err)
+	require.Len(t, cart.Items, 2)
+	assert.Equal(t, "L1", cart.Items[0].LineID)
+	assert.Equal(t, 2, cart.Items[0].Item.Quantity)
+	assert.Equal(t, "56.00", cart.Items[0].Item.Subtotal)
+	assert.Equal(t, "牛肉面套餐", cart.Items[1].Item.DishName)
+	assert.Len(t, cart.Items[1].Item.Components, 2)
+	assert.Equal(t, "86.00", cart.Pricing.ItemsTotal)
+	assert.Equal(t, "90.00", cart.Pricing.FinalAmount)
+}
+
+func TestCartService_AddItem_UsesMenuPrice(t *testing.T) {
+	// Arrange - 请求中的名称和单价与菜单不一致
+	service := newTestCartService(NewMockCartRepository(), newNoodleMenu())
+	item := largeNoodle(1)
+	item.DishName = "便宜面"
+	item.Price = 1.00
+
+	// Act
+	cart, err := service.AddItem(context.Background(), 1001, "merchant_001", item)
+
+	// Assert - 购物车按菜单价计价：22.00 + 4.00 + 2.00 = 28.00
+	require.NoError(t, err)
+	require.Len(t, cart.Items, 1)
+	assert.Equal(t, "牛肉面", cart.Items[0].Item.DishName)
+	assert.Equal(t, "22.00", cart.Items[0].Item.Price)
+	assert.Equal(t, "28.00", cart.Pricing.ItemsTotal)
+}
+
+func TestCartService_AddItem_RejectsInvalidSelection(t *testing.T) {
+	// Arrange
+	repo := NewMockCartRepository()
+	service := newTestCartService(repo, newNoodleMenu())
+	req := &OrderItemRequest{DishID: "dish_101", DishName: "牛肉面", Quantity: 1, Price: 22.00}
+
+	// Act - 未选择必选的份量
+	_, err := service.AddItem(context.Background(), 1001, "merchant_001", req)
+
+	// Assert
+	var businessErr *BusinessError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, domain.ErrInvalidOptionSelection.Code, businessErr.Code)
+	assert.Empty(t, repo.carts)
+}
+
+func TestCartService_UpdateAndRemoveItem(t *testing.T) {
+	// Arrange
+	service := newTestCartService(NewMockCartRepository(), newNoodleMenu())
+	ctx := context.Background()
+	_, err := service.AddItem(ctx, 1001, "merchant_001", largeNoodle(1))
+	require.NoError(t, err)
+
+	// Act
+	updated, updateErr := service.UpdateItem(ctx, 1001, "merchant_001", "L1", &UpdateCartItemRequest{Quantity: 3})
+	_, invalidErr := service.UpdateItem(ctx, 1001, "merchant_001", "L1", &UpdateCartItemRequest{Quantity: 0})
+	_, missingErr := service.RemoveItem(ctx, 1001, "merchant_001", "L9")
+	removed, removeErr := service.RemoveItem(ctx, 1001, "merchant_001", "L1")
+
+	// Assert - 购物车清空后价格全部为 0
+	require.NoError(t, updateErr)
+	assert.Equal(t, "84.00", updated.Pricing.ItemsTotal)
+	var validationErr *ValidationError
+	assert.ErrorAs(t, invalidErr, &validationErr)
+	var businessErr *BusinessError
+	require.ErrorAs(t, missingErr, &businessErr)
+	assert.Equal(t, domain.ErrCartItemNotFound.Code, businessErr.Code)
+	require.NoError(t, removeErr)
+	assert.Empty(t, removed.Items)
+	assert.Equal(t, "0.00", removed.Pricing.FinalAmount)
+}
+
+func TestCartService_GetCart_ExpiredCartIsEmpty(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := newTestCartService(NewMockCartRepository(), newNoodleMenu(),
+		WithCartTTL(time.Hour), WithCartClock(func() time.Time { return now }))
+	ctx := context.Background()
+	added, err := service.AddItem(ctx, 1001, "merchant_001", largeNoodle(1))
+	require.NoError(t, err)
+
+	// Act
+	now = now.Add(time.Hour)
+	cart, err := service.GetCart(ctx, 1001, "merchant_001")
+
+	// Assert - 有效期从最后一次修改开始计算
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-01T13:00:00Z", added.ExpiresAt)
+	assert.Empty(t, cart.Items)
+}
+
+func TestCartService_GetCart_MarksUnavailableItems(t *testing.T) {
+	// Arrange
+	menu := newNoodleMenu()
+	service := newTestCartService(NewMockCartRepository(), menu)
+	ctx := context.Background()
+	_, err := service.AddItem(ctx, 1001, "merchant_001", largeNoodle(1))
+	require.NoError(t, err)
+	_, err = service.AddItem(ctx, 1001, "merchant_001", &OrderItemRequest{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00})
+	require.NoError(t, err)
+
+	// Act - 商家下架了加料选项
+	menu.dishes["dish_101"].OptionGroups = menu.dishes["dish_101"].OptionGroups[:1]
+	cart, err := service.GetCart(ctx, 1001, "merchant_001")
+
+	// Assert - 不可下单的行不计入价格
+	require.NoError(t, err)
+	assert.Equal(t, domain.ErrUnknownDishOption.Code, cart.Items[0].Unavailable)
+	assert.Empty(t, cart.Items[1].Unavailable)
+	assert.Equal(t, "28.00", cart.Pricing.ItemsTotal)
+}
+
+func TestCartService_Checkout(t *testing.T) {
+	// Arrange
+	repo := NewMockCartRepository()
+	service := newTestCartService(repo, newNoodleMenu())
+	ctx := context.Background()
+	preview, err := service.AddItem(ctx, 1001, "merchant_001", largeNoodle(2))
+	require.NoError(t, err)
+	checkout := &CheckoutRequest{
+		DeliveryInfo: DeliveryInfoRequest{RecipientName: "张三", RecipientPhone: "13800138000", Address: "北京市朝阳区xxx"},
+		Remark:       "少放辣",
+	}
+
+	// Act
+	order, err := service.Checkout(ctx, 1001, "merchant_001", checkout)
+	_, emptyErr := service.Checkout(ctx, 1001, "merchant_001", checkout)
+
+	// Assert - 订单价格与预览一致，下单后购物车被删除
+	require.NoError(t, err)
+	assert.Equal(t, preview.Pricing, order.Pricing)
+	assert.Equal(t, "少放辣", order.Remark)
+	require.Len(t, order.Items, 1)
+	assert.Len(t, order.Items[0].Options, 2)
+	assert.Empty(t, repo.carts)
+	var businessErr *BusinessError
+	require.ErrorAs(t, emptyErr, &businessErr)
+	assert.Equal(t, domain.ErrCartEmpty.Code, businessErr.Code)
+}
@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+
+	"order-service/internal/domain"
+)
+
+// DeliveryAreaService 定义商家配送范围接口（输入端口）
+// 未配置配送范围的商家不限制收货地址，按固定配送费计价
+type DeliveryAreaService interface {
+	// GetArea 查询配送范围，未配置时返回 NotFoundError
+	GetArea(ctx context.Context, merchantID string) (*DeliveryAreaData, error)
+	// UpdateArea 整体替换配送范围
+	UpdateArea(ctx context.Context, merchantID string, req *UpdateDeliveryAreaRequest) (*DeliveryAreaData, error)
+	// DeleteArea 删除配送范围（恢复固定配送费）
+	DeleteArea(ctx context.Context, merchantID string) error
+}
+
+// DeliveryAreaRepository 定义商家配送范围持久化接口（输出端口）
+// 商家未配置时 Find 和 Delete 返回 NotFoundError
+type DeliveryAreaRepository interface {
+	Find(ctx context.Context, merchantID string) (*domain.DeliveryArea, error)
+	Save(ctx context.Context, area *domain.DeliveryArea) error
+	Delete(ctx context.Context, merchantID string) error
+}
+
+// UpdateDeliveryAreaRequest 更新商家配送范围请求（Zones 为空表示只按距离档位限制配送范围）
+type UpdateDeliveryAreaRequest struct {
+	Origin   GeoPointRequest          `validate:"required"`
+	Zones    []DeliveryZoneRequest    `validate:"max=20,dive"`
+	FeeBands []DeliveryFeeBandRequest `validate:"required,min=1,max=20,dive"`
+}
+
+// GeoPointRequest 地理坐标请求（WGS-84 经纬度）
+type GeoPointRequest struct {
+	Latitude  *float64 `validate:"required,min=-90,max=90"`
+	Longitude *float64 `validate:"required,min=-180,max=180"`
+}
+
+// DeliveryZoneRequest 配送区域请求（多边形顶点按顺序排列，首尾自动闭合）
+type DeliveryZoneRequest struct {
+	Name    string            `validate:"required,max=50"`
+	Polygon []GeoPointRequest `validate:"required,min=3,max=200,dive"`
+}
+
+// DeliveryFeeBandRequest 距离档位请求（配送距离不超过 MaxDistance 米时收取 Fee，各档位距离不能重复）
+type DeliveryFeeBandRequest struct {
+	MaxDistance int     `validate:"required,min=1,max=100000"`
+	Fee         float64 `validate:"min=0,max=1000"`
+}
+
+// DeliveryAreaData 商家配送范围数据（FeeBands 按距离升序）
+type DeliveryAreaData struct {
+	MerchantID string
+	Origin     GeoPointData
+	Zones      []DeliveryZoneData
+	FeeBands   []DeliveryFeeBandData
+	UpdatedAt  string
+}
+
+// GeoPointData 地理坐标数据
+type GeoPointData struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// DeliveryZoneData 配送区域数据
+type DeliveryZoneData struct {
+	Name    string
+	Polygon []GeoPointData
+}
+
+// DeliveryFeeBandData 距离档位数据
+type DeliveryFeeBandData struct {
+	MaxDistance int
+	Fee         string
+}
@@ -0,0 +1,144 @@
+package application
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"order-service/internal/domain"
+)
+
+// deliveryAreaService 商家配送范围应用服务实现
+type deliveryAreaService struct {
+	repo DeliveryAreaRepository
+	now  func() time.Time
+}
+
+// DeliveryAreaOption 商家配送范围服务可选配置
+type DeliveryAreaOption func(*deliveryAreaService)
+
+// WithDeliveryAreaClock 配置时钟（测试使用）
+func WithDeliveryAreaClock(now func() time.Time) DeliveryAreaOption {
+	return func(s *deliveryAreaService) {
+		s.now = now
+	}
+}
+
+// NewDeliveryAreaService 创建商家配送范围服务实例
+func NewDeliveryAreaService(repo DeliveryAreaRepository, opts ...DeliveryAreaOption) DeliveryAreaService {
+	s := &deliveryAreaService{repo: repo, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetArea 实现 DeliveryAreaService 接口
+func (s *deliveryAreaService) GetArea(ctx context.Context, merchantID string) (*DeliveryAreaData, error) {
+	area, err := s.repo.Find(ctx, merchantID)
+	if err != nil {
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, err
+		}
+		return nil, NewInternalError("failed to find delivery area", err)
+	}
+	return convertToDeliveryAreaDTO(area), nil
+}
+
+// UpdateArea 实现 DeliveryAreaService 接口（距离档位按距离升序保存）
+func (s *deliveryAreaService) UpdateArea(ctx context.Context, merchantID string, req *UpdateDeliveryAreaRequest) (*DeliveryAreaData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	area := &domain.DeliveryArea{
+		MerchantID: merchantID,
+		Origin:     toGeoPoint(req.Origin),
+		Zones:      make([]domain.DeliveryZone, len(req.Zones)),
+		FeeBands:   make([]domain.DeliveryFeeBand, len(req.FeeBands)),
+		UpdatedAt:  s.now(),
+	}
+	for i, zone := range req.Zones {
+		area.Zones[i] = domain.DeliveryZone{Name: zone.Name, Polygon: make([]domain.GeoPoint, len(zone.Polygon))}
+		for j, point := range zone.Polygon {
+			area.Zones[i].Polygon[j] = toGeoPoint(point)
+		}
+	}
+	for i, band := range req.FeeBands {
+		area.FeeBands[i] = domain.DeliveryFeeBand{MaxDistance: band.MaxDistance, Fee: decimal.NewFromFloat(band.Fee).Round(2)}
+	}
+	slices.SortFunc(area.FeeBands, func(a, b domain.DeliveryFeeBand) int {
+		return cmp.Compare(a.MaxDistance, b.MaxDistance)
+	})
+	for i := 1; i < len(area.FeeBands); i++ {
+		if area.FeeBands[i].MaxDistance == area.FeeBands[i-1].MaxDistance {
+			return nil, NewValidationError("FeeBands", fmt.Sprintf("duplicate maxDistance %d", area.FeeBands[i].MaxDistance))
+		}
+	}
+
+	if err := s.repo.Save(ctx, area); err != nil {
+		return nil, NewInternalError("failed to save delivery area", err)
+	}
+	return convertToDeliveryAreaDTO(area), nil
+}
+
+// DeleteArea 实现 DeliveryAreaService 接口
+func (s *deliveryAreaService) DeleteArea(ctx context.Context, merchantID string) error {
+	if err := s.repo.Delete(ctx, merchantID); err != nil {
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) {
+			return err
+		}
+		return NewInternalError("failed to delete delivery area", err)
+	}
+	return nil
+}
+
+// findDeliveryArea 查询商家配送范围，未配置时返回 nil
+func findDeliveryArea(ctx context.Context, repo DeliveryAreaRepository, merchantID string) (*domain.DeliveryArea, error) {
+	area, err := repo.Find(ctx, merchantID)
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, NewInternalError("failed to find delivery area", err)
+	}
+	return area, nil
+}
+
+// toGeoPoint 转换已校验的坐标请求
+func toGeoPoint(req GeoPointRequest) domain.GeoPoint {
+	return domain.GeoPoint{Latitude: *req.Latitude, Longitude: *req.Longitude}
+}
+
+// toGeoPointData 转换坐标
+func toGeoPointData(point domain.GeoPoint) GeoPointData {
+	return GeoPointData{Latitude: point.Latitude, Longitude: point.Longitude}
+}
+
+// convertToDeliveryAreaDTO 转换商家配送范围到 DTO
+func convertToDeliveryAreaDTO(area *domain.DeliveryArea) *DeliveryAreaData {
+	data := &DeliveryAreaData{
+		MerchantID: area.MerchantID,
+		Origin:     toGeoPointData(area.Origin),
+		Zones:      make([]DeliveryZoneData, len(area.Zones)),
+		FeeBands:   make([]DeliveryFeeBandData, len(area.FeeBands)),
+		UpdatedAt:  area.UpdatedAt.Format(time.RFC3339),
+	}
+	for i, zone := range area.Zones {
+		data.Zones[i] = DeliveryZoneData{Name: zone.Name, Polygon: make([]GeoPointData, len(zone.Polygon))}
+		for j, point := range zone.Polygon {
+			data.Zones[i].Polygon[j] = toGeoPointData(point)
+		}
+	}
+	for i, band := range area.FeeBands {
+		data.FeeBands[i] = DeliveryFeeBandData{MaxDistance: band.MaxDistance, Fee: band.Fee.StringFixed(2)}
+	}
+	return data
+}
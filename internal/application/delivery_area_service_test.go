@@ -0,0 +1,216 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDeliveryAreaRepository 模拟商家配送范围仓储
+type MockDeliveryAreaRepository struct {
+	areas map[string]*domain.DeliveryArea
+}
+
+func NewMockDeliveryAreaRepository() *MockDeliveryAreaRepository {
+	return &MockDeliveryAreaRepository{areas: make(map[string]*domain.DeliveryArea)}
+}
+
+func (m *MockDeliveryAreaRepository) Find(ctx context.Context, merchantID string) (*domain.DeliveryArea, error) {
+	area, ok := m.areas[merchantID]
+	if !ok {
+		return nil, NewNotFoundError("delivery area not found")
+	}
+	return area, nil
+}
+
+func (m *MockDeliveryAreaRepository) Save(ctx context.Context, area *domain.DeliveryArea) error {
+	m.areas[area.MerchantID] = area
+	return nil
+}
+
+func (m *MockDeliveryAreaRepository) Delete(ctx context.Context, merchantID string) error {
+	if _, ok := m.areas[merchantID]; !ok {
+		return NewNotFoundError("delivery area not found")
+	}
+	delete(m.areas, merchantID)
+	return nil
+}
+
+// geoPoint 创建坐标请求
+func geoPoint(latitude, longitude float64) *GeoPointRequest {
+	return &GeoPointRequest{Latitude: &latitude, Longitude: &longitude}
+}
+
+// newDeliveryAreaRequest 出餐地点在 (39.90, 116.40)、矩形配送区域、1 公里内 3 元、3 公里内 5 元的配送范围（档位乱序）
+func newDeliveryAreaRequest() *UpdateDeliveryAreaRequest {
+	return &UpdateDeliveryAreaRequest{
+		Origin: *geoPoint(39.90, 116.40),
+		Zones: []DeliveryZoneRequest{{Name: "东城", Polygon: []GeoPointRequest{
+			*geoPoint(39.88, 116.37), *geoPoint(39.88, 116.43), *geoPoint(39.92, 116.43), *geoPoint(39.92, 116.37),
+		}}},
+		FeeBands: []DeliveryFeeBandRequest{
+			{MaxDistance: 3000, Fee: 5},
+			{MaxDistance: 1000, Fee: 3},
+		},
+	}
+}
+
+func TestDeliveryAreaService_UpdateArea(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 6, 3, 11, 0, 0, 0, time.UTC)
+	repo := NewMockDeliveryAreaRepository()
+	service := NewDeliveryAreaService(repo, WithDeliveryAreaClock(func() time.Time { return now }))
+
+	// Act
+	updated, err := service.UpdateArea(context.Background(), "merchant_001", newDeliveryAreaRequest())
+	require.NoError(t, err)
+	found, findErr := service.GetArea(context.Background(), "merchant_001")
+
+	// Assert - 距离档位按距离升序保存
+	require.NoError(t, findErr)
+	assert.Equal(t, []DeliveryFeeBandData{{MaxDistance: 1000, Fee: "3.00"}, {MaxDistance: 3000, Fee: "5.00"}}, updated.FeeBands)
+	assert.Equal(t, GeoPointData{Latitude: 39.90, Longitude: 116.40}, updated.Origin)
+	require.Len(t, updated.Zones, 1)
+	assert.Len(t, updated.Zones[0].Polygon, 4)
+	assert.Equal(t, "2025-06-03T11:00:00Z", updated.UpdatedAt)
+	assert.Equal(t, updated, found)
+}
+
+func TestDeliveryAreaService_UpdateArea_ValidationError(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(req *UpdateDeliveryAreaRequest)
+		wantField string
+	}{
+		{"缺少纬度", func(req *UpdateDeliveryAreaRequest) { req.Origin.Latitude = nil }, "Latitude"},
+		{"经度超出范围", func(req *UpdateDeliveryAreaRequest) { req.Origin = *geoPoint(39.90, 190) }, "Longitude"},
+		{"多边形顶点不足", func(req *UpdateDeliveryAreaRequest) { req.Zones[0].Polygon = req.Zones[0].Polygon[:2] }, "Polygon"},
+		{"没有距离档位", func(req *UpdateDeliveryAreaRequest) { req.FeeBands = nil }, "FeeBands"},
+		{"档位距离重复", func(req *UpdateDeliveryAreaRequest) { req.FeeBands[1].MaxDistance = 3000 }, "FeeBands"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service := NewDeliveryAreaService(NewMockDeliveryAreaRepository())
+			req := newDeliveryAreaRequest()
+			tt.modify(req)
+
+			// Act
+			area, err := service.UpdateArea(context.Background(), "merchant_001", req)
+
+			// Assert
+			assert.Nil(t, area)
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantField, validationErr.Field)
+		})
+	}
+}
+
+func TestDeliveryAreaService_DeleteArea(t *testing.T) {
+	// Arrange
+	service := NewDeliveryAreaService(NewMockDeliveryAreaRepository())
+	_, err := service.UpdateArea(context.Background(), "merchant_001", newDeliveryAreaRequest())
+	require.NoError(t, err)
+
+	// Act
+	deleteErr := service.DeleteArea(context.Background(), "merchant_001")
+	_, getErr := service.GetArea(context.Background(), "merchant_001")
+	deleteAgain := service.DeleteArea(context.Background(), "merchant_001")
+
+	// Assert
+	require.NoError(t, deleteErr)
+	assert.IsType(t, &NotFoundError{}, getErr)
+	assert.IsType(t, &NotFoundError{}, deleteAgain)
+}
+
+// newDeliveryAreaOrderService 创建配置了 merchant_001 配送范围的应用服务
+func newDeliveryAreaOrderService(t *testing.T, opts ...ServiceOption) OrderService {
+	areas := NewMockDeliveryAreaRepository()
+	_, err := NewDeliveryAreaService(areas).UpdateArea(context.Background(), "merchant_001", newDeliveryAreaRequest())
+	require.NoError(t, err)
+	return NewOrderService(NewMockOrderRepository(), append(opts, WithDeliveryAreas(areas))...)
+}
+
+func TestOrderService_CreateOrder_DeliveryArea(t *testing.T) {
+	tests := []struct {
+		name            string
+		merchantID      string
+		location        *GeoPointRequest
+		wantDeliveryFee string
+		wantCode        *domain.DomainError
+		wantField       string
+	}{
+		{name: "1 公里内", location: geoPoint(39.905, 116.40), wantDeliveryFee: "3.00"},
+		{name: "3 公里内", location: geoPoint(39.915, 116.41), wantDeliveryFee: "5.00"},
+		{name: "区域内但超出最远档位", location: geoPoint(39.88, 116.37), wantCode: domain.ErrBeyondDeliveryDistance},
+		{name: "不在配送区域内", location: geoPoint(39.95, 116.40), wantCode: domain.ErrOutOfDeliveryZone},
+		{name: "缺少收货坐标", wantField: "Location"},
+		{name: "未配置配送范围的商家", merchantID: "merchant_002", wantDeliveryFee: "3.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service := newDeliveryAreaOrderService(t)
+			req := newOptionsOrderRequest(OrderItemRequest{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00})
+			if tt.merchantID != "" {
+				req.MerchantID = tt.merchantID
+			}
+			req.DeliveryInfo.Location = tt.location
+
+			// Act
+			orderData, err := service.CreateOrder(context.Background(), 1001, req)
+
+			// Assert
+			switch {
+			case tt.wantCode != nil:
+				assert.Nil(t, orderData)
+				assertBusinessCode(t, err, tt.wantCode)
+			case tt.wantField != "":
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, tt.wantField, validationErr.Field)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantDeliveryFee, orderData.Pricing.DeliveryFee)
+				if tt.location != nil {
+					assert.Equal(t, &GeoPointData{Latitude: *tt.location.Latitude, Longitude: *tt.location.Longitude}, orderData.DeliveryInfo.Location)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderService_QuoteOrder_BindsDeliveryLocation(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	areas := NewMockDeliveryAreaRepository()
+	_, err := NewDeliveryAreaService(areas).UpdateArea(context.Background(), "merchant_001", newDeliveryAreaRequest())
+	require.NoError(t, err)
+	service, _ := newQuoteService(&now, WithDeliveryAreas(areas))
+	req := newOptionsOrderRequest(OrderItemRequest{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00})
+	req.DeliveryInfo.Location = geoPoint(39.905, 116.40)
+
+	quote, err := service.QuoteOrder(context.Background(), 1001, req)
+	require.NoError(t, err)
+
+	// Act - 换成更远的收货地址后使用原报价下单
+	req.QuoteToken = quote.QuoteToken
+	req.DeliveryInfo.Location = geoPoint(39.915, 116.41)
+	_, movedErr := service.CreateOrder(context.Background(), 1001, req)
+	req.DeliveryInfo.Location = geoPoint(39.905, 116.40)
+	orderData, err := service.CreateOrder(context.Background(), 1001, req)
+
+	// Assert - 报价包含距离配送费，收货坐标变化时报价失效
+	require.NoError(t, err)
+	assert.Equal(t, "3.00", quote.Pricing.DeliveryFee)
+	assertBusinessCode(t, movedErr, domain.ErrQuoteMismatch)
+	assert.Equal(t, quote.Pricing, orderData.Pricing)
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// 预订配送时段默认规则
+const (
+	DefaultSlotDuration    = 30 * time.Minute
+	DefaultSlotMinLeadTime = 45 * time.Minute
+	DefaultSlotMaxAdvance  = 7 * 24 * time.Hour
+)
+
+func init() {
+	// 注册预订配送时段时间验证函数
+	_ = Validator.RegisterValidation("rfc3339", validateRFC3339)
+}
+
+// validateRFC3339 验证 RFC 3339 格式的时间
+func validateRFC3339(fl validator.FieldLevel) bool {
+	_, err := time.Parse(time.RFC3339, fl.Field().String())
+	return err == nil
+}
+
+// SlotPolicy 预订配送时段规则
+// 时段按商家时区当天 0 点起每 Duration 划分，预订单的配送时间须为时段开始时间
+type SlotPolicy struct {
+	Duration    time.Duration // 时段长度（须整除 24 小时）
+	MinLeadTime time.Duration // 时段开始时间距下单时间的最小提前量
+	MaxAdvance  time.Duration // 时段开始时间距下单时间的最大提前量
+}
+
+// DefaultSlotPolicy 默认时段规则（30 分钟一个时段，最早提前 45 分钟、最多提前 7 天预订）
+func DefaultSlotPolicy() SlotPolicy {
+	return SlotPolicy{
+		Duration:    DefaultSlotDuration,
+		MinLeadTime: DefaultSlotMinLeadTime,
+		MaxAdvance:  DefaultSlotMaxAdvance,
+	}
+}
+
+// DeliverySlotService 定义预订配送时段查询接口（输入端口）
+type DeliverySlotService interface {
+	// ListSlots 查询商家某天（商家时区）可预订的配送时段
+	ListSlots(ctx context.Context, merchantID string, req *ListDeliverySlotsRequest) ([]DeliverySlotData, error)
+}
+
+// DeliverySlotRepository 定义配送时段预订持久化接口（输出端口）
+// 时段以商家ID和时段开始时间标识，每个预订单占用一个名额
+type DeliverySlotRepository interface {
+	// Reserve 为订单预订时段，已预订数达到 capacity 时返回 false（capacity 为 0 表示不限，同一订单重复预订视为成功）
+	Reserve(ctx context.Context, merchantID string, slot time.Time, orderNumber string, capacity int) (bool, error)
+	// Release 释放订单的时段预订（未预订时忽略）
+	Release(ctx context.Context, merchantID string, slot time.Time, orderNumber string) error
+	// Count 统计时段已预订的订单数
+	Count(ctx context.Context, merchantID string, slot time.Time) (int, error)
+}
+
+// ListDeliverySlotsRequest 查询配送时段请求（Date 为空表示商家时区的今天）
+type ListDeliverySlotsRequest struct {
+	Date string `validate:"omitempty,date"`
+}
+
+// DeliverySlotData 配送时段数据（Capacity 为 0 表示不限）
+type DeliverySlotData struct {
+	Start     string
+	End       string
+	Capacity  int
+	Booked    int
+	Available bool
+}
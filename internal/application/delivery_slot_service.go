@@ -0,0 +1,96 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"order-service/internal/domain"
+)
+
+// deliverySlotService 预订配送时段查询服务实现
+type deliverySlotService struct {
+	profiles MerchantProfileRepository
+	slots    DeliverySlotRepository
+	policy   SlotPolicy
+	location *time.Location
+	now      func() time.Time
+}
+
+// DeliverySlotOption 配送时段查询服务可选配置
+type DeliverySlotOption func(*deliverySlotService)
+
+// WithDeliverySlotClock 配置时钟（测试使用）
+func WithDeliverySlotClock(now func() time.Time) DeliverySlotOption {
+	return func(s *deliverySlotService) {
+		s.now = now
+	}
+}
+
+// NewDeliverySlotService 创建配送时段查询服务实例
+func NewDeliverySlotService(profiles MerchantProfileRepository, slots DeliverySlotRepository, policy SlotPolicy, opts ...DeliverySlotOption) DeliverySlotService {
+	s := &deliverySlotService{
+		profiles: profiles,
+		slots:    slots,
+		policy:   policy,
+		location: defaultMerchantLocation(),
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ListSlots 实现 DeliverySlotService 接口（只返回在可预订范围内且商家营业的时段，包括已约满的时段）
+func (s *deliverySlotService) ListSlots(ctx context.Context, merchantID string, req *ListDeliverySlotsRequest) ([]DeliverySlotData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	profile, err := loadMerchantProfile(ctx, s.profiles, merchantID, s.location)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	day := now.In(profile.Location)
+	if req.Date != "" {
+		day, _ = time.ParseInLocation(domain.HolidayLayout, req.Date, profile.Location)
+	}
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, profile.Location)
+	end := start.AddDate(0, 0, 1)
+
+	slots := []DeliverySlotData{}
+	for slot := start; slot.Before(end); slot = slot.Add(s.policy.Duration) {
+		if !s.policy.bookable(slot, now) || !profile.SlotOpen(slot, s.policy.Duration) {
+			continue
+		}
+		booked, err := s.slots.Count(ctx, merchantID, slot)
+		if err != nil {
+			return nil, NewInternalError("failed to count slot reservations", err)
+		}
+		slots = append(slots, DeliverySlotData{
+			Start:     slot.Format(time.RFC3339),
+			End:       slot.Add(s.policy.Duration).Format(time.RFC3339),
+			Capacity:  profile.SlotCapacity,
+			Booked:    booked,
+			Available: profile.SlotCapacity == 0 || booked < profile.SlotCapacity,
+		})
+	}
+	return slots, nil
+}
+
+// check 校验预订单的配送时段：须为时段开始时间，且在可预订范围内
+func (p SlotPolicy) check(slot time.Time, location *time.Location, now time.Time) error {
+	if !slot.Equal(domain.SlotStart(slot, location, p.Duration)) {
+		return NewValidationError("ScheduledFor", "must be the start of a delivery slot")
+	}
+	if !p.bookable(slot, now) {
+		return toApplicationError(domain.ErrSlotUnavailable)
+	}
+	return nil
+}
+
+// bookable 时段开始时间是否在可预订范围内
+func (p SlotPolicy) bookable(slot, now time.Time) bool {
+	return !slot.Before(now.Add(p.MinLeadTime)) && !slot.After(now.Add(p.MaxAdvance))
+}
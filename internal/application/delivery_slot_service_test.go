@@ -0,0 +1,264 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDeliverySlotRepository 模拟配送时段预订仓储
+type MockDeliverySlotRepository struct {
+	reservations map[int64][]string // 时段开始的 Unix 时间 -> 订单号（测试只使用一个商家）
+}
+
+func NewMockDeliverySlotRepository() *MockDeliverySlotRepository {
+	return &MockDeliverySlotRepository{reservations: make(map[int64][]string)}
+}
+
+func (m *MockDeliverySlotRepository) Reserve(ctx context.Context, merchantID string, slot time.Time, orderNumber string, capacity int) (bool, error) {
+	orders := m.reservations[slot.Unix()]
+	if capacity > 0 && len(orders) >= capacity {
+		return false, nil
+	}
+	m.reservations[slot.Unix()] = append(orders, orderNumber)
+	return true, nil
+}
+
+func (m *MockDeliverySlotRepository) Release(ctx context.Context, merchantID string, slot time.Time, orderNumber string) error {
+	orders := m.reservations[slot.Unix()]
+	for i, reserved := range orders {
+		if reserved == orderNumber {
+			m.reservations[slot.Unix()] = append(orders[:i], orders[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *MockDeliverySlotRepository) Count(ctx context.Context, merchantID string, slot time.Time) (int, error) {
+	return len(m.reservations[slot.Unix()]), nil
+}
+
+// scheduledOrderFixture 午市营业、每个时段 1 个名额的商家，以及可预订的订单服务
+type scheduledOrderFixture struct {
+	now      time.Time
+	repo     *MockOrderRepository
+	slots    *MockDeliverySlotRepository
+	profiles MerchantProfileRepository
+	orders   OrderService
+}
+
+// newScheduledOrderFixture 创建预订单测试环境（时钟为商家时区周二 11:00）
+func newScheduledOrderFixture(t *testing.T) *scheduledOrderFixture {
+	f := &scheduledOrderFixture{
+		now:      tuesdayLunch,
+		repo:     NewMockOrderRepository().(*MockOrderRepository),
+		slots:    NewMockDeliverySlotRepository(),
+		profiles: NewMockMerchantProfileRepository(),
+	}
+	clock := func() time.Time { return f.now }
+	req := newLunchProfileRequest()
+	req.SlotCapacity = 1
+	_, err := NewMerchantProfileService(f.profiles, WithMerchantProfileClock(clock)).
+		UpdateProfile(context.Background(), "merchant_001", req)
+	require.NoError(t, err)
+	f.orders = NewOrderService(f.repo,
+		WithPaymentGateway(&MockPaymentGateway{}),
+		WithMerchantProfiles(f.profiles),
+		WithDeliverySlots(f.slots, DefaultSlotPolicy()),
+		WithOrderClock(clock),
+	)
+	return f
+}
+
+// create 预订 slot 时段配送的订单（slot 为零值时立即配送）
+func (f *scheduledOrderFixture) create(slot time.Time) (*OrderData, error) {
+	req := newMerchantRuleOrderRequest(1)
+	if !slot.IsZero() {
+		req.ScheduledFor = slot.Format(time.RFC3339)
+	}
+	return f.orders.CreateOrder(context.Background(), 1001, req)
+}
+
+func TestOrderService_CreateOrder_ScheduledDelivery(t *testing.T) {
+	lunchSlot := tuesdayLunch.Add(90 * time.Minute)
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		setup     func(t *testing.T, f *scheduledOrderFixture)
+		slot      time.Time
+		wantCode  *domain.DomainError
+		wantField string
+	}{
+		{name: "预订午市时段", slot: lunchSlot},
+		{name: "下单时未营业也可预订", now: tuesdayLunch.Add(-2 * time.Hour), slot: lunchSlot},
+		{
+			name: "达到订单上限不影响预订",
+			setup: func(t *testing.T, f *scheduledOrderFixture) {
+				for range 2 {
+					_, err := f.create(time.Time{})
+					require.NoError(t, err)
+				}
+			},
+			slot: lunchSlot,
+		},
+		{name: "不是时段开始时间", slot: lunchSlot.Add(10 * time.Minute), wantField: "ScheduledFor"},
+		{name: "提前量不足", slot: tuesdayLunch.Add(30 * time.Minute), wantCode: domain.ErrSlotUnavailable},
+		{name: "超出可预订天数", slot: lunchSlot.AddDate(0, 0, 8), wantCode: domain.ErrSlotUnavailable},
+		{name: "非营业时段", slot: tuesdayLunch.Add(4 * time.Hour), wantCode: domain.ErrSlotUnavailable},
+		{name: "休息日", slot: lunchSlot.AddDate(0, 0, 6), wantCode: domain.ErrSlotUnavailable},
+		{
+			name: "时段已约满",
+			setup: func(t *testing.T, f *scheduledOrderFixture) {
+				_, err := f.create(lunchSlot)
+				require.NoError(t, err)
+			},
+			slot:     lunchSlot,
+			wantCode: domain.ErrSlotFull,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := newScheduledOrderFixture(t)
+			if !tt.now.IsZero() {
+				f.now = tt.now
+			}
+			if tt.setup != nil {
+				tt.setup(t, f)
+			}
+
+			// Act
+			orderData, err := f.create(tt.slot)
+
+			// Assert
+			switch {
+			case tt.wantCode != nil:
+				assert.Nil(t, orderData)
+				assertBusinessCode(t, err, tt.wantCode)
+			case tt.wantField != "":
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, tt.wantField, validationErr.Field)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.slot.Format(time.RFC3339), orderData.ScheduledFor)
+				count, _ := f.slots.Count(context.Background(), "merchant_001", tt.slot)
+				assert.Equal(t, 1, count)
+			}
+		})
+	}
+}
+
+func TestOrderService_CreateOrder_ScheduledDeliveryNotConfigured(t *testing.T) {
+	// Arrange
+	service := NewOrderService(NewMockOrderRepository())
+	req := newMerchantRuleOrderRequest(1)
+	req.ScheduledFor = time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+
+	// Act
+	orderData, err := service.CreateOrder(context.Background(), 1001, req)
+
+	// Assert
+	assert.Nil(t, orderData)
+	assert.IsType(t, &ValidationError{}, err)
+}
+
+func TestOrderService_ScheduledOrder_ReleasesSlot(t *testing.T) {
+	slot := tuesdayLunch.Add(90 * time.Minute)
+
+	tests := []struct {
+		name   string
+		finish func(t *testing.T, orders OrderService, orderNumber string)
+	}{
+		{
+			name: "用户取消",
+			finish: func(t *testing.T, orders OrderService, orderNumber string) {
+				_, err := orders.CancelOrder(context.Background(), 1001, orderNumber, &CancelOrderRequest{Reason: "改主意了"})
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "商家拒单",
+			finish: func(t *testing.T, orders OrderService, orderNumber string) {
+				payOrder(t, orders, 1001, orderNumber, "pay_001")
+				_, err := orders.RejectOrder(context.Background(), "merchant_001", orderNumber, &RejectOrderRequest{Reason: "食材不足"})
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			f := newScheduledOrderFixture(t)
+			first, err := f.create(slot)
+			require.NoError(t, err)
+
+			// Act
+			tt.finish(t, f.orders, first.OrderNumber)
+			second, err := f.create(slot)
+
+			// Assert - 名额释放后可以再次预订
+			require.NoError(t, err)
+			assert.NotEqual(t, first.OrderNumber, second.OrderNumber)
+		})
+	}
+}
+
+func TestOrderService_QuoteOrder_ScheduledDelivery(t *testing.T) {
+	// Arrange
+	f := newScheduledOrderFixture(t)
+	slot := tuesdayLunch.Add(90 * time.Minute)
+	req := newMerchantRuleOrderRequest(1)
+	req.ScheduledFor = slot.Format(time.RFC3339)
+
+	// Act
+	quote, err := f.orders.QuoteOrder(context.Background(), 1001, req)
+	require.NoError(t, err)
+	_, err = f.create(slot)
+	require.NoError(t, err)
+	_, fullErr := f.orders.QuoteOrder(context.Background(), 1001, req)
+
+	// Assert - 报价不占用名额
+	assert.Equal(t, "40.00", quote.Pricing.FinalAmount)
+	assertBusinessCode(t, fullErr, domain.ErrSlotFull)
+}
+
+func TestDeliverySlotService_ListSlots(t *testing.T) {
+	// Arrange
+	f := newScheduledOrderFixture(t)
+	service := NewDeliverySlotService(f.profiles, f.slots, DefaultSlotPolicy(),
+		WithDeliverySlotClock(func() time.Time { return f.now }))
+	_, err := f.create(tuesdayLunch.Add(90 * time.Minute))
+	require.NoError(t, err)
+
+	// Act
+	today, err := service.ListSlots(context.Background(), "merchant_001", &ListDeliverySlotsRequest{})
+	require.NoError(t, err)
+	tomorrow, err := service.ListSlots(context.Background(), "merchant_001", &ListDeliverySlotsRequest{Date: "2025-06-04"})
+	require.NoError(t, err)
+	holiday, err := service.ListSlots(context.Background(), "merchant_001", &ListDeliverySlotsRequest{Date: "2025-06-09"})
+	require.NoError(t, err)
+	_, invalidErr := service.ListSlots(context.Background(), "merchant_001", &ListDeliverySlotsRequest{Date: "06/04"})
+
+	// Assert - 今天 11:45 之后营业的时段（12:00 至 13:30 开始），已约满的时段仍然返回
+	require.Len(t, today, 4)
+	assert.Equal(t, "2025-06-03T12:00:00+08:00", today[0].Start)
+	assert.Equal(t, "2025-06-03T12:30:00+08:00", today[0].End)
+	assert.Equal(t, DeliverySlotData{
+		Start: "2025-06-03T12:30:00+08:00", End: "2025-06-03T13:00:00+08:00", Capacity: 1, Booked: 1, Available: false,
+	}, today[1])
+	assert.Equal(t, "2025-06-03T13:30:00+08:00", today[3].Start)
+	assert.Len(t, tomorrow, 8)
+	assert.True(t, tomorrow[0].Available)
+	assert.Empty(t, holiday)
+	assert.IsType(t, &ValidationError{}, invalidErr)
+}
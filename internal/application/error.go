@@ -1,6 +1,10 @@
 package application
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"time"
+)
 
 // ValidationError 验证错误（应用层使用）
 type ValidationError struct {
@@ -60,3 +64,59 @@ func NewNotFoundError(message string) *NotFoundError {
 		Message: message,
 	}
 }
+
+// BusinessError 业务规则错误（应用层使用，携带业务错误码）
+type BusinessError struct {
+	Code    string
+	Message string
+}
+
+func (e *BusinessError) Error() string {
+	return fmt.Sprintf("business error: %s - %s", e.Code, e.Message)
+}
+
+// NewBusinessError 创建业务规则错误
+func NewBusinessError(code, message string) *BusinessError {
+	return &BusinessError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// RateLimitError 限流错误（应用层使用，RetryAfter 为距离下一次可重试的时间）
+// Limit、Remaining、ResetAfter 为拒绝请求的规则的令牌桶状态（Limit 为 0 表示未知）
+type RateLimitError struct {
+	Message    string
+	RetryAfter time.Duration
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited: %s", e.Message)
+}
+
+// RetryAfterSeconds 向上取整的重试等待秒数（至少 1 秒）
+func (e *RateLimitError) RetryAfterSeconds() int {
+	return max(1, int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+// NewRateLimitError 创建限流错误
+func NewRateLimitError(message string, retryAfter time.Duration) *RateLimitError {
+	return &RateLimitError{
+		Message:    message,
+		RetryAfter: retryAfter,
+	}
+}
+
+// NewRateLimitErrorFromDecision 按限流存储的拒绝判定创建限流错误
+func NewRateLimitErrorFromDecision(message string, decision RateLimitDecision) *RateLimitError {
+	return &RateLimitError{
+		Message:    message,
+		RetryAfter: decision.RetryAfter,
+		Limit:      decision.Limit,
+		Remaining:  decision.Remaining,
+		ResetAfter: decision.ResetAfter,
+	}
+}
@@ -0,0 +1,142 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultHealthCheckTimeout 单个就绪检查项的默认超时时间
+const DefaultHealthCheckTimeout = 2 * time.Second
+
+// HealthChecker 就绪检查项（输出端口，由仓储等适配器实现）
+// 返回 nil 表示组件可以处理请求
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// HealthCheckFunc 函数形式的就绪检查项
+type HealthCheckFunc func(ctx context.Context) error
+
+// HealthCheck 实现 HealthChecker
+func (f HealthCheckFunc) HealthCheck(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Health 服务健康状态
+// 存活（liveness）只要求进程能够响应；就绪（readiness）聚合各组件注册的检查项，
+// 开始停机后立即变为未就绪，使负载均衡在连接排空前摘除实例
+type Health struct {
+	mu       sync.RWMutex
+	checks   []namedHealthCheck
+	draining atomic.Bool
+	timeout  time.Duration
+}
+
+// namedHealthCheck 已注册的检查项
+type namedHealthCheck struct {
+	name    string
+	checker HealthChecker
+}
+
+// HealthCheckResult 单个检查项的结果
+type HealthCheckResult struct {
+	Name  string
+	Error error // 为空表示检查通过
+}
+
+// ReadinessReport 就绪检查结果（检查项按注册顺序排列）
+type ReadinessReport struct {
+	Ready    bool
+	Draining bool
+	Checks   []HealthCheckResult
+}
+
+// HealthOption 健康状态可选配置
+type HealthOption func(*Health)
+
+// WithHealthCheckTimeout 配置单个检查项的超时时间
+func WithHealthCheckTimeout(timeout time.Duration) HealthOption {
+	return func(h *Health) {
+		h.timeout = timeout
+	}
+}
+
+// NewHealth 创建健康状态
+func NewHealth(opts ...HealthOption) *Health {
+	h := &Health{timeout: DefaultHealthCheckTimeout}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Register 注册就绪检查项
+func (h *Health) Register(name string, checker HealthChecker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks = append(h.checks, namedHealthCheck{name: name, checker: checker})
+}
+
+// StartDraining 标记服务开始停机，此后就绪检查始终失败
+func (h *Health) StartDraining() {
+	h.draining.Store(true)
+}
+
+// Draining 服务是否已开始停机
+func (h *Health) Draining() bool {
+	return h.draining.Load()
+}
+
+// Readiness 并发执行全部检查项，任一失败或已开始停机时未就绪
+func (h *Health) Readiness(ctx context.Context) ReadinessReport {
+	h.mu.RLock()
+	checks := append([]namedHealthCheck(nil), h.checks...)
+	h.mu.RUnlock()
+
+	results := make([]HealthCheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check namedHealthCheck) {
+			defer wg.Done()
+			results[i] = HealthCheckResult{Name: check.name, Error: h.run(ctx, check.checker)}
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := ReadinessReport{Draining: h.Draining(), Checks: results}
+	report.Ready = !report.Draining
+	for _, result := range results {
+		if result.Error != nil {
+			report.Ready = false
+		}
+	}
+	return report
+}
+
+// run 在超时时间内执行检查项（检查项不响应 ctx 时也按超时处理）
+func (h *Health) run(ctx context.Context, checker HealthChecker) error {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("health check panicked: %v", r)
+			}
+		}()
+		done <- checker.HealthCheck(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("health check timed out: %w", ctx.Err())
+	}
+}
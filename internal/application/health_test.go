@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// passingCheck 始终通过的检查项
+var passingCheck = HealthCheckFunc(func(ctx context.Context) error { return nil })
+
+func TestHealth_Readiness_AllChecksPass(t *testing.T) {
+	// Arrange
+	health := NewHealth()
+	health.Register("repository", passingCheck)
+	health.Register("relay", passingCheck)
+
+	// Act
+	report := health.Readiness(context.Background())
+
+	// Assert
+	assert.True(t, report.Ready)
+	assert.False(t, report.Draining)
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, "repository", report.Checks[0].Name)
+	assert.NoError(t, report.Checks[0].Error)
+}
+
+func TestHealth_Readiness_FailingCheck(t *testing.T) {
+	// Arrange
+	health := NewHealth()
+	health.Register("repository", HealthCheckFunc(func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}))
+	health.Register("relay", passingCheck)
+
+	// Act
+	report := health.Readiness(context.Background())
+
+	// Assert
+	assert.False(t, report.Ready)
+	assert.EqualError(t, report.Checks[0].Error, "connection refused")
+	assert.NoError(t, report.Checks[1].Error)
+}
+
+func TestHealth_Readiness_TimesOutHangingCheck(t *testing.T) {
+	// Arrange - 检查项不响应 ctx
+	health := NewHealth(WithHealthCheckTimeout(10 * time.Millisecond))
+	release := make(chan struct{})
+	defer close(release)
+	health.Register("repository", HealthCheckFunc(func(ctx context.Context) error {
+		<-release
+		return nil
+	}))
+
+	// Act
+	report := health.Readiness(context.Background())
+
+	// Assert
+	assert.False(t, report.Ready)
+	assert.ErrorIs(t, report.Checks[0].Error, context.DeadlineExceeded)
+}
+
+func TestHealth_Readiness_Draining(t *testing.T) {
+	// Arrange
+	health := NewHealth()
+	health.Register("repository", passingCheck)
+
+	// Act
+	health.StartDraining()
+	report := health.Readiness(context.Background())
+
+	// Assert - 检查项通过但已开始停机
+	assert.False(t, report.Ready)
+	assert.True(t, report.Draining)
+	assert.NoError(t, report.Checks[0].Error)
+}
@@ -0,0 +1,53 @@
+package application
+
+import (
+	"context"
+
+	"order-service/internal/domain"
+)
+
+// InventoryService 定义商家餐品库存接口（输入端口）
+// 未配置库存的餐品不限量
+type InventoryService interface {
+	// GetInventory 查询餐品库存和商家时区今天的已售份数（未配置时返回空列表）
+	GetInventory(ctx context.Context, merchantID string) (*InventoryData, error)
+	// UpdateInventory 整体替换餐品库存配置（已售份数和未支付订单的占用保持不变）
+	UpdateInventory(ctx context.Context, merchantID string, req *UpdateInventoryRequest) (*InventoryData, error)
+}
+
+// InventoryRepository 定义商家餐品库存持久化接口（输出端口）
+type InventoryRepository interface {
+	// Find 查询商家库存，未配置时返回 NotFoundError
+	Find(ctx context.Context, merchantID string) (*domain.Inventory, error)
+	// Update 在同一事务中加载商家库存（未配置时为 domain.NewInventory）、执行 update 并保存，返回保存后的库存；
+	// update 返回错误时不保存。同一商家的 Update 必须串行执行（如 SELECT ... FOR UPDATE），并发下单依赖此保证不超卖
+	Update(ctx context.Context, merchantID string, update func(inv *domain.Inventory) error) (*domain.Inventory, error)
+}
+
+// UpdateInventoryRequest 更新餐品库存请求（每个餐品只能出现一次）
+type UpdateInventoryRequest struct {
+	Dishes []DishStockRequest `validate:"max=200,dive"`
+}
+
+// DishStockRequest 餐品库存请求（Stock 为空表示不限库存，DailyLimit 为 0 表示不限每日份数）
+type DishStockRequest struct {
+	DishID     string `validate:"required,max=64"`
+	Stock      *int   `validate:"omitempty,min=0,max=100000"`
+	DailyLimit int    `validate:"min=0,max=100000"`
+}
+
+// InventoryData 商家餐品库存数据（Dishes 按 DishID 升序）
+type InventoryData struct {
+	MerchantID string
+	Dishes     []DishStockData
+	UpdatedAt  string // 未配置时为空
+}
+
+// DishStockData 餐品库存数据（SoldToday 含未支付订单的占用，Available 为今天还可下单的份数）
+type DishStockData struct {
+	DishID     string
+	Stock      *int
+	DailyLimit int
+	SoldToday  int
+	Available  int
+}
@@ -0,0 +1,204 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"order-service/internal/domain"
+	"order-service/internal/logging"
+)
+
+// inventoryService 商家餐品库存应用服务实现
+type inventoryService struct {
+	profiles MerchantProfileRepository
+	repo     InventoryRepository
+	location *time.Location
+	now      func() time.Time
+}
+
+// InventoryOption 商家餐品库存服务可选配置
+type InventoryOption func(*inventoryService)
+
+// WithInventoryClock 配置时钟（测试使用）
+func WithInventoryClock(now func() time.Time) InventoryOption {
+	return func(s *inventoryService) {
+		s.now = now
+	}
+}
+
+// NewInventoryService 创建商家餐品库存服务实例（按商家营业配置的时区计算每日限售）
+func NewInventoryService(profiles MerchantProfileRepository, repo InventoryRepository, opts ...InventoryOption) InventoryService {
+	s := &inventoryService{
+		profiles: profiles,
+		repo:     repo,
+		location: defaultMerchantLocation(),
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetInventory 实现 InventoryService 接口
+func (s *inventoryService) GetInventory(ctx context.Context, merchantID string) (*InventoryData, error) {
+	inv, err := s.repo.Find(ctx, merchantID)
+	var notFound *NotFoundError
+	switch {
+	case errors.As(err, &notFound):
+		inv = domain.NewInventory(merchantID)
+	case err != nil:
+		return nil, NewInternalError("failed to find inventory", err)
+	}
+	return s.convertToDTO(ctx, inv)
+}
+
+// UpdateInventory 实现 InventoryService 接口
+func (s *inventoryService) UpdateInventory(ctx context.Context, merchantID string, req *UpdateInventoryRequest) (*InventoryData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	dishes := make([]domain.DishStock, len(req.Dishes))
+	seen := make(map[string]bool, len(req.Dishes))
+	for i, dish := range req.Dishes {
+		if seen[dish.DishID] {
+			return nil, NewValidationError("Dishes", "duplicate dishId "+dish.DishID)
+		}
+		seen[dish.DishID] = true
+		dishes[i] = domain.DishStock{DishID: dish.DishID, Stock: dish.Stock, DailyLimit: dish.DailyLimit}
+	}
+
+	inv, err := s.repo.Update(ctx, merchantID, func(inv *domain.Inventory) error {
+		inv.SetDishes(dishes, s.now())
+		return nil
+	})
+	if err != nil {
+		return nil, NewInternalError("failed to save inventory", err)
+	}
+	return s.convertToDTO(ctx, inv)
+}
+
+// convertToDTO 转换商家库存到 DTO（已售份数按商家时区的今天计算）
+func (s *inventoryService) convertToDTO(ctx context.Context, inv *domain.Inventory) (*InventoryData, error) {
+	profile := domain.NewMerchantProfile(inv.MerchantID, s.location)
+	if s.profiles != nil {
+		var err error
+		if profile, err = loadMerchantProfile(ctx, s.profiles, inv.MerchantID, s.location); err != nil {
+			return nil, err
+		}
+	}
+	today := s.now().In(profile.Location).Format(domain.HolidayLayout)
+
+	data := &InventoryData{
+		MerchantID: inv.MerchantID,
+		Dishes:     make([]DishStockData, len(inv.Dishes)),
+	}
+	if !inv.UpdatedAt.IsZero() {
+		data.UpdatedAt = inv.UpdatedAt.Format(time.RFC3339)
+	}
+	for i, dish := range inv.Dishes {
+		available, _ := inv.Available(today, dish.DishID)
+		data.Dishes[i] = DishStockData{
+			DishID:     dish.DishID,
+			Stock:      dish.Stock,
+			DailyLimit: dish.DailyLimit,
+			SoldToday:  inv.Sold(today, dish.DishID),
+			Available:  available,
+		}
+	}
+	return data, nil
+}
+
+// stockLines 订单项占用的库存
+func stockLines(items []domain.OrderItem) []domain.StockLine {
+	lines := make([]domain.StockLine, len(items))
+	for i, item := range items {
+		lines[i] = domain.StockLine{Item: i, DishID: item.DishID, DishName: item.DishName, Quantity: item.Quantity}
+	}
+	return lines
+}
+
+// stockDay 订单计入每日限售的日期（商家时区；预订单按配送时段所在日期）
+func stockDay(scheduledFor, now time.Time, location *time.Location) string {
+	if !scheduledFor.IsZero() {
+		now = scheduledFor
+	}
+	return now.In(location).Format(domain.HolidayLayout)
+}
+
+// checkStock 校验餐品库存能否满足订单项（不占用库存，商家未配置库存时不限制）
+func checkStock(ctx context.Context, repo InventoryRepository, merchantID, day string, items []domain.OrderItem) error {
+	if repo == nil {
+		return nil
+	}
+	inv, err := repo.Find(ctx, merchantID)
+	var notFound *NotFoundError
+	switch {
+	case errors.As(err, &notFound):
+		return nil
+	case err != nil:
+		return NewInternalError("failed to find inventory", err)
+	}
+	if err := inv.Check(day, stockLines(items)); err != nil {
+		return toApplicationError(err)
+	}
+	return nil
+}
+
+// errStockUnchanged 库存无需修改（仓储 Update 不保存）
+var errStockUnchanged = errors.New("stock unchanged")
+
+// reserveStock 为订单占用餐品库存（并发下单时由仓储保证串行执行），同时清理今天之前的已售份数
+func reserveStock(ctx context.Context, repo InventoryRepository, order *domain.Order, day, today string, now time.Time) error {
+	if repo == nil {
+		return nil
+	}
+	_, err := repo.Update(ctx, order.MerchantID, func(inv *domain.Inventory) error {
+		if len(inv.Dishes) == 0 {
+			return errStockUnchanged
+		}
+		inv.PruneSold(today)
+		return inv.Reserve(order.OrderNumber, day, stockLines(order.Items), now)
+	})
+	var domainErr *domain.DomainError
+	switch {
+	case err == nil, errors.Is(err, errStockUnchanged):
+		return nil
+	case errors.As(err, &domainErr):
+		return toApplicationError(domainErr)
+	default:
+		return NewInternalError("failed to reserve stock", err)
+	}
+}
+
+// releaseStock 释放订单占用的餐品库存（释放失败只记录日志）
+func releaseStock(ctx context.Context, repo InventoryRepository, order *domain.Order, now time.Time) {
+	updateReservation(ctx, repo, order, "failed to release stock", func(inv *domain.Inventory) bool {
+		return inv.Release(order.OrderNumber, now)
+	})
+}
+
+// commitStock 商家接单后订单占用转为已售出（失败只记录日志，占用保留到被释放）
+func commitStock(ctx context.Context, repo InventoryRepository, order *domain.Order, now time.Time) {
+	updateReservation(ctx, repo, order, "failed to commit stock", func(inv *domain.Inventory) bool {
+		return inv.Commit(order.OrderNumber, now)
+	})
+}
+
+// updateReservation 修改订单的库存占用（订单没有占用时不保存）
+func updateReservation(ctx context.Context, repo InventoryRepository, order *domain.Order, message string, update func(inv *domain.Inventory) bool) {
+	if repo == nil {
+		return
+	}
+	_, err := repo.Update(ctx, order.MerchantID, func(inv *domain.Inventory) error {
+		if !update(inv) {
+			return errStockUnchanged
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStockUnchanged) {
+		logging.FromContext(ctx).Warn(message, "order_number", order.OrderNumber, logging.KeyError, err)
+	}
+}
@@ -0,0 +1,248 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockInventoryRepository 模拟餐品库存仓储（Update 加锁串行执行并保存副本，模拟行锁事务）
+type MockInventoryRepository struct {
+	mu          sync.Mutex
+	inventories map[string]*domain.Inventory
+}
+
+func NewMockInventoryRepository() *MockInventoryRepository {
+	return &MockInventoryRepository{inventories: make(map[string]*domain.Inventory)}
+}
+
+func (m *MockInventoryRepository) Find(ctx context.Context, merchantID string) (*domain.Inventory, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inv, ok := m.inventories[merchantID]
+	if !ok {
+		return nil, NewNotFoundError("inventory not found")
+	}
+	return copyInventory(inv), nil
+}
+
+func (m *MockInventoryRepository) Update(ctx context.Context, merchantID string, update func(inv *domain.Inventory) error) (*domain.Inventory, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inv := domain.NewInventory(merchantID)
+	if stored, ok := m.inventories[merchantID]; ok {
+		inv = copyInventory(stored)
+	}
+	if err := update(inv); err != nil {
+		return nil, err
+	}
+	m.inventories[merchantID] = copyInventory(inv)
+	return inv, nil
+}
+
+// copyInventory 深拷贝商家库存
+func copyInventory(inv *domain.Inventory) *domain.Inventory {
+	result := domain.NewInventory(inv.MerchantID)
+	result.SetDishes(inv.Dishes, inv.UpdatedAt)
+	for i, dish := range result.Dishes {
+		if dish.Stock != nil {
+			stock := *dish.Stock
+			result.Dishes[i].Stock = &stock
+		}
+	}
+	for day, sold := range inv.DailySold {
+		result.DailySold[day] = make(map[string]int, len(sold))
+		for dishID, quantity := range sold {
+			result.DailySold[day][dishID] = quantity
+		}
+	}
+	for orderNumber, reservation := range inv.Reservations {
+		reservation.Lines = append([]domain.StockLine(nil), reservation.Lines...)
+		result.Reservations[orderNumber] = reservation
+	}
+	return result
+}
+
+// lockedOrderRepository 可并发写入的模拟订单仓储
+type lockedOrderRepository struct {
+	*MockOrderRepository
+	mu sync.Mutex
+}
+
+func (r *lockedOrderRepository) Create(ctx context.Context, order *domain.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.MockOrderRepository.Create(ctx, order)
+}
+
+// inventoryFixture 宫保鸡丁剩余 5 份、拍黄瓜每日限售 3 份的商家，以及占用库存的订单服务
+type inventoryFixture struct {
+	repo      *MockOrderRepository
+	stock     *MockInventoryRepository
+	orders    OrderService
+	inventory InventoryService
+}
+
+// newInventoryFixture 创建餐品库存测试环境（时钟为周二 11:00）
+func newInventoryFixture(t *testing.T) *inventoryFixture {
+	f := &inventoryFixture{
+		repo:  NewMockOrderRepository().(*MockOrderRepository),
+		stock: NewMockInventoryRepository(),
+	}
+	clock := func() time.Time { return tuesdayLunch }
+	f.orders = NewOrderService(f.repo, WithPaymentGateway(&MockPaymentGateway{}), WithInventory(f.stock), WithOrderClock(clock))
+	f.inventory = NewInventoryService(NewMockMerchantProfileRepository(), f.stock, WithInventoryClock(clock))
+
+	stock := 5
+	_, err := f.inventory.UpdateInventory(context.Background(), "merchant_001", &UpdateInventoryRequest{
+		Dishes: []DishStockRequest{{DishID: "dish_003", DailyLimit: 3}, {DishID: "dish_001", Stock: &stock}},
+	})
+	require.NoError(t, err)
+	return f
+}
+
+// dish 查询餐品今天的库存数据
+func (f *inventoryFixture) dish(t *testing.T, dishID string) DishStockData {
+	t.Helper()
+	data, err := f.inventory.GetInventory(context.Background(), "merchant_001")
+	require.NoError(t, err)
+	for _, dish := range data.Dishes {
+		if dish.DishID == dishID {
+			return dish
+		}
+	}
+	t.Fatalf("dish %s not configured", dishID)
+	return DishStockData{}
+}
+
+func TestInventoryService_UpdateAndGet(t *testing.T) {
+	// Arrange
+	f := newInventoryFixture(t)
+	ctx := context.Background()
+
+	// Act
+	data, err := f.inventory.GetInventory(ctx, "merchant_001")
+	require.NoError(t, err)
+	unconfigured, unconfiguredErr := f.inventory.GetInventory(ctx, "merchant_002")
+	_, duplicateErr := f.inventory.UpdateInventory(ctx, "merchant_001", &UpdateInventoryRequest{
+		Dishes: []DishStockRequest{{DishID: "dish_001"}, {DishID: "dish_001", DailyLimit: 10}},
+	})
+	_, invalidErr := f.inventory.UpdateInventory(ctx, "merchant_001", &UpdateInventoryRequest{
+		Dishes: []DishStockRequest{{DishID: "dish_001", DailyLimit: -1}},
+	})
+
+	// Assert - 按 dishId 升序返回，未配置的商家返回空列表
+	require.Len(t, data.Dishes, 2)
+	assert.Equal(t, "dish_001", data.Dishes[0].DishID)
+	assert.Equal(t, 5, *data.Dishes[0].Stock)
+	assert.Equal(t, 5, data.Dishes[0].Available)
+	assert.Nil(t, data.Dishes[1].Stock)
+	assert.Equal(t, 3, data.Dishes[1].Available)
+	assert.Equal(t, "2025-06-03T11:00:00+08:00", data.UpdatedAt)
+	require.NoError(t, unconfiguredErr)
+	assert.Empty(t, unconfigured.Dishes)
+	assert.Empty(t, unconfigured.UpdatedAt)
+	var validationErr *ValidationError
+	require.ErrorAs(t, duplicateErr, &validationErr)
+	assert.Equal(t, "Dishes", validationErr.Field)
+	assert.ErrorAs(t, invalidErr, &validationErr)
+}
+
+func TestOrderService_CreateOrder_ReservesStock(t *testing.T) {
+	// Arrange
+	f := newInventoryFixture(t)
+	ctx := context.Background()
+
+	// Act - 第一单占用 2 份宫保鸡丁和 1 份拍黄瓜，第二单要 4 份宫保鸡丁
+	_, err := f.orders.CreateOrder(ctx, 1001, newMerchantRuleOrderRequest(2))
+	require.NoError(t, err)
+	_, quoteErr := f.orders.QuoteOrder(ctx, 1001, newMerchantRuleOrderRequest(4))
+	orderData, err := f.orders.CreateOrder(ctx, 1001, newMerchantRuleOrderRequest(4))
+
+	// Assert - 错误指明无法满足的订单项，售罄的订单不占用任何库存
+	assert.Nil(t, orderData)
+	assertBusinessCode(t, quoteErr, domain.ErrDishSoldOut)
+	var businessErr *BusinessError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, domain.ErrDishSoldOut.Code, businessErr.Code)
+	assert.Equal(t, "items[1] dish_001 (宫保鸡丁) is sold out: requested 4, available 3", businessErr.Message)
+	assert.Len(t, f.repo.orders, 1)
+	assert.Equal(t, 3, f.dish(t, "dish_001").Available)
+	assert.Equal(t, 1, f.dish(t, "dish_003").SoldToday)
+}
+
+func TestOrderService_StockReleasedOnCancelAndCommittedOnAccept(t *testing.T) {
+	// Arrange
+	f := newInventoryFixture(t)
+	ctx := context.Background()
+	cancelled, err := f.orders.CreateOrder(ctx, 1001, newMerchantRuleOrderRequest(2))
+	require.NoError(t, err)
+	accepted, err := f.orders.CreateOrder(ctx, 1001, newMerchantRuleOrderRequest(1))
+	require.NoError(t, err)
+
+	// Act
+	_, err = f.orders.CancelOrder(ctx, 1001, cancelled.OrderNumber, &CancelOrderRequest{})
+	require.NoError(t, err)
+	payOrder(t, f.orders, 1001, accepted.OrderNumber, "pay_001")
+	_, err = f.orders.AcceptOrder(ctx, "merchant_001", accepted.OrderNumber)
+	require.NoError(t, err)
+	_, err = f.orders.RefundOrder(ctx, 1001, accepted.OrderNumber, &RefundOrderRequest{Reason: "不想要了"})
+	require.NoError(t, err)
+
+	// Assert - 取消的订单归还库存，接单后的订单已售出，退款不再归还
+	assert.Equal(t, 4, f.dish(t, "dish_001").Available)
+	assert.Equal(t, 1, f.dish(t, "dish_003").SoldToday)
+	inv, err := f.stock.Find(ctx, "merchant_001")
+	require.NoError(t, err)
+	assert.Empty(t, inv.Reservations)
+}
+
+func TestOrderService_CreateOrder_ConcurrentStockReservation(t *testing.T) {
+	// Arrange - 宫保鸡丁剩余 5 份，20 个用户并发各下单 1 份
+	f := newInventoryFixture(t)
+	repo := &lockedOrderRepository{MockOrderRepository: f.repo}
+	orders := NewOrderService(repo, WithInventory(f.stock), WithOrderClock(func() time.Time { return tuesdayLunch }))
+	ctx := context.Background()
+
+	// Act
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := newOptionsOrderRequest(OrderItemRequest{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00})
+			_, errs[i] = orders.CreateOrder(ctx, uint64(1001+i), req)
+		}()
+	}
+	wg.Wait()
+
+	// Assert - 恰好 5 单成功，其余全部售罄，不超卖
+	created, soldOut := 0, 0
+	for _, err := range errs {
+		var businessErr *BusinessError
+		switch {
+		case err == nil:
+			created++
+		case errors.As(err, &businessErr) && businessErr.Code == domain.ErrDishSoldOut.Code:
+			soldOut++
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	assert.Equal(t, 5, created)
+	assert.Equal(t, 15, soldOut)
+	assert.Len(t, f.repo.orders, 5)
+	assert.Equal(t, 0, f.dish(t, "dish_001").Available)
+	inv, _ := f.stock.Find(ctx, "merchant_001")
+	assert.Len(t, inv.Reservations, 5)
+}
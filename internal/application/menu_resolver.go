@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shopspring/decimal"
+	"order-service/internal/domain"
+)
+
+// menuResolver 按商家菜单将订单项请求解析为领域订单项（下单和购物车价格预览共用）
+// catalog 为空时不能选择规格和套餐组件
+type menuResolver struct {
+	catalog MenuCatalog
+}
+
+// resolveItems 转换订单项
+// 菜单中的套餐按套餐价和组件生成订单项；菜单中的餐品名称和单价以菜单为准，并按选项组规则校验规格（包括未选择必选规格）；
+// 请求中的名称和单价只用于不在菜单中的餐品，这类餐品不能选择规格
+func (r menuResolver) resolveItems(ctx context.Context, merchantID string, items []OrderItemRequest) ([]domain.OrderItem, error) {
+	result := make([]domain.OrderItem, len(items))
+	for i, item := range items {
+		combo, err := r.findCombo(ctx, merchantID, item)
+		if err != nil {
+			return nil, err
+		}
+		if combo != nil {
+			if result[i], err = r.comboItem(combo, item); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if result[i], err = r.dishItem(ctx, merchantID, item); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// findCombo 查找订单项对应的套餐，不是套餐时返回 nil；选择了套餐组件但套餐不在菜单中时返回错误
+func (r menuResolver) findCombo(ctx context.Context, merchantID string, item OrderItemRequest) (*domain.Combo, error) {
+	var combo *domain.Combo
+	if r.catalog != nil {
+		found, err := r.catalog.FindCombo(ctx, merchantID, item.DishID)
+		var notFound *NotFoundError
+		switch {
+		case err == nil:
+			combo = found
+		case !errors.As(err, &notFound):
+			return nil, NewInternalError("failed to find combo", err)
+		}
+	}
+	if combo == nil && len(item.Components) > 0 {
+		return nil, toApplicationError(domain.NewDomainError(domain.ErrComboNotInMenu.Code, "combo "+item.DishID+" is not in merchant menu"))
+	}
+	return combo, nil
+}
+
+// comboItem 生成套餐订单项（名称和套餐价以菜单为准，未替换的组件位使用默认餐品）
+func (r menuResolver) comboItem(combo *domain.Combo, item OrderItemRequest) (domain.OrderItem, error) {
+	if len(item.Options) > 0 {
+		return domain.OrderItem{}, toApplicationError(domain.NewDomainError(domain.ErrUnknownDishOption.Code, "combo "+combo.ComboID+" has no options"))
+	}
+
+	selections := make([]domain.ComboSelection, len(item.Components))
+	for i, component := range item.Components {
+		selections[i] = domain.ComboSelection{SlotID: component.SlotID, DishID: component.DishID}
+	}
+	components, err := combo.SelectComponents(selections)
+	if err != nil {
+		return domain.OrderItem{}, toApplicationError(err)
+	}
+	return domain.OrderItem{
+		DishID:     combo.ComboID,
+		DishName:   combo.Name,
+		Quantity:   item.Quantity,
+		Price:      combo.Price,
+		Components: components,
+	}, nil
+}
+
+// dishItem 生成餐品订单项：菜单中的餐品按菜单的名称、单价和选项组规则生成，不在菜单中的餐品不能选择规格
+func (r menuResolver) dishItem(ctx context.Context, merchantID string, item OrderItemRequest) (domain.OrderItem, error) {
+	result := domain.OrderItem{
+		DishID:   item.DishID,
+		DishName: item.DishName,
+		Quantity: item.Quantity,
+		Price:    decimal.NewFromFloat(item.Price),
+	}
+	if r.catalog == nil {
+		if len(item.Options) > 0 {
+			return domain.OrderItem{}, toApplicationError(domain.ErrDishNotInMenu)
+		}
+		return result, nil
+	}
+
+	dish, err := r.catalog.FindDish(ctx, merchantID, item.DishID)
+	if err != nil {
+		var notFound *NotFoundError
+		if !errors.As(err, &notFound) {
+			return domain.OrderItem{}, NewInternalError("failed to find dish", err)
+		}
+		if len(item.Options) > 0 {
+			return domain.OrderItem{}, toApplicationError(domain.NewDomainError(domain.ErrDishNotInMenu.Code, "dish "+item.DishID+" is not in merchant menu"))
+		}
+		return result, nil
+	}
+
+	selections := make([]domain.OptionSelection, len(item.Options))
+	for i, option := range item.Options {
+		selections[i] = domain.OptionSelection{GroupID: option.GroupID, OptionID: option.OptionID}
+	}
+	if result.Options, err = dish.SelectOptions(selections); err != nil {
+		return domain.OrderItem{}, toApplicationError(err)
+	}
+	result.DishName = dish.Name
+	result.Price = dish.Price
+	return result, nil
+}
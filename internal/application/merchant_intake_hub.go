@@ -0,0 +1,404 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"order-service/internal/domain"
+)
+
+// 商家接单推送默认配置
+const (
+	DefaultIntakeBufferSize = 64
+	DefaultIntakeMaxPending = 1000
+	DefaultIntakeAckHistory = 1000
+)
+
+// MerchantOrderPush 推送给商家终端的订单（新订单、已支付订单或到达推送时间的预订单）
+type MerchantOrderPush struct {
+	DeliveryID   string // 投递ID（领域事件ID），商家终端确认时回传
+	OrderNumber  string
+	EventType    string
+	Status       string
+	Items        []OrderPushItem
+	FinalAmount  string
+	ScheduledFor time.Time // 预订配送时段的开始时间（仅预订单）
+	OccurredAt   time.Time
+}
+
+// OrderPushItem 推送中的订单项（UnitPrice 含规格加价，Options 和 Components 为出餐需要的规格和套餐组件）
+type OrderPushItem struct {
+	DishID     string
+	DishName   string
+	Quantity   int
+	Price      string
+	UnitPrice  string
+	Options    []OrderItemOptionData
+	Components []OrderItemComponentData
+}
+
+// MerchantPresence 商家在线状态
+type MerchantPresence struct {
+	MerchantID  string
+	Online      bool
+	Connections int
+	ConnectedAt time.Time // 当前在线会话中最早的连接时间
+	LastSeenAt  time.Time // 最近一次收到商家终端消息的时间
+}
+
+// MerchantIntakeHub 商家接单推送中心
+// 订阅事件总线，将新订单和已支付订单推送给商家在线终端；
+// 未确认的推送会保留，商家终端重连时重新投递（至少一次，终端应按投递ID去重）
+type MerchantIntakeHub struct {
+	mu         sync.Mutex
+	merchants  map[string]*merchantIntake
+	bufferSize int
+	maxPending int
+	ackHistory int
+	now        func() time.Time
+}
+
+// merchantIntake 单个商家的待确认推送、会话和在线状态
+type merchantIntake struct {
+	pending    []MerchantOrderPush
+	acked      map[string]struct{}
+	ackedOrder []string
+	sessions   map[*MerchantSession]time.Time // 会话 -> 连接时间
+	lastSeenAt time.Time
+}
+
+// MerchantSession 商家终端会话
+type MerchantSession struct {
+	pushes     chan MerchantOrderPush
+	hub        *MerchantIntakeHub
+	merchantID string
+	closeOnce  sync.Once
+}
+
+// IntakeOption 接单推送中心可选配置
+type IntakeOption func(*MerchantIntakeHub)
+
+// WithIntakeBufferSize 配置每个会话的缓冲大小（缓冲满时断开慢会话）
+func WithIntakeBufferSize(size int) IntakeOption {
+	return func(h *MerchantIntakeHub) {
+		h.bufferSize = size
+	}
+}
+
+// WithIntakeMaxPending 配置每个商家最多保留的未确认推送数量（超过后丢弃最早的推送）
+func WithIntakeMaxPending(max int) IntakeOption {
+	return func(h *MerchantIntakeHub) {
+		h.maxPending = max
+	}
+}
+
+// WithIntakeClock 配置时钟（测试使用）
+func WithIntakeClock(now func() time.Time) IntakeOption {
+	return func(h *MerchantIntakeHub) {
+		h.now = now
+	}
+}
+
+// NewMerchantIntakeHub 创建商家接单推送中心
+func NewMerchantIntakeHub(opts ...IntakeOption) *MerchantIntakeHub {
+	h := &MerchantIntakeHub{
+		merchants:  make(map[string]*merchantIntake),
+		bufferSize: DefaultIntakeBufferSize,
+		maxPending: DefaultIntakeMaxPending,
+		ackHistory: DefaultIntakeAckHistory,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// HandleEvent 将新订单、已支付订单和到达推送时间的预订单推送给商家（订阅事件总线）
+// outbox 可能重复投递事件，待确认或已确认的投递ID会被忽略
+func (h *MerchantIntakeHub) HandleEvent(ctx context.Context, event domain.Event) error {
+	push, ok := toMerchantOrderPush(event)
+	if !ok {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	intake := h.intake(event.MerchantID())
+	if _, ok := intake.acked[push.DeliveryID]; ok {
+		return nil
+	}
+	for _, pending := range intake.pending {
+		if pending.DeliveryID == push.DeliveryID {
+			return nil
+		}
+	}
+
+	intake.pending = append(intake.pending, push)
+	if len(intake.pending) > h.maxPending {
+		intake.pending = intake.pending[len(intake.pending)-h.maxPending:]
+	}
+
+	for session := range intake.sessions {
+		select {
+		case session.pushes <- push:
+		default:
+			// 慢会话：断开连接，商家终端重连后重新投递未确认的推送
+			delete(intake.sessions, session)
+			close(session.pushes)
+		}
+	}
+	return nil
+}
+
+// Connect 建立商家终端会话，返回需要重新投递的未确认推送；会话结束时必须 Close
+func (h *MerchantIntakeHub) Connect(merchantID string) (*MerchantSession, []MerchantOrderPush) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	intake := h.intake(merchantID)
+	session := &MerchantSession{
+		pushes:     make(chan MerchantOrderPush, h.bufferSize),
+		hub:        h,
+		merchantID: merchantID,
+	}
+	now := h.now()
+	intake.sessions[session] = now
+	intake.lastSeenAt = now
+	return session, append([]MerchantOrderPush(nil), intake.pending...)
+}
+
+// Ack 确认推送已送达，返回投递ID是否在待确认列表中
+func (h *MerchantIntakeHub) Ack(merchantID, deliveryID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	intake := h.intake(merchantID)
+	return intake.ack(func(push MerchantOrderPush) bool {
+		return push.DeliveryID == deliveryID
+	}, h.ackHistory) > 0
+}
+
+// AckOrder 确认某订单的全部推送（商家已接单或拒单）
+func (h *MerchantIntakeHub) AckOrder(merchantID, orderNumber string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	intake := h.intake(merchantID)
+	intake.ack(func(push MerchantOrderPush) bool {
+		return push.OrderNumber == orderNumber
+	}, h.ackHistory)
+}
+
+// Pending 返回商家未确认的推送
+func (h *MerchantIntakeHub) Pending(merchantID string) []MerchantOrderPush {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if intake, ok := h.merchants[merchantID]; ok {
+		return append([]MerchantOrderPush(nil), intake.pending...)
+	}
+	return nil
+}
+
+// Presence 返回商家在线状态
+func (h *MerchantIntakeHub) Presence(merchantID string) MerchantPresence {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	intake, ok := h.merchants[merchantID]
+	if !ok {
+		return MerchantPresence{MerchantID: merchantID}
+	}
+	return intake.presence(merchantID)
+}
+
+// OnlineMerchants 返回当前在线的商家（按商家ID排序）
+func (h *MerchantIntakeHub) OnlineMerchants() []MerchantPresence {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result := make([]MerchantPresence, 0)
+	for merchantID, intake := range h.merchants {
+		if len(intake.sessions) > 0 {
+			result = append(result, intake.presence(merchantID))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].MerchantID < result[j].MerchantID
+	})
+	return result
+}
+
+// DisconnectAll 断开全部会话（停机时调用，商家终端重连后重新投递未确认的推送）
+func (h *MerchantIntakeHub) DisconnectAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, intake := range h.merchants {
+		for session := range intake.sessions {
+			delete(intake.sessions, session)
+			close(session.pushes)
+		}
+	}
+}
+
+// Pushes 返回实时推送通道，通道关闭表示会话已被断开
+func (s *MerchantSession) Pushes() <-chan MerchantOrderPush {
+	return s.pushes
+}
+
+// Touch 记录收到商家终端消息（更新最近活跃时间）
+func (s *MerchantSession) Touch() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	if intake, ok := s.hub.merchants[s.merchantID]; ok {
+		intake.lastSeenAt = s.hub.now()
+	}
+}
+
+// Close 结束会话
+func (s *MerchantSession) Close() {
+	s.closeOnce.Do(func() {
+		s.hub.disconnect(s)
+	})
+}
+
+// disconnect 移除会话
+func (h *MerchantIntakeHub) disconnect(session *MerchantSession) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	intake, ok := h.merchants[session.merchantID]
+	if !ok {
+		return
+	}
+	if _, ok := intake.sessions[session]; ok {
+		delete(intake.sessions, session)
+		close(session.pushes)
+	}
+}
+
+// intake 返回商家的接单状态，不存在时创建（调用方需持有锁）
+func (h *MerchantIntakeHub) intake(merchantID string) *merchantIntake {
+	if intake, ok := h.merchants[merchantID]; ok {
+		return intake
+	}
+	intake := &merchantIntake{
+		acked:    make(map[string]struct{}),
+		sessions: make(map[*MerchantSession]time.Time),
+	}
+	h.merchants[merchantID] = intake
+	return intake
+}
+
+// ack 移除匹配的待确认推送并记录已确认的投递ID（保留最近 history 条用于去重），返回移除数量
+func (m *merchantIntake) ack(match func(MerchantOrderPush) bool, history int) int {
+	kept := m.pending[:0]
+	removed := 0
+	for _, push := range m.pending {
+		if !match(push) {
+			kept = append(kept, push)
+			continue
+		}
+		removed++
+		m.acked[push.DeliveryID] = struct{}{}
+		m.ackedOrder = append(m.ackedOrder, push.DeliveryID)
+	}
+	m.pending = kept
+
+	for len(m.ackedOrder) > history {
+		delete(m.acked, m.ackedOrder[0])
+		m.ackedOrder = m.ackedOrder[1:]
+	}
+	return removed
+}
+
+// presence 生成在线状态（调用方需持有锁）
+func (m *merchantIntake) presence(merchantID string) MerchantPresence {
+	presence := MerchantPresence{
+		MerchantID:  merchantID,
+		Online:      len(m.sessions) > 0,
+		Connections: len(m.sessions),
+		LastSeenAt:  m.lastSeenAt,
+	}
+	for _, connectedAt := range m.sessions {
+		if presence.ConnectedAt.IsZero() || connectedAt.Before(presence.ConnectedAt) {
+			presence.ConnectedAt = connectedAt
+		}
+	}
+	return presence
+}
+
+// toMerchantOrderPush 将新订单、已支付和预订单推送事件转换为商家推送
+// 预订单的新订单和已支付事件不推送，在配送时段开始前由 order.released 事件一次推送
+func toMerchantOrderPush(event domain.Event) (MerchantOrderPush, bool) {
+	push := MerchantOrderPush{
+		DeliveryID:  event.EventID(),
+		OrderNumber: event.AggregateID(),
+		EventType:   event.EventType(),
+		OccurredAt:  event.OccurredAt(),
+	}
+	switch e := event.(type) {
+	case domain.OrderCreated:
+		if e.ScheduledFor != nil {
+			return MerchantOrderPush{}, false
+		}
+		push.Status = string(e.Status)
+		push.FinalAmount = e.FinalAmount
+		push.Items = toOrderPushItems(e.Items)
+	case domain.OrderPaid:
+		if e.ScheduledFor != nil {
+			return MerchantOrderPush{}, false
+		}
+		push.Status = string(e.Status)
+		push.FinalAmount = e.Amount
+	case domain.OrderReleased:
+		push.Status = string(e.Status)
+		push.FinalAmount = e.FinalAmount
+		push.Items = toOrderPushItems(e.Items)
+		push.ScheduledFor = e.ScheduledFor
+	default:
+		return MerchantOrderPush{}, false
+	}
+	return push, true
+}
+
+// toOrderPushItems 将事件中的订单项快照转换为推送订单项
+func toOrderPushItems(eventItems []domain.EventOrderItem) []OrderPushItem {
+	items := make([]OrderPushItem, len(eventItems))
+	for i, item := range eventItems {
+		items[i] = OrderPushItem{
+			DishID:    item.DishID,
+			DishName:  item.DishName,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
+			UnitPrice: item.UnitPrice,
+		}
+		for _, option := range item.Options {
+			items[i].Options = append(items[i].Options, OrderItemOptionData{
+				GroupID:    option.GroupID,
+				GroupName:  option.GroupName,
+				OptionID:   option.OptionID,
+				OptionName: option.OptionName,
+				PriceDelta: option.PriceDelta,
+			})
+		}
+		for _, component := range item.Components {
+			items[i].Components = append(items[i].Components, OrderItemComponentData{
+				SlotID:         component.SlotID,
+				SlotName:       component.SlotName,
+				DishID:         component.DishID,
+				DishName:       component.DishName,
+				Quantity:       component.Quantity,
+				Surcharge:      component.Surcharge,
+				AllocatedPrice: component.AllocatedPrice,
+			})
+		}
+	}
+	return items
+}
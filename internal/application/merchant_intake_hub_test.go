@@ -0,0 +1,202 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIntakeEvents 创建订单并支付，返回 created、paid 两个事件
+func newIntakeEvents(t *testing.T, merchantID string) (*domain.Order, []domain.Event) {
+	order := domain.NewOrder(1001, merchantID, []domain.OrderItem{
+		{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: decimal.NewFromFloat(28.00)},
+	}, domain.DeliveryInfo{}, "")
+	require.NoError(t, order.MarkPaid("pay_001"))
+	return order, order.PullEvents()
+}
+
+func TestMerchantIntakeHub_PushesToConnectedSessions(t *testing.T) {
+	// Arrange
+	hub := NewMerchantIntakeHub()
+	session, pending := hub.Connect("merchant_001")
+	defer session.Close()
+	order, events := newIntakeEvents(t, "merchant_001")
+
+	// Act
+	for _, event := range events {
+		require.NoError(t, hub.HandleEvent(context.Background(), event))
+	}
+
+	// Assert
+	assert.Empty(t, pending)
+	created := <-session.Pushes()
+	paid := <-session.Pushes()
+	assert.Equal(t, order.OrderNumber, created.OrderNumber)
+	assert.Equal(t, domain.EventTypeOrderCreated, created.EventType)
+	require.Len(t, created.Items, 1)
+	assert.Equal(t, "宫保鸡丁", created.Items[0].DishName)
+	assert.Equal(t, "32.00", created.FinalAmount)
+	assert.Equal(t, domain.EventTypeOrderPaid, paid.EventType)
+	assert.Equal(t, "PAID", paid.Status)
+}
+
+func TestMerchantIntakeHub_RedeliversUnackedOnReconnect(t *testing.T) {
+	// Arrange - 商家离线时产生的推送
+	hub := NewMerchantIntakeHub()
+	_, events := newIntakeEvents(t, "merchant_001")
+	for _, event := range events {
+		require.NoError(t, hub.HandleEvent(context.Background(), event))
+	}
+
+	// Act - 连接后确认第一条推送，然后断线重连
+	session, pending := hub.Connect("merchant_001")
+	require.Len(t, pending, 2)
+	assert.True(t, hub.Ack("merchant_001", pending[0].DeliveryID))
+	session.Close()
+	reconnected, redelivered := hub.Connect("merchant_001")
+	defer reconnected.Close()
+
+	// Assert - 只重新投递未确认的推送
+	require.Len(t, redelivered, 1)
+	assert.Equal(t, events[1].EventID(), redelivered[0].DeliveryID)
+
+	// 已确认的事件被重复投递时不会再推送
+	require.NoError(t, hub.HandleEvent(context.Background(), events[0]))
+	assert.Len(t, hub.Pending("merchant_001"), 1)
+}
+
+func TestMerchantIntakeHub_AckOrder(t *testing.T) {
+	hub := NewMerchantIntakeHub()
+	order, events := newIntakeEvents(t, "merchant_001")
+	_, otherEvents := newIntakeEvents(t, "merchant_001")
+	for _, event := range append(events, otherEvents...) {
+		require.NoError(t, hub.HandleEvent(context.Background(), event))
+	}
+
+	hub.AckOrder("merchant_001", order.OrderNumber)
+
+	pending := hub.Pending("merchant_001")
+	require.Len(t, pending, 2)
+	assert.NotEqual(t, order.OrderNumber, pending[0].OrderNumber)
+	assert.False(t, hub.Ack("merchant_001", events[0].EventID()))
+}
+
+func TestMerchantIntakeHub_IgnoresOtherEventsAndMerchants(t *testing.T) {
+	hub := NewMerchantIntakeHub()
+	session, _ := hub.Connect("merchant_001")
+	defer session.Close()
+
+	order := domain.NewOrder(1001, "merchant_002", nil, domain.DeliveryInfo{}, "")
+	require.NoError(t, order.Cancel(""))
+	for _, event := range order.PullEvents() {
+		require.NoError(t, hub.HandleEvent(context.Background(), event))
+	}
+
+	// 其他商家的订单不推送给本商家，取消事件不推送
+	assert.Len(t, session.Pushes(), 0)
+	pending := hub.Pending("merchant_002")
+	require.Len(t, pending, 1)
+	assert.Equal(t, domain.EventTypeOrderCreated, pending[0].EventType)
+}
+
+func TestMerchantIntakeHub_DropsSlowSession(t *testing.T) {
+	hub := NewMerchantIntakeHub(WithIntakeBufferSize(1))
+	session, _ := hub.Connect("merchant_001")
+	defer session.Close()
+
+	_, events := newIntakeEvents(t, "merchant_001")
+	for _, event := range events {
+		require.NoError(t, hub.HandleEvent(context.Background(), event))
+	}
+
+	_, ok := <-session.Pushes()
+	assert.True(t, ok)
+	_, ok = <-session.Pushes()
+	assert.False(t, ok)
+	// 推送仍待确认，重连后重新投递
+	assert.Len(t, hub.Pending("merchant_001"), 2)
+	assert.False(t, hub.Presence("merchant_001").Online)
+}
+
+func TestMerchantIntakeHub_Presence(t *testing.T) {
+	// Arrange
+	clock := &testClock{now: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	hub := NewMerchantIntakeHub(WithIntakeClock(clock.Now))
+
+	// Act
+	first, _ := hub.Connect("merchant_002")
+	clock.Advance(time.Minute)
+	second, _ := hub.Connect("merchant_002")
+	tablet, _ := hub.Connect("merchant_001")
+	clock.Advance(time.Minute)
+	second.Touch()
+
+	// Assert
+	presence := hub.Presence("merchant_002")
+	assert.True(t, presence.Online)
+	assert.Equal(t, 2, presence.Connections)
+	assert.Equal(t, clock.now.Add(-2*time.Minute), presence.ConnectedAt)
+	assert.Equal(t, clock.now, presence.LastSeenAt)
+
+	online := hub.OnlineMerchants()
+	require.Len(t, online, 2)
+	assert.Equal(t, "merchant_001", online[0].MerchantID)
+
+	first.Close()
+	second.Close()
+	tablet.Close()
+	assert.False(t, hub.Presence("merchant_002").Online)
+	assert.Empty(t, hub.OnlineMerchants())
+	assert.False(t, hub.Presence("merchant_999").Online)
+}
+
+func TestMerchantIntakeHub_DisconnectAll(t *testing.T) {
+	// Arrange
+	hub := NewMerchantIntakeHub()
+	session, _ := hub.Connect("merchant_001")
+	_, events := newIntakeEvents(t, "merchant_001")
+
+	// Act
+	hub.DisconnectAll()
+	require.NoError(t, hub.HandleEvent(context.Background(), events[0]))
+
+	// Assert - 会话断开，推送保留在待确认列表中供重连后投递
+	_, ok := <-session.Pushes()
+	assert.False(t, ok)
+	assert.Empty(t, hub.OnlineMerchants())
+	assert.Len(t, hub.Pending("merchant_001"), 1)
+	session.Close()
+}
+
+func TestMerchantIntakeHub_PushesScheduledOrderOnRelease(t *testing.T) {
+	// Arrange
+	hub := NewMerchantIntakeHub()
+	session, _ := hub.Connect("merchant_001")
+	defer session.Close()
+	slot := time.Date(2025, 6, 3, 12, 30, 0, 0, time.UTC)
+	order := domain.NewScheduledOrder(1001, "merchant_001", []domain.OrderItem{
+		{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: decimal.NewFromFloat(28.00)},
+	}, domain.DeliveryInfo{}, "", domain.DefaultFees(), slot)
+	require.NoError(t, order.MarkPaid("pay_001"))
+	require.NoError(t, order.Release(slot.Add(-30*time.Minute)))
+
+	// Act
+	for _, event := range order.PullEvents() {
+		require.NoError(t, hub.HandleEvent(context.Background(), event))
+	}
+
+	// Assert - 预订单的新订单和已支付事件不推送，推送时带订单项和配送时段
+	require.Len(t, session.Pushes(), 1)
+	push := <-session.Pushes()
+	assert.Equal(t, domain.EventTypeOrderReleased, push.EventType)
+	assert.Equal(t, "PAID", push.Status)
+	assert.Equal(t, slot, push.ScheduledFor)
+	require.Len(t, push.Items, 1)
+	assert.Equal(t, "32.00", push.FinalAmount)
+}
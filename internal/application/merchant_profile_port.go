@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"order-service/internal/domain"
+)
+
+// ClockPattern 营业时段时刻格式 HH:MM（00:00 至 24:00）
+const ClockPattern = `^(([01]\d|2[0-3]):[0-5]\d|24:00)$`
+
+var clockRegex = regexp.MustCompile(ClockPattern)
+
+// Weekdays 营业时段的星期名称（按 time.Weekday 排列）
+var Weekdays = []string{"SUNDAY", "MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY"}
+
+func init() {
+	// 注册营业时段时刻和休息日日期验证函数
+	_ = Validator.RegisterValidation("clock", validateClock)
+	_ = Validator.RegisterValidation("date", validateDate)
+}
+
+// validateClock 验证营业时段时刻
+func validateClock(fl validator.FieldLevel) bool {
+	return clockRegex.MatchString(fl.Field().String())
+}
+
+// validateDate 验证休息日日期（YYYY-MM-DD）
+func validateDate(fl validator.FieldLevel) bool {
+	_, err := time.Parse(domain.HolidayLayout, fl.Field().String())
+	return err == nil
+}
+
+// MerchantProfileService 定义商家营业配置接口（输入端口）
+// 未配置营业信息的商家全天接单、不限起送金额、订单数和预订时段容量
+type MerchantProfileService interface {
+	GetProfile(ctx context.Context, merchantID string) (*MerchantProfileData, error)
+	// UpdateProfile 整体替换营业配置（不影响忙碌暂停状态）
+	UpdateProfile(ctx context.Context, merchantID string, req *UpdateMerchantProfileRequest) (*MerchantProfileData, error)
+	Pause(ctx context.Context, merchantID string, req *PauseMerchantRequest) (*MerchantProfileData, error)
+	Resume(ctx context.Context, merchantID string) (*MerchantProfileData, error)
+}
+
+// MerchantProfileRepository 定义商家营业配置持久化接口（输出端口）
+// 商家未配置时 Find 返回 NotFoundError
+type MerchantProfileRepository interface {
+	Find(ctx context.Context, merchantID string) (*domain.MerchantProfile, error)
+	Save(ctx context.Context, profile *domain.MerchantProfile) error
+}
+
+// UpdateMerchantProfileRequest 更新商家营业配置请求（OpeningHours 为空表示全天营业）
+type UpdateMerchantProfileRequest struct {
+	Timezone        string                `validate:"required,timezone"`
+	OpeningHours    []OpeningHoursRequest `validate:"max=50,dive"`
+	Holidays        []string              `validate:"max=366,dive,date"`
+	MinOrderAmount  float64               `validate:"min=0,max=10000"`
+	MaxActiveOrders int                   `validate:"min=0,max=10000"`
+	SlotCapacity    int                   `validate:"min=0,max=10000"`
+}
+
+// OpeningHoursRequest 营业时段请求（Close 须晚于 Open，跨零点的营业拆成两天的两个时段）
+type OpeningHoursRequest struct {
+	Weekday string `validate:"required,oneof=SUNDAY MONDAY TUESDAY WEDNESDAY THURSDAY FRIDAY SATURDAY"`
+	Open    string `validate:"required,clock"`
+	Close   string `validate:"required,clock"`
+}
+
+// PauseMerchantRequest 忙碌暂停接单请求
+type PauseMerchantRequest struct {
+	Minutes int `validate:"required,min=1,max=1440"`
+}
+
+// MerchantProfileData 商家营业配置数据（Status 为当前接单状态）
+type MerchantProfileData struct {
+	MerchantID      string
+	Timezone        string
+	OpeningHours    []OpeningHoursData
+	Holidays        []string
+	PausedUntil     string
+	MinOrderAmount  string
+	MaxActiveOrders int
+	SlotCapacity    int
+	Status          string
+	UpdatedAt       string
+}
+
+// OpeningHoursData 营业时段数据
+type OpeningHoursData struct {
+	Weekday string
+	Open    string
+	Close   string
+}
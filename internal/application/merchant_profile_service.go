@@ -0,0 +1,190 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"order-service/internal/domain"
+)
+
+// DefaultMerchantTimezone 未配置营业信息的商家使用的时区
+const DefaultMerchantTimezone = "Asia/Shanghai"
+
+// merchantProfileService 商家营业配置应用服务实现
+type merchantProfileService struct {
+	repo     MerchantProfileRepository
+	location *time.Location
+	now      func() time.Time
+}
+
+// MerchantProfileOption 商家营业配置服务可选配置
+type MerchantProfileOption func(*merchantProfileService)
+
+// WithMerchantProfileClock 配置时钟（测试使用）
+func WithMerchantProfileClock(now func() time.Time) MerchantProfileOption {
+	return func(s *merchantProfileService) {
+		s.now = now
+	}
+}
+
+// NewMerchantProfileService 创建商家营业配置服务实例
+func NewMerchantProfileService(repo MerchantProfileRepository, opts ...MerchantProfileOption) MerchantProfileService {
+	s := &merchantProfileService{repo: repo, location: defaultMerchantLocation(), now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetProfile 实现 MerchantProfileService 接口（未配置时返回全天营业的默认配置）
+func (s *merchantProfileService) GetProfile(ctx context.Context, merchantID string) (*MerchantProfileData, error) {
+	profile, err := s.loadProfile(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	return convertToProfileDTO(profile, s.now()), nil
+}
+
+// UpdateProfile 实现 MerchantProfileService 接口
+func (s *merchantProfileService) UpdateProfile(ctx context.Context, merchantID string, req *UpdateMerchantProfileRequest) (*MerchantProfileData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	location, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return nil, NewValidationError("Timezone", err.Error())
+	}
+	hours := make([]domain.OpeningHours, len(req.OpeningHours))
+	for i, h := range req.OpeningHours {
+		open, close := parseClock(h.Open), parseClock(h.Close)
+		if close <= open {
+			return nil, NewValidationError("OpeningHours", fmt.Sprintf("%s %s-%s: close must be after open", h.Weekday, h.Open, h.Close))
+		}
+		hours[i] = domain.OpeningHours{Weekday: time.Weekday(slices.Index(Weekdays, h.Weekday)), Open: open, Close: close}
+	}
+
+	profile, err := s.loadProfile(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	profile.Location = location
+	profile.OpeningHours = hours
+	profile.Holidays = slices.Compact(slices.Sorted(slices.Values(req.Holidays)))
+	profile.MinOrderAmount = decimal.NewFromFloat(req.MinOrderAmount).Round(2)
+	profile.MaxActiveOrders = req.MaxActiveOrders
+	profile.SlotCapacity = req.SlotCapacity
+	profile.UpdatedAt = s.now()
+	return s.save(ctx, profile)
+}
+
+// Pause 实现 MerchantProfileService 接口
+func (s *merchantProfileService) Pause(ctx context.Context, merchantID string, req *PauseMerchantRequest) (*MerchantProfileData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	profile, err := s.loadProfile(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	profile.Pause(s.now(), time.Duration(req.Minutes)*time.Minute)
+	return s.save(ctx, profile)
+}
+
+// Resume 实现 MerchantProfileService 接口
+func (s *merchantProfileService) Resume(ctx context.Context, merchantID string) (*MerchantProfileData, error) {
+	profile, err := s.loadProfile(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	profile.Resume(s.now())
+	return s.save(ctx, profile)
+}
+
+// loadProfile 查询商家营业配置，未配置时返回默认配置
+func (s *merchantProfileService) loadProfile(ctx context.Context, merchantID string) (*domain.MerchantProfile, error) {
+	return loadMerchantProfile(ctx, s.repo, merchantID, s.location)
+}
+
+// save 保存商家营业配置并返回 DTO
+func (s *merchantProfileService) save(ctx context.Context, profile *domain.MerchantProfile) (*MerchantProfileData, error) {
+	if err := s.repo.Save(ctx, profile); err != nil {
+		return nil, NewInternalError("failed to save merchant profile", err)
+	}
+	return convertToProfileDTO(profile, s.now()), nil
+}
+
+// findMerchantProfile 查询商家营业配置，未配置时返回 nil
+func findMerchantProfile(ctx context.Context, repo MerchantProfileRepository, merchantID string) (*domain.MerchantProfile, error) {
+	profile, err := repo.Find(ctx, merchantID)
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, NewInternalError("failed to find merchant profile", err)
+	}
+	return profile, nil
+}
+
+// loadMerchantProfile 查询商家营业配置，未配置时返回 location 时区全天营业的默认配置
+func loadMerchantProfile(ctx context.Context, repo MerchantProfileRepository, merchantID string, location *time.Location) (*domain.MerchantProfile, error) {
+	profile, err := findMerchantProfile(ctx, repo, merchantID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		profile = domain.NewMerchantProfile(merchantID, location)
+	}
+	return profile, nil
+}
+
+// defaultMerchantLocation 默认商家时区（时区数据不可用时使用 UTC）
+func defaultMerchantLocation() *time.Location {
+	location, err := time.LoadLocation(DefaultMerchantTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// parseClock 将已校验的 HH:MM 转换为当天 0 点起的分钟数
+func parseClock(clock string) int {
+	var hour, minute int
+	_, _ = fmt.Sscanf(clock, "%d:%d", &hour, &minute)
+	return hour*60 + minute
+}
+
+// formatClock 将当天 0 点起的分钟数格式化为 HH:MM
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// convertToProfileDTO 转换商家营业配置到 DTO（PausedUntil 仅在暂停中返回）
+func convertToProfileDTO(profile *domain.MerchantProfile, now time.Time) *MerchantProfileData {
+	data := &MerchantProfileData{
+		MerchantID:      profile.MerchantID,
+		Timezone:        profile.Location.String(),
+		OpeningHours:    make([]OpeningHoursData, len(profile.OpeningHours)),
+		Holidays:        slices.Clone(profile.Holidays),
+		MinOrderAmount:  profile.MinOrderAmount.StringFixed(2),
+		MaxActiveOrders: profile.MaxActiveOrders,
+		SlotCapacity:    profile.SlotCapacity,
+		Status:          string(profile.Status(now)),
+		UpdatedAt:       profile.UpdatedAt.Format(time.RFC3339),
+	}
+	for i, h := range profile.OpeningHours {
+		data.OpeningHours[i] = OpeningHoursData{
+			Weekday: Weekdays[h.Weekday],
+			Open:    formatClock(h.Open),
+			Close:   formatClock(h.Close),
+		}
+	}
+	if profile.Paused(now) {
+		data.PausedUntil = profile.PausedUntil.Format(time.RFC3339)
+	}
+	return data
+}
@@ -0,0 +1,239 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MockMerchantProfileRepository 模拟商家营业配置仓储
+type MockMerchantProfileRepository struct {
+	profiles map[string]*domain.MerchantProfile
+}
+
+func NewMockMerchantProfileRepository() *MockMerchantProfileRepository {
+	return &MockMerchantProfileRepository{profiles: make(map[string]*domain.MerchantProfile)}
+}
+
+func (m *MockMerchantProfileRepository) Find(ctx context.Context, merchantID string) (*domain.MerchantProfile, error) {
+	profile, ok := m.profiles[merchantID]
+	if !ok {
+		return nil, NewNotFoundError("merchant profile not found")
+	}
+	return profile, nil
+}
+
+func (m *MockMerchantProfileRepository) Save(ctx context.Context, profile *domain.MerchantProfile) error {
+	m.profiles[profile.MerchantID] = profile
+	return nil
+}
+
+// tuesdayLunch 商家时区周二 11:00
+var tuesdayLunch = time.Date(2025, 6, 3, 11, 0, 0, 0, time.FixedZone("CST", 8*3600))
+
+// newLunchProfileRequest 每天 10:00-14:00 营业、起送 20 元、最多 2 单处理中的营业配置
+func newLunchProfileRequest() *UpdateMerchantProfileRequest {
+	req := &UpdateMerchantProfileRequest{
+		Timezone:        "Asia/Shanghai",
+		Holidays:        []string{"2025-06-09", "2025-06-02", "2025-06-09"},
+		MinOrderAmount:  20,
+		MaxActiveOrders: 2,
+	}
+	for _, day := range Weekdays {
+		req.OpeningHours = append(req.OpeningHours, OpeningHoursRequest{Weekday: day, Open: "10:00", Close: "14:00"})
+	}
+	return req
+}
+
+func TestMerchantProfileService_UpdateAndGetProfile(t *testing.T) {
+	// Arrange
+	now := tuesdayLunch
+	service := NewMerchantProfileService(NewMockMerchantProfileRepository(),
+		WithMerchantProfileClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	// Act
+	updated, err := service.UpdateProfile(ctx, "merchant_001", newLunchProfileRequest())
+	require.NoError(t, err)
+	now = now.Add(3 * time.Hour)
+	profile, err := service.GetProfile(ctx, "merchant_001")
+
+	// Assert - 休息日去重排序，14:00 后不营业
+	require.NoError(t, err)
+	assert.Equal(t, "OPEN", updated.Status)
+	assert.Equal(t, "CLOSED", profile.Status)
+	assert.Equal(t, "Asia/Shanghai", profile.Timezone)
+	assert.Equal(t, []string{"2025-06-02", "2025-06-09"}, profile.Holidays)
+	assert.Equal(t, OpeningHoursData{Weekday: "SUNDAY", Open: "10:00", Close: "14:00"}, profile.OpeningHours[0])
+	assert.Equal(t, "20.00", profile.MinOrderAmount)
+	assert.Equal(t, 2, profile.MaxActiveOrders)
+}
+
+func TestMerchantProfileService_GetProfile_DefaultsToAlwaysOpen(t *testing.T) {
+	// Arrange
+	service := NewMerchantProfileService(NewMockMerchantProfileRepository())
+
+	// Act
+	profile, err := service.GetProfile(context.Background(), "merchant_001")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "OPEN", profile.Status)
+	assert.Empty(t, profile.OpeningHours)
+	assert.Equal(t, "0.00", profile.MinOrderAmount)
+}
+
+func TestMerchantProfileService_UpdateProfile_ValidationError(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(req *UpdateMerchantProfileRequest)
+	}{
+		{"未知时区", func(req *UpdateMerchantProfileRequest) { req.Timezone = "Mars/Olympus" }},
+		{"时刻格式错误", func(req *UpdateMerchantProfileRequest) { req.OpeningHours[0].Close = "24:30" }},
+		{"结束不晚于开始", func(req *UpdateMerchantProfileRequest) { req.OpeningHours[0].Close = "10:00" }},
+		{"星期名称错误", func(req *UpdateMerchantProfileRequest) { req.OpeningHours[0].Weekday = "MON" }},
+		{"休息日格式错误", func(req *UpdateMerchantProfileRequest) { req.Holidays = []string{"2025/06/02"} }},
+		{"起送金额为负", func(req *UpdateMerchantProfileRequest) { req.MinOrderAmount = -1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repo := NewMockMerchantProfileRepository()
+			service := NewMerchantProfileService(repo)
+			req := newLunchProfileRequest()
+			tt.mutate(req)
+
+			// Act
+			profile, err := service.UpdateProfile(context.Background(), "merchant_001", req)
+
+			// Assert
+			assert.Nil(t, profile)
+			assert.IsType(t, &ValidationError{}, err)
+			assert.Empty(t, repo.profiles)
+		})
+	}
+}
+
+func TestMerchantProfileService_PauseAndResume(t *testing.T) {
+	// Arrange
+	now := tuesdayLunch
+	service := NewMerchantProfileService(NewMockMerchantProfileRepository(),
+		WithMerchantProfileClock(func() time.Time { return now }))
+	ctx := context.Background()
+
+	// Act
+	paused, err := service.Pause(ctx, "merchant_001", &PauseMerchantRequest{Minutes: 20})
+	require.NoError(t, err)
+	resumed, err := service.Resume(ctx, "merchant_001")
+	require.NoError(t, err)
+	_, invalidErr := service.Pause(ctx, "merchant_001", &PauseMerchantRequest{Minutes: 0})
+
+	// Assert
+	assert.Equal(t, "PAUSED", paused.Status)
+	assert.Equal(t, now.Add(20*time.Minute).Format(time.RFC3339), paused.PausedUntil)
+	assert.Equal(t, "OPEN", resumed.Status)
+	assert.Empty(t, resumed.PausedUntil)
+	assert.IsType(t, &ValidationError{}, invalidErr)
+}
+
+func TestOrderService_CreateOrder_MerchantRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		now      time.Time
+		setup    func(t *testing.T, profiles MerchantProfileService, orders OrderService)
+		quantity int
+		wantCode *domain.DomainError
+	}{
+		{
+			name:     "营业中",
+			now:      tuesdayLunch,
+			quantity: 1,
+		},
+		{
+			name:     "非营业时间",
+			now:      tuesdayLunch.Add(4 * time.Hour),
+			quantity: 1,
+			wantCode: domain.ErrMerchantClosed,
+		},
+		{
+			name:     "休息日",
+			now:      tuesdayLunch.AddDate(0, 0, -1),
+			quantity: 1,
+			wantCode: domain.ErrMerchantHoliday,
+		},
+		{
+			name: "忙碌暂停",
+			now:  tuesdayLunch,
+			setup: func(t *testing.T, profiles MerchantProfileService, orders OrderService) {
+				_, err := profiles.Pause(context.Background(), "merchant_001", &PauseMerchantRequest{Minutes: 10})
+				require.NoError(t, err)
+			},
+			quantity: 1,
+			wantCode: domain.ErrMerchantPaused,
+		},
+		{
+			name: "达到订单上限",
+			now:  tuesdayLunch,
+			setup: func(t *testing.T, profiles MerchantProfileService, orders OrderService) {
+				for range 2 {
+					_, err := orders.CreateOrder(context.Background(), 1001, newMerchantRuleOrderRequest(1))
+					require.NoError(t, err)
+				}
+			},
+			quantity: 1,
+			wantCode: domain.ErrMerchantAtCapacity,
+		},
+		{
+			name:     "未达起送金额",
+			now:      tuesdayLunch,
+			quantity: 0,
+			wantCode: domain.ErrBelowMinOrderAmount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			clock := func() time.Time { return tt.now }
+			profileRepo := NewMockMerchantProfileRepository()
+			profiles := NewMerchantProfileService(profileRepo, WithMerchantProfileClock(clock))
+			_, err := profiles.UpdateProfile(context.Background(), "merchant_001", newLunchProfileRequest())
+			require.NoError(t, err)
+			orders := NewOrderService(NewMockOrderRepository(), WithMerchantProfiles(profileRepo), WithOrderClock(clock))
+			if tt.setup != nil {
+				tt.setup(t, profiles, orders)
+			}
+			req := newMerchantRuleOrderRequest(tt.quantity)
+
+			// Act
+			quote, quoteErr := orders.QuoteOrder(context.Background(), 1001, req)
+			orderData, err := orders.CreateOrder(context.Background(), 1001, req)
+
+			// Assert - 报价与下单使用相同的接单规则
+			if tt.wantCode == nil {
+				assert.NoError(t, quoteErr)
+				assert.NoError(t, err)
+				return
+			}
+			assert.Nil(t, quote)
+			assert.Nil(t, orderData)
+			assertBusinessCode(t, quoteErr, tt.wantCode)
+			assertBusinessCode(t, err, tt.wantCode)
+		})
+	}
+}
+
+// newMerchantRuleOrderRequest 宫保鸡丁 28 元 × quantity 加一份 8 元小菜的下单请求
+func newMerchantRuleOrderRequest(quantity int) *CreateOrderRequest {
+	items := []OrderItemRequest{{DishID: "dish_003", DishName: "拍黄瓜", Quantity: 1, Price: 8.00}}
+	if quantity > 0 {
+		items = append(items, OrderItemRequest{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: quantity, Price: 28.00})
+	}
+	return newOptionsOrderRequest(items...)
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"order-service/internal/domain"
+)
+
+// DefaultListLimit 订单列表默认每页数量
+const DefaultListLimit = 20
+
+// EncodeOrderCursor 生成订单在列表中的游标（对客户端不透明）
+func EncodeOrderCursor(order *domain.Order) string {
+	raw := fmt.Sprintf("%d:%s", order.CreatedAt.UnixNano(), order.OrderNumber)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeOrderCursor 解析订单列表游标
+func DecodeOrderCursor(cursor string) (*OrderCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("decode cursor: %w", err)
+	}
+
+	nanos, orderNumber, ok := strings.Cut(string(raw), ":")
+	if !ok || orderNumber == "" {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor timestamp: %w", err)
+	}
+	return &OrderCursor{CreatedAt: time.Unix(0, n), OrderNumber: orderNumber}, nil
+}
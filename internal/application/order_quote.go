@@ -0,0 +1,150 @@
+package application
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"order-service/internal/domain"
+)
+
+// DefaultQuoteTTL 报价 token 默认有效期
+const DefaultQuoteTTL = 5 * time.Minute
+
+// quoteVersion 报价 token 格式版本
+const quoteVersion = 1
+
+// quoteKeyLabel 从签名密钥派生报价专用密钥的标签（与 JWT 共用密钥时避免两种 token 互相冒用）
+const quoteKeyLabel = "order-quote"
+
+// QuoteSigner 签发和校验报价 token
+// token 格式为 base64url(JSON 报价内容) + "." + base64url(HMAC-SHA256 签名)，绑定用户、商家、订单项、收货坐标和报价金额
+type QuoteSigner struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+// QuoteSignerOption 报价签名可选配置
+type QuoteSignerOption func(*QuoteSigner)
+
+// WithQuoteTTL 配置报价 token 有效期
+func WithQuoteTTL(ttl time.Duration) QuoteSignerOption {
+	return func(q *QuoteSigner) {
+		q.ttl = ttl
+	}
+}
+
+// WithQuoteClock 配置时钟（测试使用）
+func WithQuoteClock(now func() time.Time) QuoteSignerOption {
+	return func(q *QuoteSigner) {
+		q.now = now
+	}
+}
+
+// NewQuoteSigner 创建报价签名实例
+func NewQuoteSigner(secret []byte, opts ...QuoteSignerOption) *QuoteSigner {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(quoteKeyLabel))
+	q := &QuoteSigner{key: mac.Sum(nil), ttl: DefaultQuoteTTL, now: time.Now}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+// quoteClaims 报价 token 内容（金额保留两位小数的字符串）
+type quoteClaims struct {
+	Version      int    `json:"v"`
+	UserID       uint64 `json:"uid"`
+	MerchantID   string `json:"mid"`
+	ItemsDigest  string `json:"items"`
+	Destination  string `json:"dest,omitempty"` // 收货坐标（配送费按距离计算，坐标变化时报价失效）
+	PackagingFee string `json:"packagingFee"`
+	DeliveryFee  string `json:"deliveryFee"`
+	FinalAmount  string `json:"finalAmount"`
+	ExpiresAt    int64  `json:"exp"`
+}
+
+// sign 为下单请求和报价价格签发 token，返回 token 和过期时间
+func (q *QuoteSigner) sign(userID uint64, req *CreateOrderRequest, pricing domain.Pricing) (string, time.Time) {
+	expiresAt := q.now().Add(q.ttl).Truncate(time.Second)
+	payload, _ := json.Marshal(quoteClaims{
+		Version:      quoteVersion,
+		UserID:       userID,
+		MerchantID:   req.MerchantID,
+		ItemsDigest:  itemsDigest(req.Items),
+		Destination:  destinationKey(req.DeliveryInfo.Location),
+		PackagingFee: pricing.PackagingFee.StringFixed(2),
+		DeliveryFee:  pricing.DeliveryFee.StringFixed(2),
+		FinalAmount:  pricing.FinalAmount.StringFixed(2),
+		ExpiresAt:    expiresAt.Unix(),
+	})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(q.signature(encoded)), expiresAt
+}
+
+// verify 校验 token 签名、有效期以及与下单请求是否一致，返回报价时的固定费用和最终金额
+func (q *QuoteSigner) verify(token string, userID uint64, req *CreateOrderRequest) (domain.Fees, decimal.Decimal, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return domain.Fees{}, decimal.Zero, domain.ErrInvalidQuote
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, q.signature(encoded)) {
+		return domain.Fees{}, decimal.Zero, domain.ErrInvalidQuote
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return domain.Fees{}, decimal.Zero, domain.ErrInvalidQuote
+	}
+
+	var claims quoteClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Version != quoteVersion {
+		return domain.Fees{}, decimal.Zero, domain.ErrInvalidQuote
+	}
+	packagingFee, err1 := decimal.NewFromString(claims.PackagingFee)
+	deliveryFee, err2 := decimal.NewFromString(claims.DeliveryFee)
+	finalAmount, err3 := decimal.NewFromString(claims.FinalAmount)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return domain.Fees{}, decimal.Zero, domain.ErrInvalidQuote
+	}
+
+	if !q.now().Before(time.Unix(claims.ExpiresAt, 0)) {
+		return domain.Fees{}, decimal.Zero, domain.ErrQuoteExpired
+	}
+	if claims.UserID != userID || claims.MerchantID != req.MerchantID || claims.ItemsDigest != itemsDigest(req.Items) ||
+		claims.Destination != destinationKey(req.DeliveryInfo.Location) {
+		return domain.Fees{}, decimal.Zero, domain.ErrQuoteMismatch
+	}
+	return domain.Fees{PackagingFee: packagingFee, DeliveryFee: deliveryFee}, finalAmount, nil
+}
+
+// signature 计算 HMAC-SHA256 签名
+func (q *QuoteSigner) signature(encoded string) []byte {
+	mac := hmac.New(sha256.New, q.key)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// destinationKey 收货坐标（保留 6 位小数，约 0.1 米），未提供坐标时为空
+func destinationKey(location *GeoPointRequest) string {
+	if location == nil {
+		return ""
+	}
+	point := toGeoPoint(*location)
+	return fmt.Sprintf("%.6f,%.6f", point.Latitude, point.Longitude)
+}
+
+// itemsDigest 订单项请求摘要（订单项或其顺序变化时摘要不同）
+func itemsDigest(items []OrderItemRequest) string {
+	payload, _ := json.Marshal(items)
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
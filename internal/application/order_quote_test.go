@@ -0,0 +1,207 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newQuoteService 创建配置了报价签名和固定时钟的应用服务
+func newQuoteService(now *time.Time, opts ...ServiceOption) (OrderService, *MockOrderRepository) {
+	repo := NewMockOrderRepository().(*MockOrderRepository)
+	signer := NewQuoteSigner([]byte("test-secret"), WithQuoteClock(func() time.Time { return *now }))
+	return NewOrderService(repo, append(opts, WithQuoteSigner(signer))...), repo
+}
+
+// newNoodleQuoteRequest 创建牛肉面（大份加卤蛋）下单请求
+func newNoodleQuoteRequest() *CreateOrderRequest {
+	return newOptionsOrderRequest(OrderItemRequest{DishID: "dish_101", DishName: "牛肉面", Quantity: 2, Price: 22.00, Options: []OrderItemOptionRequest{
+		{GroupID: "size", OptionID: "large"},
+		{GroupID: "addons", OptionID: "egg"},
+	}})
+}
+
+// assertBusinessCode 断言错误为指定错误码的业务错误
+func assertBusinessCode(t *testing.T, err error, want *domain.DomainError) {
+	t.Helper()
+	var businessErr *BusinessError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, want.Code, businessErr.Code)
+}
+
+func TestOrderService_QuoteOrder_MatchesCreateOrder(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	service, repo := newQuoteService(&now, WithMenuCatalog(newNoodleMenu()))
+	req := newNoodleQuoteRequest()
+
+	// Act
+	quote, err := service.QuoteOrder(context.Background(), 1001, req)
+	require.NoError(t, err)
+	req.QuoteToken = quote.QuoteToken
+	orderData, createErr := service.CreateOrder(context.Background(), 1001, req)
+
+	// Assert - (22.00 + 4.00 + 2.00) × 2 = 56.00，加打包费和配送费 60.00，报价不保存订单
+	require.NoError(t, createErr)
+	assert.Equal(t, "merchant_001", quote.MerchantID)
+	assert.Equal(t, PricingInfo{ItemsTotal: "56.00", PackagingFee: "1.00", DeliveryFee: "3.00", FinalAmount: "60.00"}, quote.Pricing)
+	require.Len(t, quote.Items, 1)
+	assert.Equal(t, "28.00", quote.Items[0].UnitPrice)
+	assert.Equal(t, "2025-06-01T12:05:00Z", quote.ExpiresAt)
+	assert.NotEmpty(t, quote.QuoteToken)
+	assert.Equal(t, quote.Pricing, orderData.Pricing)
+	assert.Len(t, repo.orders, 1)
+}
+
+func TestOrderService_QuoteOrder_ValidationError(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	service, _ := newQuoteService(&now)
+	req := newOptionsOrderRequest()
+
+	// Act
+	quote, err := service.QuoteOrder(context.Background(), 1001, req)
+
+	// Assert
+	assert.Nil(t, quote)
+	assert.IsType(t, &ValidationError{}, err)
+}
+
+func TestOrderService_QuoteOrder_WithoutSigner(t *testing.T) {
+	// Arrange
+	service := NewOrderService(NewMockOrderRepository())
+	req := newOptionsOrderRequest(OrderItemRequest{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00})
+
+	// Act
+	quote, err := service.QuoteOrder(context.Background(), 1001, req)
+	req.QuoteToken = "forged.token"
+	_, createErr := service.CreateOrder(context.Background(), 1001, req)
+
+	// Assert - 未配置签名时只返回价格，任何报价 token 都无效
+	require.NoError(t, err)
+	assert.Equal(t, "32.00", quote.Pricing.FinalAmount)
+	assert.Empty(t, quote.QuoteToken)
+	assert.Empty(t, quote.ExpiresAt)
+	assertBusinessCode(t, createErr, domain.ErrInvalidQuote)
+}
+
+func TestOrderService_CreateOrder_HonoursQuotedFees(t *testing.T) {
+	// Arrange - 报价后固定费用调整，签名密钥不变
+	now := time.Now()
+	quoting, _ := newQuoteService(&now)
+	ordering, _ := newQuoteService(&now, WithFees(domain.Fees{PackagingFee: decimal.NewFromInt(2), DeliveryFee: decimal.NewFromInt(5)}))
+	req := newOptionsOrderRequest(OrderItemRequest{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00})
+
+	quote, err := quoting.QuoteOrder(context.Background(), 1001, req)
+	require.NoError(t, err)
+
+	// Act
+	req.QuoteToken = quote.QuoteToken
+	orderData, err := ordering.CreateOrder(context.Background(), 1001, req)
+
+	// Assert - 按报价时的费用下单
+	require.NoError(t, err)
+	assert.Equal(t, "1.00", orderData.Pricing.PackagingFee)
+	assert.Equal(t, "3.00", orderData.Pricing.DeliveryFee)
+	assert.Equal(t, "32.00", orderData.Pricing.FinalAmount)
+}
+
+func TestOrderService_CreateOrder_RejectsInvalidQuote(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		userID   uint64
+		mutate   func(req *CreateOrderRequest)
+		after    time.Duration
+		wantCode *domain.DomainError
+	}{
+		{
+			name:     "篡改签名",
+			userID:   1001,
+			mutate:   func(req *CreateOrderRequest) { req.QuoteToken += "x" },
+			wantCode: domain.ErrInvalidQuote,
+		},
+		{
+			name:     "格式错误",
+			userID:   1001,
+			mutate:   func(req *CreateOrderRequest) { req.QuoteToken = "not-a-token" },
+			wantCode: domain.ErrInvalidQuote,
+		},
+		{
+			name:     "已过期",
+			userID:   1001,
+			after:    DefaultQuoteTTL,
+			wantCode: domain.ErrQuoteExpired,
+		},
+		{
+			name:     "其他用户",
+			userID:   1002,
+			wantCode: domain.ErrQuoteMismatch,
+		},
+		{
+			name:     "订单项变化",
+			userID:   1001,
+			mutate:   func(req *CreateOrderRequest) { req.Items[0].Quantity = 3 },
+			wantCode: domain.ErrQuoteMismatch,
+		},
+		{
+			name:     "商家变化",
+			userID:   1001,
+			mutate:   func(req *CreateOrderRequest) { req.MerchantID = "merchant_002" },
+			wantCode: domain.ErrQuoteMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			clock := now
+			service, repo := newQuoteService(&clock, WithMenuCatalog(newNoodleMenu()))
+			req := newNoodleQuoteRequest()
+			quote, err := service.QuoteOrder(context.Background(), 1001, req)
+			require.NoError(t, err)
+			req.QuoteToken = quote.QuoteToken
+			if tt.mutate != nil {
+				tt.mutate(req)
+			}
+			clock = clock.Add(tt.after)
+
+			// Act
+			orderData, err := service.CreateOrder(context.Background(), tt.userID, req)
+
+			// Assert
+			assert.Nil(t, orderData)
+			assertBusinessCode(t, err, tt.wantCode)
+			assert.Empty(t, repo.orders)
+		})
+	}
+}
+
+func TestOrderService_CreateOrder_RejectsQuoteAfterPriceChange(t *testing.T) {
+	// Arrange
+	now := time.Now()
+	menu := newNoodleMenu()
+	service, repo := newQuoteService(&now, WithMenuCatalog(menu))
+	req := newNoodleQuoteRequest()
+	quote, err := service.QuoteOrder(context.Background(), 1001, req)
+	require.NoError(t, err)
+
+	// 报价后商家调整了大份加价
+	menu.dishes["dish_101"].OptionGroups[0].Options[1].PriceDelta = decimal.NewFromInt(5)
+
+	// Act
+	req.QuoteToken = quote.QuoteToken
+	orderData, err := service.CreateOrder(context.Background(), 1001, req)
+
+	// Assert
+	assert.Nil(t, orderData)
+	assertBusinessCode(t, err, domain.ErrQuotePriceChanged)
+	assert.Empty(t, repo.orders)
+}
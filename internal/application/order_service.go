@@ -2,81 +2,843 @@ package application
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/shopspring/decimal"
 	"order-service/internal/domain"
+	"order-service/internal/logging"
 )
 
 // orderService 应用服务实现
 type orderService struct {
-	repo OrderRepository
+	repo     OrderRepository
+	payment  PaymentGateway
+	menu     menuResolver
+	fees     domain.Fees
+	quotes   *QuoteSigner
+	profiles MerchantProfileRepository
+	slots    DeliverySlotRepository
+	policy   SlotPolicy
+	areas    DeliveryAreaRepository
+	book     AddressBookRepository
+	stock    InventoryRepository
+	location *time.Location
+	now      func() time.Time
+}
+
+// ServiceOption 应用服务可选配置
+type ServiceOption func(*orderService)
+
+// WithPaymentGateway 配置支付网关（退款时使用）
+func WithPaymentGateway(payment PaymentGateway) ServiceOption {
+	return func(s *orderService) {
+		s.payment = payment
+	}
+}
+
+// WithMenuCatalog 配置商家菜单（未配置时不能选择规格）
+func WithMenuCatalog(catalog MenuCatalog) ServiceOption {
+	return func(s *orderService) {
+		s.menu = menuResolver{catalog: catalog}
+	}
+}
+
+// WithFees 配置订单固定费用（默认打包费 1 元、配送费 3 元）
+func WithFees(fees domain.Fees) ServiceOption {
+	return func(s *orderService) {
+		s.fees = fees
+	}
+}
+
+// WithQuoteSigner 配置报价 token 签名（未配置时报价不返回 token，下单时拒绝任何报价 token）
+func WithQuoteSigner(quotes *QuoteSigner) ServiceOption {
+	return func(s *orderService) {
+		s.quotes = quotes
+	}
+}
+
+// WithMerchantProfiles 配置商家营业配置（下单时校验营业时间、起送金额和订单上限，未配置时不限制）
+func WithMerchantProfiles(profiles MerchantProfileRepository) ServiceOption {
+	return func(s *orderService) {
+		s.profiles = profiles
+	}
+}
+
+// WithDeliverySlots 配置预订配送时段（未配置时不接受预订单）
+func WithDeliverySlots(slots DeliverySlotRepository, policy SlotPolicy) ServiceOption {
+	return func(s *orderService) {
+		s.slots = slots
+		s.policy = policy
+	}
+}
+
+// WithDeliveryAreas 配置商家配送范围（下单时校验收货坐标并按距离计算配送费，未配置时使用固定配送费）
+func WithDeliveryAreas(areas DeliveryAreaRepository) ServiceOption {
+	return func(s *orderService) {
+		s.areas = areas
+	}
+}
+
+// WithAddressBook 配置用户地址簿（未配置时下单不能引用地址簿中的地址）
+func WithAddressBook(book AddressBookRepository) ServiceOption {
+	return func(s *orderService) {
+		s.book = book
+	}
+}
+
+// WithInventory 配置餐品库存（下单时占用库存和每日限售份数，未配置时不限量）
+func WithInventory(stock InventoryRepository) ServiceOption {
+	return func(s *orderService) {
+		s.stock = stock
+	}
+}
+
+// WithOrderClock 配置营业时间校验使用的时钟（测试使用）
+func WithOrderClock(now func() time.Time) ServiceOption {
+	return func(s *orderService) {
+		s.now = now
+	}
 }
 
 // NewOrderService 创建应用服务实例
-func NewOrderService(repo OrderRepository) OrderService {
-	return &orderService{repo: repo}
+func NewOrderService(repo OrderRepository, opts ...ServiceOption) OrderService {
+	s := &orderService{repo: repo, fees: domain.DefaultFees(), location: defaultMerchantLocation(), now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // CreateOrder 实现 OrderService 接口
 func (s *orderService) CreateOrder(ctx context.Context, userID uint64, req *CreateOrderRequest) (*OrderData, error) {
-	// 1. 验证请求数据（使用 validator）
-	if err := Validator.Struct(req); err != nil {
-		// 转换 validator 错误为应用层错误
-		if validationErrors, ok := err.(validator.ValidationErrors); ok {
-			for _, e := range validationErrors {
-				return nil, NewValidationError(e.Field(), e.Error())
-			}
-		}
-		return nil, NewValidationError("", err.Error())
+	// 1. 复制引用的地址簿地址，验证请求数据（使用 validator）
+	req, err := s.withSavedAddress(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRequest(req); err != nil {
+		return nil, err
 	}
 
-	// 2. 转换 DTO 到领域对象
-	items := s.convertToOrderItems(req.Items)
-	delivery := domain.DeliveryInfo{
-		RecipientName:  req.DeliveryInfo.RecipientName,
-		RecipientPhone: req.DeliveryInfo.RecipientPhone,
-		Address:        req.DeliveryInfo.Address,
+	// 2. 转换 DTO 到领域对象（按商家菜单校验规格选项）
+	items, err := s.menu.resolveItems(ctx, req.MerchantID, req.Items)
+	if err != nil {
+		return nil, err
+	}
+	scheduledFor, err := s.scheduledFor(req)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.checkMerchant(ctx, req.MerchantID, items, scheduledFor)
+	if err != nil {
+		return nil, err
+	}
+	delivery := toDeliveryInfo(req.DeliveryInfo)
+
+	// 3. 校验配送范围并按距离计算配送费；携带报价 token 时按报价费用计价，实付金额须与报价一致
+	fees, err := s.deliveryFees(ctx, req.MerchantID, req.DeliveryInfo.Location)
+	if err != nil {
+		return nil, err
+	}
+	if req.QuoteToken != "" {
+		if fees, err = s.quotedFees(userID, req, items); err != nil {
+			return nil, err
+		}
 	}
 
-	// 3. 创建订单（领域对象负责初始化所有状态）
-	order := domain.NewOrder(userID, req.MerchantID, items, delivery, req.Remark)
+	// 4. 创建订单（领域对象负责初始化所有状态）
+	order := domain.NewScheduledOrder(userID, req.MerchantID, items, delivery, req.Remark, fees, scheduledFor)
 
-	// 4. 保存订单
+	// 5. 预订单占用时段名额，占用餐品库存，保存订单（领域事件同时写入 outbox，由投递器异步投递）
+	ctx = withUserLogger(ctx, userID)
+	if order.IsScheduled() {
+		if err := s.reserveSlot(ctx, order, profile.SlotCapacity); err != nil {
+			return nil, err
+		}
+	}
+	now := s.now()
+	today := now.In(profile.Location).Format(domain.HolidayLayout)
+	if err := reserveStock(ctx, s.stock, order, stockDay(scheduledFor, now, profile.Location), today, now); err != nil {
+		releaseSlot(ctx, s.slots, order)
+		return nil, err
+	}
 	if err := s.repo.Create(ctx, order); err != nil {
+		releaseSlot(ctx, s.slots, order)
+		releaseStock(ctx, s.stock, order, now)
 		return nil, NewInternalError("failed to create order", err)
 	}
+	// 收件人手机号和地址由日志处理器脱敏
+	logging.FromContext(ctx).Info("order created",
+		"order_number", order.OrderNumber,
+		"merchant_id", order.MerchantID,
+		"final_amount", order.Pricing.FinalAmount.StringFixed(2),
+		"recipient_phone", order.Delivery.RecipientPhone,
+		"address", order.Delivery.Address,
+	)
 
-	// 5. 返回结果
+	// 6. 返回结果
 	return s.convertToDTO(order), nil
 }
 
-// convertToOrderItems 转换订单项
-func (s *orderService) convertToOrderItems(items []OrderItemRequest) []domain.OrderItem {
-	result := make([]domain.OrderItem, len(items))
+// QuoteOrder 实现 OrderService 接口（按下单的校验和计价流程试算价格，不保存订单）
+func (s *orderService) QuoteOrder(ctx context.Context, userID uint64, req *CreateOrderRequest) (*QuoteData, error) {
+	req, err := s.withSavedAddress(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	items, err := s.menu.resolveItems(ctx, req.MerchantID, req.Items)
+	if err != nil {
+		return nil, err
+	}
+	scheduledFor, err := s.scheduledFor(req)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.checkMerchant(ctx, req.MerchantID, items, scheduledFor)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStock(ctx, s.stock, req.MerchantID, stockDay(scheduledFor, s.now(), profile.Location), items); err != nil {
+		return nil, err
+	}
+	fees, err := s.deliveryFees(ctx, req.MerchantID, req.DeliveryInfo.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	pricing := domain.CalculatePricing(items, fees)
+	result := &QuoteData{
+		MerchantID: req.MerchantID,
+		Items:      make([]OrderItemData, len(items)),
+		Pricing:    toPricingInfo(pricing),
+	}
 	for i, item := range items {
-		result[i] = domain.OrderItem{
-			DishID:   item.DishID,
-			DishName: item.DishName,
-			Quantity: item.Quantity,
-			Price:    decimal.NewFromFloat(item.Price),
+		result.Items[i] = toOrderItemData(item)
+	}
+	if s.quotes != nil {
+		token, expiresAt := s.quotes.sign(userID, req, pricing)
+		result.QuoteToken = token
+		result.ExpiresAt = expiresAt.Format(time.RFC3339)
+	}
+	return result, nil
+}
+
+// quotedFees 校验报价 token 并返回报价时的固定费用（按报价费用重新计价后金额变化时拒绝下单）
+func (s *orderService) quotedFees(userID uint64, req *CreateOrderRequest, items []domain.OrderItem) (domain.Fees, error) {
+	if s.quotes == nil {
+		return domain.Fees{}, toApplicationError(domain.ErrInvalidQuote)
+	}
+	fees, finalAmount, err := s.quotes.verify(req.QuoteToken, userID, req)
+	if err != nil {
+		return domain.Fees{}, toApplicationError(err)
+	}
+	if !domain.CalculatePricing(items, fees).FinalAmount.Equal(finalAmount) {
+		return domain.Fees{}, toApplicationError(domain.ErrQuotePriceChanged)
+	}
+	return fees, nil
+}
+
+// withSavedAddress 将请求引用的地址簿地址复制为配送信息（下单后修改地址不影响订单），未引用地址时原样返回
+func (s *orderService) withSavedAddress(ctx context.Context, userID uint64, req *CreateOrderRequest) (*CreateOrderRequest, error) {
+	if req.AddressID == "" {
+		return req, nil
+	}
+	if s.book == nil {
+		return nil, NewValidationError("AddressID", "saved addresses are not supported")
+	}
+	if req.DeliveryInfo != (DeliveryInfoRequest{}) {
+		return nil, NewValidationError("AddressID", "addressId and deliveryInfo cannot be used together")
+	}
+	book, err := loadAddressBook(ctx, s.book, userID)
+	if err != nil {
+		return nil, err
+	}
+	address, err := book.Find(req.AddressID)
+	if err != nil {
+		return nil, toApplicationError(err)
+	}
+	resolved := *req
+	resolved.DeliveryInfo = toDeliveryInfoRequest(address.Delivery)
+	return &resolved, nil
+}
+
+// deliveryFees 按商家配送范围校验收货坐标并计算配送费（商家未配置配送范围时使用固定配送费）
+func (s *orderService) deliveryFees(ctx context.Context, merchantID string, location *GeoPointRequest) (domain.Fees, error) {
+	fees := s.fees
+	if s.areas == nil {
+		return fees, nil
+	}
+	area, err := findDeliveryArea(ctx, s.areas, merchantID)
+	if err != nil || area == nil {
+		return fees, err
+	}
+	if location == nil {
+		return domain.Fees{}, NewValidationError("Location", "delivery location is required by merchant delivery area")
+	}
+	quote, err := area.Quote(toGeoPoint(*location))
+	if err != nil {
+		return domain.Fees{}, toApplicationError(err)
+	}
+	fees.DeliveryFee = quote.Fee
+	return fees, nil
+}
+
+// scheduledFor 解析预订配送时段（立即配送时返回零值）
+func (s *orderService) scheduledFor(req *CreateOrderRequest) (time.Time, error) {
+	if req.ScheduledFor == "" {
+		return time.Time{}, nil
+	}
+	if s.slots == nil {
+		return time.Time{}, NewValidationError("ScheduledFor", "scheduled delivery is not supported")
+	}
+	scheduledFor, err := time.Parse(time.RFC3339, req.ScheduledFor)
+	if err != nil {
+		return time.Time{}, NewValidationError("ScheduledFor", err.Error())
+	}
+	return scheduledFor, nil
+}
+
+// checkMerchant 按商家营业配置校验能否接单，返回商家营业配置（商家未配置时不限制）
+// 预订单按配送时段校验营业时间和时段名额，立即配送的订单按当前营业状态和订单上限校验
+func (s *orderService) checkMerchant(ctx context.Context, merchantID string, items []domain.OrderItem, scheduledFor time.Time) (*domain.MerchantProfile, error) {
+	profile := domain.NewMerchantProfile(merchantID, s.location)
+	if s.profiles != nil {
+		var err error
+		if profile, err = loadMerchantProfile(ctx, s.profiles, merchantID, s.location); err != nil {
+			return nil, err
 		}
 	}
-	return result
+	itemsTotal := domain.CalculatePricing(items, s.fees).ItemsTotal
+
+	if !scheduledFor.IsZero() {
+		if err := s.policy.check(scheduledFor, profile.Location, s.now()); err != nil {
+			return nil, err
+		}
+		if err := profile.CheckScheduledAcceptance(scheduledFor, s.policy.Duration, itemsTotal); err != nil {
+			return nil, toApplicationError(err)
+		}
+		if profile.SlotCapacity > 0 {
+			booked, err := s.slots.Count(ctx, merchantID, scheduledFor)
+			if err != nil {
+				return nil, NewInternalError("failed to count slot reservations", err)
+			}
+			if booked >= profile.SlotCapacity {
+				return nil, toApplicationError(domain.ErrSlotFull)
+			}
+		}
+		return profile, nil
+	}
+
+	activeOrders := 0
+	if profile.MaxActiveOrders > 0 {
+		var err error
+		if activeOrders, err = s.repo.CountActiveByMerchant(ctx, merchantID); err != nil {
+			return nil, NewInternalError("failed to count merchant orders", err)
+		}
+	}
+	if err := profile.CheckAcceptance(s.now(), itemsTotal, activeOrders); err != nil {
+		return nil, toApplicationError(err)
+	}
+	return profile, nil
+}
+
+// reserveSlot 为预订单占用配送时段名额（并发下单时以预订结果为准）
+func (s *orderService) reserveSlot(ctx context.Context, order *domain.Order, capacity int) error {
+	reserved, err := s.slots.Reserve(ctx, order.MerchantID, order.ScheduledFor, order.OrderNumber, capacity)
+	if err != nil {
+		return NewInternalError("failed to reserve delivery slot", err)
+	}
+	if !reserved {
+		return toApplicationError(domain.ErrSlotFull)
+	}
+	return nil
+}
+
+// releaseSlot 释放预订单占用的配送时段名额（释放失败只记录日志，名额在时段过后自然失效）
+func releaseSlot(ctx context.Context, slots DeliverySlotRepository, order *domain.Order) {
+	if !order.IsScheduled() || slots == nil {
+		return
+	}
+	if err := slots.Release(ctx, order.MerchantID, order.ScheduledFor, order.OrderNumber); err != nil {
+		logging.FromContext(ctx).Warn("failed to release delivery slot",
+			"order_number", order.OrderNumber, logging.KeyError, err)
+	}
+}
+
+// GetOrder 实现 OrderService 接口（只能查询自己的订单）
+func (s *orderService) GetOrder(ctx context.Context, userID uint64, orderNumber string) (*OrderData, error) {
+	order, err := s.findUserOrder(ctx, userID, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+	return s.convertToDTO(order), nil
+}
+
+// ListOrders 实现 OrderService 接口（按创建时间倒序分页查询自己的订单）
+func (s *orderService) ListOrders(ctx context.Context, userID uint64, req *ListOrdersRequest) (*OrderListData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	query := OrderListQuery{
+		UserID: userID,
+		Status: domain.OrderStatus(req.Status),
+		Limit:  req.Limit,
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultListLimit
+	}
+	if req.Cursor != "" {
+		cursor, err := DecodeOrderCursor(req.Cursor)
+		if err != nil {
+			return nil, NewValidationError("Cursor", "invalid cursor")
+		}
+		query.After = cursor
+	}
+
+	// 多查一条用于判断是否还有下一页
+	limit := query.Limit
+	query.Limit++
+	orders, err := s.repo.FindByUser(ctx, query)
+	if err != nil {
+		return nil, NewInternalError("failed to list orders", err)
+	}
+
+	result := &OrderListData{Orders: make([]OrderData, 0, limit)}
+	if len(orders) > limit {
+		orders = orders[:limit]
+		result.HasMore = true
+	}
+	for _, order := range orders {
+		orderData := s.convertToDTO(order)
+		orderData.Cursor = EncodeOrderCursor(order)
+		result.Orders = append(result.Orders, *orderData)
+	}
+	if result.HasMore {
+		result.NextCursor = EncodeOrderCursor(orders[len(orders)-1])
+	}
+	return result, nil
+}
+
+// ConfirmPayment 实现 OrderService 接口
+// 仅由已验签的支付回调调用，实付金额须与订单应付金额一致
+func (s *orderService) ConfirmPayment(ctx context.Context, req *ConfirmPaymentRequest) (*OrderData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		return nil, NewValidationError("Amount", "must be a decimal amount")
+	}
+
+	order, err := s.findOrder(ctx, req.OrderNumber)
+	if err != nil {
+		return nil, err
+	}
+	ctx = withUserLogger(ctx, order.UserID)
+	if !amount.Equal(order.Pricing.FinalAmount) {
+		return nil, NewBusinessError(domain.ErrPaymentAmountMismatch.Code, fmt.Sprintf("paid amount %s does not match order amount %s",
+			amount.StringFixed(2), order.Pricing.FinalAmount.StringFixed(2)))
+	}
+	if err := order.MarkPaid(req.PaymentID); err != nil {
+		return nil, toApplicationError(err)
+	}
+
+	if err := s.saveOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("order paid", "order_number", order.OrderNumber, "payment_id", req.PaymentID)
+	return s.convertToDTO(order), nil
+}
+
+// CancelOrder 实现 OrderService 接口
+func (s *orderService) CancelOrder(ctx context.Context, userID uint64, orderNumber string, req *CancelOrderRequest) (*OrderData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	ctx = withUserLogger(ctx, userID)
+	order, err := s.findUserOrder(ctx, userID, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+	if err := order.Cancel(req.Reason); err != nil {
+		return nil, toApplicationError(err)
+	}
+
+	if err := s.saveOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("order cancelled", "order_number", order.OrderNumber, "reason", req.Reason)
+	return s.convertToDTO(order), nil
+}
+
+// RefundOrder 实现 OrderService 接口
+func (s *orderService) RefundOrder(ctx context.Context, userID uint64, orderNumber string, req *RefundOrderRequest) (*RefundData, error) {
+	// 1. 验证请求数据
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	if s.payment == nil {
+		return nil, NewInternalError("payment gateway not configured", nil)
+	}
+
+	// 2. 加载订单（只能操作自己的订单）
+	ctx = withUserLogger(ctx, userID)
+	order, err := s.findUserOrder(ctx, userID, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 领域对象计算退款金额，通过支付网关退款
+	lines := make([]domain.RefundLine, len(req.Items))
+	for i, item := range req.Items {
+		lines[i] = domain.RefundLine{DishID: item.DishID, SlotID: item.SlotID, Quantity: item.Quantity}
+	}
+	refund, err := s.refund(ctx, order, lines, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return s.convertToRefundDTO(order, refund), nil
+}
+
+// AcceptOrder 实现 OrderService 接口（商家接单）
+func (s *orderService) AcceptOrder(ctx context.Context, merchantID, orderNumber string) (*OrderData, error) {
+	ctx = withMerchantLogger(ctx, merchantID)
+	order, err := s.findMerchantOrder(ctx, merchantID, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+	if err := order.Accept(); err != nil {
+		return nil, toApplicationError(err)
+	}
+
+	if err := s.saveOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("order accepted", "order_number", order.OrderNumber)
+	return s.convertToDTO(order), nil
+}
+
+// RejectOrder 实现 OrderService 接口（商家拒单，并向用户全额退款）
+func (s *orderService) RejectOrder(ctx context.Context, merchantID, orderNumber string, req *RejectOrderRequest) (*OrderData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+	if s.payment == nil {
+		return nil, NewInternalError("payment gateway not configured", nil)
+	}
+
+	ctx = withMerchantLogger(ctx, merchantID)
+	order, err := s.findMerchantOrder(ctx, merchantID, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+	if err := order.Reject(req.Reason); err != nil {
+		return nil, toApplicationError(err)
+	}
+	if err := s.saveOrder(ctx, order); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).Info("order rejected", "order_number", order.OrderNumber, "reason", req.Reason)
+
+	// 退款失败时订单保持已拒单状态，可以再次发起退款
+	if _, err := s.refund(ctx, order, nil, req.Reason); err != nil {
+		return nil, err
+	}
+	return s.convertToDTO(order), nil
+}
+
+// refund 记录退款中状态并通过支付网关退款，保存退款结果（lines 为空表示全额退款）
+func (s *orderService) refund(ctx context.Context, order *domain.Order, lines []domain.RefundLine, reason string) (*domain.Refund, error) {
+	refund, err := order.RequestRefund(lines, reason)
+	if err != nil {
+		return nil, toApplicationError(err)
+	}
+	refundID := refund.RefundID
+	if err := s.saveOrder(ctx, order); err != nil {
+		return nil, err
+	}
+
+	payErr := s.payment.Refund(ctx, &PaymentRefundRequest{
+		OrderNumber: order.OrderNumber,
+		PaymentID:   order.PaymentID,
+		RefundID:    refundID,
+		Amount:      refund.Amount,
+	})
+	logger := logging.FromContext(ctx).With(
+		"order_number", order.OrderNumber,
+		"refund_id", refundID,
+		"amount", refund.Amount.StringFixed(2),
+	)
+	if err := s.settleRefund(ctx, order, refundID, payErr); err != nil {
+		// 支付网关已处理退款但结果没有保存，订单停留在退款中，需要人工对账
+		logger.Error("refund result not recorded, manual reconciliation required",
+			"refund_succeeded", payErr == nil, logging.KeyError, err)
+		return nil, NewInternalError("refund result not recorded", err)
+	}
+	if payErr != nil {
+		logger.Warn("refund failed", logging.KeyError, payErr)
+		return nil, NewInternalError("failed to process refund", payErr)
+	}
+	logger.Info("refund completed")
+
+	refund, _ = order.FindRefund(refundID)
+	return refund, nil
+}
+
+// settleRefund 保存支付网关的退款结果
+// 退款已经发生，保存时订单被并发修改则重新加载订单后再次记录结果（order 更新为最新状态）；
+// 请求取消不影响保存，避免网关已退款而订单停留在退款中
+func (s *orderService) settleRefund(ctx context.Context, order *domain.Order, refundID string, payErr error) error {
+	ctx = context.WithoutCancel(ctx)
+	for attempt := 1; ; attempt++ {
+		if payErr != nil {
+			_ = order.FailRefund(refundID, payErr.Error())
+		} else {
+			_ = order.CompleteRefund(refundID)
+		}
+		err := s.saveOrder(ctx, order)
+		if err == nil || !isOrderConflict(err) || attempt == maxConflictRetries {
+			return err
+		}
+
+		latest, findErr := s.repo.FindByOrderNumber(ctx, order.OrderNumber)
+		if findErr != nil {
+			return NewInternalError("failed to reload order", findErr)
+		}
+		*order = *latest
+	}
+}
+
+// withUserLogger 为 context 携带的 logger 追加用户ID，后续日志（包括仓储、支付网关）都携带该字段
+func withUserLogger(ctx context.Context, userID uint64) context.Context {
+	return logging.With(ctx, logging.KeyUserID, userID)
+}
+
+// withMerchantLogger 为 context 携带的 logger 追加商家ID
+func withMerchantLogger(ctx context.Context, merchantID string) context.Context {
+	return logging.With(ctx, "merchant_id", merchantID)
+}
+
+// saveOrder 保存订单变更（领域事件同时写入 outbox），订单取消、拒单或全额退款后释放配送时段名额和餐品库存，
+// 商家接单后餐品库存的占用转为已售出
+// 订单在加载后被其他请求修改时返回 ORDER_CONFLICT 业务错误，调用方可重新发起请求
+func (s *orderService) saveOrder(ctx context.Context, order *domain.Order) error {
+	if err := s.repo.Update(ctx, order); err != nil {
+		if errors.Is(err, domain.ErrOrderConflict) {
+			return toApplicationError(domain.ErrOrderConflict)
+		}
+		return NewInternalError("failed to save order", err)
+	}
+	switch order.Status {
+	case domain.OrderStatusCancelled, domain.OrderStatusRejected, domain.OrderStatusRefunded:
+		releaseSlot(ctx, s.slots, order)
+		releaseStock(ctx, s.stock, order, s.now())
+	case domain.OrderStatusAccepted:
+		commitStock(ctx, s.stock, order, s.now())
+	}
+	return nil
+}
+
+// findOrder 按订单号查询订单
+func (s *orderService) findOrder(ctx context.Context, orderNumber string) (*domain.Order, error) {
+	order, err := s.repo.FindByOrderNumber(ctx, orderNumber)
+	if err != nil {
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, notFound
+		}
+		return nil, NewInternalError("failed to find order", err)
+	}
+	return order, nil
+}
+
+// findUserOrder 查询属于指定用户的订单（不属于该用户时视为不存在）
+func (s *orderService) findUserOrder(ctx context.Context, userID uint64, orderNumber string) (*domain.Order, error) {
+	order, err := s.findOrder(ctx, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, NewNotFoundError("order " + orderNumber + " not found")
+	}
+	return order, nil
+}
+
+// findMerchantOrder 查询属于指定商家的订单（不属于该商家时视为不存在）
+func (s *orderService) findMerchantOrder(ctx context.Context, merchantID, orderNumber string) (*domain.Order, error) {
+	order, err := s.findOrder(ctx, orderNumber)
+	if err != nil {
+		return nil, err
+	}
+	if order.MerchantID != merchantID {
+		return nil, NewNotFoundError("order " + orderNumber + " not found")
+	}
+	return order, nil
 }
 
 // convertToDTO 转换领域对象到 DTO
 func (s *orderService) convertToDTO(order *domain.Order) *OrderData {
-	return &OrderData{
-		OrderNumber: order.OrderNumber,
-		Status:      string(order.Status),
-		Pricing: PricingInfo{
-			ItemsTotal:   order.Pricing.ItemsTotal.StringFixed(2),
-			PackagingFee: order.Pricing.PackagingFee.StringFixed(2),
-			DeliveryFee:  order.Pricing.DeliveryFee.StringFixed(2),
-			FinalAmount:  order.Pricing.FinalAmount.StringFixed(2),
-		},
-		CreatedAt: order.CreatedAt.Format(time.RFC3339),
+	items := make([]OrderItemData, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = toOrderItemData(item)
+	}
+
+	data := &OrderData{
+		OrderNumber:  order.OrderNumber,
+		MerchantID:   order.MerchantID,
+		Status:       string(order.Status),
+		Items:        items,
+		Pricing:      toPricingInfo(order.Pricing),
+		DeliveryInfo: toDeliveryInfoData(order.Delivery),
+		Remark:       order.Remark,
+		CreatedAt:    order.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    order.UpdatedAt.Format(time.RFC3339),
+	}
+	if order.IsScheduled() {
+		data.ScheduledFor = order.ScheduledFor.Format(time.RFC3339)
+	}
+	return data
+}
+
+// convertToRefundDTO 转换退款实体到 DTO
+func (s *orderService) convertToRefundDTO(order *domain.Order, refund *domain.Refund) *RefundData {
+	items := make([]RefundItemData, len(refund.Items))
+	for i, item := range refund.Items {
+		items[i] = RefundItemData{
+			DishID:   item.DishID,
+			SlotID:   item.SlotID,
+			Quantity: item.Quantity,
+			Amount:   item.Amount.StringFixed(2),
+		}
+	}
+
+	return &RefundData{
+		RefundID:     refund.RefundID,
+		OrderNumber:  order.OrderNumber,
+		OrderStatus:  string(order.Status),
+		Status:       string(refund.Status),
+		Items:        items,
+		PackagingFee: refund.PackagingFee.StringFixed(2),
+		DeliveryFee:  refund.DeliveryFee.StringFixed(2),
+		Amount:       refund.Amount.StringFixed(2),
+		CreatedAt:    refund.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// validateRequest 验证请求数据并转换为应用层错误
+func validateRequest(req interface{}) error {
+	if err := Validator.Struct(req); err != nil {
+		// 转换 validator 错误为应用层错误
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range validationErrors {
+				return NewValidationError(e.Field(), e.Error())
+			}
+		}
+		return NewValidationError("", err.Error())
+	}
+	return nil
+}
+
+// maxConflictRetries 记录退款结果遇到并发修改时最多尝试的次数（超过后记录错误日志等待人工对账）
+const maxConflictRetries = 5
+
+// isOrderConflict 是否为订单并发修改冲突
+func isOrderConflict(err error) bool {
+	var businessErr *BusinessError
+	return errors.As(err, &businessErr) && businessErr.Code == domain.ErrOrderConflict.Code
+}
+
+// toApplicationError 将领域错误转换为应用层错误
+func toApplicationError(err error) error {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		return NewBusinessError(domainErr.Code, domainErr.Message)
+	}
+	return NewInternalError("unexpected domain error", err)
+}
+
+// toDeliveryInfo 转换已校验的配送信息请求
+func toDeliveryInfo(req DeliveryInfoRequest) domain.DeliveryInfo {
+	delivery := domain.DeliveryInfo{
+		RecipientName:  req.RecipientName,
+		RecipientPhone: req.RecipientPhone,
+		Address:        req.Address,
 	}
+	if req.Location != nil {
+		location := toGeoPoint(*req.Location)
+		delivery.Location = &location
+	}
+	return delivery
+}
+
+// toDeliveryInfoData 转换配送信息
+func toDeliveryInfoData(delivery domain.DeliveryInfo) DeliveryInfoData {
+	data := DeliveryInfoData{
+		RecipientName:  delivery.RecipientName,
+		RecipientPhone: delivery.RecipientPhone,
+		Address:        delivery.Address,
+	}
+	if delivery.Location != nil {
+		location := toGeoPointData(*delivery.Location)
+		data.Location = &location
+	}
+	return data
+}
+
+// toOrderItemData 转换订单项
+func toOrderItemData(item domain.OrderItem) OrderItemData {
+	data := OrderItemData{
+		DishID:     item.DishID,
+		DishName:   item.DishName,
+		Quantity:   item.Quantity,
+		Price:      item.Price.StringFixed(2),
+		UnitPrice:  item.UnitPrice().StringFixed(2),
+		Subtotal:   item.Subtotal().StringFixed(2),
+		Components: toComponentData(item.Components),
+	}
+	for _, option := range item.Options {
+		data.Options = append(data.Options, OrderItemOptionData{
+			GroupID:    option.GroupID,
+			GroupName:  option.GroupName,
+			OptionID:   option.OptionID,
+			OptionName: option.OptionName,
+			PriceDelta: option.PriceDelta.StringFixed(2),
+		})
+	}
+	return data
+}
+
+// toPricingInfo 转换价格信息
+func toPricingInfo(pricing domain.Pricing) PricingInfo {
+	return PricingInfo{
+		ItemsTotal:   pricing.ItemsTotal.StringFixed(2),
+		PackagingFee: pricing.PackagingFee.StringFixed(2),
+		DeliveryFee:  pricing.DeliveryFee.StringFixed(2),
+		FinalAmount:  pricing.FinalAmount.StringFixed(2),
+	}
+}
+
+// toComponentData 转换套餐组件
+func toComponentData(components []domain.OrderItemComponent) []OrderItemComponentData {
+	var result []OrderItemComponentData
+	for _, component := range components {
+		result = append(result, OrderItemComponentData{
+			SlotID:         component.SlotID,
+			SlotName:       component.SlotName,
+			DishID:         component.DishID,
+			DishName:       component.DishName,
+			Quantity:       component.Quantity,
+			Surcharge:      component.Surcharge.StringFixed(2),
+			AllocatedPrice: component.AllocatedPrice.StringFixed(2),
+		})
+	}
+	return result
 }
@@ -1,18 +1,27 @@
 package application
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"sort"
 	"testing"
+	"time"
 
 	"order-service/internal/domain"
+	"order-service/internal/logging"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-// MockOrderRepository 模拟 Repository
+// MockOrderRepository 模拟 Repository（同时实现 outbox）
 type MockOrderRepository struct {
 	orders map[string]*domain.Order
+	outbox []OutboxEntry
 }
 
 func NewMockOrderRepository() OrderRepository {
@@ -26,6 +35,7 @@ func (m *MockOrderRepository) Create(ctx context.Context, order *domain.Order) e
 		return fmt.Errorf("order already exists")
 	}
 	m.orders[order.OrderNumber] = order
+	m.appendOutbox(order)
 	return nil
 }
 
@@ -37,6 +47,162 @@ func (m *MockOrderRepository) FindByOrderNumber(ctx context.Context, orderNumber
 	return order, nil
 }
 
+func (m *MockOrderRepository) Update(ctx context.Context, order *domain.Order) error {
+	if _, exists := m.orders[order.OrderNumber]; !exists {
+		return NewNotFoundError("order not found")
+	}
+	m.orders[order.OrderNumber] = order
+	m.appendOutbox(order)
+	return nil
+}
+
+func (m *MockOrderRepository) FindByUser(ctx context.Context, query OrderListQuery) ([]*domain.Order, error) {
+	var result []*domain.Order
+	for _, order := range m.orders {
+		if order.UserID == query.UserID &&
+			(query.Status == "" || order.Status == query.Status) &&
+			(query.After == nil || query.After.Precedes(order)) {
+			result = append(result, order)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+		return result[i].OrderNumber > result[j].OrderNumber
+	})
+	if len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+	return result, nil
+}
+
+func (m *MockOrderRepository) CountActiveByMerchant(ctx context.Context, merchantID string) (int, error) {
+	count := 0
+	for _, order := range m.orders {
+		if order.MerchantID == merchantID && order.Active() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockOrderRepository) FindDueScheduled(ctx context.Context, before time.Time, limit int) ([]*domain.Order, error) {
+	var result []*domain.Order
+	for _, order := range m.orders {
+		if order.IsScheduled() && order.ReleasedAt.IsZero() &&
+			order.Status == domain.OrderStatusPaid && !order.ScheduledFor.After(before) {
+			result = append(result, order)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ScheduledFor.Before(result[j].ScheduledFor)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *MockOrderRepository) FindUnpaidBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Order, error) {
+	var result []*domain.Order
+	for _, order := range m.orders {
+		if order.Status == domain.OrderStatusPendingPayment && order.CreatedAt.Before(before) {
+			result = append(result, order)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *MockOrderRepository) appendOutbox(order *domain.Order) {
+	for _, event := range order.PullEvents() {
+		m.outbox = append(m.outbox, NewOutboxEntry(event))
+	}
+}
+
+func (m *MockOrderRepository) FetchPendingOutbox(ctx context.Context, now time.Time, limit int) ([]OutboxEntry, error) {
+	var result []OutboxEntry
+	for _, entry := range m.outbox {
+		if entry.Status == OutboxStatusPending && !entry.NextAttemptAt.After(now) && len(result) < limit {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockOrderRepository) UpdateOutboxEntry(ctx context.Context, entry OutboxEntry) error {
+	for i := range m.outbox {
+		if m.outbox[i].ID == entry.ID {
+			m.outbox[i] = entry
+			return nil
+		}
+	}
+	return NewNotFoundError("outbox entry not found")
+}
+
+func (m *MockOrderRepository) FindOutboxEntries(ctx context.Context, status OutboxStatus) ([]OutboxEntry, error) {
+	var result []OutboxEntry
+	for _, entry := range m.outbox {
+		if entry.Status == status {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+// outboxEventTypes 返回 outbox 中事件的类型列表
+func (m *MockOrderRepository) outboxEventTypes() []string {
+	types := make([]string, len(m.outbox))
+	for i, entry := range m.outbox {
+		types[i] = entry.Event.EventType()
+	}
+	return types
+}
+
+// MockEventPublisher 模拟事件发布器（前 failures 次发布返回错误）
+type MockEventPublisher struct {
+	events   []domain.Event
+	failures int
+}
+
+func (m *MockEventPublisher) Publish(ctx context.Context, events ...domain.Event) error {
+	if m.failures > 0 {
+		m.failures--
+		return fmt.Errorf("subscriber unavailable")
+	}
+	m.events = append(m.events, events...)
+	return nil
+}
+
+// failingRepository 创建总是失败的仓储
+type failingRepository struct {
+	*MockOrderRepository
+}
+
+func (f *failingRepository) Create(ctx context.Context, order *domain.Order) error {
+	return fmt.Errorf("database unavailable")
+}
+
+// MockPaymentGateway 模拟支付网关
+type MockPaymentGateway struct {
+	refunds []*PaymentRefundRequest
+	err     error
+}
+
+func (m *MockPaymentGateway) Refund(ctx context.Context, req *PaymentRefundRequest) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.refunds = append(m.refunds, req)
+	return nil
+}
+
 func TestOrderService_CreateOrder_Success(t *testing.T) {
 	// Arrange
 	repo := NewMockOrderRepository().(*MockOrderRepository)
@@ -111,3 +277,692 @@ func TestOrderService_CreateOrder_ValidationError(t *testing.T) {
 	assert.Nil(t, orderData)
 	assert.IsType(t, &ValidationError{}, err)
 }
+
+func TestOrderService_CreateOrder_WithFees(t *testing.T) {
+	// Arrange
+	service := NewOrderService(NewMockOrderRepository(), WithFees(domain.Fees{
+		PackagingFee: decimal.NewFromFloat(0.50),
+		DeliveryFee:  decimal.Zero,
+	}))
+	req := &CreateOrderRequest{
+		MerchantID: "merchant_001",
+		Items:      []OrderItemRequest{{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 2, Price: 28.00}},
+		DeliveryInfo: DeliveryInfoRequest{
+			RecipientName:  "张三",
+			RecipientPhone: "13800138000",
+			Address:        "北京市朝阳区xxx",
+		},
+	}
+
+	// Act
+	orderData, err := service.CreateOrder(context.Background(), 1001, req)
+
+	// Assert - 56.00 + 0.50 + 0.00
+	require.NoError(t, err)
+	assert.Equal(t, "0.50", orderData.Pricing.PackagingFee)
+	assert.Equal(t, "0.00", orderData.Pricing.DeliveryFee)
+	assert.Equal(t, "56.50", orderData.Pricing.FinalAmount)
+}
+
+// MockMenuCatalog 模拟商家菜单
+type MockMenuCatalog struct {
+	dishes map[string]*domain.Dish  // 按餐品ID索引（不区分商家）
+	combos map[string]*domain.Combo // 按套餐ID索引（不区分商家）
+}
+
+func (m *MockMenuCatalog) FindDish(ctx context.Context, merchantID, dishID string) (*domain.Dish, error) {
+	dish, ok := m.dishes[dishID]
+	if !ok {
+		return nil, NewNotFoundError("dish not found")
+	}
+	return dish, nil
+}
+
+func (m *MockMenuCatalog) FindCombo(ctx context.Context, merchantID, comboID string) (*domain.Combo, error) {
+	combo, ok := m.combos[comboID]
+	if !ok {
+		return nil, NewNotFoundError("combo not found")
+	}
+	return combo, nil
+}
+
+// newNoodleMenu 创建测试菜单：牛肉面份量必选一项（大份 +4.00），加料可选（卤蛋 +2.00）；
+// 套餐 30.00 含牛肉面和饮料（按单点价 22 : 8 分摊，换成橙汁 +3.00）
+func newNoodleMenu() *MockMenuCatalog {
+	return &MockMenuCatalog{combos: map[string]*domain.Combo{
+		"combo_201": {
+			ComboID: "combo_201",
+			Name:    "牛肉面套餐",
+			Price:   decimal.NewFromInt(30),
+			Slots: []domain.ComboSlot{
+				{SlotID: "main", Name: "主食", Quantity: 1, Choices: []domain.ComboChoice{
+					{DishID: "dish_101", DishName: "牛肉面", ListPrice: decimal.NewFromInt(22)},
+				}},
+				{SlotID: "drink", Name: "饮料", Quantity: 1, Choices: []domain.ComboChoice{
+					{DishID: "dish_104", DishName: "酸梅汤", ListPrice: decimal.NewFromInt(8)},
+					{DishID: "dish_105", DishName: "鲜榨橙汁", ListPrice: decimal.NewFromInt(8), Surcharge: decimal.NewFromInt(3)},
+				}},
+			},
+		},
+	}, dishes: map[string]*domain.Dish{
+		"dish_101": {
+			DishID: "dish_101",
+			Name:   "牛肉面",
+			Price:  decimal.NewFromInt(22),
+			OptionGroups: []domain.OptionGroup{
+				{GroupID: "size", Name: "份量", Required: true, MaxSelect: 1, Options: []domain.DishOption{
+					{OptionID: "regular", Name: "标准份"},
+					{OptionID: "large", Name: "大份", PriceDelta: decimal.NewFromInt(4)},
+				}},
+				{GroupID: "addons", Name: "加料", Options: []domain.DishOption{
+					{OptionID: "egg", Name: "卤蛋", PriceDelta: decimal.NewFromInt(2)},
+				}},
+			},
+		},
+	}}
+}
+
+// newOptionsOrderRequest 创建包含指定订单项的下单请求
+func newOptionsOrderRequest(items ...OrderItemRequest) *CreateOrderRequest {
+	return &CreateOrderRequest{
+		MerchantID: "merchant_001",
+		Items:      items,
+		DeliveryInfo: DeliveryInfoRequest{
+			RecipientName:  "张三",
+			RecipientPhone: "13800138000",
+			Address:        "北京市朝阳区xxx",
+		},
+	}
+}
+
+func TestOrderService_CreateOrder_WithOptions(t *testing.T) {
+	// Arrange
+	service := NewOrderService(NewMockOrderRepository(), WithMenuCatalog(newNoodleMenu()))
+	req := newOptionsOrderRequest(
+		OrderItemRequest{DishID: "dish_101", DishName: "牛肉面", Quantity: 2, Price: 22.00, Options: []OrderItemOptionRequest{
+			{GroupID: "addons", OptionID: "egg"},
+			{GroupID: "size", OptionID: "large"},
+		}},
+		OrderItemRequest{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00},
+	)
+
+	// Act
+	orderData, err := service.CreateOrder(context.Background(), 1001, req)
+
+	// Assert - (22.00 + 4.00 + 2.00) × 2 + 28.00 = 84.00，加打包费和配送费 88.00
+	require.NoError(t, err)
+	assert.Equal(t, "84.00", orderData.Pricing.ItemsTotal)
+	assert.Equal(t, "88.00", orderData.Pricing.FinalAmount)
+	noodle := orderData.Items[0]
+	assert.Equal(t, "22.00", noodle.Price)
+	assert.Equal(t, "28.00", noodle.UnitPrice)
+	assert.Equal(t, "56.00", noodle.Subtotal)
+	require.Len(t, noodle.Options, 2)
+	assert.Equal(t, OrderItemOptionData{GroupID: "size", GroupName: "份量", OptionID: "large", OptionName: "大份", PriceDelta: "4.00"}, noodle.Options[0])
+	assert.Empty(t, orderData.Items[1].Options)
+}
+
+func TestOrderService_CreateOrder_UsesMenuNameAndPrice(t *testing.T) {
+	// Arrange - 请求中的名称和单价与菜单不一致
+	service := NewOrderService(NewMockOrderRepository(), WithMenuCatalog(newNoodleMenu()))
+	req := newOptionsOrderRequest(OrderItemRequest{DishID: "dish_101", DishName: "便宜面", Quantity: 1, Price: 1.00, Options: []OrderItemOptionRequest{
+		{GroupID: "size", OptionID: "regular"},
+	}})
+
+	// Act
+	orderData, err := service.CreateOrder(context.Background(), 1001, req)
+
+	// Assert - 名称和单价以菜单为准
+	require.NoError(t, err)
+	noodle := orderData.Items[0]
+	assert.Equal(t, "牛肉面", noodle.DishName)
+	assert.Equal(t, "22.00", noodle.Price)
+	assert.Equal(t, "22.00", orderData.Pricing.ItemsTotal)
+}
+
+func TestOrderService_CreateOrder_Combo(t *testing.T) {
+	// Arrange - 套餐名称和价格以菜单为准
+	service := NewOrderService(NewMockOrderRepository(), WithMenuCatalog(newNoodleMenu()))
+	req := newOptionsOrderRequest(OrderItemRequest{DishID: "combo_201", DishName: "套餐", Quantity: 2, Price: 30.00,
+		Components: []ComboComponentRequest{{SlotID: "drink", DishID: "dish_105"}}})
+
+	// Act
+	orderData, err := service.CreateOrder(context.Background(), 1001, req)
+
+	// Assert - (30.00 + 3.00) × 2 = 66.00；30.00 按 22 : 8 分摊为 22.00 和 8.00，换饮料加价计入饮料
+	require.NoError(t, err)
+	assert.Equal(t, "66.00", orderData.Pricing.ItemsTotal)
+	combo := orderData.Items[0]
+	assert.Equal(t, "牛肉面套餐", combo.DishName)
+	assert.Equal(t, "33.00", combo.UnitPrice)
+	require.Len(t, combo.Components, 2)
+	assert.Equal(t, OrderItemComponentData{
+		SlotID: "drink", SlotName: "饮料", DishID: "dish_105", DishName: "鲜榨橙汁", Quantity: 1, Surcharge: "3.00", AllocatedPrice: "11.00",
+	}, combo.Components[1])
+	assert.Equal(t, "22.00", combo.Components[0].AllocatedPrice)
+}
+
+func TestOrderService_RefundOrder_ComboComponent(t *testing.T) {
+	// Arrange
+	repo := NewMockOrderRepository()
+	service := NewOrderService(repo, WithMenuCatalog(newNoodleMenu()), WithPaymentGateway(&MockPaymentGateway{}))
+	ctx := context.Background()
+	created, err := service.CreateOrder(ctx, 1001, newOptionsOrderRequest(OrderItemRequest{DishID: "combo_201", DishName: "套餐", Quantity: 1, Price: 30.00}))
+	require.NoError(t, err)
+	payOrder(t, service, 1001, created.OrderNumber, "pay_001")
+
+	// Act
+	refund, err := service.RefundOrder(ctx, 1001, created.OrderNumber, &RefundOrderRequest{
+		Items: []RefundItemRequest{{DishID: "combo_201", SlotID: "drink", Quantity: 1}},
+	})
+
+	// Assert - 饮料分摊 8.00，打包费按 8/30 退还 0.27
+	require.NoError(t, err)
+	assert.Equal(t, RefundItemData{DishID: "combo_201", SlotID: "drink", Quantity: 1, Amount: "8.00"}, refund.Items[0])
+	assert.Equal(t, "8.27", refund.Amount)
+}
+
+func TestOrderService_CreateOrder_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		catalog  MenuCatalog
+		item     OrderItemRequest
+		wantCode string
+	}{
+		{
+			name:     "missing required option",
+			catalog:  newNoodleMenu(),
+			item:     OrderItemRequest{DishID: "dish_101", DishName: "牛肉面", Quantity: 1, Price: 22.00},
+			wantCode: domain.ErrInvalidOptionSelection.Code,
+		},
+		{
+			name:    "unknown option",
+			catalog: newNoodleMenu(),
+			item: OrderItemRequest{DishID: "dish_101", DishName: "牛肉面", Quantity: 1, Price: 22.00,
+				Options: []OrderItemOptionRequest{{GroupID: "size", OptionID: "huge"}}},
+			wantCode: domain.ErrUnknownDishOption.Code,
+		},
+		{
+			name:    "options for dish not in menu",
+			catalog: newNoodleMenu(),
+			item: OrderItemRequest{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00,
+				Options: []OrderItemOptionRequest{{GroupID: "spice", OptionID: "mild"}}},
+			wantCode: domain.ErrDishNotInMenu.Code,
+		},
+		{
+			name:    "invalid combo substitution",
+			catalog: newNoodleMenu(),
+			item: OrderItemRequest{DishID: "combo_201", DishName: "套餐", Quantity: 1, Price: 30.00,
+				Components: []ComboComponentRequest{{SlotID: "drink", DishID: "dish_101"}}},
+			wantCode: domain.ErrInvalidComboSelection.Code,
+		},
+		{
+			name:    "components for combo not in menu",
+			catalog: newNoodleMenu(),
+			item: OrderItemRequest{DishID: "combo_999", DishName: "套餐", Quantity: 1, Price: 30.00,
+				Components: []ComboComponentRequest{{SlotID: "drink", DishID: "dish_105"}}},
+			wantCode: domain.ErrComboNotInMenu.Code,
+		},
+		{
+			name: "options without catalog",
+			item: OrderItemRequest{DishID: "dish_101", DishName: "牛肉面", Quantity: 1, Price: 22.00,
+				Options: []OrderItemOptionRequest{{GroupID: "size", OptionID: "large"}}},
+			wantCode: domain.ErrDishNotInMenu.Code,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repo := NewMockOrderRepository().(*MockOrderRepository)
+			var opts []ServiceOption
+			if tt.catalog != nil {
+				opts = append(opts, WithMenuCatalog(tt.catalog))
+			}
+			service := NewOrderService(repo, opts...)
+
+			// Act
+			_, err := service.CreateOrder(context.Background(), 1001, newOptionsOrderRequest(tt.item))
+
+			// Assert - 规格不合法时不创建订单
+			var businessErr *BusinessError
+			require.ErrorAs(t, err, &businessErr)
+			assert.Equal(t, tt.wantCode, businessErr.Code)
+			assert.Empty(t, repo.orders)
+		})
+	}
+}
+
+func TestOrderService_CreateOrder_LogsWithRequestContext(t *testing.T) {
+	// Arrange - 适配器放入 context 的 logger 携带请求ID
+	var buf bytes.Buffer
+	ctx := logging.WithLogger(context.Background(), logging.New(&buf, slog.LevelInfo))
+	ctx = logging.WithRequestID(ctx, "req-1")
+	service := NewOrderService(NewMockOrderRepository())
+
+	// Act
+	orderData, err := service.CreateOrder(ctx, 1001, &CreateOrderRequest{
+		MerchantID: "merchant_001",
+		Items:      []OrderItemRequest{{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00}},
+		DeliveryInfo: DeliveryInfoRequest{
+			RecipientName:  "张三",
+			RecipientPhone: "13800138000",
+			Address:        "北京市朝阳区xxx",
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert - 日志携带请求ID和用户ID，收件人信息已脱敏
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "order created", entry["msg"])
+	assert.Equal(t, "req-1", entry[logging.KeyRequestID])
+	assert.Equal(t, float64(1001), entry[logging.KeyUserID])
+	assert.Equal(t, orderData.OrderNumber, entry["order_number"])
+	assert.Equal(t, "138****8000", entry["recipient_phone"])
+	assert.NotContains(t, buf.String(), "13800138000")
+}
+
+// createPaidOrder 创建并保存一个已支付订单（2×28.00 + 1×12.00，最终金额 72.00）
+func createPaidOrder(t *testing.T, repo OrderRepository) *domain.Order {
+	order := domain.NewOrder(1001, "merchant_001", []domain.OrderItem{
+		{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 2, Price: decimal.NewFromFloat(28.00)},
+		{DishID: "dish_002", DishName: "米饭", Quantity: 1, Price: decimal.NewFromFloat(12.00)},
+	}, domain.DeliveryInfo{RecipientName: "张三", RecipientPhone: "13800138000", Address: "北京市朝阳区xxx"}, "")
+	require.NoError(t, order.MarkPaid("pay_001"))
+	require.NoError(t, repo.Create(context.Background(), order))
+	return order
+}
+
+func TestOrderService_RefundOrder_Partial(t *testing.T) {
+	// Arrange
+	repo := NewMockOrderRepository()
+	payment := &MockPaymentGateway{}
+	service := NewOrderService(repo, WithPaymentGateway(payment))
+	order := createPaidOrder(t, repo)
+
+	req := &RefundOrderRequest{
+		Items:  []RefundItemRequest{{DishID: "dish_001", Quantity: 1}},
+		Reason: "少送了一份",
+	}
+
+	// Act
+	refundData, err := service.RefundOrder(context.Background(), 1001, order.OrderNumber, req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "REFUNDED", refundData.Status)
+	assert.Equal(t, "PAID", refundData.OrderStatus)
+	assert.Equal(t, "28.41", refundData.Amount)
+	assert.Equal(t, "0.41", refundData.PackagingFee)
+	assert.Equal(t, "0.00", refundData.DeliveryFee)
+	require.Len(t, payment.refunds, 1)
+	assert.Equal(t, "pay_001", payment.refunds[0].PaymentID)
+	assert.Equal(t, "28.41", payment.refunds[0].Amount.StringFixed(2))
+}
+
+func TestOrderService_RefundOrder_Full(t *testing.T) {
+	repo := NewMockOrderRepository()
+	service := NewOrderService(repo, WithPaymentGateway(&MockPaymentGateway{}))
+	order := createPaidOrder(t, repo)
+
+	refundData, err := service.RefundOrder(context.Background(), 1001, order.OrderNumber, &RefundOrderRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "72.00", refundData.Amount)
+	assert.Equal(t, "REFUNDED", refundData.OrderStatus)
+}
+
+func TestOrderService_RefundOrder_OtherUsersOrder(t *testing.T) {
+	repo := NewMockOrderRepository()
+	service := NewOrderService(repo, WithPaymentGateway(&MockPaymentGateway{}))
+	order := createPaidOrder(t, repo)
+
+	_, err := service.RefundOrder(context.Background(), 2002, order.OrderNumber, &RefundOrderRequest{})
+
+	assert.IsType(t, &NotFoundError{}, err)
+}
+
+func TestOrderService_RefundOrder_QuantityExceeded(t *testing.T) {
+	repo := NewMockOrderRepository()
+	service := NewOrderService(repo, WithPaymentGateway(&MockPaymentGateway{}))
+	order := createPaidOrder(t, repo)
+
+	req := &RefundOrderRequest{Items: []RefundItemRequest{{DishID: "dish_001", Quantity: 5}}}
+	_, err := service.RefundOrder(context.Background(), 1001, order.OrderNumber, req)
+
+	var businessErr *BusinessError
+	require.ErrorAs(t, err, &businessErr)
+	assert.Equal(t, domain.ErrRefundQuantityExceeded.Code, businessErr.Code)
+}
+
+func TestOrderService_RefundOrder_ValidationError(t *testing.T) {
+	repo := NewMockOrderRepository()
+	service := NewOrderService(repo, WithPaymentGateway(&MockPaymentGateway{}))
+	order := createPaidOrder(t, repo)
+
+	req := &RefundOrderRequest{Items: []RefundItemRequest{{DishID: "dish_001", Quantity: 0}}}
+	_, err := service.RefundOrder(context.Background(), 1001, order.OrderNumber, req)
+
+	assert.IsType(t, &ValidationError{}, err)
+}
+
+func TestOrderService_RefundOrder_PaymentFailure(t *testing.T) {
+	// Arrange
+	repo := NewMockOrderRepository()
+	service := NewOrderService(repo, WithPaymentGateway(&MockPaymentGateway{err: fmt.Errorf("channel unavailable")}))
+	order := createPaidOrder(t, repo)
+
+	// Act
+	_, err := service.RefundOrder(context.Background(), 1001, order.OrderNumber, &RefundOrderRequest{})
+
+	// Assert - 退款记录为失败，可退额度被释放
+	assert.IsType(t, &InternalError{}, err)
+	saved, _ := repo.FindByOrderNumber(context.Background(), order.OrderNumber)
+	require.Len(t, saved.Refunds, 1)
+	assert.Equal(t, domain.RefundStatusFailed, saved.Refunds[0].Status)
+	assert.Equal(t, "72.00", saved.RefundableAmount().StringFixed(2))
+}
+
+// cancellingPaymentGateway 退款成功后取消请求 context 的支付网关（模拟退款期间客户端断开连接）
+type cancellingPaymentGateway struct {
+	MockPaymentGateway
+	cancel context.CancelFunc
+}
+
+func (g *cancellingPaymentGateway) Refund(ctx context.Context, req *PaymentRefundRequest) error {
+	defer g.cancel()
+	return g.MockPaymentGateway.Refund(ctx, req)
+}
+
+// contextCheckingOrderRepository context 已取消时拒绝保存的仓储
+type contextCheckingOrderRepository struct {
+	OrderRepository
+}
+
+func (r *contextCheckingOrderRepository) Update(ctx context.Context, order *domain.Order) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return r.OrderRepository.Update(ctx, order)
+}
+
+func TestOrderService_RefundOrder_RecordsResultAfterCancel(t *testing.T) {
+	// Arrange
+	repo := NewMockOrderRepository()
+	order := createPaidOrder(t, repo)
+	ctx, cancel := context.WithCancel(context.Background())
+	service := NewOrderService(&contextCheckingOrderRepository{repo}, WithPaymentGateway(&cancellingPaymentGateway{cancel: cancel}))
+
+	// Act
+	_, err := service.RefundOrder(ctx, 1001, order.OrderNumber, &RefundOrderRequest{})
+
+	// Assert - 网关已退款，请求取消后仍然记录退款结果
+	require.NoError(t, err)
+	saved, _ := repo.FindByOrderNumber(context.Background(), order.OrderNumber)
+	assert.Equal(t, domain.OrderStatusRefunded, saved.Status)
+	assert.Equal(t, domain.RefundStatusRefunded, saved.Refunds[0].Status)
+}
+
+func TestOrderService_CreateOrder_WritesEventsToOutbox(t *testing.T) {
+	// Arrange
+	repo := NewMockOrderRepository().(*MockOrderRepository)
+	service := NewOrderService(repo)
+
+	// Act
+	orderData, err := service.CreateOrder(context.Background(), 1001, &CreateOrderRequest{
+		MerchantID: "merchant_001",
+		Items:      []OrderItemRequest{{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00}},
+		DeliveryInfo: DeliveryInfoRequest{
+			RecipientName:  "张三",
+			RecipientPhone: "13800138000",
+			Address:        "北京市朝阳区xxx",
+		},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, repo.outbox, 1)
+	assert.Equal(t, domain.EventTypeOrderCreated, repo.outbox[0].Event.EventType())
+	assert.Equal(t, orderData.OrderNumber, repo.outbox[0].Event.AggregateID())
+	assert.Equal(t, OutboxStatusPending, repo.outbox[0].Status)
+}
+
+func TestOrderService_CreateOrder_NoEventsWhenSaveFails(t *testing.T) {
+	repo := &failingRepository{NewMockOrderRepository().(*MockOrderRepository)}
+	service := NewOrderService(repo)
+
+	_, err := service.CreateOrder(context.Background(), 1001, &CreateOrderRequest{
+		MerchantID: "merchant_001",
+		Items:      []OrderItemRequest{{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00}},
+		DeliveryInfo: DeliveryInfoRequest{
+			RecipientName:  "张三",
+			RecipientPhone: "13800138000",
+			Address:        "北京市朝阳区xxx",
+		},
+	})
+
+	assert.IsType(t, &InternalError{}, err)
+	assert.Empty(t, repo.outbox)
+}
+
+// payOrder 以订单应付金额确认支付（模拟已验签的支付回调）
+func payOrder(t *testing.T, orders OrderService, userID uint64, orderNumber, paymentID string) {
+	t.Helper()
+	orderData, err := orders.GetOrder(context.Background(), userID, orderNumber)
+	require.NoError(t, err)
+	_, err = orders.ConfirmPayment(context.Background(), &ConfirmPaymentRequest{
+		OrderNumber: orderNumber,
+		PaymentID:   paymentID,
+		Amount:      orderData.Pricing.FinalAmount,
+	})
+	require.NoError(t, err)
+}
+
+func TestOrderService_ConfirmPayment(t *testing.T) {
+	// Arrange
+	repo := NewMockOrderRepository().(*MockOrderRepository)
+	service := NewOrderService(repo)
+	order := domain.NewOrder(1001, "merchant_001", []domain.OrderItem{
+		{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 2, Price: decimal.NewFromFloat(28.00)},
+	}, domain.DeliveryInfo{}, "")
+	require.NoError(t, repo.Create(context.Background(), order))
+	amount := order.Pricing.FinalAmount.StringFixed(2)
+	ctx := context.Background()
+
+	// Act
+	_, mismatchErr := service.ConfirmPayment(ctx, &ConfirmPaymentRequest{OrderNumber: order.OrderNumber, PaymentID: "pay_001", Amount: "0.01"})
+	_, invalidErr := service.ConfirmPayment(ctx, &ConfirmPaymentRequest{OrderNumber: order.OrderNumber, PaymentID: "pay_001", Amount: "abc"})
+	_, notFoundErr := service.ConfirmPayment(ctx, &ConfirmPaymentRequest{OrderNumber: "unknown", PaymentID: "pay_001", Amount: amount})
+	orderData, err := service.ConfirmPayment(ctx, &ConfirmPaymentRequest{OrderNumber: order.OrderNumber, PaymentID: "pay_001", Amount: amount})
+
+	// Assert - 金额不一致时不改变订单状态
+	require.NoError(t, err)
+	assert.Equal(t, "PAID", orderData.Status)
+	assert.Equal(t, []string{domain.EventTypeOrderCreated, domain.EventTypeOrderPaid}, repo.outboxEventTypes())
+	assertBusinessCode(t, mismatchErr, domain.ErrPaymentAmountMismatch)
+	var validationErr *ValidationError
+	assert.ErrorAs(t, invalidErr, &validationErr)
+	var notFound *NotFoundError
+	assert.ErrorAs(t, notFoundErr, &notFound)
+
+	// 重复支付返回业务错误
+	_, err = service.ConfirmPayment(ctx, &ConfirmPaymentRequest{OrderNumber: order.OrderNumber, PaymentID: "pay_002", Amount: amount})
+	assertBusinessCode(t, err, domain.ErrInvalidOrderStatus)
+}
+
+func TestOrderService_CancelOrder(t *testing.T) {
+	// Arrange
+	repo := NewMockOrderRepository().(*MockOrderRepository)
+	service := NewOrderService(repo)
+	order := domain.NewOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "")
+	require.NoError(t, repo.Create(context.Background(), order))
+
+	// Act
+	orderData, err := service.CancelOrder(context.Background(), 1001, order.OrderNumber, &CancelOrderRequest{Reason: "点错了"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "CANCELLED", orderData.Status)
+	assert.Equal(t, []string{domain.EventTypeOrderCreated, domain.EventTypeOrderCancelled}, repo.outboxEventTypes())
+}
+
+func TestOrderService_RefundOrder_WritesRefundEvents(t *testing.T) {
+	repo := NewMockOrderRepository().(*MockOrderRepository)
+	service := NewOrderService(repo, WithPaymentGateway(&MockPaymentGateway{}))
+	order := createPaidOrder(t, repo)
+
+	_, err := service.RefundOrder(context.Background(), 1001, order.OrderNumber, &RefundOrderRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		domain.EventTypeOrderCreated,
+		domain.EventTypeOrderPaid,
+		domain.EventTypeRefundRequested,
+		domain.EventTypeRefundCompleted,
+	}, repo.outboxEventTypes())
+}
+
+func TestOrderService_GetOrder(t *testing.T) {
+	repo := NewMockOrderRepository()
+	service := NewOrderService(repo)
+	order := domain.NewOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "")
+	require.NoError(t, repo.Create(context.Background(), order))
+
+	orderData, err := service.GetOrder(context.Background(), 1001, order.OrderNumber)
+	require.NoError(t, err)
+	assert.Equal(t, "PENDING_PAYMENT", orderData.Status)
+
+	// 其他用户的订单视为不存在
+	_, err = service.GetOrder(context.Background(), 2002, order.OrderNumber)
+	assert.IsType(t, &NotFoundError{}, err)
+}
+
+func TestOrderService_AcceptOrder(t *testing.T) {
+	// Arrange
+	repo := NewMockOrderRepository().(*MockOrderRepository)
+	service := NewOrderService(repo)
+	order := createPaidOrder(t, repo)
+
+	// Act
+	orderData, err := service.AcceptOrder(context.Background(), "merchant_001", order.OrderNumber)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "ACCEPTED", orderData.Status)
+	assert.Contains(t, repo.outboxEventTypes(), domain.EventTypeOrderAccepted)
+
+	// 重复接单返回业务错误
+	_, err = service.AcceptOrder(context.Background(), "merchant_001", order.OrderNumber)
+	assert.IsType(t, &BusinessError{}, err)
+}
+
+func TestOrderService_AcceptOrder_OtherMerchantsOrder(t *testing.T) {
+	repo := NewMockOrderRepository()
+	service := NewOrderService(repo)
+	order := createPaidOrder(t, repo)
+
+	_, err := service.AcceptOrder(context.Background(), "merchant_002", order.OrderNumber)
+
+	assert.IsType(t, &NotFoundError{}, err)
+}
+
+func TestOrderService_RejectOrder_RefundsInFull(t *testing.T) {
+	// Arrange
+	repo := NewMockOrderRepository().(*MockOrderRepository)
+	gateway := &MockPaymentGateway{}
+	service := NewOrderService(repo, WithPaymentGateway(gateway))
+	order := createPaidOrder(t, repo)
+
+	// Act
+	orderData, err := service.RejectOrder(context.Background(), "merchant_001", order.OrderNumber, &RejectOrderRequest{Reason: "菜品已售罄"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "REFUNDED", orderData.Status)
+	require.Len(t, gateway.refunds, 1)
+	assert.Equal(t, "72.00", gateway.refunds[0].Amount.StringFixed(2))
+	assert.Contains(t, repo.outboxEventTypes(), domain.EventTypeOrderRejected)
+}
+
+func TestOrderService_RejectOrder_RequiresReason(t *testing.T) {
+	repo := NewMockOrderRepository()
+	service := NewOrderService(repo, WithPaymentGateway(&MockPaymentGateway{}))
+	order := createPaidOrder(t, repo)
+
+	_, err := service.RejectOrder(context.Background(), "merchant_001", order.OrderNumber, &RejectOrderRequest{})
+
+	assert.IsType(t, &ValidationError{}, err)
+}
+
+func TestOrderService_ListOrders_Pagination(t *testing.T) {
+	// Arrange - 用户 1001 的 5 个订单和其他用户的 1 个订单
+	repo := NewMockOrderRepository()
+	service := NewOrderService(repo)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		order := domain.NewOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "")
+		order.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, repo.Create(context.Background(), order))
+	}
+	require.NoError(t, repo.Create(context.Background(), domain.NewOrder(2002, "merchant_001", nil, domain.DeliveryInfo{}, "")))
+
+	// Act
+	first, err := service.ListOrders(context.Background(), 1001, &ListOrdersRequest{Limit: 2})
+	require.NoError(t, err)
+	second, err := service.ListOrders(context.Background(), 1001, &ListOrdersRequest{Limit: 2, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	last, err := service.ListOrders(context.Background(), 1001, &ListOrdersRequest{Limit: 2, Cursor: second.NextCursor})
+	require.NoError(t, err)
+
+	// Assert - 按创建时间倒序，翻页不重复不遗漏
+	assert.Len(t, first.Orders, 2)
+	assert.True(t, first.HasMore)
+	assert.Len(t, second.Orders, 2)
+	assert.Len(t, last.Orders, 1)
+	assert.False(t, last.HasMore)
+	assert.Empty(t, last.NextCursor)
+	assert.Equal(t, first.NextCursor, first.Orders[1].Cursor)
+
+	seen := map[string]bool{}
+	var previous string
+	for _, page := range []*OrderListData{first, second, last} {
+		for _, order := range page.Orders {
+			assert.False(t, seen[order.OrderNumber])
+			seen[order.OrderNumber] = true
+			if previous != "" {
+				assert.Less(t, order.CreatedAt, previous)
+			}
+			previous = order.CreatedAt
+		}
+	}
+	assert.Len(t, seen, 5)
+}
+
+func TestOrderService_ListOrders_FilterByStatus(t *testing.T) {
+	repo := NewMockOrderRepository()
+	service := NewOrderService(repo)
+	createPaidOrder(t, repo)
+	require.NoError(t, repo.Create(context.Background(), domain.NewOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "")))
+
+	list, err := service.ListOrders(context.Background(), 1001, &ListOrdersRequest{Status: "PAID"})
+
+	require.NoError(t, err)
+	require.Len(t, list.Orders, 1)
+	assert.Equal(t, "PAID", list.Orders[0].Status)
+	assert.Len(t, list.Orders[0].Items, 2)
+}
+
+func TestOrderService_ListOrders_InvalidRequest(t *testing.T) {
+	service := NewOrderService(NewMockOrderRepository())
+
+	_, err := service.ListOrders(context.Background(), 1001, &ListOrdersRequest{Cursor: "!!!"})
+	require.IsType(t, &ValidationError{}, err)
+	assert.Equal(t, "Cursor", err.(*ValidationError).Field)
+
+	_, err = service.ListOrders(context.Background(), 1001, &ListOrdersRequest{Limit: 101})
+	assert.IsType(t, &ValidationError{}, err)
+
+	_, err = service.ListOrders(context.Background(), 1001, &ListOrdersRequest{Status: "SHIPPED"})
+	assert.IsType(t, &ValidationError{}, err)
+}
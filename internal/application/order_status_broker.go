@@ -0,0 +1,261 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"order-service/internal/domain"
+)
+
+// 状态推送代理默认配置
+const (
+	DefaultBrokerHistorySize = 50
+	DefaultBrokerBufferSize  = 16
+	DefaultBrokerMaxOrders   = 10000
+)
+
+// OrderStatusUpdate 推送给客户端的订单状态变更
+// Status 为事件发生后的订单状态，退款申请/失败事件不改变订单状态时为空
+type OrderStatusUpdate struct {
+	EventID     string
+	OrderNumber string
+	EventType   string
+	Status      string
+	OccurredAt  time.Time
+}
+
+// OrderStatusBroker 订单状态变更扇出代理
+// 订阅事件总线，按订单号将状态变更广播给所有订阅者，并为每个订单保留最近的变更用于断线续传，
+// 订阅者无需轮询仓储
+type OrderStatusBroker struct {
+	mu          sync.Mutex
+	topics      map[string]*statusTopic
+	topicOrder  []string // 按创建顺序记录订单号，用于淘汰
+	historySize int
+	bufferSize  int
+	maxOrders   int
+}
+
+// statusTopic 单个订单的变更历史和订阅者
+type statusTopic struct {
+	history     []OrderStatusUpdate
+	subscribers map[*OrderStatusSubscription]struct{}
+}
+
+// OrderStatusSubscription 订单状态订阅
+type OrderStatusSubscription struct {
+	// Replay 续传时需要补发的变更（Last-Event-ID 之后的历史）
+	Replay []OrderStatusUpdate
+	// Resumed Last-Event-ID 是否命中历史；未命中时客户端需要先获取当前状态
+	Resumed bool
+
+	updates     chan OrderStatusUpdate
+	broker      *OrderStatusBroker
+	orderNumber string
+	closeOnce   sync.Once
+}
+
+// BrokerOption 状态推送代理可选配置
+type BrokerOption func(*OrderStatusBroker)
+
+// WithBrokerHistorySize 配置每个订单保留的历史变更数量
+func WithBrokerHistorySize(size int) BrokerOption {
+	return func(b *OrderStatusBroker) {
+		b.historySize = size
+	}
+}
+
+// WithBrokerBufferSize 配置每个订阅者的缓冲大小（缓冲满时断开慢订阅者）
+func WithBrokerBufferSize(size int) BrokerOption {
+	return func(b *OrderStatusBroker) {
+		b.bufferSize = size
+	}
+}
+
+// WithBrokerMaxOrders 配置最多保留历史的订单数量（超过后淘汰最早且无订阅者的订单）
+func WithBrokerMaxOrders(max int) BrokerOption {
+	return func(b *OrderStatusBroker) {
+		b.maxOrders = max
+	}
+}
+
+// NewOrderStatusBroker 创建订单状态推送代理
+func NewOrderStatusBroker(opts ...BrokerOption) *OrderStatusBroker {
+	b := &OrderStatusBroker{
+		topics:      make(map[string]*statusTopic),
+		historySize: DefaultBrokerHistorySize,
+		bufferSize:  DefaultBrokerBufferSize,
+		maxOrders:   DefaultBrokerMaxOrders,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// HandleEvent 接收领域事件并广播给订阅了该订单的客户端（订阅事件总线）
+// outbox 可能重复投递事件，已记录的事件ID会被忽略
+func (b *OrderStatusBroker) HandleEvent(ctx context.Context, event domain.Event) error {
+	update := toOrderStatusUpdate(event)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	topic := b.topic(update.OrderNumber)
+	for _, existing := range topic.history {
+		if existing.EventID == update.EventID {
+			return nil
+		}
+	}
+
+	topic.history = append(topic.history, update)
+	if len(topic.history) > b.historySize {
+		topic.history = topic.history[len(topic.history)-b.historySize:]
+	}
+
+	for sub := range topic.subscribers {
+		select {
+		case sub.updates <- update:
+		default:
+			// 慢订阅者：断开连接，客户端携带 Last-Event-ID 重连后从历史续传
+			delete(topic.subscribers, sub)
+			close(sub.updates)
+		}
+	}
+	return nil
+}
+
+// Subscribe 订阅订单状态变更
+// lastEventID 命中历史时 Replay 为其后的变更；调用方负责校验订单归属，使用完毕后必须 Close
+func (b *OrderStatusBroker) Subscribe(orderNumber, lastEventID string) *OrderStatusSubscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	topic := b.topic(orderNumber)
+	sub := &OrderStatusSubscription{
+		updates:     make(chan OrderStatusUpdate, b.bufferSize),
+		broker:      b,
+		orderNumber: orderNumber,
+	}
+	if lastEventID != "" {
+		for i, update := range topic.history {
+			if update.EventID == lastEventID {
+				sub.Replay = append([]OrderStatusUpdate(nil), topic.history[i+1:]...)
+				sub.Resumed = true
+				break
+			}
+		}
+	}
+	topic.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Subscribers 返回订单当前的订阅者数量
+func (b *OrderStatusBroker) Subscribers(orderNumber string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if topic, ok := b.topics[orderNumber]; ok {
+		return len(topic.subscribers)
+	}
+	return 0
+}
+
+// DisconnectAll 断开全部订阅（停机时调用，客户端携带 Last-Event-ID 重连到其他实例）
+func (b *OrderStatusBroker) DisconnectAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, topic := range b.topics {
+		for sub := range topic.subscribers {
+			delete(topic.subscribers, sub)
+			close(sub.updates)
+		}
+	}
+}
+
+// Updates 返回实时变更通道，通道关闭表示订阅已被断开
+func (s *OrderStatusSubscription) Updates() <-chan OrderStatusUpdate {
+	return s.updates
+}
+
+// Close 取消订阅
+func (s *OrderStatusSubscription) Close() {
+	s.closeOnce.Do(func() {
+		s.broker.unsubscribe(s)
+	})
+}
+
+// unsubscribe 移除订阅者
+func (b *OrderStatusBroker) unsubscribe(sub *OrderStatusSubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	topic, ok := b.topics[sub.orderNumber]
+	if !ok {
+		return
+	}
+	if _, ok := topic.subscribers[sub]; ok {
+		delete(topic.subscribers, sub)
+		close(sub.updates)
+	}
+}
+
+// topic 返回订单的订阅主题，不存在时创建（调用方需持有锁）
+func (b *OrderStatusBroker) topic(orderNumber string) *statusTopic {
+	if topic, ok := b.topics[orderNumber]; ok {
+		return topic
+	}
+
+	b.evict()
+	topic := &statusTopic{subscribers: make(map[*OrderStatusSubscription]struct{})}
+	b.topics[orderNumber] = topic
+	b.topicOrder = append(b.topicOrder, orderNumber)
+	return topic
+}
+
+// evict 订单数达到上限时淘汰最早创建且没有订阅者的订单，为新订单腾出位置（调用方需持有锁）
+func (b *OrderStatusBroker) evict() {
+	if len(b.topics) < b.maxOrders {
+		return
+	}
+
+	kept := b.topicOrder[:0]
+	for _, orderNumber := range b.topicOrder {
+		topic := b.topics[orderNumber]
+		if len(b.topics) >= b.maxOrders && len(topic.subscribers) == 0 {
+			delete(b.topics, orderNumber)
+			continue
+		}
+		kept = append(kept, orderNumber)
+	}
+	b.topicOrder = kept
+}
+
+// toOrderStatusUpdate 将领域事件转换为状态变更
+func toOrderStatusUpdate(event domain.Event) OrderStatusUpdate {
+	update := OrderStatusUpdate{
+		EventID:     event.EventID(),
+		OrderNumber: event.AggregateID(),
+		EventType:   event.EventType(),
+		OccurredAt:  event.OccurredAt(),
+	}
+	switch e := event.(type) {
+	case domain.OrderCreated:
+		update.Status = string(e.Status)
+	case domain.OrderPaid:
+		update.Status = string(e.Status)
+	case domain.OrderCancelled:
+		update.Status = string(e.Status)
+	case domain.OrderAccepted:
+		update.Status = string(e.Status)
+	case domain.OrderRejected:
+		update.Status = string(e.Status)
+	case domain.OrderReleased:
+		update.Status = string(e.Status)
+	case domain.RefundCompleted:
+		update.Status = string(e.Status)
+	}
+	return update
+}
@@ -0,0 +1,141 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPaidOrderEvents 创建订单并支付，返回 created、paid 两个事件
+func newPaidOrderEvents(t *testing.T) (*domain.Order, []domain.Event) {
+	order := domain.NewOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "")
+	require.NoError(t, order.MarkPaid("pay_001"))
+	return order, order.PullEvents()
+}
+
+func TestOrderStatusBroker_FanOut(t *testing.T) {
+	// Arrange
+	broker := NewOrderStatusBroker()
+	order, events := newPaidOrderEvents(t)
+	first := broker.Subscribe(order.OrderNumber, "")
+	second := broker.Subscribe(order.OrderNumber, "")
+	defer first.Close()
+	defer second.Close()
+
+	// Act
+	for _, event := range events {
+		require.NoError(t, broker.HandleEvent(context.Background(), event))
+	}
+
+	// Assert - 每个订阅者都收到全部变更
+	for _, sub := range []*OrderStatusSubscription{first, second} {
+		created := <-sub.Updates()
+		paid := <-sub.Updates()
+		assert.Equal(t, domain.EventTypeOrderCreated, created.EventType)
+		assert.Equal(t, "PENDING_PAYMENT", created.Status)
+		assert.Equal(t, domain.EventTypeOrderPaid, paid.EventType)
+		assert.Equal(t, "PAID", paid.Status)
+	}
+}
+
+func TestOrderStatusBroker_IgnoresDuplicateAndOtherOrders(t *testing.T) {
+	broker := NewOrderStatusBroker()
+	order, events := newPaidOrderEvents(t)
+	other, otherEvents := newPaidOrderEvents(t)
+	sub := broker.Subscribe(order.OrderNumber, "")
+	defer sub.Close()
+
+	// outbox 重复投递同一事件
+	require.NoError(t, broker.HandleEvent(context.Background(), events[0]))
+	require.NoError(t, broker.HandleEvent(context.Background(), events[0]))
+	require.NoError(t, broker.HandleEvent(context.Background(), otherEvents[0]))
+
+	assert.Len(t, sub.Updates(), 1)
+	assert.NotEqual(t, order.OrderNumber, other.OrderNumber)
+}
+
+func TestOrderStatusBroker_ResumeFromLastEventID(t *testing.T) {
+	broker := NewOrderStatusBroker()
+	order, events := newPaidOrderEvents(t)
+	for _, event := range events {
+		require.NoError(t, broker.HandleEvent(context.Background(), event))
+	}
+
+	// 命中历史：补发其后的变更
+	sub := broker.Subscribe(order.OrderNumber, events[0].EventID())
+	defer sub.Close()
+	assert.True(t, sub.Resumed)
+	require.Len(t, sub.Replay, 1)
+	assert.Equal(t, events[1].EventID(), sub.Replay[0].EventID)
+
+	// 未命中历史：需要客户端重新获取当前状态
+	unknown := broker.Subscribe(order.OrderNumber, "evt_unknown")
+	defer unknown.Close()
+	assert.False(t, unknown.Resumed)
+	assert.Empty(t, unknown.Replay)
+}
+
+func TestOrderStatusBroker_DropsSlowSubscriber(t *testing.T) {
+	broker := NewOrderStatusBroker(WithBrokerBufferSize(1))
+	order, events := newPaidOrderEvents(t)
+	sub := broker.Subscribe(order.OrderNumber, "")
+	defer sub.Close()
+
+	for _, event := range events {
+		require.NoError(t, broker.HandleEvent(context.Background(), event))
+	}
+
+	// 缓冲中的变更仍可读取，之后通道关闭
+	_, ok := <-sub.Updates()
+	assert.True(t, ok)
+	_, ok = <-sub.Updates()
+	assert.False(t, ok)
+	assert.Equal(t, 0, broker.Subscribers(order.OrderNumber))
+}
+
+func TestOrderStatusBroker_EvictsIdleOrders(t *testing.T) {
+	broker := NewOrderStatusBroker(WithBrokerMaxOrders(2))
+	watched := broker.Subscribe("ORDER_WATCHED", "")
+	defer watched.Close()
+
+	for i := 0; i < 5; i++ {
+		order := domain.NewOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, fmt.Sprint(i))
+		require.NoError(t, broker.HandleEvent(context.Background(), order.PullEvents()[0]))
+	}
+
+	// 有订阅者的订单不会被淘汰
+	assert.Equal(t, 1, broker.Subscribers("ORDER_WATCHED"))
+	assert.LessOrEqual(t, len(broker.topics), 2)
+}
+
+func TestOrderStatusSubscription_CloseIsIdempotent(t *testing.T) {
+	broker := NewOrderStatusBroker()
+	sub := broker.Subscribe("ORDER_001", "")
+
+	sub.Close()
+	sub.Close()
+
+	assert.Equal(t, 0, broker.Subscribers("ORDER_001"))
+}
+
+func TestOrderStatusBroker_DisconnectAll(t *testing.T) {
+	// Arrange
+	broker := NewOrderStatusBroker()
+	order, events := newPaidOrderEvents(t)
+	sub := broker.Subscribe(order.OrderNumber, "")
+
+	// Act
+	broker.DisconnectAll()
+
+	// Assert - 通道关闭，之后的 Close 和事件广播都是安全的
+	_, ok := <-sub.Updates()
+	assert.False(t, ok)
+	assert.Equal(t, 0, broker.Subscribers(order.OrderNumber))
+	sub.Close()
+	require.NoError(t, broker.HandleEvent(context.Background(), events[0]))
+}
@@ -0,0 +1,166 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"order-service/internal/logging"
+)
+
+// 投递器默认配置
+const (
+	DefaultRelayBatchSize   = 100
+	DefaultRelayMaxAttempts = 10
+	DefaultRelayBaseBackoff = time.Second
+	DefaultRelayMaxBackoff  = 5 * time.Minute
+)
+
+// OutboxRelay outbox 投递器
+// 读取待投递的 outbox 记录并投递给事件发布器，保证至少一次（at-least-once）投递：
+// 投递成功但标记失败时（例如进程崩溃）事件会被再次投递，订阅者应按事件ID去重
+type OutboxRelay struct {
+	outbox      OutboxRepository
+	publisher   EventPublisher
+	batchSize   int
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	now         func() time.Time
+
+	fetchHealth // 就绪检查只反映读取 outbox 是否失败（投递失败由重试和死信处理）
+}
+
+// RelayOption 投递器可选配置
+type RelayOption func(*OutboxRelay)
+
+// WithRelayBatchSize 配置每批投递数量
+func WithRelayBatchSize(size int) RelayOption {
+	return func(r *OutboxRelay) {
+		r.batchSize = size
+	}
+}
+
+// WithRelayMaxAttempts 配置最大投递次数（超过后进入死信状态）
+func WithRelayMaxAttempts(attempts int) RelayOption {
+	return func(r *OutboxRelay) {
+		r.maxAttempts = attempts
+	}
+}
+
+// WithRelayBackoff 配置重试退避时间（指数增长，不超过 max）
+func WithRelayBackoff(base, max time.Duration) RelayOption {
+	return func(r *OutboxRelay) {
+		r.baseBackoff = base
+		r.maxBackoff = max
+	}
+}
+
+// WithRelayClock 配置时钟（测试使用）
+func WithRelayClock(now func() time.Time) RelayOption {
+	return func(r *OutboxRelay) {
+		r.now = now
+	}
+}
+
+// NewOutboxRelay 创建 outbox 投递器
+func NewOutboxRelay(outbox OutboxRepository, publisher EventPublisher, opts ...RelayOption) *OutboxRelay {
+	r := &OutboxRelay{
+		outbox:      outbox,
+		publisher:   publisher,
+		batchSize:   DefaultRelayBatchSize,
+		maxAttempts: DefaultRelayMaxAttempts,
+		baseBackoff: DefaultRelayBaseBackoff,
+		maxBackoff:  DefaultRelayMaxBackoff,
+		now:         time.Now,
+		fetchHealth: fetchHealth{failure: "failed to fetch outbox entries"},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Run 按固定间隔循环投递，直到 ctx 结束
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "outbox relay", r.RelayOnce)
+}
+
+// RelayOnce 投递一批到期的 outbox 记录，返回投递成功的数量
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	entries, err := r.outbox.FetchPendingOutbox(ctx, r.now(), r.batchSize)
+	if err := r.observe(err); err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	var errs []error
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			break
+		}
+
+		publishErr := r.publisher.Publish(ctx, entry.Event)
+		if publishErr == nil {
+			entry.Status = OutboxStatusDelivered
+			entry.DeliveredAt = r.now()
+			entry.LastError = ""
+			delivered++
+		} else {
+			r.scheduleRetry(&entry, publishErr)
+			logOutboxFailure(ctx, entry, publishErr)
+		}
+		entry.Attempts++
+
+		if err := r.outbox.UpdateOutboxEntry(ctx, entry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return delivered, NewInternalError("failed to update outbox entries", errors.Join(errs...))
+	}
+	return delivered, nil
+}
+
+// scheduleRetry 记录投递失败并安排重试，超过最大次数时进入死信状态
+func (r *OutboxRelay) scheduleRetry(entry *OutboxEntry, err error) {
+	entry.LastError = err.Error()
+	if entry.Attempts+1 >= r.maxAttempts {
+		entry.Status = OutboxStatusDeadLetter
+		return
+	}
+	entry.NextAttemptAt = r.now().Add(r.backoff(entry.Attempts + 1))
+}
+
+// logOutboxFailure 记录投递失败，进入死信状态时记为 error
+func logOutboxFailure(ctx context.Context, entry OutboxEntry, err error) {
+	logger := logging.FromContext(ctx).With(
+		"event_id", entry.Event.EventID(),
+		"event_type", entry.Event.EventType(),
+		"attempt", entry.Attempts+1,
+		logging.KeyError, err,
+	)
+	if entry.Status == OutboxStatusDeadLetter {
+		logger.Error("outbox entry dead-lettered")
+		return
+	}
+	logger.Warn("outbox publish failed", "next_attempt_at", entry.NextAttemptAt)
+}
+
+// backoff 第 attempt 次失败后的退避时间
+func (r *OutboxRelay) backoff(attempt int) time.Duration {
+	return exponentialBackoff(r.baseBackoff, r.maxBackoff, attempt)
+}
+
+// exponentialBackoff 指数退避：base × 2^(attempt-1)，不超过 max
+func exponentialBackoff(base, max time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
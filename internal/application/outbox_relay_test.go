@@ -0,0 +1,211 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock 可手动推进的测试时钟
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func (c *testClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// crashingOutbox 标记投递结果时模拟进程崩溃的 outbox
+type crashingOutbox struct {
+	*MockOrderRepository
+	crashes int
+}
+
+func (c *crashingOutbox) UpdateOutboxEntry(ctx context.Context, entry OutboxEntry) error {
+	if c.crashes > 0 {
+		c.crashes--
+		return fmt.Errorf("process crashed")
+	}
+	return c.MockOrderRepository.UpdateOutboxEntry(ctx, entry)
+}
+
+// createOrderViaService 通过应用服务创建订单
+func createOrderViaService(t *testing.T, service OrderService) *OrderData {
+	orderData, err := service.CreateOrder(context.Background(), 1001, &CreateOrderRequest{
+		MerchantID: "merchant_001",
+		Items:      []OrderItemRequest{{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00}},
+		DeliveryInfo: DeliveryInfoRequest{
+			RecipientName:  "张三",
+			RecipientPhone: "13800138000",
+			Address:        "北京市朝阳区xxx",
+		},
+	})
+	require.NoError(t, err)
+	return orderData
+}
+
+func TestOutboxRelay_RelayOnce_DeliversPendingEntries(t *testing.T) {
+	// Arrange
+	repo := NewMockOrderRepository().(*MockOrderRepository)
+	publisher := &MockEventPublisher{}
+	relay := NewOutboxRelay(repo, publisher)
+	orderData := createOrderViaService(t, NewOrderService(repo))
+
+	// Act
+	delivered, err := relay.RelayOnce(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, orderData.OrderNumber, publisher.events[0].AggregateID())
+	assert.Equal(t, OutboxStatusDelivered, repo.outbox[0].Status)
+	assert.Equal(t, 1, repo.outbox[0].Attempts)
+	assert.False(t, repo.outbox[0].DeliveredAt.IsZero())
+
+	// 已投递的记录不会再次投递
+	delivered, err = relay.RelayOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Len(t, publisher.events, 1)
+}
+
+func TestOutboxRelay_CrashBetweenSaveAndPublish(t *testing.T) {
+	// Arrange - 订单保存成功后进程崩溃，事件尚未发布
+	repo := NewMockOrderRepository().(*MockOrderRepository)
+	orderData := createOrderViaService(t, NewOrderService(repo))
+
+	// Act - 重启后新的投递器接管 outbox
+	publisher := &MockEventPublisher{}
+	_, err := NewOutboxRelay(repo, publisher).RelayOnce(context.Background())
+
+	// Assert - 事件没有丢失
+	require.NoError(t, err)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, domain.EventTypeOrderCreated, publisher.events[0].EventType())
+	assert.Equal(t, orderData.OrderNumber, publisher.events[0].AggregateID())
+}
+
+func TestOutboxRelay_CrashBetweenPublishAndMarkDelivered(t *testing.T) {
+	// Arrange - 发布成功但标记投递结果前进程崩溃
+	repo := NewMockOrderRepository().(*MockOrderRepository)
+	createOrderViaService(t, NewOrderService(repo))
+	publisher := &MockEventPublisher{}
+
+	_, err := NewOutboxRelay(&crashingOutbox{MockOrderRepository: repo, crashes: 1}, publisher).RelayOnce(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, OutboxStatusPending, repo.outbox[0].Status)
+
+	// Act - 重启后再次投递
+	_, err = NewOutboxRelay(repo, publisher).RelayOnce(context.Background())
+
+	// Assert - 至少一次投递：事件被重复投递，事件ID相同便于订阅者去重
+	require.NoError(t, err)
+	require.Len(t, publisher.events, 2)
+	assert.Equal(t, publisher.events[0].EventID(), publisher.events[1].EventID())
+	assert.Equal(t, OutboxStatusDelivered, repo.outbox[0].Status)
+}
+
+func TestOutboxRelay_RetriesWithExponentialBackoff(t *testing.T) {
+	// Arrange
+	repo := NewMockOrderRepository().(*MockOrderRepository)
+	createOrderViaService(t, NewOrderService(repo))
+	clock := &testClock{now: time.Now()}
+	publisher := &MockEventPublisher{failures: 2}
+	relay := NewOutboxRelay(repo, publisher, WithRelayClock(clock.Now), WithRelayBackoff(time.Second, time.Minute))
+	ctx := context.Background()
+
+	// Act & Assert - 第一次失败，1 秒后重试
+	_, err := relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.outbox[0].Attempts)
+	assert.Equal(t, clock.now.Add(time.Second), repo.outbox[0].NextAttemptAt)
+	assert.Equal(t, "subscriber unavailable", repo.outbox[0].LastError)
+
+	// 未到重试时间不会投递
+	_, _ = relay.RelayOnce(ctx)
+	assert.Equal(t, 1, repo.outbox[0].Attempts)
+
+	// 第二次失败，2 秒后重试
+	clock.Advance(time.Second)
+	_, _ = relay.RelayOnce(ctx)
+	assert.Equal(t, 2, repo.outbox[0].Attempts)
+	assert.Equal(t, clock.now.Add(2*time.Second), repo.outbox[0].NextAttemptAt)
+
+	// 第三次成功
+	clock.Advance(2 * time.Second)
+	delivered, _ := relay.RelayOnce(ctx)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, OutboxStatusDelivered, repo.outbox[0].Status)
+	assert.Len(t, publisher.events, 1)
+}
+
+func TestOutboxRelay_DeadLetterAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	repo := NewMockOrderRepository().(*MockOrderRepository)
+	createOrderViaService(t, NewOrderService(repo))
+	clock := &testClock{now: time.Now()}
+	relay := NewOutboxRelay(repo, &MockEventPublisher{failures: 100},
+		WithRelayClock(clock.Now), WithRelayMaxAttempts(3), WithRelayBackoff(time.Second, time.Minute))
+	ctx := context.Background()
+
+	// Act
+	for i := 0; i < 5; i++ {
+		_, _ = relay.RelayOnce(ctx)
+		clock.Advance(time.Minute)
+	}
+
+	// Assert
+	dead, err := repo.FindOutboxEntries(ctx, OutboxStatusDeadLetter)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 3, dead[0].Attempts)
+	assert.Equal(t, "subscriber unavailable", dead[0].LastError)
+}
+
+func TestOutboxRelay_Backoff_CappedAtMax(t *testing.T) {
+	relay := NewOutboxRelay(nil, nil, WithRelayBackoff(time.Second, 10*time.Second))
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 2*time.Second, relay.backoff(2))
+	assert.Equal(t, 8*time.Second, relay.backoff(4))
+	assert.Equal(t, 10*time.Second, relay.backoff(5))
+	assert.Equal(t, 10*time.Second, relay.backoff(30))
+}
+
+// unavailableOutbox 读取失败的 outbox
+type unavailableOutbox struct {
+	*MockOrderRepository
+	err error
+}
+
+func (u *unavailableOutbox) FetchPendingOutbox(ctx context.Context, now time.Time, limit int) ([]OutboxEntry, error) {
+	if u.err != nil {
+		return nil, u.err
+	}
+	return u.MockOrderRepository.FetchPendingOutbox(ctx, now, limit)
+}
+
+func TestOutboxRelay_HealthCheck(t *testing.T) {
+	// Arrange
+	outbox := &unavailableOutbox{MockOrderRepository: NewMockOrderRepository().(*MockOrderRepository), err: fmt.Errorf("database down")}
+	relay := NewOutboxRelay(outbox, &MockEventPublisher{})
+
+	// Act - 读取失败后恢复
+	_, fetchErr := relay.RelayOnce(context.Background())
+	unhealthy := relay.HealthCheck(context.Background())
+	outbox.err = nil
+	_, _ = relay.RelayOnce(context.Background())
+	healthy := relay.HealthCheck(context.Background())
+
+	// Assert
+	assert.Error(t, fetchErr)
+	assert.ErrorContains(t, unhealthy, "database down")
+	assert.NoError(t, healthy)
+}
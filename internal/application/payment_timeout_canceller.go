@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"order-service/internal/domain"
+	"order-service/internal/logging"
+)
+
+// 支付超时取消器默认配置
+const (
+	DefaultTimeoutBatchSize = 100
+	DefaultPaymentTimeout   = 15 * time.Minute
+)
+
+// PaymentTimeoutReason 支付超时自动取消的取消原因
+const PaymentTimeoutReason = "payment timeout"
+
+// PaymentTimeoutCanceller 支付超时取消器
+// 自动取消下单后 timeout 内仍未支付的订单（记录 order.cancelled 事件），
+// 并释放订单占用的配送时段名额和餐品库存。
+// 修改的是仓储返回的订单副本，保存时版本冲突的订单跳过，不覆盖并发的支付
+type PaymentTimeoutCanceller struct {
+	repo      OrderRepository
+	slots     DeliverySlotRepository
+	stock     InventoryRepository
+	timeout   time.Duration
+	batchSize int
+	now       func() time.Time
+
+	fetchHealth // 最近一次查询支付超时订单的结果，用于就绪检查
+}
+
+// CancellerOption 支付超时取消器可选配置
+type CancellerOption func(*PaymentTimeoutCanceller)
+
+// WithPaymentTimeout 配置支付超时时间（下单后多久未支付自动取消）
+func WithPaymentTimeout(timeout time.Duration) CancellerOption {
+	return func(c *PaymentTimeoutCanceller) {
+		c.timeout = timeout
+	}
+}
+
+// WithTimeoutBatchSize 配置每批取消数量
+func WithTimeoutBatchSize(size int) CancellerOption {
+	return func(c *PaymentTimeoutCanceller) {
+		c.batchSize = size
+	}
+}
+
+// WithTimeoutReservations 配置取消后需要释放的配送时段名额和餐品库存（未配置的不释放）
+func WithTimeoutReservations(slots DeliverySlotRepository, stock InventoryRepository) CancellerOption {
+	return func(c *PaymentTimeoutCanceller) {
+		c.slots = slots
+		c.stock = stock
+	}
+}
+
+// WithTimeoutClock 配置时钟（测试使用）
+func WithTimeoutClock(now func() time.Time) CancellerOption {
+	return func(c *PaymentTimeoutCanceller) {
+		c.now = now
+	}
+}
+
+// NewPaymentTimeoutCanceller 创建支付超时取消器
+func NewPaymentTimeoutCanceller(repo OrderRepository, opts ...CancellerOption) *PaymentTimeoutCanceller {
+	c := &PaymentTimeoutCanceller{
+		repo:        repo,
+		timeout:     DefaultPaymentTimeout,
+		batchSize:   DefaultTimeoutBatchSize,
+		now:         time.Now,
+		fetchHealth: fetchHealth{failure: "failed to find unpaid orders"},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Run 按固定间隔循环取消，直到 ctx 结束
+func (c *PaymentTimeoutCanceller) Run(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "payment timeout cancel", c.CancelOnce)
+}
+
+// CancelOnce 取消一批支付超时的订单，返回取消成功的数量
+func (c *PaymentTimeoutCanceller) CancelOnce(ctx context.Context) (int, error) {
+	now := c.now()
+	orders, err := c.repo.FindUnpaidBefore(ctx, now.Add(-c.timeout), c.batchSize)
+	if err := c.observe(err); err != nil {
+		return 0, err
+	}
+
+	cancelled := 0
+	var errs []error
+	for _, order := range orders {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := order.Cancel(PaymentTimeoutReason); err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", order.OrderNumber, err))
+			continue
+		}
+		if err := c.repo.Update(ctx, order); err != nil {
+			// 查询后订单已被并发修改（如支付回调先到）：跳过且不释放名额和库存，仍未支付的订单在下一轮重新查询
+			if errors.Is(err, domain.ErrOrderConflict) {
+				logging.FromContext(ctx).Info("unpaid order modified concurrently, skipped", "order_number", order.OrderNumber)
+				continue
+			}
+			errs = append(errs, fmt.Errorf("order %s: %w", order.OrderNumber, err))
+			continue
+		}
+		releaseSlot(ctx, c.slots, order)
+		releaseStock(ctx, c.stock, order, now)
+		cancelled++
+		logging.FromContext(ctx).Info("unpaid order cancelled",
+			"order_number", order.OrderNumber,
+			"merchant_id", order.MerchantID,
+			"created_at", order.CreatedAt,
+		)
+	}
+
+	if len(errs) > 0 {
+		return cancelled, NewInternalError("failed to cancel unpaid orders", errors.Join(errs...))
+	}
+	return cancelled, nil
+}
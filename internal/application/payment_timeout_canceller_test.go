@@ -0,0 +1,132 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentTimeoutCanceller_CancelOnce(t *testing.T) {
+	// Arrange - 两个未支付订单（其中一个是预订单）和一个已支付订单
+	f := newScheduledOrderFixture(t)
+	stock := NewMockInventoryRepository()
+	orders := NewOrderService(f.repo,
+		WithMerchantProfiles(f.profiles),
+		WithDeliverySlots(f.slots, DefaultSlotPolicy()),
+		WithInventory(stock),
+		WithOrderClock(func() time.Time { return f.now }),
+	)
+	ctx := context.Background()
+	limit := 10
+	_, err := NewInventoryService(f.profiles, stock).UpdateInventory(ctx, "merchant_001", &UpdateInventoryRequest{
+		Dishes: []DishStockRequest{{DishID: "dish_001", Stock: &limit}},
+	})
+	require.NoError(t, err)
+	scheduledReq := newMerchantRuleOrderRequest(2)
+	scheduledReq.ScheduledFor = tuesdayLunch.Add(90 * time.Minute).Format(time.RFC3339)
+	scheduled, err := orders.CreateOrder(ctx, 1001, scheduledReq)
+	require.NoError(t, err)
+	unpaid, err := orders.CreateOrder(ctx, 1001, newMerchantRuleOrderRequest(3))
+	require.NoError(t, err)
+	paid, err := orders.CreateOrder(ctx, 1001, newMerchantRuleOrderRequest(1))
+	require.NoError(t, err)
+	payOrder(t, orders, 1001, paid.OrderNumber, "pay_001")
+
+	clock := &testClock{now: time.Now().Add(10 * time.Minute)}
+	canceller := NewPaymentTimeoutCanceller(f.repo,
+		WithPaymentTimeout(15*time.Minute),
+		WithTimeoutReservations(f.slots, stock),
+		WithTimeoutClock(clock.Now))
+
+	// Act - 下单 10 分钟后未超时，20 分钟后取消
+	early, err := canceller.CancelOnce(ctx)
+	require.NoError(t, err)
+	clock.Advance(10 * time.Minute)
+	cancelled, err := canceller.CancelOnce(ctx)
+	require.NoError(t, err)
+	again, err := canceller.CancelOnce(ctx)
+	require.NoError(t, err)
+
+	// Assert - 只取消未支付的订单，并释放其时段名额和餐品库存
+	assert.Equal(t, 0, early)
+	assert.Equal(t, 2, cancelled)
+	assert.Equal(t, 0, again)
+	assert.Equal(t, domain.OrderStatusCancelled, f.repo.orders[scheduled.OrderNumber].Status)
+	assert.Equal(t, domain.OrderStatusCancelled, f.repo.orders[unpaid.OrderNumber].Status)
+	assert.Equal(t, domain.OrderStatusPaid, f.repo.orders[paid.OrderNumber].Status)
+	assert.Empty(t, f.slots.reservations[tuesdayLunch.Add(90*time.Minute).Unix()])
+	inv, err := stock.Find(ctx, "merchant_001")
+	require.NoError(t, err)
+	assert.Equal(t, 9, *inv.Dishes[0].Stock)
+	assert.Len(t, inv.Reservations, 1)
+	assert.Contains(t, f.repo.outboxEventTypes(), domain.EventTypeOrderCancelled)
+	assert.NoError(t, canceller.HealthCheck(ctx))
+}
+
+func TestPaymentTimeoutCanceller_SkipsConcurrentlyModifiedOrders(t *testing.T) {
+	// Arrange - 未支付的预订单在取消器查询后被并发修改（如支付回调先到）
+	f := newScheduledOrderFixture(t)
+	stock := NewMockInventoryRepository()
+	orders := NewOrderService(f.repo,
+		WithMerchantProfiles(f.profiles),
+		WithDeliverySlots(f.slots, DefaultSlotPolicy()),
+		WithInventory(stock),
+		WithOrderClock(func() time.Time { return f.now }),
+	)
+	ctx := context.Background()
+	limit := 10
+	_, err := NewInventoryService(f.profiles, stock).UpdateInventory(ctx, "merchant_001", &UpdateInventoryRequest{
+		Dishes: []DishStockRequest{{DishID: "dish_001", Stock: &limit}},
+	})
+	require.NoError(t, err)
+	req := newMerchantRuleOrderRequest(2)
+	req.ScheduledFor = tuesdayLunch.Add(90 * time.Minute).Format(time.RFC3339)
+	orderData, err := orders.CreateOrder(ctx, 1001, req)
+	require.NoError(t, err)
+	canceller := NewPaymentTimeoutCanceller(&conflictingOrderRepository{f.repo},
+		WithPaymentTimeout(15*time.Minute),
+		WithTimeoutReservations(f.slots, stock),
+		WithTimeoutClock(func() time.Time { return time.Now().Add(time.Hour) }))
+
+	// Act
+	cancelled, err := canceller.CancelOnce(ctx)
+
+	// Assert - 冲突的订单跳过，不视为失败，也不释放其时段名额和餐品库存
+	require.NoError(t, err)
+	assert.Equal(t, 0, cancelled)
+	assert.Equal(t, domain.OrderStatusPendingPayment, f.repo.orders[orderData.OrderNumber].Status)
+	assert.Len(t, f.slots.reservations[tuesdayLunch.Add(90*time.Minute).Unix()], 1)
+	inv, err := stock.Find(ctx, "merchant_001")
+	require.NoError(t, err)
+	assert.Contains(t, inv.Reservations, orderData.OrderNumber)
+	assert.NoError(t, canceller.HealthCheck(ctx))
+}
+
+// failingUnpaidRepository 查询支付超时订单失败的仓储
+type failingUnpaidRepository struct {
+	*MockOrderRepository
+}
+
+func (r *failingUnpaidRepository) FindUnpaidBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Order, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestPaymentTimeoutCanceller_HealthCheck(t *testing.T) {
+	// Arrange
+	canceller := NewPaymentTimeoutCanceller(&failingUnpaidRepository{NewMockOrderRepository().(*MockOrderRepository)})
+	ctx := context.Background()
+
+	// Act
+	_, err := canceller.CancelOnce(ctx)
+
+	// Assert - 查询失败时就绪检查报告错误
+	var internalErr *InternalError
+	assert.ErrorAs(t, err, &internalErr)
+	assert.ErrorContains(t, canceller.HealthCheck(ctx), "connection refused")
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"order-service/internal/logging"
+)
+
+// runPeriodically 立即执行一次 once，之后按固定间隔循环执行，直到 ctx 结束
+// once 返回错误时记录 "{task} failed" 日志，下一轮照常执行
+func runPeriodically(ctx context.Context, interval time.Duration, task string, once func(ctx context.Context) (int, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := once(ctx); err != nil {
+			logging.FromContext(ctx).Error(task+" failed", logging.KeyError, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fetchHealth 后台任务最近一次读取待处理数据的结果，嵌入任务后提供就绪检查
+type fetchHealth struct {
+	failure string // 读取失败时的错误描述，如 "failed to fetch outbox entries"
+
+	mu  sync.Mutex
+	err error
+}
+
+// observe 记录本次读取的结果，失败时返回内部错误
+func (h *fetchHealth) observe(err error) error {
+	h.mu.Lock()
+	h.err = err
+	h.mu.Unlock()
+
+	if err != nil {
+		return NewInternalError(h.failure, err)
+	}
+	return nil
+}
+
+// HealthCheck 就绪检查：最近一次读取失败时报告错误
+func (h *fetchHealth) HealthCheck(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.err != nil {
+		return fmt.Errorf("%s: %w", h.failure, h.err)
+	}
+	return nil
+}
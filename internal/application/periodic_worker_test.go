@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunPeriodically(t *testing.T) {
+	// Arrange - 第一次执行失败，第三次执行时结束
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	once := func(ctx context.Context) (int, error) {
+		calls++
+		if calls == 3 {
+			cancel()
+		}
+		if calls == 1 {
+			return 0, errors.New("connection refused")
+		}
+		return 1, nil
+	}
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		runPeriodically(ctx, time.Millisecond, "test task", once)
+		close(done)
+	}()
+
+	// Assert - 失败后继续执行，ctx 结束后返回
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("runPeriodically did not return after ctx was cancelled")
+	}
+	assert.Equal(t, 3, calls)
+}
+
+func TestFetchHealth(t *testing.T) {
+	// Arrange
+	h := &fetchHealth{failure: "failed to fetch entries"}
+	ctx := context.Background()
+
+	// Act
+	failed := h.observe(errors.New("connection refused"))
+	unhealthy := h.HealthCheck(ctx)
+	recovered := h.observe(nil)
+
+	// Assert - 读取失败时返回内部错误并报告未就绪，恢复后就绪
+	var internalErr *InternalError
+	require.ErrorAs(t, failed, &internalErr)
+	assert.EqualError(t, unhealthy, "failed to fetch entries: connection refused")
+	assert.NoError(t, recovered)
+	assert.NoError(t, h.HealthCheck(ctx))
+}
@@ -3,16 +3,20 @@ package application
 import (
 	"context"
 	"regexp"
+	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
 	"order-service/internal/domain"
 )
 
 // Validator 全局验证器实例
 var Validator = validator.New()
 
-// 手机号正则表达式：1开头，第二位是3-9，后面9位数字
-var phoneRegex = regexp.MustCompile(`^1[3-9]\d{9}$`)
+// PhonePattern 手机号正则表达式：1开头，第二位是3-9，后面9位数字
+const PhonePattern = `^1[3-9]\d{9}$`
+
+var phoneRegex = regexp.MustCompile(PhonePattern)
 
 func init() {
 	// 注册自定义手机号验证函数
@@ -29,44 +33,233 @@ func validatePhone(fl validator.FieldLevel) bool {
 // Web 适配器通过此接口调用核心业务逻辑
 type OrderService interface {
 	CreateOrder(ctx context.Context, userID uint64, req *CreateOrderRequest) (*OrderData, error)
+	QuoteOrder(ctx context.Context, userID uint64, req *CreateOrderRequest) (*QuoteData, error)
+	GetOrder(ctx context.Context, userID uint64, orderNumber string) (*OrderData, error)
+	ListOrders(ctx context.Context, userID uint64, req *ListOrdersRequest) (*OrderListData, error)
+	// ConfirmPayment 确认支付结果（仅供已验证来源的支付回调调用，不对用户开放）
+	ConfirmPayment(ctx context.Context, req *ConfirmPaymentRequest) (*OrderData, error)
+	CancelOrder(ctx context.Context, userID uint64, orderNumber string, req *CancelOrderRequest) (*OrderData, error)
+	RefundOrder(ctx context.Context, userID uint64, orderNumber string, req *RefundOrderRequest) (*RefundData, error)
+	AcceptOrder(ctx context.Context, merchantID, orderNumber string) (*OrderData, error)
+	RejectOrder(ctx context.Context, merchantID, orderNumber string, req *RejectOrderRequest) (*OrderData, error)
 }
 
 // OrderRepository 定义数据持久化接口（输出端口）
 // 应用服务通过此接口访问数据存储
+// Create 和 Update 必须在同一事务中保存订单及其待发布的领域事件（写入 outbox），
+// 保存成功后清空聚合中的待发布事件并递增 order.Version；
+// 查询返回订单副本，Update 时仓储中的版本号与 order.Version 不一致返回 domain.ErrOrderConflict（乐观锁）
 type OrderRepository interface {
 	Create(ctx context.Context, order *domain.Order) error
 	FindByOrderNumber(ctx context.Context, orderNumber string) (*domain.Order, error)
+	Update(ctx context.Context, order *domain.Order) error
+	FindByUser(ctx context.Context, query OrderListQuery) ([]*domain.Order, error)
+	// CountActiveByMerchant 统计商家同时处理中的订单数（见 domain.Order.Active）
+	CountActiveByMerchant(ctx context.Context, merchantID string) (int, error)
+	// FindDueScheduled 按配送时段升序查询 ScheduledFor 不晚于 before、已支付且尚未推送给商家的预订单
+	FindDueScheduled(ctx context.Context, before time.Time, limit int) ([]*domain.Order, error)
+	// FindUnpaidBefore 按创建时间升序查询 CreatedAt 早于 before 的待支付订单
+	FindUnpaidBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Order, error)
+}
+
+// OrderListQuery 用户订单列表查询（按创建时间倒序，订单号倒序）
+type OrderListQuery struct {
+	UserID uint64
+	Status domain.OrderStatus // 为空表示全部状态
+	After  *OrderCursor       // 为空表示从第一条开始
+	Limit  int
+}
+
+// OrderCursor 订单列表游标（上一页最后一条订单的排序键）
+type OrderCursor struct {
+	CreatedAt   time.Time
+	OrderNumber string
+}
+
+// Precedes 订单在列表中是否排在游标之后（即属于下一页）
+func (c *OrderCursor) Precedes(order *domain.Order) bool {
+	if !order.CreatedAt.Equal(c.CreatedAt) {
+		return order.CreatedAt.Before(c.CreatedAt)
+	}
+	return order.OrderNumber < c.OrderNumber
+}
+
+// PaymentGateway 定义支付网关接口（输出端口）
+// 应用服务通过此接口将退款提交到支付渠道
+type PaymentGateway interface {
+	Refund(ctx context.Context, req *PaymentRefundRequest) error
+}
+
+// MenuCatalog 定义商家菜单查询接口（输出端口）
+// 下单时据此校验规格选项和套餐组件，并取得名称、加价和套餐价；餐品或套餐不在菜单中时返回 NotFoundError
+type MenuCatalog interface {
+	FindDish(ctx context.Context, merchantID, dishID string) (*domain.Dish, error)
+	FindCombo(ctx context.Context, merchantID, comboID string) (*domain.Combo, error)
+}
+
+// OutboxRepository 定义事件 outbox 访问接口（输出端口）
+// outbox 投递器通过此接口读取待投递事件并更新投递状态
+type OutboxRepository interface {
+	FetchPendingOutbox(ctx context.Context, now time.Time, limit int) ([]OutboxEntry, error)
+	UpdateOutboxEntry(ctx context.Context, entry OutboxEntry) error
+	FindOutboxEntries(ctx context.Context, status OutboxStatus) ([]OutboxEntry, error)
+}
+
+// EventPublisher 定义领域事件发布接口（输出端口）
+// outbox 投递器通过此接口将事件投递给订阅者
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...domain.Event) error
+}
+
+// OutboxStatus outbox 记录投递状态
+type OutboxStatus string
+
+const (
+	OutboxStatusPending    OutboxStatus = "PENDING"
+	OutboxStatusDelivered  OutboxStatus = "DELIVERED"
+	OutboxStatusDeadLetter OutboxStatus = "DEAD_LETTER"
+)
+
+// OutboxEntry outbox 记录（与订单在同一事务中写入）
+type OutboxEntry struct {
+	ID            string
+	Event         domain.Event
+	Status        OutboxStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	DeliveredAt   time.Time
+}
+
+// NewOutboxEntry 根据领域事件创建待投递的 outbox 记录
+func NewOutboxEntry(event domain.Event) OutboxEntry {
+	return OutboxEntry{
+		ID:            event.EventID(),
+		Event:         event,
+		Status:        OutboxStatusPending,
+		NextAttemptAt: event.OccurredAt(),
+		CreatedAt:     event.OccurredAt(),
+	}
+}
+
+// PaymentRefundRequest 支付渠道退款请求
+type PaymentRefundRequest struct {
+	OrderNumber string
+	PaymentID   string
+	RefundID    string
+	Amount      decimal.Decimal
 }
 
 // CreateOrderRequest 创建订单请求（应用层 DTO）
 type CreateOrderRequest struct {
 	MerchantID   string              `validate:"required"`
 	Items        []OrderItemRequest  `validate:"required,min=1,dive"`
-	DeliveryInfo DeliveryInfoRequest `validate:"required"`
+	DeliveryInfo DeliveryInfoRequest `validate:"required_without=AddressID"` // 与 AddressID 二选一
+	AddressID    string              `validate:"omitempty,max=32"`           // 地址簿中的地址ID，下单时复制为配送信息
 	Remark       string              `validate:"omitempty,max=200"`
+	QuoteToken   string              `validate:"omitempty,max=2048"` // 报价 token，提供时按报价费用下单，实付金额须与报价一致
+	ScheduledFor string              `validate:"omitempty,rfc3339"`  // 预订配送时段的开始时间，为空表示立即配送
 }
 
-// OrderItemRequest 订单项请求
+// OrderItemRequest 订单项请求（Price 为餐品基础单价；菜单中的餐品名称、单价和规格加价均以菜单为准，忽略请求中的 DishName 和 Price）
+// 套餐的 DishID 为套餐ID，名称和套餐价以菜单为准，Components 为组件位的替换选择
 type OrderItemRequest struct {
-	DishID   string  `validate:"required"`
-	DishName string  `validate:"required"`
-	Quantity int     `validate:"required,gt=0"`
-	Price    float64 `validate:"required,gt=0"`
+	DishID     string                   `validate:"required"`
+	DishName   string                   `validate:"required"`
+	Quantity   int                      `validate:"required,gt=0"`
+	Price      float64                  `validate:"required,gt=0"`
+	Options    []OrderItemOptionRequest `validate:"omitempty,max=20,dive"`
+	Components []ComboComponentRequest  `validate:"omitempty,max=20,dive"`
+}
+
+// ComboComponentRequest 套餐组件位的替换选择
+type ComboComponentRequest struct {
+	SlotID string `validate:"required"`
+	DishID string `validate:"required"`
 }
 
-// DeliveryInfoRequest 配送信息请求
+// OrderItemOptionRequest 订单项规格选择
+type OrderItemOptionRequest struct {
+	GroupID  string `validate:"required"`
+	OptionID string `validate:"required"`
+}
+
+// DeliveryInfoRequest 配送信息请求（商家配置了配送范围时 Location 必填）
 type DeliveryInfoRequest struct {
-	RecipientName  string `validate:"required"`
-	RecipientPhone string `validate:"required,phone"`
-	Address        string `validate:"required"`
+	RecipientName  string           `validate:"required"`
+	RecipientPhone string           `validate:"required,phone"`
+	Address        string           `validate:"required"`
+	Location       *GeoPointRequest `validate:"omitempty"`
+}
+
+// ListOrdersRequest 订单列表请求（Cursor 为上一页返回的 NextCursor）
+type ListOrdersRequest struct {
+	Limit  int    `validate:"omitempty,min=1,max=100"`
+	Cursor string `validate:"omitempty"`
+	Status string `validate:"omitempty,oneof=PENDING_PAYMENT PAID ACCEPTED REJECTED CANCELLED REFUNDED"`
 }
 
 // OrderData 订单数据（应用层 DTO）
 type OrderData struct {
-	OrderNumber string
-	Status      string
-	Pricing     PricingInfo
-	CreatedAt   string
+	OrderNumber  string
+	MerchantID   string
+	Status       string
+	Items        []OrderItemData
+	Pricing      PricingInfo
+	DeliveryInfo DeliveryInfoData
+	Remark       string
+	ScheduledFor string // 预订配送时段的开始时间（立即配送的订单为空）
+	CreatedAt    string
+	UpdatedAt    string
+	Cursor       string // 订单在列表中的游标（仅列表查询返回）
+}
+
+// DeliveryInfoData 配送信息数据（未提供收货坐标时 Location 为 nil）
+type DeliveryInfoData struct {
+	RecipientName  string
+	RecipientPhone string
+	Address        string
+	Location       *GeoPointData
+}
+
+// OrderItemData 订单项数据（Price 为基础单价，UnitPrice 含规格加价）
+type OrderItemData struct {
+	DishID     string
+	DishName   string
+	Quantity   int
+	Price      string
+	UnitPrice  string
+	Subtotal   string
+	Options    []OrderItemOptionData
+	Components []OrderItemComponentData
+}
+
+// OrderItemComponentData 套餐组件数据（AllocatedPrice 为每份套餐分摊到该组件的金额）
+type OrderItemComponentData struct {
+	SlotID         string
+	SlotName       string
+	DishID         string
+	DishName       string
+	Quantity       int
+	Surcharge      string
+	AllocatedPrice string
+}
+
+// OrderItemOptionData 订单项已选规格数据
+type OrderItemOptionData struct {
+	GroupID    string
+	GroupName  string
+	OptionID   string
+	OptionName string
+	PriceDelta string
+}
+
+// OrderListData 订单列表数据（HasMore 为 true 时使用 NextCursor 查询下一页）
+type OrderListData struct {
+	Orders     []OrderData
+	NextCursor string
+	HasMore    bool
 }
 
 // PricingInfo 价格信息
@@ -76,3 +269,63 @@ type PricingInfo struct {
 	DeliveryFee  string
 	FinalAmount  string
 }
+
+// QuoteData 报价数据（QuoteToken 在 ExpiresAt 前随相同的商家和订单项下单时生效，未配置签名时为空）
+type QuoteData struct {
+	MerchantID string
+	Items      []OrderItemData
+	Pricing    PricingInfo
+	QuoteToken string
+	ExpiresAt  string
+}
+
+// ConfirmPaymentRequest 支付结果确认请求（Amount 为实付金额，两位小数的字符串）
+type ConfirmPaymentRequest struct {
+	OrderNumber string `validate:"required,max=64"`
+	PaymentID   string `validate:"required,max=64"`
+	Amount      string `validate:"required"`
+}
+
+// CancelOrderRequest 取消订单请求
+type CancelOrderRequest struct {
+	Reason string `validate:"omitempty,max=200"`
+}
+
+// RejectOrderRequest 商家拒单请求
+type RejectOrderRequest struct {
+	Reason string `validate:"required,max=200"`
+}
+
+// RefundOrderRequest 退款请求（Items 为空表示全额退款）
+type RefundOrderRequest struct {
+	Items  []RefundItemRequest `validate:"omitempty,dive"`
+	Reason string              `validate:"omitempty,max=200"`
+}
+
+// RefundItemRequest 退款项请求（退套餐中的单个组件时 DishID 为套餐ID、SlotID 为组件位ID）
+type RefundItemRequest struct {
+	DishID   string `validate:"required"`
+	SlotID   string `validate:"omitempty,max=64"`
+	Quantity int    `validate:"required,gt=0"`
+}
+
+// RefundData 退款数据（应用层 DTO）
+type RefundData struct {
+	RefundID     string
+	OrderNumber  string
+	OrderStatus  string
+	Status       string
+	Items        []RefundItemData
+	PackagingFee string
+	DeliveryFee  string
+	Amount       string
+	CreatedAt    string
+}
+
+// RefundItemData 退款项数据
+type RefundItemData struct {
+	DishID   string
+	SlotID   string
+	Quantity int
+	Amount   string
+}
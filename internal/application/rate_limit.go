@@ -0,0 +1,33 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimit 令牌桶限额：桶容量为 Burst，每 Period 匀速补充 Rate 个令牌，每个请求消耗一个令牌
+type RateLimit struct {
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+// Enabled 限额是否生效（Rate、Period、Burst 任一不为正时视为不限流）
+func (l RateLimit) Enabled() bool {
+	return l.Rate > 0 && l.Period > 0 && l.Burst > 0
+}
+
+// RateLimitDecision 一次限流判定结果
+type RateLimitDecision struct {
+	Allowed    bool
+	Limit      int           // 桶容量
+	Remaining  int           // 本次判定后剩余的令牌数
+	ResetAfter time.Duration // 令牌桶补满所需时间
+	RetryAfter time.Duration // 被拒绝时距离下一个令牌可用的时间（允许时为 0）
+}
+
+// RateLimiter 定义限流存储接口（输出端口）
+// 同一 key 的令牌桶在所有实例间共享时需由共享存储实现；判定和扣减令牌必须是原子操作
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit RateLimit) (RateLimitDecision, error)
+}
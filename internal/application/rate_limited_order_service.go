@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"strconv"
+
+	"order-service/internal/logging"
+)
+
+// 下单限流规则名（同时作为令牌桶 key 的前缀）
+const (
+	RuleCreateOrderUser     = "create_order_user"
+	RuleCreateOrderMerchant = "create_order_merchant"
+)
+
+// rateLimitedOrderService 按用户和商家限制下单频率的 OrderService 装饰器
+// 在应用层限流，HTTP 下单、购物车结算、GraphQL 和 gRPC 共用同一组令牌桶
+type rateLimitedOrderService struct {
+	OrderService
+	limiter     RateLimiter
+	perUser     RateLimit
+	perMerchant RateLimit
+}
+
+// NewRateLimitedOrderService 为 CreateOrder 增加按用户和按商家的限流，限额未生效的维度不限流
+func NewRateLimitedOrderService(inner OrderService, limiter RateLimiter, perUser, perMerchant RateLimit) OrderService {
+	return &rateLimitedOrderService{OrderService: inner, limiter: limiter, perUser: perUser, perMerchant: perMerchant}
+}
+
+// CreateOrder 依次检查用户和商家的下单限额，任一超限即返回 RateLimitError（不再消耗其后规则的令牌）
+func (s *rateLimitedOrderService) CreateOrder(ctx context.Context, userID uint64, req *CreateOrderRequest) (*OrderData, error) {
+	if err := s.allow(ctx, RuleCreateOrderUser, strconv.FormatUint(userID, 10), s.perUser); err != nil {
+		return nil, err
+	}
+	if req != nil && req.MerchantID != "" {
+		if err := s.allow(ctx, RuleCreateOrderMerchant, req.MerchantID, s.perMerchant); err != nil {
+			return nil, err
+		}
+	}
+	return s.OrderService.CreateOrder(ctx, userID, req)
+}
+
+// allow 消耗规则下 key 的一个令牌；限流存储出错时放行请求
+func (s *rateLimitedOrderService) allow(ctx context.Context, rule, key string, limit RateLimit) error {
+	if !limit.Enabled() {
+		return nil
+	}
+	decision, err := s.limiter.Allow(ctx, rule+":"+key, limit)
+	if err != nil {
+		logging.FromContext(ctx).Warn("rate limiter unavailable, allowing request", "rule", rule, logging.KeyError, err)
+		return nil
+	}
+	if !decision.Allowed {
+		logging.FromContext(ctx).Info("order creation rate limited", "rule", rule)
+		return NewRateLimitErrorFromDecision("too many orders, retry later", decision)
+	}
+	return nil
+}
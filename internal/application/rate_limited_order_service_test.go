@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingRateLimiter 按 key 计数的模拟限流存储：每个 key 最多放行 Burst 次
+type countingRateLimiter struct {
+	used map[string]int
+	err  error
+}
+
+func newCountingRateLimiter() *countingRateLimiter {
+	return &countingRateLimiter{used: make(map[string]int)}
+}
+
+func (l *countingRateLimiter) Allow(ctx context.Context, key string, limit RateLimit) (RateLimitDecision, error) {
+	if l.err != nil {
+		return RateLimitDecision{}, l.err
+	}
+	if l.used[key] >= limit.Burst {
+		return RateLimitDecision{Limit: limit.Burst, RetryAfter: 1500 * time.Millisecond}, nil
+	}
+	l.used[key]++
+	return RateLimitDecision{Allowed: true, Limit: limit.Burst, Remaining: limit.Burst - l.used[key]}, nil
+}
+
+// newDishOrderRequest 创建向指定商家下单 1 份宫保鸡丁的请求
+func newDishOrderRequest(merchantID string) *CreateOrderRequest {
+	req := newOptionsOrderRequest(OrderItemRequest{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: 28.00})
+	req.MerchantID = merchantID
+	return req
+}
+
+func TestRateLimitedOrderService_CreateOrder(t *testing.T) {
+	// Arrange - 每个用户 2 单，每个商家 3 单
+	repo := NewMockOrderRepository().(*MockOrderRepository)
+	limiter := newCountingRateLimiter()
+	orders := NewRateLimitedOrderService(NewOrderService(repo), limiter,
+		RateLimit{Rate: 2, Period: time.Minute, Burst: 2},
+		RateLimit{Rate: 3, Period: time.Minute, Burst: 3})
+	ctx := context.Background()
+
+	// Act
+	_, err := orders.CreateOrder(ctx, 1001, newDishOrderRequest("merchant_001"))
+	require.NoError(t, err)
+	_, err = orders.CreateOrder(ctx, 1001, newDishOrderRequest("merchant_001"))
+	require.NoError(t, err)
+	_, userErr := orders.CreateOrder(ctx, 1001, newDishOrderRequest("merchant_001"))
+	_, err = orders.CreateOrder(ctx, 2002, newDishOrderRequest("merchant_001"))
+	require.NoError(t, err)
+	_, merchantErr := orders.CreateOrder(ctx, 3003, newDishOrderRequest("merchant_001"))
+	_, otherMerchantErr := orders.CreateOrder(ctx, 3003, newDishOrderRequest("merchant_002"))
+
+	// Assert - 用户超限时不消耗商家的令牌，被拒绝的请求不创建订单
+	var rateLimitErr *RateLimitError
+	require.ErrorAs(t, userErr, &rateLimitErr)
+	assert.Equal(t, 2, rateLimitErr.RetryAfterSeconds())
+	assert.Equal(t, 2, rateLimitErr.Limit)
+	assert.Equal(t, 0, rateLimitErr.Remaining)
+	assert.ErrorAs(t, merchantErr, &rateLimitErr)
+	assert.NoError(t, otherMerchantErr)
+	assert.Equal(t, 3, limiter.used[RuleCreateOrderMerchant+":merchant_001"])
+	assert.Len(t, repo.orders, 4)
+}
+
+func TestRateLimitedOrderService_AllowsWhenLimiterUnavailable(t *testing.T) {
+	// Arrange
+	limiter := newCountingRateLimiter()
+	limiter.err = errors.New("store unavailable")
+	orders := NewRateLimitedOrderService(NewOrderService(NewMockOrderRepository()), limiter,
+		RateLimit{Rate: 1, Period: time.Minute, Burst: 1}, RateLimit{})
+
+	// Act
+	_, first := orders.CreateOrder(context.Background(), 1001, newDishOrderRequest("merchant_001"))
+	_, second := orders.CreateOrder(context.Background(), 1001, newDishOrderRequest("merchant_001"))
+
+	// Assert - 限流存储不可用时放行请求
+	assert.NoError(t, first)
+	assert.NoError(t, second)
+}
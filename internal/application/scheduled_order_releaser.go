@@ -0,0 +1,115 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"order-service/internal/domain"
+	"order-service/internal/logging"
+)
+
+// 预订单推送器默认配置
+const (
+	DefaultReleaseBatchSize = 100
+	DefaultReleaseLeadTime  = 30 * time.Minute
+)
+
+// ScheduledOrderReleaser 预订单推送器
+// 在配送时段开始前 leadTime 将已支付的预订单推送给商家备餐（记录 order.released 事件，
+// 商家终端和 webhook 通过 outbox 收到）；未支付的预订单不推送。
+// 修改的是仓储返回的订单副本，保存时版本冲突的订单跳过，不覆盖并发的修改
+type ScheduledOrderReleaser struct {
+	repo      OrderRepository
+	leadTime  time.Duration
+	batchSize int
+	now       func() time.Time
+
+	fetchHealth // 最近一次查询到期预订单的结果，用于就绪检查
+}
+
+// ReleaserOption 预订单推送器可选配置
+type ReleaserOption func(*ScheduledOrderReleaser)
+
+// WithReleaseLeadTime 配置推送提前量（配送时段开始前多久推送给商家）
+func WithReleaseLeadTime(leadTime time.Duration) ReleaserOption {
+	return func(r *ScheduledOrderReleaser) {
+		r.leadTime = leadTime
+	}
+}
+
+// WithReleaseBatchSize 配置每批推送数量
+func WithReleaseBatchSize(size int) ReleaserOption {
+	return func(r *ScheduledOrderReleaser) {
+		r.batchSize = size
+	}
+}
+
+// WithReleaseClock 配置时钟（测试使用）
+func WithReleaseClock(now func() time.Time) ReleaserOption {
+	return func(r *ScheduledOrderReleaser) {
+		r.now = now
+	}
+}
+
+// NewScheduledOrderReleaser 创建预订单推送器
+func NewScheduledOrderReleaser(repo OrderRepository, opts ...ReleaserOption) *ScheduledOrderReleaser {
+	r := &ScheduledOrderReleaser{
+		repo:        repo,
+		leadTime:    DefaultReleaseLeadTime,
+		batchSize:   DefaultReleaseBatchSize,
+		now:         time.Now,
+		fetchHealth: fetchHealth{failure: "failed to find due scheduled orders"},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Run 按固定间隔循环推送，直到 ctx 结束
+func (r *ScheduledOrderReleaser) Run(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "scheduled order release", r.ReleaseOnce)
+}
+
+// ReleaseOnce 推送一批到期的预订单，返回推送成功的数量
+func (r *ScheduledOrderReleaser) ReleaseOnce(ctx context.Context) (int, error) {
+	now := r.now()
+	orders, err := r.repo.FindDueScheduled(ctx, now.Add(r.leadTime), r.batchSize)
+	if err := r.observe(err); err != nil {
+		return 0, err
+	}
+
+	released := 0
+	var errs []error
+	for _, order := range orders {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := order.Release(now); err != nil {
+			errs = append(errs, fmt.Errorf("order %s: %w", order.OrderNumber, err))
+			continue
+		}
+		if err := r.repo.Update(ctx, order); err != nil {
+			// 查询后订单已被并发修改（如用户退款）：跳过，仍需推送的订单在下一轮重新查询
+			if errors.Is(err, domain.ErrOrderConflict) {
+				logging.FromContext(ctx).Info("scheduled order modified concurrently, skipped", "order_number", order.OrderNumber)
+				continue
+			}
+			errs = append(errs, fmt.Errorf("order %s: %w", order.OrderNumber, err))
+			continue
+		}
+		released++
+		logging.FromContext(ctx).Info("scheduled order released",
+			"order_number", order.OrderNumber,
+			"merchant_id", order.MerchantID,
+			"scheduled_for", order.ScheduledFor,
+		)
+	}
+
+	if len(errs) > 0 {
+		return released, NewInternalError("failed to release scheduled orders", errors.Join(errs...))
+	}
+	return released, nil
+}
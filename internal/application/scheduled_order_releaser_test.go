@@ -0,0 +1,123 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"order-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledOrderReleaser_ReleaseOnce(t *testing.T) {
+	// Arrange - 12:30 和 13:00 两个时段的已支付预订单，以及一个未支付的 12:30 预订单
+	f := newScheduledOrderFixture(t)
+	ctx := context.Background()
+	var paid []string
+	for _, slot := range []time.Time{tuesdayLunch.Add(90 * time.Minute), tuesdayLunch.Add(2 * time.Hour)} {
+		orderData, err := f.create(slot)
+		require.NoError(t, err)
+		payOrder(t, f.orders, 1001, orderData.OrderNumber, "pay_"+orderData.OrderNumber)
+		paid = append(paid, orderData.OrderNumber)
+	}
+	unpaid := domain.NewScheduledOrder(1001, "merchant_001", nil, domain.DeliveryInfo{}, "", domain.DefaultFees(), tuesdayLunch.Add(90*time.Minute))
+	require.NoError(t, f.repo.Create(ctx, unpaid))
+
+	clock := &testClock{now: tuesdayLunch.Add(55 * time.Minute)}
+	releaser := NewScheduledOrderReleaser(f.repo, WithReleaseLeadTime(30*time.Minute), WithReleaseClock(clock.Now))
+
+	// Act - 11:55 还没到 12:30 时段的推送时间，12:00 推送 12:30 时段
+	early, err := releaser.ReleaseOnce(ctx)
+	require.NoError(t, err)
+	clock.Advance(5 * time.Minute)
+	released, err := releaser.ReleaseOnce(ctx)
+	require.NoError(t, err)
+	again, err := releaser.ReleaseOnce(ctx)
+	require.NoError(t, err)
+
+	// Assert - 每个预订单只推送一次，未支付的预订单不推送
+	assert.Equal(t, 0, early)
+	assert.Equal(t, 1, released)
+	assert.Equal(t, 0, again)
+	assert.Equal(t, clock.Now(), f.repo.orders[paid[0]].ReleasedAt)
+	assert.True(t, f.repo.orders[paid[1]].ReleasedAt.IsZero())
+	assert.True(t, unpaid.ReleasedAt.IsZero())
+	assert.Contains(t, f.repo.outboxEventTypes(), domain.EventTypeOrderReleased)
+	assert.NoError(t, releaser.HealthCheck(ctx))
+}
+
+func TestScheduledOrderReleaser_ReleasedOrderCountsTowardCapacity(t *testing.T) {
+	// Arrange - 订单上限为 2，已有 2 个已支付的预订单
+	f := newScheduledOrderFixture(t)
+	ctx := context.Background()
+	for _, slot := range []time.Time{tuesdayLunch.Add(90 * time.Minute), tuesdayLunch.Add(2 * time.Hour)} {
+		orderData, err := f.create(slot)
+		require.NoError(t, err)
+		payOrder(t, f.orders, 1001, orderData.OrderNumber, "pay_001")
+	}
+	_, beforeErr := f.orders.QuoteOrder(ctx, 1001, newMerchantRuleOrderRequest(1))
+	releaser := NewScheduledOrderReleaser(f.repo, WithReleaseLeadTime(3*time.Hour),
+		WithReleaseClock(func() time.Time { return f.now }))
+
+	// Act
+	released, err := releaser.ReleaseOnce(ctx)
+	require.NoError(t, err)
+	_, afterErr := f.orders.QuoteOrder(ctx, 1001, newMerchantRuleOrderRequest(1))
+
+	// Assert - 预订单推送给商家后才计入处理中的订单
+	assert.NoError(t, beforeErr)
+	assert.Equal(t, 2, released)
+	assertBusinessCode(t, afterErr, domain.ErrMerchantAtCapacity)
+}
+
+// conflictingOrderRepository 查询返回订单副本、保存时总是版本冲突的仓储（模拟订单在查询后被并发修改）
+type conflictingOrderRepository struct {
+	*MockOrderRepository
+}
+
+func (r *conflictingOrderRepository) FindDueScheduled(ctx context.Context, before time.Time, limit int) ([]*domain.Order, error) {
+	orders, err := r.MockOrderRepository.FindDueScheduled(ctx, before, limit)
+	return copyOrders(orders), err
+}
+
+func (r *conflictingOrderRepository) FindUnpaidBefore(ctx context.Context, before time.Time, limit int) ([]*domain.Order, error) {
+	orders, err := r.MockOrderRepository.FindUnpaidBefore(ctx, before, limit)
+	return copyOrders(orders), err
+}
+
+func (r *conflictingOrderRepository) Update(ctx context.Context, order *domain.Order) error {
+	return fmt.Errorf("order %s is stale: %w", order.OrderNumber, domain.ErrOrderConflict)
+}
+
+// copyOrders 浅拷贝订单（推送和取消只修改订单的状态字段）
+func copyOrders(orders []*domain.Order) []*domain.Order {
+	result := make([]*domain.Order, len(orders))
+	for i, order := range orders {
+		copied := *order
+		result[i] = &copied
+	}
+	return result
+}
+
+func TestScheduledOrderReleaser_SkipsConcurrentlyModifiedOrders(t *testing.T) {
+	// Arrange - 已支付的预订单在推送器查询后被并发修改
+	f := newScheduledOrderFixture(t)
+	ctx := context.Background()
+	orderData, err := f.create(tuesdayLunch.Add(90 * time.Minute))
+	require.NoError(t, err)
+	payOrder(t, f.orders, 1001, orderData.OrderNumber, "pay_001")
+	releaser := NewScheduledOrderReleaser(&conflictingOrderRepository{f.repo},
+		WithReleaseClock(func() time.Time { return tuesdayLunch.Add(time.Hour) }))
+
+	// Act
+	released, err := releaser.ReleaseOnce(ctx)
+
+	// Assert - 冲突的订单跳过，不视为失败，也不覆盖仓储中的订单
+	require.NoError(t, err)
+	assert.Equal(t, 0, released)
+	assert.True(t, f.repo.orders[orderData.OrderNumber].ReleasedAt.IsZero())
+	assert.NotContains(t, f.repo.outboxEventTypes(), domain.EventTypeOrderReleased)
+}
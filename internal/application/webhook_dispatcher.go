@@ -0,0 +1,174 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"order-service/internal/domain"
+	"order-service/internal/logging"
+)
+
+// Webhook 投递器默认配置
+const (
+	DefaultWebhookBatchSize        = 50
+	DefaultWebhookMaxAttempts      = 8
+	DefaultWebhookDisableThreshold = 20
+	DefaultWebhookBaseBackoff      = 5 * time.Second
+	DefaultWebhookMaxBackoff       = time.Hour
+)
+
+// WebhookDispatcher Webhook 异步投递器
+// 失败按指数退避重试，超过最大次数标记为 FAILED；
+// 订阅连续失败达到阈值时自动停用，停用订阅的待投递记录标记为 SKIPPED
+type WebhookDispatcher struct {
+	repo             WebhookRepository
+	sender           WebhookSender
+	batchSize        int
+	maxAttempts      int
+	disableThreshold int
+	baseBackoff      time.Duration
+	maxBackoff       time.Duration
+	now              func() time.Time
+
+	fetchHealth // 就绪检查只反映读取待投递记录是否失败（投递失败由重试和自动停用处理）
+}
+
+// WebhookDispatcherOption 投递器可选配置
+type WebhookDispatcherOption func(*WebhookDispatcher)
+
+// WithWebhookMaxAttempts 配置单条投递的最大尝试次数
+func WithWebhookMaxAttempts(attempts int) WebhookDispatcherOption {
+	return func(d *WebhookDispatcher) {
+		d.maxAttempts = attempts
+	}
+}
+
+// WithWebhookDisableThreshold 配置订阅自动停用的连续失败次数
+func WithWebhookDisableThreshold(threshold int) WebhookDispatcherOption {
+	return func(d *WebhookDispatcher) {
+		d.disableThreshold = threshold
+	}
+}
+
+// WithWebhookBackoff 配置重试退避时间（指数增长，不超过 max）
+func WithWebhookBackoff(base, max time.Duration) WebhookDispatcherOption {
+	return func(d *WebhookDispatcher) {
+		d.baseBackoff = base
+		d.maxBackoff = max
+	}
+}
+
+// WithWebhookClock 配置时钟（测试使用）
+func WithWebhookClock(now func() time.Time) WebhookDispatcherOption {
+	return func(d *WebhookDispatcher) {
+		d.now = now
+	}
+}
+
+// NewWebhookDispatcher 创建 Webhook 投递器
+func NewWebhookDispatcher(repo WebhookRepository, sender WebhookSender, opts ...WebhookDispatcherOption) *WebhookDispatcher {
+	d := &WebhookDispatcher{
+		repo:             repo,
+		sender:           sender,
+		batchSize:        DefaultWebhookBatchSize,
+		maxAttempts:      DefaultWebhookMaxAttempts,
+		disableThreshold: DefaultWebhookDisableThreshold,
+		baseBackoff:      DefaultWebhookBaseBackoff,
+		maxBackoff:       DefaultWebhookMaxBackoff,
+		now:              time.Now,
+		fetchHealth:      fetchHealth{failure: "failed to fetch webhook deliveries"},
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Run 按固定间隔循环投递，直到 ctx 结束
+func (d *WebhookDispatcher) Run(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, "webhook dispatch", d.DispatchOnce)
+}
+
+// DispatchOnce 投递一批到期的 Webhook，返回投递成功的数量
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.repo.FindDueDeliveries(ctx, d.now(), d.batchSize)
+	if err := d.observe(err); err != nil {
+		return 0, err
+	}
+
+	succeeded := 0
+	var errs []error
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+		ok, err := d.deliver(ctx, delivery)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if ok {
+			succeeded++
+		}
+	}
+
+	if len(errs) > 0 {
+		return succeeded, NewInternalError("failed to update webhook deliveries", errors.Join(errs...))
+	}
+	return succeeded, nil
+}
+
+// deliver 投递单条记录并保存投递结果和订阅状态
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *domain.WebhookDelivery) (bool, error) {
+	subscription, err := d.repo.FindSubscription(ctx, delivery.SubscriptionID)
+	if err != nil || !subscription.Active() {
+		// 订阅已删除或已停用
+		delivery.Skip()
+		return false, d.repo.UpdateDelivery(ctx, delivery)
+	}
+
+	attemptedAt := d.now()
+	start := time.Now()
+	statusCode, sendErr := d.sender.Send(ctx, &WebhookRequest{
+		DeliveryID: delivery.ID,
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		Payload:    delivery.Payload,
+		Timestamp:  attemptedAt,
+	})
+	attempt := domain.WebhookAttempt{
+		AttemptedAt: attemptedAt,
+		StatusCode:  statusCode,
+		Duration:    time.Since(start),
+	}
+
+	if sendErr == nil {
+		delivery.RecordAttempt(attempt, true, time.Time{}, false)
+		subscription.RecordSuccess()
+	} else {
+		attempt.Error = sendErr.Error()
+		attempts := len(delivery.Attempts) + 1
+		retryAt := attemptedAt.Add(exponentialBackoff(d.baseBackoff, d.maxBackoff, attempts))
+		delivery.RecordAttempt(attempt, false, retryAt, attempts >= d.maxAttempts)
+		subscription.RecordFailure(d.disableThreshold)
+		logging.FromContext(ctx).Warn("webhook delivery failed",
+			"delivery_id", delivery.ID,
+			"subscription_id", subscription.ID,
+			"event_type", delivery.EventType,
+			"attempt", attempts,
+			"status_code", statusCode,
+			"delivery_status", delivery.Status,
+			logging.KeyError, sendErr,
+		)
+	}
+
+	if err := d.repo.UpdateDelivery(ctx, delivery); err != nil {
+		return false, err
+	}
+	if err := d.repo.SaveSubscription(ctx, subscription); err != nil {
+		return false, err
+	}
+	return sendErr == nil, nil
+}
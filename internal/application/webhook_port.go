@@ -0,0 +1,148 @@
+package application
+
+import (
+	"context"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"order-service/internal/domain"
+)
+
+func init() {
+	// 注册 Webhook 事件类型验证函数
+	_ = Validator.RegisterValidation("event_type", validateEventType)
+	_ = Validator.RegisterValidation("webhook_url", validateWebhookURL)
+}
+
+// WebhookURLPattern Webhook 地址须为 https（与 webhook_url 校验一致，用于生成 OpenAPI 文档）
+const WebhookURLPattern = `^https://`
+
+// nonPublicPrefixes IsGlobalUnicast 之外仍不可公网访问的地址段
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // 本网络
+	netip.MustParsePrefix("100.64.0.0/10"), // 运营商级 NAT 共享地址
+	netip.MustParsePrefix("198.18.0.0/15"), // 网络基准测试
+	netip.MustParsePrefix("240.0.0.0/4"),   // 保留地址
+}
+
+// IsPublicAddr 是否为可投递 Webhook 的公网地址
+// 拒绝回环、私有、链路本地、组播、未指定等地址，防止商家通过 Webhook 访问内网服务（SSRF）
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateWebhookURL 验证 Webhook 地址：必须为 https，不带用户信息，主机不能是 localhost 或非公网 IP
+// 域名解析后的地址由发送器在建立连接时检查（防止 DNS 重绑定）
+func validateWebhookURL(fl validator.FieldLevel) bool {
+	u, err := url.Parse(fl.Field().String())
+	if err != nil || u.Scheme != "https" || u.User != nil {
+		return false
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return IsPublicAddr(addr)
+	}
+	return true
+}
+
+// validateEventType 验证事件类型（领域事件类型或通配符 *）
+func validateEventType(fl validator.FieldLevel) bool {
+	eventType := fl.Field().String()
+	return eventType == domain.WebhookEventAll || slices.Contains(domain.EventTypes, eventType)
+}
+
+// WebhookService 定义商家 Webhook 管理接口（输入端口）
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, merchantID string, req *CreateWebhookRequest) (*WebhookSubscriptionData, error)
+	ListSubscriptions(ctx context.Context, merchantID string) ([]WebhookSubscriptionData, error)
+	DeleteSubscription(ctx context.Context, merchantID, subscriptionID string) error
+	EnableSubscription(ctx context.Context, merchantID, subscriptionID string) (*WebhookSubscriptionData, error)
+	ListDeliveries(ctx context.Context, merchantID, subscriptionID string, limit int) ([]WebhookDeliveryData, error)
+	// HandleEvent 为订阅了该事件的商家 Webhook 创建投递记录（订阅事件总线）
+	HandleEvent(ctx context.Context, event domain.Event) error
+}
+
+// WebhookRepository 定义 Webhook 订阅和投递记录的持久化接口（输出端口）
+type WebhookRepository interface {
+	SaveSubscription(ctx context.Context, subscription *domain.WebhookSubscription) error
+	FindSubscription(ctx context.Context, subscriptionID string) (*domain.WebhookSubscription, error)
+	FindSubscriptionsByMerchant(ctx context.Context, merchantID string) ([]*domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+	// CreateDelivery 创建投递记录，ID 已存在时返回 false（用于事件去重）
+	CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (bool, error)
+	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookDelivery, error)
+	FindDeliveriesBySubscription(ctx context.Context, subscriptionID string, limit int) ([]*domain.WebhookDelivery, error)
+}
+
+// WebhookSender 定义 Webhook 发送接口（输出端口）
+// 适配器负责签名和 HTTP 传输，非 2xx 响应视为失败
+type WebhookSender interface {
+	Send(ctx context.Context, req *WebhookRequest) (statusCode int, err error)
+}
+
+// WebhookRequest Webhook 发送请求
+type WebhookRequest struct {
+	DeliveryID string
+	URL        string
+	Secret     string
+	EventID    string
+	EventType  string
+	Payload    []byte
+	Timestamp  time.Time
+}
+
+// CreateWebhookRequest 创建 Webhook 订阅请求（Secret 为空时自动生成，URL 须为公网 https 地址）
+type CreateWebhookRequest struct {
+	URL        string   `validate:"required,http_url,webhook_url,max=500"`
+	EventTypes []string `validate:"required,min=1,dive,event_type"`
+	Secret     string   `validate:"omitempty,min=16,max=128"`
+}
+
+// WebhookSubscriptionData Webhook 订阅数据（Secret 仅在创建时返回）
+type WebhookSubscriptionData struct {
+	ID                  string
+	MerchantID          string
+	URL                 string
+	EventTypes          []string
+	Secret              string
+	Status              string
+	ConsecutiveFailures int
+	DisabledReason      string
+	CreatedAt           string
+}
+
+// WebhookDeliveryData Webhook 投递记录数据
+type WebhookDeliveryData struct {
+	ID            string
+	EventID       string
+	EventType     string
+	Status        string
+	Attempts      []WebhookAttemptData
+	NextAttemptAt string
+	CreatedAt     string
+}
+
+// WebhookAttemptData 投递尝试数据
+type WebhookAttemptData struct {
+	AttemptedAt string
+	StatusCode  int
+	Error       string
+	DurationMs  int64
+}
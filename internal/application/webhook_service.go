@@ -0,0 +1,206 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"order-service/internal/domain"
+)
+
+// webhookService Webhook 管理应用服务实现
+type webhookService struct {
+	repo WebhookRepository
+}
+
+// NewWebhookService 创建 Webhook 管理服务实例
+func NewWebhookService(repo WebhookRepository) WebhookService {
+	return &webhookService{repo: repo}
+}
+
+// webhookEnvelope Webhook 推送的 JSON 负载
+type webhookEnvelope struct {
+	ID            string       `json:"id"`
+	Type          string       `json:"type"`
+	SchemaVersion int          `json:"schemaVersion"`
+	CreatedAt     time.Time    `json:"createdAt"`
+	Data          domain.Event `json:"data"`
+}
+
+// CreateSubscription 实现 WebhookService 接口
+func (s *webhookService) CreateSubscription(ctx context.Context, merchantID string, req *CreateWebhookRequest) (*WebhookSubscriptionData, error) {
+	if err := validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		secret = generateWebhookSecret()
+	}
+
+	subscription := domain.NewWebhookSubscription(merchantID, req.URL, req.EventTypes, secret)
+	if err := s.repo.SaveSubscription(ctx, subscription); err != nil {
+		return nil, NewInternalError("failed to save webhook subscription", err)
+	}
+
+	data := convertToSubscriptionDTO(subscription)
+	data.Secret = subscription.Secret
+	return data, nil
+}
+
+// ListSubscriptions 实现 WebhookService 接口
+func (s *webhookService) ListSubscriptions(ctx context.Context, merchantID string) ([]WebhookSubscriptionData, error) {
+	subscriptions, err := s.repo.FindSubscriptionsByMerchant(ctx, merchantID)
+	if err != nil {
+		return nil, NewInternalError("failed to list webhook subscriptions", err)
+	}
+
+	result := make([]WebhookSubscriptionData, len(subscriptions))
+	for i, subscription := range subscriptions {
+		result[i] = *convertToSubscriptionDTO(subscription)
+	}
+	return result, nil
+}
+
+// DeleteSubscription 实现 WebhookService 接口
+func (s *webhookService) DeleteSubscription(ctx context.Context, merchantID, subscriptionID string) error {
+	if _, err := s.findMerchantSubscription(ctx, merchantID, subscriptionID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteSubscription(ctx, subscriptionID); err != nil {
+		return NewInternalError("failed to delete webhook subscription", err)
+	}
+	return nil
+}
+
+// EnableSubscription 实现 WebhookService 接口
+func (s *webhookService) EnableSubscription(ctx context.Context, merchantID, subscriptionID string) (*WebhookSubscriptionData, error) {
+	subscription, err := s.findMerchantSubscription(ctx, merchantID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Enable()
+	if err := s.repo.SaveSubscription(ctx, subscription); err != nil {
+		return nil, NewInternalError("failed to save webhook subscription", err)
+	}
+	return convertToSubscriptionDTO(subscription), nil
+}
+
+// ListDeliveries 实现 WebhookService 接口
+func (s *webhookService) ListDeliveries(ctx context.Context, merchantID, subscriptionID string, limit int) ([]WebhookDeliveryData, error) {
+	if _, err := s.findMerchantSubscription(ctx, merchantID, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.FindDeliveriesBySubscription(ctx, subscriptionID, limit)
+	if err != nil {
+		return nil, NewInternalError("failed to list webhook deliveries", err)
+	}
+
+	result := make([]WebhookDeliveryData, len(deliveries))
+	for i, delivery := range deliveries {
+		result[i] = convertToDeliveryDTO(delivery)
+	}
+	return result, nil
+}
+
+// HandleEvent 实现 WebhookService 接口
+func (s *webhookService) HandleEvent(ctx context.Context, event domain.Event) error {
+	subscriptions, err := s.repo.FindSubscriptionsByMerchant(ctx, event.MerchantID())
+	if err != nil {
+		return NewInternalError("failed to find webhook subscriptions", err)
+	}
+
+	var payload []byte
+	for _, subscription := range subscriptions {
+		if !subscription.Active() || !subscription.Subscribes(event.EventType()) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(webhookEnvelope{
+				ID:            event.EventID(),
+				Type:          event.EventType(),
+				SchemaVersion: event.SchemaVersion(),
+				CreatedAt:     event.OccurredAt(),
+				Data:          event,
+			})
+			if err != nil {
+				return NewInternalError("failed to encode webhook payload", err)
+			}
+		}
+
+		// 事件可能被重复投递（at-least-once），已存在的投递记录直接跳过
+		if _, err := s.repo.CreateDelivery(ctx, domain.NewWebhookDelivery(subscription, event, payload)); err != nil {
+			return NewInternalError("failed to create webhook delivery", err)
+		}
+	}
+	return nil
+}
+
+// findMerchantSubscription 查询属于指定商家的订阅（不属于该商家时视为不存在）
+func (s *webhookService) findMerchantSubscription(ctx context.Context, merchantID, subscriptionID string) (*domain.WebhookSubscription, error) {
+	subscription, err := s.repo.FindSubscription(ctx, subscriptionID)
+	if err != nil {
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, notFound
+		}
+		return nil, NewInternalError("failed to find webhook subscription", err)
+	}
+	if subscription.MerchantID != merchantID {
+		return nil, NewNotFoundError("webhook " + subscriptionID + " not found")
+	}
+	return subscription, nil
+}
+
+// convertToSubscriptionDTO 转换订阅到 DTO（不包含 Secret）
+func convertToSubscriptionDTO(subscription *domain.WebhookSubscription) *WebhookSubscriptionData {
+	return &WebhookSubscriptionData{
+		ID:                  subscription.ID,
+		MerchantID:          subscription.MerchantID,
+		URL:                 subscription.URL,
+		EventTypes:          subscription.EventTypes,
+		Status:              string(subscription.Status),
+		ConsecutiveFailures: subscription.ConsecutiveFailures,
+		DisabledReason:      subscription.DisabledReason,
+		CreatedAt:           subscription.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// convertToDeliveryDTO 转换投递记录到 DTO
+func convertToDeliveryDTO(delivery *domain.WebhookDelivery) WebhookDeliveryData {
+	attempts := make([]WebhookAttemptData, len(delivery.Attempts))
+	for i, attempt := range delivery.Attempts {
+		attempts[i] = WebhookAttemptData{
+			AttemptedAt: attempt.AttemptedAt.Format(time.RFC3339),
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			DurationMs:  attempt.Duration.Milliseconds(),
+		}
+	}
+
+	data := WebhookDeliveryData{
+		ID:        delivery.ID,
+		EventID:   delivery.EventID,
+		EventType: delivery.EventType,
+		Status:    string(delivery.Status),
+		Attempts:  attempts,
+		CreatedAt: delivery.CreatedAt.Format(time.RFC3339),
+	}
+	if delivery.Status == domain.WebhookDeliveryPending {
+		data.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+	}
+	return data
+}
+
+// generateWebhookSecret 生成随机签名密钥
+func generateWebhookSecret() string {
+	b := make([]byte, 24)
+	_, _ = rand.Read(b)
+	return "whsec_" + hex.EncodeToString(b)
+}
@@ -0,0 +1,289 @@
+// Package config 服务配置：默认值 < 配置文件（YAML/TOML）< 环境变量 < 命令行参数
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+// redactedValue 输出配置时替代敏感字段的值
+const redactedValue = "******"
+
+// minSecretLength JWT 和支付回调签名密钥最小长度
+const minSecretLength = 16
+
+// DemoJWTSecret 开发用演示 JWT 密钥（默认值），仅在 auth.allowDemoSecret 为 true 时允许使用
+const DemoJWTSecret = "interview-demo-secret-key"
+
+// Config 服务配置
+// 每个叶子字段对应：配置文件键 server.httpAddr、环境变量 ORDER_SERVER_HTTP_ADDR、命令行参数 --server.http-addr；
+// secret 字段在 --print-config 中脱敏输出
+type Config struct {
+	Server    ServerConfig    `yaml:"server" toml:"server"`
+	Auth      AuthConfig      `yaml:"auth" toml:"auth"`
+	Pricing   PricingConfig   `yaml:"pricing" toml:"pricing"`
+	Cart      CartConfig      `yaml:"cart" toml:"cart"`
+	Quote     QuoteConfig     `yaml:"quote" toml:"quote"`
+	Schedule  ScheduleConfig  `yaml:"schedule" toml:"schedule"`
+	Payment   PaymentConfig   `yaml:"payment" toml:"payment"`
+	Outbox    OutboxConfig    `yaml:"outbox" toml:"outbox"`
+	Webhook   WebhookConfig   `yaml:"webhook" toml:"webhook"`
+	Log       LogConfig       `yaml:"log" toml:"log"`
+	Tracing   TracingConfig   `yaml:"tracing" toml:"tracing"`
+	RateLimit RateLimitConfig `yaml:"rateLimit" toml:"rateLimit"`
+}
+
+// ServerConfig 监听地址和停机配置
+// 收到 SIGTERM/SIGINT 后先将就绪检查置为失败并等待 DrainDelay（让负载均衡摘除实例），
+// 再在 ShutdownTimeout 内排空连接并停止后台任务
+type ServerConfig struct {
+	HTTPAddr        string        `yaml:"httpAddr" toml:"httpAddr" usage:"HTTP 监听地址"`
+	GRPCAddr        string        `yaml:"grpcAddr" toml:"grpcAddr" usage:"gRPC 监听地址"`
+	DrainDelay      time.Duration `yaml:"drainDelay" toml:"drainDelay" usage:"停机时就绪检查失败后等待多久再停止接收请求"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" toml:"shutdownTimeout" usage:"停机时排空连接和停止后台任务的最长时间"`
+	// TrustProxyHeaders 为 false 时客户端 IP 取连接对端地址，避免伪造 X-Forwarded-For 绕过按 IP 限流
+	TrustProxyHeaders bool `yaml:"trustProxyHeaders" toml:"trustProxyHeaders" usage:"从 X-Forwarded-For 获取客户端 IP（仅在反向代理之后启用）"`
+}
+
+// AuthConfig 认证配置（JWTSecretFile 非空时从文件读取密钥，优先于 JWTSecret）
+type AuthConfig struct {
+	JWTSecret     string        `yaml:"jwtSecret" toml:"jwtSecret" secret:"true" usage:"JWT 签名密钥"`
+	JWTSecretFile string        `yaml:"jwtSecretFile" toml:"jwtSecretFile" usage:"从文件读取 JWT 签名密钥"`
+	TokenTTL      time.Duration `yaml:"tokenTTL" toml:"tokenTTL" usage:"token 有效期"`
+	// AllowDemoSecret 为 false 时拒绝以演示密钥启动，避免未配置密钥的实例接受任何人都能签发的 token
+	AllowDemoSecret bool `yaml:"allowDemoSecret" toml:"allowDemoSecret" usage:"允许使用开发用演示 JWT 密钥（仅限本地开发）"`
+}
+
+// PricingConfig 订单固定费用
+type PricingConfig struct {
+	PackagingFee decimal.Decimal `yaml:"packagingFee" toml:"packagingFee" usage:"打包费（元）"`
+	DeliveryFee  decimal.Decimal `yaml:"deliveryFee" toml:"deliveryFee" usage:"配送费（元）"`
+}
+
+// CartConfig 购物车配置
+type CartConfig struct {
+	TTL time.Duration `yaml:"ttl" toml:"ttl" usage:"购物车有效期（每次修改后顺延）"`
+}
+
+// QuoteConfig 下单报价配置
+type QuoteConfig struct {
+	TTL time.Duration `yaml:"ttl" toml:"ttl" usage:"报价 token 有效期"`
+}
+
+// ScheduleConfig 预订单配置（配送时段按商家时区当天 0 点起划分）
+type ScheduleConfig struct {
+	SlotDuration    time.Duration `yaml:"slotDuration" toml:"slotDuration" usage:"配送时段长度（须为整分钟且整除 24 小时）"`
+	MinLeadTime     time.Duration `yaml:"minLeadTime" toml:"minLeadTime" usage:"时段开始时间距下单时间的最小提前量"`
+	MaxAdvance      time.Duration `yaml:"maxAdvance" toml:"maxAdvance" usage:"最多提前多久预订"`
+	ReleaseLeadTime time.Duration `yaml:"releaseLeadTime" toml:"releaseLeadTime" usage:"在配送时段开始前多久将预订单推送给商家"`
+	PollInterval    time.Duration `yaml:"pollInterval" toml:"pollInterval" usage:"预订单推送轮询间隔"`
+}
+
+// PaymentConfig 支付配置：回调签名密钥，以及支付超时（超时未支付的订单自动取消并释放配送时段名额和餐品库存）
+// CallbackSecret 为空时拒绝全部支付回调；CallbackSecretFile 非空时从文件读取密钥，优先于 CallbackSecret
+type PaymentConfig struct {
+	CallbackSecret     string        `yaml:"callbackSecret" toml:"callbackSecret" secret:"true" usage:"支付回调签名密钥（HMAC-SHA256）"`
+	CallbackSecretFile string        `yaml:"callbackSecretFile" toml:"callbackSecretFile" usage:"从文件读取支付回调签名密钥"`
+	Timeout            time.Duration `yaml:"timeout" toml:"timeout" usage:"未支付订单超时自动取消时间"`
+	PollInterval       time.Duration `yaml:"pollInterval" toml:"pollInterval" usage:"支付超时检查轮询间隔"`
+}
+
+// OutboxConfig outbox 投递器配置
+type OutboxConfig struct {
+	PollInterval time.Duration `yaml:"pollInterval" toml:"pollInterval" usage:"outbox 轮询间隔"`
+}
+
+// WebhookConfig Webhook 投递器配置
+type WebhookConfig struct {
+	DispatchInterval time.Duration `yaml:"dispatchInterval" toml:"dispatchInterval" usage:"Webhook 投递轮询间隔"`
+}
+
+// LogConfig 日志配置（JSON 格式输出到标准输出）
+type LogConfig struct {
+	Level slog.Level `yaml:"level" toml:"level" usage:"日志级别（DEBUG/INFO/WARN/ERROR）"`
+}
+
+// 链路追踪导出方式
+const (
+	TracingExporterNone   = "none"   // 不导出（仍生成 trace ID 用于日志关联和向下游传播）
+	TracingExporterOTLP   = "otlp"   // 以 OTLP/HTTP 发送到 Endpoint
+	TracingExporterStdout = "stdout" // 以 JSON 写到标准输出，File 非空时追加写入文件（本地调试使用）
+)
+
+// TracingConfig OpenTelemetry 链路追踪配置
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" toml:"exporter" usage:"链路导出方式（none/otlp/stdout）"`
+	Endpoint    string  `yaml:"endpoint" toml:"endpoint" usage:"OTLP/HTTP 接收地址，如 http://localhost:4318"`
+	File        string  `yaml:"file" toml:"file" usage:"stdout 导出方式写入的文件（为空时输出到标准输出）"`
+	SampleRatio float64 `yaml:"sampleRatio" toml:"sampleRatio" usage:"新链路采样比例（0-1，沿用上游的采样决定）"`
+	ServiceName string  `yaml:"serviceName" toml:"serviceName" usage:"上报的服务名"`
+}
+
+// RateLimitConfig 限流配置（令牌桶，格式见 RateLimit）
+type RateLimitConfig struct {
+	Enabled                bool      `yaml:"enabled" toml:"enabled" usage:"是否启用限流"`
+	CreateOrderPerUser     RateLimit `yaml:"createOrderPerUser" toml:"createOrderPerUser" usage:"每个用户的下单限额"`
+	CreateOrderPerMerchant RateLimit `yaml:"createOrderPerMerchant" toml:"createOrderPerMerchant" usage:"每个商家接收的下单限额"`
+	APIPerUser             RateLimit `yaml:"apiPerUser" toml:"apiPerUser" usage:"每个用户调用全部接口的限额"`
+	APIPerIP               RateLimit `yaml:"apiPerIP" toml:"apiPerIP" usage:"每个客户端 IP 调用全部接口的限额"`
+}
+
+// Default 默认配置（与未引入配置前的硬编码值一致）
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			HTTPAddr:        ":8080",
+			GRPCAddr:        ":9090",
+			DrainDelay:      0,
+			ShutdownTimeout: 15 * time.Second,
+		},
+		Auth: AuthConfig{
+			JWTSecret: DemoJWTSecret,
+			TokenTTL:  24 * time.Hour,
+		},
+		Pricing: PricingConfig{
+			PackagingFee: decimal.NewFromFloat(1.00),
+			DeliveryFee:  decimal.NewFromFloat(3.00),
+		},
+		Cart: CartConfig{
+			TTL: 72 * time.Hour,
+		},
+		Quote: QuoteConfig{
+			TTL: 5 * time.Minute,
+		},
+		Schedule: ScheduleConfig{
+			SlotDuration:    30 * time.Minute,
+			MinLeadTime:     45 * time.Minute,
+			MaxAdvance:      7 * 24 * time.Hour,
+			ReleaseLeadTime: 30 * time.Minute,
+			PollInterval:    30 * time.Second,
+		},
+		Payment: PaymentConfig{
+			Timeout:      15 * time.Minute,
+			PollInterval: 30 * time.Second,
+		},
+		Outbox: OutboxConfig{
+			PollInterval: 500 * time.Millisecond,
+		},
+		Webhook: WebhookConfig{
+			DispatchInterval: time.Second,
+		},
+		Log: LogConfig{
+			Level: slog.LevelInfo,
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			SampleRatio: 1,
+			ServiceName: "order-service",
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:                true,
+			CreateOrderPerUser:     RateLimit{Rate: 30, Period: time.Minute, Burst: 10},
+			CreateOrderPerMerchant: RateLimit{Rate: 600, Period: time.Minute, Burst: 100},
+			APIPerUser:             RateLimit{Rate: 300, Period: time.Minute, Burst: 60},
+			APIPerIP:               RateLimit{Rate: 600, Period: time.Minute, Burst: 120},
+		},
+	}
+}
+
+// Validate 校验配置，返回全部错误
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, key, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+		}
+	}
+
+	check(validAddr(c.Server.HTTPAddr), "server.httpAddr", "invalid listen address %q", c.Server.HTTPAddr)
+	check(validAddr(c.Server.GRPCAddr), "server.grpcAddr", "invalid listen address %q", c.Server.GRPCAddr)
+	check(c.Server.HTTPAddr != c.Server.GRPCAddr, "server.grpcAddr", "must differ from server.httpAddr")
+	check(c.Server.DrainDelay >= 0, "server.drainDelay", "must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdownTimeout", "must be positive")
+
+	check(len(c.Auth.JWTSecret) >= minSecretLength, "auth.jwtSecret", "must be at least %d characters", minSecretLength)
+	check(c.Auth.JWTSecret != DemoJWTSecret || c.Auth.AllowDemoSecret,
+		"auth.jwtSecret", "must not be the demo secret unless auth.allowDemoSecret is true (local development only)")
+	check(c.Auth.TokenTTL > 0, "auth.tokenTTL", "must be positive")
+
+	checkFee := func(key string, fee decimal.Decimal) {
+		check(!fee.IsNegative(), key, "must not be negative")
+		check(fee.Equal(fee.Round(2)), key, "must have at most 2 decimal places")
+	}
+	checkFee("pricing.packagingFee", c.Pricing.PackagingFee)
+	checkFee("pricing.deliveryFee", c.Pricing.DeliveryFee)
+
+	check(c.Cart.TTL > 0, "cart.ttl", "must be positive")
+	check(c.Quote.TTL > 0, "quote.ttl", "must be positive")
+	slot := c.Schedule.SlotDuration
+	check(slot >= time.Minute && slot%time.Minute == 0 && (24*time.Hour)%slot == 0,
+		"schedule.slotDuration", "must be a whole number of minutes that divides 24h")
+	check(c.Schedule.MinLeadTime >= 0, "schedule.minLeadTime", "must not be negative")
+	check(c.Schedule.MaxAdvance > c.Schedule.MinLeadTime, "schedule.maxAdvance", "must be greater than schedule.minLeadTime")
+	check(c.Schedule.ReleaseLeadTime >= 0, "schedule.releaseLeadTime", "must not be negative")
+	check(c.Schedule.PollInterval > 0, "schedule.pollInterval", "must be positive")
+	check(c.Payment.CallbackSecret == "" || len(c.Payment.CallbackSecret) >= minSecretLength,
+		"payment.callbackSecret", "must be at least %d characters", minSecretLength)
+	check(c.Payment.Timeout > 0, "payment.timeout", "must be positive")
+	check(c.Payment.PollInterval > 0, "payment.pollInterval", "must be positive")
+	check(c.Outbox.PollInterval > 0, "outbox.pollInterval", "must be positive")
+	check(c.Webhook.DispatchInterval > 0, "webhook.dispatchInterval", "must be positive")
+
+	switch c.Tracing.Exporter {
+	case TracingExporterNone, TracingExporterStdout:
+	case TracingExporterOTLP:
+		check(validEndpointURL(c.Tracing.Endpoint), "tracing.endpoint", "must be an http(s) URL when tracing.exporter is %s", TracingExporterOTLP)
+	default:
+		check(false, "tracing.exporter", "must be one of %s, %s, %s", TracingExporterNone, TracingExporterOTLP, TracingExporterStdout)
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sampleRatio", "must be between 0 and 1")
+	check(c.Tracing.ServiceName != "", "tracing.serviceName", "must not be empty")
+
+	return errors.Join(errs...)
+}
+
+// Redacted 返回敏感字段脱敏后的副本
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	for _, f := range leafFields(&redacted) {
+		if f.secret && f.value.String() != "" {
+			f.value.SetString(redactedValue)
+		}
+	}
+	return &redacted
+}
+
+// WriteRedacted 以 YAML 格式输出脱敏后的生效配置
+func (c *Config) WriteRedacted(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// validAddr 校验 host:port 监听地址
+func validAddr(addr string) bool {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	n, err := strconv.Atoi(port)
+	return err == nil && n >= 0 && n <= 65535
+}
+
+// validEndpointURL 校验 http(s)://host[:port] 地址
+func validEndpointURL(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,255 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// envMap 测试用环境变量
+func envMap(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+// devEnv 测试用环境变量，允许使用演示 JWT 密钥（本地开发配置）
+func devEnv(values map[string]string) func(string) (string, bool) {
+	env := map[string]string{"ORDER_AUTH_ALLOW_DEMO_SECRET": "true"}
+	for key, value := range values {
+		env[key] = value
+	}
+	return envMap(env)
+}
+
+// writeFile 在临时目录写入文件并返回路径
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	// Act
+	cfg, cli, err := Load(nil, devEnv(nil))
+
+	// Assert
+	require.NoError(t, err)
+	expected := Default()
+	expected.Auth.AllowDemoSecret = true
+	assert.Equal(t, expected, cfg)
+	assert.False(t, cli.PrintConfig)
+}
+
+func TestLoad_RejectsDemoSecret(t *testing.T) {
+	// Act
+	_, _, defaultErr := Load(nil, envMap(nil))
+	_, _, explicitErr := Load(nil, envMap(map[string]string{"ORDER_AUTH_JWT_SECRET": DemoJWTSecret}))
+	cfg, _, flagErr := Load([]string{"--auth.allow-demo-secret", "true"}, envMap(nil))
+
+	// Assert - 未显式允许时，无论默认值还是显式配置的演示密钥都被拒绝
+	assert.ErrorContains(t, defaultErr, "auth.jwtSecret: must not be the demo secret")
+	assert.ErrorContains(t, explicitErr, "auth.jwtSecret: must not be the demo secret")
+	require.NoError(t, flagErr)
+	assert.Equal(t, DemoJWTSecret, cfg.Auth.JWTSecret)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	// Arrange - 文件覆盖默认值，环境变量覆盖文件，命令行参数覆盖环境变量
+	path := writeFile(t, "config.yaml", `
+server:
+  httpAddr: ":8000"
+  grpcAddr: ":9000"
+pricing:
+  deliveryFee: 5.50
+outbox:
+  pollInterval: 2s
+`)
+	env := devEnv(map[string]string{
+		"ORDER_SERVER_HTTP_ADDR": ":8001",
+		"ORDER_AUTH_TOKEN_TTL":   "1h",
+		"ORDER_LOG_LEVEL":        "debug",
+		"ORDER_CART_TTL":         "24h",
+	})
+
+	// Act
+	cfg, _, err := Load([]string{"--config", path, "--server.http-addr", ":8002"}, env)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ":8002", cfg.Server.HTTPAddr)
+	assert.Equal(t, ":9000", cfg.Server.GRPCAddr)
+	assert.Equal(t, time.Hour, cfg.Auth.TokenTTL)
+	assert.True(t, decimal.RequireFromString("5.50").Equal(cfg.Pricing.DeliveryFee))
+	assert.True(t, decimal.NewFromInt(1).Equal(cfg.Pricing.PackagingFee))
+	assert.Equal(t, 24*time.Hour, cfg.Cart.TTL)
+	assert.Equal(t, 2*time.Second, cfg.Outbox.PollInterval)
+	assert.Equal(t, slog.LevelDebug, cfg.Log.Level)
+}
+
+func TestLoad_TOMLFromEnv(t *testing.T) {
+	// Arrange
+	path := writeFile(t, "config.toml", `
+[server]
+grpcAddr = ":9100"
+
+[webhook]
+dispatchInterval = "3s"
+
+[log]
+level = "WARN"
+`)
+
+	// Act
+	cfg, _, err := Load(nil, devEnv(map[string]string{EnvConfigFile: path}))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ":9100", cfg.Server.GRPCAddr)
+	assert.Equal(t, 3*time.Second, cfg.Webhook.DispatchInterval)
+	assert.Equal(t, slog.LevelWarn, cfg.Log.Level)
+}
+
+func TestLoad_RejectsUnknownKeys(t *testing.T) {
+	yamlPath := writeFile(t, "config.yaml", "server:\n  port: 8080\n")
+	tomlPath := writeFile(t, "config.toml", "[server]\nport = 8080\n")
+
+	_, _, yamlErr := Load([]string{"--config", yamlPath}, envMap(nil))
+	_, _, tomlErr := Load([]string{"--config", tomlPath}, envMap(nil))
+
+	assert.ErrorContains(t, yamlErr, "port")
+	assert.ErrorContains(t, tomlErr, "port")
+}
+
+func TestLoad_SecretFromFile(t *testing.T) {
+	// Arrange - 文件末尾的换行被去掉
+	secretPath := writeFile(t, "jwt_secret", "a-very-long-secret-from-file\n")
+	callbackPath := writeFile(t, "callback_secret", "a-callback-secret-from-file\r\n")
+
+	// Act
+	cfg, _, err := Load(nil, envMap(map[string]string{
+		"ORDER_AUTH_JWT_SECRET_FILE":         secretPath,
+		"ORDER_PAYMENT_CALLBACK_SECRET":      "overridden-by-the-file",
+		"ORDER_PAYMENT_CALLBACK_SECRET_FILE": callbackPath,
+	}))
+	_, _, missingErr := Load(nil, devEnv(map[string]string{"ORDER_PAYMENT_CALLBACK_SECRET_FILE": filepath.Join(t.TempDir(), "missing")}))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "a-very-long-secret-from-file", cfg.Auth.JWTSecret)
+	assert.Equal(t, "a-callback-secret-from-file", cfg.Payment.CallbackSecret)
+	assert.ErrorContains(t, missingErr, "payment.callbackSecretFile")
+}
+
+func TestLoad_StrictValidation(t *testing.T) {
+	// Act
+	_, _, err := Load([]string{
+		"--server.http-addr", "8080",
+		"--auth.jwt-secret", "short",
+		"--pricing.delivery-fee", "-1",
+		"--pricing.packaging-fee", "0.005",
+		"--server.shutdown-timeout", "0s",
+		"--cart.ttl", "0s",
+		"--quote.ttl", "-1m",
+		"--schedule.slot-duration", "7m",
+		"--schedule.max-advance", "10m",
+		"--payment.callback-secret", "short",
+	}, envMap(nil))
+
+	// Assert - 报告全部错误
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "server.httpAddr")
+	assert.ErrorContains(t, err, "auth.jwtSecret")
+	assert.ErrorContains(t, err, "pricing.deliveryFee")
+	assert.ErrorContains(t, err, "pricing.packagingFee")
+	assert.ErrorContains(t, err, "server.shutdownTimeout")
+	assert.ErrorContains(t, err, "cart.ttl")
+	assert.ErrorContains(t, err, "quote.ttl")
+	assert.ErrorContains(t, err, "schedule.slotDuration")
+	assert.ErrorContains(t, err, "schedule.maxAdvance")
+	assert.ErrorContains(t, err, "payment.callbackSecret")
+}
+
+func TestLoad_InvalidValues(t *testing.T) {
+	_, _, envErr := Load(nil, envMap(map[string]string{"ORDER_OUTBOX_POLL_INTERVAL": "soon"}))
+	_, _, flagErr := Load([]string{"--unknown"}, envMap(nil))
+	_, _, helpErr := Load([]string{"-h"}, envMap(nil))
+
+	assert.ErrorContains(t, envErr, "ORDER_OUTBOX_POLL_INTERVAL")
+	assert.Error(t, flagErr)
+	assert.True(t, errors.Is(helpErr, flag.ErrHelp))
+}
+
+func TestLoad_Tracing(t *testing.T) {
+	// Act
+	cfg, _, err := Load([]string{"--tracing.sample-ratio", "0.25"}, devEnv(map[string]string{
+		"ORDER_TRACING_EXPORTER": "otlp",
+		"ORDER_TRACING_ENDPOINT": "http://localhost:4318",
+	}))
+	_, _, missingEndpointErr := Load(nil, envMap(map[string]string{"ORDER_TRACING_EXPORTER": "otlp"}))
+	_, _, invalidErr := Load([]string{"--tracing.exporter", "jaeger", "--tracing.sample-ratio", "2"}, envMap(nil))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, TracingExporterOTLP, cfg.Tracing.Exporter)
+	assert.Equal(t, 0.25, cfg.Tracing.SampleRatio)
+	assert.ErrorContains(t, missingEndpointErr, "tracing.endpoint")
+	assert.ErrorContains(t, invalidErr, "tracing.exporter")
+	assert.ErrorContains(t, invalidErr, "tracing.sampleRatio")
+}
+
+func TestLoad_RateLimit(t *testing.T) {
+	// Arrange
+	path := writeFile(t, "config.yaml", "rateLimit:\n  createOrderPerUser: 5/10s:2\n  apiPerIP: \"off\"\n")
+
+	// Act
+	cfg, _, err := Load([]string{"--config", path}, devEnv(map[string]string{"ORDER_RATE_LIMIT_API_PER_USER": "100/1h"}))
+	_, _, invalidErr := Load([]string{"--rateLimit.create-order-per-merchant", "10/minute"}, envMap(nil))
+
+	// Assert - 省略 burst 时等于 rate
+	require.NoError(t, err)
+	assert.Equal(t, RateLimit{Rate: 5, Period: 10 * time.Second, Burst: 2}, cfg.RateLimit.CreateOrderPerUser)
+	assert.Equal(t, RateLimit{Rate: 100, Period: time.Hour, Burst: 100}, cfg.RateLimit.APIPerUser)
+	assert.Equal(t, RateLimit{}, cfg.RateLimit.APIPerIP)
+	assert.ErrorContains(t, invalidErr, "period")
+
+	text, err := cfg.RateLimit.CreateOrderPerUser.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "5/10s:2", string(text))
+}
+
+func TestConfig_WriteRedacted(t *testing.T) {
+	// Arrange
+	cfg, cli, err := Load([]string{"--print-config", "--auth.jwt-secret", "super-secret-signing-key"}, envMap(nil))
+	require.NoError(t, err)
+	var out bytes.Buffer
+
+	// Act
+	require.NoError(t, cfg.WriteRedacted(&out))
+
+	// Assert - 原配置不受影响
+	assert.True(t, cli.PrintConfig)
+	assert.Contains(t, out.String(), "jwtSecret: '******'")
+	assert.NotContains(t, out.String(), "super-secret-signing-key")
+	assert.Contains(t, out.String(), "httpAddr: :8080")
+	assert.Contains(t, out.String(), "tokenTTL: 24h0m0s")
+	assert.Contains(t, out.String(), "level: INFO")
+	assert.Equal(t, "super-secret-signing-key", cfg.Auth.JWTSecret)
+}
+
+func TestNaming(t *testing.T) {
+	assert.Equal(t, "ORDER_AUTH_TOKEN_TTL", envName("auth.tokenTTL"))
+	assert.Equal(t, "ORDER_SERVER_GRPC_ADDR", envName("server.grpcAddr"))
+	assert.Equal(t, "auth.jwt-secret-file", flagName("auth.jwtSecretFile"))
+	assert.Equal(t, "server.http-addr", flagName("server.httpAddr"))
+}
@@ -0,0 +1,276 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix 环境变量前缀
+const EnvPrefix = "ORDER_"
+
+// EnvConfigFile 指定配置文件路径的环境变量（--config 优先）
+const EnvConfigFile = EnvPrefix + "CONFIG"
+
+// CommandLine 控制加载过程的命令行参数
+type CommandLine struct {
+	ConfigFile  string // --config 配置文件路径（.yaml/.yml/.toml）
+	PrintConfig bool   // --print-config 输出脱敏后的生效配置并退出
+}
+
+// field 配置叶子字段
+type field struct {
+	key    string // 配置文件中的完整键，如 server.httpAddr
+	usage  string
+	secret bool
+	value  reflect.Value
+}
+
+// Load 按优先级加载配置：默认值 < 配置文件 < 环境变量 < 命令行参数
+// 加载后读取 *File 形式的密钥文件并严格校验；-h/--help 时返回 flag.ErrHelp
+func Load(args []string, lookupEnv func(string) (string, bool)) (*Config, *CommandLine, error) {
+	cfg := Default()
+	fields := leafFields(cfg)
+
+	// 先解析命令行参数（仅记录，最后应用），以便获取 --config
+	cli := &CommandLine{}
+	flags := flag.NewFlagSet("order-service", flag.ContinueOnError)
+	flags.StringVar(&cli.ConfigFile, "config", "", "配置文件路径（.yaml/.yml/.toml），也可通过 "+EnvConfigFile+" 指定")
+	flags.BoolVar(&cli.PrintConfig, "print-config", false, "输出脱敏后的生效配置并退出")
+	overrides := make(map[string]string)
+	for _, f := range fields {
+		flags.Var(&flagValue{key: f.key, overrides: overrides}, flagName(f.key), f.usage)
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	if flags.NArg() > 0 {
+		return nil, nil, fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+
+	if cli.ConfigFile == "" {
+		cli.ConfigFile, _ = lookupEnv(EnvConfigFile)
+	}
+	if cli.ConfigFile != "" {
+		if err := loadFile(cfg, cli.ConfigFile); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, f := range fields {
+		if raw, ok := lookupEnv(envName(f.key)); ok {
+			if err := setValue(f.value, raw); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", envName(f.key), err)
+			}
+		}
+	}
+
+	for _, f := range fields {
+		if raw, ok := overrides[f.key]; ok {
+			if err := setValue(f.value, raw); err != nil {
+				return nil, nil, fmt.Errorf("--%s: %w", flagName(f.key), err)
+			}
+		}
+	}
+
+	if err := resolveSecretFiles(cfg); err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return cfg, cli, nil
+}
+
+// loadFile 按扩展名读取 YAML 或 TOML 配置文件，拒绝未知的键
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("parse config file %s: %w", path, err)
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return fmt.Errorf("parse config file %s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("parse config file %s: unknown keys %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("unsupported config file format %q (use .yaml, .yml or .toml)", filepath.Ext(path))
+	}
+	return nil
+}
+
+// resolveSecretFiles 从文件读取密钥（去掉末尾换行，便于挂载 Docker/Kubernetes secret）
+func resolveSecretFiles(cfg *Config) error {
+	secrets := []struct {
+		name   string
+		path   string
+		secret *string
+	}{
+		{"auth.jwtSecretFile", cfg.Auth.JWTSecretFile, &cfg.Auth.JWTSecret},
+		{"payment.callbackSecretFile", cfg.Payment.CallbackSecretFile, &cfg.Payment.CallbackSecret},
+	}
+	for _, s := range secrets {
+		if s.path == "" {
+			continue
+		}
+		data, err := os.ReadFile(s.path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.name, err)
+		}
+		*s.secret = strings.TrimRight(string(data), "\r\n")
+	}
+	return nil
+}
+
+// leafFields 按声明顺序列出配置的叶子字段
+func leafFields(cfg *Config) []field {
+	var fields []field
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			key := prefix + sf.Tag.Get("yaml")
+			fv := v.Field(i)
+			if sf.Type.Kind() == reflect.Struct && !isScalarStruct(fv) {
+				walk(fv, key+".")
+				continue
+			}
+			fields = append(fields, field{
+				key:    key,
+				usage:  sf.Tag.Get("usage"),
+				secret: sf.Tag.Get("secret") == "true",
+				value:  fv,
+			})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	return fields
+}
+
+// isScalarStruct 以文本表示的结构体（如 decimal.Decimal）视为叶子字段
+func isScalarStruct(v reflect.Value) bool {
+	_, ok := v.Addr().Interface().(encoding.TextUnmarshaler)
+	return ok
+}
+
+// setValue 将字符串解析为字段值
+func setValue(v reflect.Value, raw string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(raw))
+	}
+
+	switch v.Interface().(type) {
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+	return nil
+}
+
+// flagValue 记录命令行参数，在配置文件和环境变量之后应用
+type flagValue struct {
+	key       string
+	overrides map[string]string
+}
+
+func (f *flagValue) String() string {
+	return ""
+}
+
+func (f *flagValue) Set(raw string) error {
+	f.overrides[f.key] = raw
+	return nil
+}
+
+// envName 配置键对应的环境变量名，如 auth.jwtSecret -> ORDER_AUTH_JWT_SECRET
+func envName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.Join(keyWords(key), "_"))
+}
+
+// flagName 配置键对应的命令行参数名，如 auth.jwtSecret -> auth.jwt-secret
+func flagName(key string) string {
+	section, name, ok := strings.Cut(key, ".")
+	if !ok {
+		return strings.Join(splitCamel(key), "-")
+	}
+	return section + "." + strings.Join(splitCamel(name), "-")
+}
+
+// keyWords 拆分完整配置键的单词
+func keyWords(key string) []string {
+	var words []string
+	for _, part := range strings.Split(key, ".") {
+		words = append(words, splitCamel(part)...)
+	}
+	return words
+}
+
+// splitCamel 按驼峰拆分为小写单词，连续大写视为缩写（tokenTTL -> token, ttl）
+func splitCamel(s string) []string {
+	runes := []rune(s)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		lowerToUpper := unicode.IsLower(runes[i-1]) && unicode.IsUpper(runes[i])
+		acronymEnd := unicode.IsUpper(runes[i-1]) && unicode.IsUpper(runes[i]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if lowerToUpper || acronymEnd {
+			words = append(words, strings.ToLower(string(runes[start:i])))
+			start = i
+		}
+	}
+	return append(words, strings.ToLower(string(runes[start:])))
+}
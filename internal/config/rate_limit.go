@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rateLimitOff 不限流
+const rateLimitOff = "off"
+
+// RateLimit 令牌桶限额，文本格式为 "{rate}/{period}[:{burst}]"，如 "30/1m:10" 表示
+// 每分钟补充 30 个令牌、最多突发 10 个请求，省略 burst 时等于 rate；"off" 表示不限流
+type RateLimit struct {
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+// UnmarshalText 解析文本格式的限额
+func (l *RateLimit) UnmarshalText(text []byte) error {
+	raw := strings.TrimSpace(string(text))
+	if raw == rateLimitOff {
+		*l = RateLimit{}
+		return nil
+	}
+
+	rate, rest, ok := strings.Cut(raw, "/")
+	if !ok {
+		return fmt.Errorf("invalid rate limit %q, expected {rate}/{period}[:{burst}] or %s", raw, rateLimitOff)
+	}
+	period, burst, hasBurst := strings.Cut(rest, ":")
+
+	var parsed RateLimit
+	var err error
+	if parsed.Rate, err = strconv.Atoi(rate); err != nil || parsed.Rate <= 0 {
+		return fmt.Errorf("invalid rate limit %q: rate must be a positive integer", raw)
+	}
+	if parsed.Period, err = time.ParseDuration(period); err != nil || parsed.Period <= 0 {
+		return fmt.Errorf("invalid rate limit %q: period must be a positive duration", raw)
+	}
+	parsed.Burst = parsed.Rate
+	if hasBurst {
+		if parsed.Burst, err = strconv.Atoi(burst); err != nil || parsed.Burst <= 0 {
+			return fmt.Errorf("invalid rate limit %q: burst must be a positive integer", raw)
+		}
+	}
+	*l = parsed
+	return nil
+}
+
+// MarshalText 输出文本格式的限额
+func (l RateLimit) MarshalText() ([]byte, error) {
+	if l.Rate <= 0 {
+		return []byte(rateLimitOff), nil
+	}
+	return []byte(fmt.Sprintf("%d/%s:%d", l.Rate, l.Period, l.Burst)), nil
+}
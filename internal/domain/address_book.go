@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// MaxSavedAddresses 地址簿最多保存的地址数
+const MaxSavedAddresses = 20
+
+// AddressLabel 地址标签
+type AddressLabel string
+
+const (
+	AddressLabelHome   AddressLabel = "HOME"
+	AddressLabelOffice AddressLabel = "OFFICE"
+	AddressLabelOther  AddressLabel = "OTHER"
+)
+
+// AddressBook 地址簿聚合根（每个用户一个）
+// 地址簿不为空时有且只有一个默认地址；下单引用的地址在下单时复制为订单的配送信息，之后修改地址不影响已有订单
+type AddressBook struct {
+	UserID        uint64
+	Addresses     []SavedAddress
+	NextAddressNo int
+	UpdatedAt     time.Time
+}
+
+// SavedAddress 地址簿中的地址（AddressID 在地址簿内唯一）
+type SavedAddress struct {
+	AddressID string
+	Label     AddressLabel
+	Delivery  DeliveryInfo
+	IsDefault bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewAddressBook 创建空地址簿
+func NewAddressBook(userID uint64) *AddressBook {
+	return &AddressBook{UserID: userID, NextAddressNo: 1}
+}
+
+// Add 新增地址（第一个地址自动成为默认地址）
+func (b *AddressBook) Add(label AddressLabel, delivery DeliveryInfo, makeDefault bool, now time.Time) (*SavedAddress, error) {
+	if len(b.Addresses) >= MaxSavedAddresses {
+		return nil, ErrAddressBookFull
+	}
+
+	b.Addresses = append(b.Addresses, SavedAddress{
+		AddressID: fmt.Sprintf("A%d", b.NextAddressNo),
+		Label:     label,
+		Delivery:  delivery,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	b.NextAddressNo++
+	address := &b.Addresses[len(b.Addresses)-1]
+	if makeDefault || len(b.Addresses) == 1 {
+		b.setDefault(address.AddressID)
+	}
+	b.UpdatedAt = now
+	return address, nil
+}
+
+// Update 修改地址（makeDefault 为 false 时不改变默认地址）
+func (b *AddressBook) Update(addressID string, label AddressLabel, delivery DeliveryInfo, makeDefault bool, now time.Time) (*SavedAddress, error) {
+	address, err := b.Find(addressID)
+	if err != nil {
+		return nil, err
+	}
+	address.Label = label
+	address.Delivery = delivery
+	address.UpdatedAt = now
+	if makeDefault {
+		b.setDefault(addressID)
+	}
+	b.UpdatedAt = now
+	return address, nil
+}
+
+// SetDefault 设为默认地址
+func (b *AddressBook) SetDefault(addressID string, now time.Time) (*SavedAddress, error) {
+	address, err := b.Find(addressID)
+	if err != nil {
+		return nil, err
+	}
+	b.setDefault(addressID)
+	b.UpdatedAt = now
+	return address, nil
+}
+
+// Remove 删除地址（删除默认地址时最早添加的地址成为默认地址）
+func (b *AddressBook) Remove(addressID string, now time.Time) error {
+	address, err := b.Find(addressID)
+	if err != nil {
+		return err
+	}
+	wasDefault := address.IsDefault
+	b.Addresses = slices.DeleteFunc(b.Addresses, func(a SavedAddress) bool { return a.AddressID == addressID })
+	if wasDefault && len(b.Addresses) > 0 {
+		b.Addresses[0].IsDefault = true
+	}
+	b.UpdatedAt = now
+	return nil
+}
+
+// Find 根据地址ID查找地址
+func (b *AddressBook) Find(addressID string) (*SavedAddress, error) {
+	for i := range b.Addresses {
+		if b.Addresses[i].AddressID == addressID {
+			return &b.Addresses[i], nil
+		}
+	}
+	return nil, NewDomainError(ErrAddressNotFound.Code, fmt.Sprintf("address %s not found", addressID))
+}
+
+// setDefault 将指定地址设为唯一的默认地址
+func (b *AddressBook) setDefault(addressID string) {
+	for i := range b.Addresses {
+		b.Addresses[i].IsDefault = b.Addresses[i].AddressID == addressID
+	}
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDelivery 创建配送信息
+func newTestDelivery(address string) DeliveryInfo {
+	return DeliveryInfo{RecipientName: "张三", RecipientPhone: "13800138000", Address: address}
+}
+
+// defaultAddressIDs 返回地址簿中的默认地址ID
+func defaultAddressIDs(book *AddressBook) []string {
+	var ids []string
+	for _, address := range book.Addresses {
+		if address.IsDefault {
+			ids = append(ids, address.AddressID)
+		}
+	}
+	return ids
+}
+
+func TestAddressBook_AddAndSetDefault(t *testing.T) {
+	// Arrange
+	book := NewAddressBook(1001)
+	now := time.Date(2025, 6, 3, 11, 0, 0, 0, time.UTC)
+
+	// Act
+	home, err := book.Add(AddressLabelHome, newTestDelivery("家"), false, now)
+	require.NoError(t, err)
+	office, err := book.Add(AddressLabelOffice, newTestDelivery("公司"), false, now)
+	require.NoError(t, err)
+	afterAdd := defaultAddressIDs(book)
+	_, err = book.Add(AddressLabelOther, newTestDelivery("父母家"), true, now)
+	require.NoError(t, err)
+	afterDefaultAdd := defaultAddressIDs(book)
+	_, err = book.SetDefault(office.AddressID, now)
+	require.NoError(t, err)
+
+	// Assert - 第一个地址自动成为默认地址，任何时候只有一个默认地址
+	assert.Equal(t, "A1", home.AddressID)
+	assert.Equal(t, []string{"A1"}, afterAdd)
+	assert.Equal(t, []string{"A3"}, afterDefaultAdd)
+	assert.Equal(t, []string{"A2"}, defaultAddressIDs(book))
+	assert.Equal(t, now, book.UpdatedAt)
+}
+
+func TestAddressBook_Add_Full(t *testing.T) {
+	// Arrange
+	book := NewAddressBook(1001)
+	for i := range MaxSavedAddresses {
+		_, err := book.Add(AddressLabelOther, newTestDelivery(fmt.Sprintf("地址%d", i)), false, time.Now())
+		require.NoError(t, err)
+	}
+
+	// Act
+	address, err := book.Add(AddressLabelOther, newTestDelivery("多一个"), false, time.Now())
+
+	// Assert
+	assert.Nil(t, address)
+	assert.ErrorIs(t, err, ErrAddressBookFull)
+}
+
+func TestAddressBook_RemoveDefault(t *testing.T) {
+	// Arrange
+	book := NewAddressBook(1001)
+	for _, address := range []string{"家", "公司", "父母家"} {
+		_, err := book.Add(AddressLabelOther, newTestDelivery(address), false, time.Now())
+		require.NoError(t, err)
+	}
+	_, err := book.SetDefault("A3", time.Now())
+	require.NoError(t, err)
+
+	// Act
+	removeErr := book.Remove("A3", time.Now())
+	removeAgain := book.Remove("A3", time.Now())
+
+	// Assert - 删除默认地址后最早添加的地址成为默认地址，地址ID不复用
+	require.NoError(t, removeErr)
+	assert.ErrorIs(t, removeAgain, ErrAddressNotFound)
+	assert.Equal(t, []string{"A1"}, defaultAddressIDs(book))
+	address, err := book.Add(AddressLabelOther, newTestDelivery("新家"), false, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "A4", address.AddressID)
+}
+
+func TestAddressBook_Update(t *testing.T) {
+	// Arrange
+	book := NewAddressBook(1001)
+	created := time.Date(2025, 6, 3, 11, 0, 0, 0, time.UTC)
+	_, err := book.Add(AddressLabelHome, newTestDelivery("家"), false, created)
+	require.NoError(t, err)
+	_, err = book.Add(AddressLabelOffice, newTestDelivery("公司"), false, created)
+	require.NoError(t, err)
+
+	// Act
+	updated, err := book.Update("A2", AddressLabelOffice, newTestDelivery("新公司"), false, created.Add(time.Hour))
+	require.NoError(t, err)
+	_, missingErr := book.Update("A9", AddressLabelHome, newTestDelivery("家"), false, created)
+
+	// Assert - 未要求设为默认时不改变默认地址
+	assert.Equal(t, "新公司", updated.Delivery.Address)
+	assert.Equal(t, created, updated.CreatedAt)
+	assert.Equal(t, created.Add(time.Hour), updated.UpdatedAt)
+	assert.Equal(t, []string{"A1"}, defaultAddressIDs(book))
+	assert.ErrorIs(t, missingErr, ErrAddressNotFound)
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxCartItems 购物车最多的订单项行数
+const MaxCartItems = 50
+
+// Cart 购物车聚合根（每个用户在每个商家下一个购物车）
+// 购物车只保存用户的选择，价格在预览和结算时按商家菜单重新计算；ExpiresAt 之后购物车失效
+type Cart struct {
+	UserID     uint64
+	MerchantID string
+	Items      []CartItem
+	NextLineNo int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	ExpiresAt  time.Time
+}
+
+// CartItem 购物车中的一行（字段与下单的订单项一致，LineID 在购物车内唯一）
+type CartItem struct {
+	LineID     string
+	DishID     string
+	DishName   string
+	Quantity   int
+	Price      decimal.Decimal
+	Options    []OptionSelection
+	Components []ComboSelection
+}
+
+// NewCart 创建空购物车
+func NewCart(userID uint64, merchantID string, now time.Time, ttl time.Duration) *Cart {
+	return &Cart{
+		UserID:     userID,
+		MerchantID: merchantID,
+		NextLineNo: 1,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		ExpiresAt:  now.Add(ttl),
+	}
+}
+
+// IsExpired 购物车是否已过期
+func (c *Cart) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// Touch 记录修改时间并顺延过期时间
+func (c *Cart) Touch(now time.Time, ttl time.Duration) {
+	c.UpdatedAt = now
+	c.ExpiresAt = now.Add(ttl)
+}
+
+// AddItem 加入购物车：与已有行的餐品、价格和所选规格/组件都相同时合并数量，否则新增一行
+func (c *Cart) AddItem(item CartItem) (*CartItem, error) {
+	for i := range c.Items {
+		if c.Items[i].sameSelection(item) {
+			c.Items[i].Quantity += item.Quantity
+			return &c.Items[i], nil
+		}
+	}
+	if len(c.Items) >= MaxCartItems {
+		return nil, ErrCartFull
+	}
+
+	item.LineID = fmt.Sprintf("L%d", c.NextLineNo)
+	c.NextLineNo++
+	c.Items = append(c.Items, item)
+	return &c.Items[len(c.Items)-1], nil
+}
+
+// UpdateItemQuantity 修改购物车行的数量
+func (c *Cart) UpdateItemQuantity(lineID string, quantity int) error {
+	item, ok := c.findItem(lineID)
+	if !ok {
+		return cartItemNotFound(lineID)
+	}
+	item.Quantity = quantity
+	return nil
+}
+
+// RemoveItem 移除购物车行
+func (c *Cart) RemoveItem(lineID string) error {
+	if _, ok := c.findItem(lineID); !ok {
+		return cartItemNotFound(lineID)
+	}
+	c.Items = slices.DeleteFunc(c.Items, func(item CartItem) bool { return item.LineID == lineID })
+	return nil
+}
+
+// findItem 根据行ID查找购物车行
+func (c *Cart) findItem(lineID string) (*CartItem, bool) {
+	for i := range c.Items {
+		if c.Items[i].LineID == lineID {
+			return &c.Items[i], true
+		}
+	}
+	return nil, false
+}
+
+// sameSelection 两行是否为同一餐品的相同选择（不比较数量和行ID）
+func (i CartItem) sameSelection(other CartItem) bool {
+	return i.DishID == other.DishID &&
+		i.DishName == other.DishName &&
+		i.Price.Equal(other.Price) &&
+		slices.Equal(i.Options, other.Options) &&
+		slices.Equal(i.Components, other.Components)
+}
+
+// cartItemNotFound 购物车行不存在错误
+func cartItemNotFound(lineID string) error {
+	return NewDomainError(ErrCartItemNotFound.Code, fmt.Sprintf("cart item %s not found", lineID))
+}
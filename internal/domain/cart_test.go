@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noodleCartItem 选择了指定份量的牛肉面购物车行
+func noodleCartItem(size string, quantity int) CartItem {
+	return CartItem{
+		DishID:   "dish_101",
+		DishName: "牛肉面",
+		Quantity: quantity,
+		Price:    decimal.NewFromInt(22),
+		Options:  []OptionSelection{{GroupID: "size", OptionID: size}},
+	}
+}
+
+func TestCart_AddItem_MergesSameSelection(t *testing.T) {
+	// Arrange
+	cart := NewCart(1001, "merchant_001", time.Now(), time.Hour)
+
+	// Act
+	first, err := cart.AddItem(noodleCartItem("regular", 1))
+	require.NoError(t, err)
+	_, err = cart.AddItem(noodleCartItem("regular", 2))
+	require.NoError(t, err)
+	large, err := cart.AddItem(noodleCartItem("large", 1))
+	require.NoError(t, err)
+
+	// Assert - 规格不同的同一餐品为不同行
+	require.Len(t, cart.Items, 2)
+	assert.Equal(t, "L1", first.LineID)
+	assert.Equal(t, 3, cart.Items[0].Quantity)
+	assert.Equal(t, "L2", large.LineID)
+}
+
+func TestCart_AddItem_Full(t *testing.T) {
+	// Arrange
+	cart := NewCart(1001, "merchant_001", time.Now(), time.Hour)
+	for i := 0; i < MaxCartItems; i++ {
+		_, err := cart.AddItem(CartItem{DishID: "dish_" + string(rune('A'+i)), DishName: "餐品", Quantity: 1, Price: decimal.NewFromInt(1)})
+		require.NoError(t, err)
+	}
+
+	// Act
+	_, err := cart.AddItem(noodleCartItem("regular", 1))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrCartFull)
+}
+
+func TestCart_UpdateAndRemoveItem(t *testing.T) {
+	// Arrange
+	cart := NewCart(1001, "merchant_001", time.Now(), time.Hour)
+	_, _ = cart.AddItem(noodleCartItem("regular", 1))
+	_, _ = cart.AddItem(noodleCartItem("large", 1))
+
+	// Act
+	updateErr := cart.UpdateItemQuantity("L2", 4)
+	removeErr := cart.RemoveItem("L1")
+	missingErr := cart.RemoveItem("L1")
+	added, _ := cart.AddItem(noodleCartItem("regular", 1))
+
+	// Assert - 行ID删除后不复用
+	require.NoError(t, updateErr)
+	require.NoError(t, removeErr)
+	assert.ErrorIs(t, missingErr, ErrCartItemNotFound)
+	assert.Equal(t, 4, cart.Items[0].Quantity)
+	assert.Equal(t, "L3", added.LineID)
+}
+
+func TestCart_TouchExtendsExpiry(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cart := NewCart(1001, "merchant_001", now, time.Hour)
+
+	// Act
+	cart.Touch(now.Add(30*time.Minute), time.Hour)
+
+	// Assert
+	assert.False(t, cart.IsExpired(now.Add(time.Hour)))
+	assert.True(t, cart.IsExpired(now.Add(90*time.Minute)))
+}
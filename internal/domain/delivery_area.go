@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// earthRadiusMeters 地球平均半径（米）
+const earthRadiusMeters = 6371008.8
+
+// GeoPoint 地理坐标值对象（WGS-84 经纬度，单位为度）
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// DistanceTo 按 haversine 公式计算到另一点的球面距离（米）
+func (p GeoPoint) DistanceTo(other GeoPoint) float64 {
+	lat1, lat2 := radians(p.Latitude), radians(other.Latitude)
+	dLat := lat2 - lat1
+	dLng := radians(other.Longitude - p.Longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// radians 角度转弧度
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// DeliveryZone 配送区域（多边形顶点按顺序排列，首尾自动闭合）
+type DeliveryZone struct {
+	Name    string
+	Polygon []GeoPoint
+}
+
+// Contains 射线法判断坐标是否在区域内
+// 配送区域范围较小，按经纬度平面近似计算；不支持跨 180 度经线的区域
+func (z DeliveryZone) Contains(point GeoPoint) bool {
+	inside := false
+	for i, j := 0, len(z.Polygon)-1; i < len(z.Polygon); j, i = i, i+1 {
+		a, b := z.Polygon[i], z.Polygon[j]
+		if (a.Latitude > point.Latitude) != (b.Latitude > point.Latitude) {
+			crossing := (b.Longitude-a.Longitude)*(point.Latitude-a.Latitude)/(b.Latitude-a.Latitude) + a.Longitude
+			if point.Longitude < crossing {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// DeliveryFeeBand 距离档位（配送距离不超过 MaxDistance 米时收取 Fee）
+type DeliveryFeeBand struct {
+	MaxDistance int
+	Fee         decimal.Decimal
+}
+
+// DeliveryQuote 配送报价（Distance 为出餐地点到收货地址的直线距离，单位米）
+type DeliveryQuote struct {
+	Zone     string
+	Distance int
+	Fee      decimal.Decimal
+}
+
+// DeliveryArea 商家配送范围（聚合根）
+// 收货坐标须落在任一配送区域内，配送费按出餐地点到收货坐标的直线距离所在档位计算
+type DeliveryArea struct {
+	MerchantID string
+	Origin     GeoPoint          // 出餐地点
+	Zones      []DeliveryZone    // 为空表示只按距离档位限制配送范围
+	FeeBands   []DeliveryFeeBand // 按 MaxDistance 升序排列，超出最后一档不配送
+	UpdatedAt  time.Time
+}
+
+// Quote 计算配送到 destination 的配送费
+func (a *DeliveryArea) Quote(destination GeoPoint) (DeliveryQuote, error) {
+	var quote DeliveryQuote
+	if len(a.Zones) > 0 {
+		zone, ok := a.zoneOf(destination)
+		if !ok {
+			return DeliveryQuote{}, ErrOutOfDeliveryZone
+		}
+		quote.Zone = zone.Name
+	}
+
+	quote.Distance = int(math.Round(a.Origin.DistanceTo(destination)))
+	for _, band := range a.FeeBands {
+		if quote.Distance <= band.MaxDistance {
+			quote.Fee = band.Fee
+			return quote, nil
+		}
+	}
+	return DeliveryQuote{}, ErrBeyondDeliveryDistance
+}
+
+// zoneOf 查找包含坐标的第一个配送区域
+func (a *DeliveryArea) zoneOf(point GeoPoint) (DeliveryZone, bool) {
+	for _, zone := range a.Zones {
+		if zone.Contains(point) {
+			return zone, true
+		}
+	}
+	return DeliveryZone{}, false
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNotchedDeliveryArea 出餐地点在 (39.90, 116.40)，配送区域为东北角缺一块的矩形，三个距离档位
+func newNotchedDeliveryArea() *DeliveryArea {
+	return &DeliveryArea{
+		MerchantID: "merchant_001",
+		Origin:     GeoPoint{Latitude: 39.90, Longitude: 116.40},
+		Zones: []DeliveryZone{{Name: "东城", Polygon: []GeoPoint{
+			{Latitude: 39.88, Longitude: 116.37},
+			{Latitude: 39.88, Longitude: 116.43},
+			{Latitude: 39.91, Longitude: 116.43},
+			{Latitude: 39.91, Longitude: 116.40},
+			{Latitude: 39.94, Longitude: 116.40},
+			{Latitude: 39.94, Longitude: 116.37},
+		}}},
+		FeeBands: []DeliveryFeeBand{
+			{MaxDistance: 1000, Fee: decimal.NewFromInt(3)},
+			{MaxDistance: 3000, Fee: decimal.NewFromInt(5)},
+			{MaxDistance: 5000, Fee: decimal.NewFromInt(8)},
+		},
+	}
+}
+
+func TestGeoPoint_DistanceTo(t *testing.T) {
+	// Arrange
+	origin := GeoPoint{Latitude: 39.90, Longitude: 116.40}
+	north := GeoPoint{Latitude: 40.90, Longitude: 116.40}
+	east := GeoPoint{Latitude: 0, Longitude: 1}
+
+	// Act & Assert - 经线上 1 度和赤道上 1 度约 111.195 公里，距离与方向无关
+	assert.InDelta(t, 111195, origin.DistanceTo(north), 1)
+	assert.InDelta(t, 111195, GeoPoint{}.DistanceTo(east), 1)
+	assert.Equal(t, origin.DistanceTo(north), north.DistanceTo(origin))
+	assert.Zero(t, origin.DistanceTo(origin))
+}
+
+func TestDeliveryZone_Contains(t *testing.T) {
+	zone := newNotchedDeliveryArea().Zones[0]
+
+	tests := []struct {
+		name  string
+		point GeoPoint
+		want  bool
+	}{
+		{"南侧区域内", GeoPoint{Latitude: 39.895, Longitude: 116.42}, true},
+		{"西北侧区域内", GeoPoint{Latitude: 39.93, Longitude: 116.38}, true},
+		{"东北角缺口", GeoPoint{Latitude: 39.925, Longitude: 116.42}, false},
+		{"区域以南", GeoPoint{Latitude: 39.87, Longitude: 116.40}, false},
+		{"区域以西", GeoPoint{Latitude: 39.90, Longitude: 116.36}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, zone.Contains(tt.point))
+		})
+	}
+}
+
+func TestDeliveryArea_Quote(t *testing.T) {
+	tests := []struct {
+		name     string
+		noZones  bool
+		point    GeoPoint
+		wantFee  int64
+		wantZone string
+		wantErr  error
+	}{
+		{name: "第一档", point: GeoPoint{Latitude: 39.905, Longitude: 116.40}, wantFee: 3, wantZone: "东城"},
+		{name: "第二档", point: GeoPoint{Latitude: 39.885, Longitude: 116.42}, wantFee: 5, wantZone: "东城"},
+		{name: "第三档", point: GeoPoint{Latitude: 39.93, Longitude: 116.38}, wantFee: 8, wantZone: "东城"},
+		{name: "不在配送区域内", point: GeoPoint{Latitude: 39.925, Longitude: 116.42}, wantErr: ErrOutOfDeliveryZone},
+		{name: "未配置区域时只按距离限制", noZones: true, point: GeoPoint{Latitude: 39.925, Longitude: 116.42}, wantFee: 8},
+		{name: "超出最远档位", noZones: true, point: GeoPoint{Latitude: 39.96, Longitude: 116.40}, wantErr: ErrBeyondDeliveryDistance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			area := newNotchedDeliveryArea()
+			if tt.noZones {
+				area.Zones = nil
+			}
+
+			// Act
+			quote, err := area.Quote(tt.point)
+
+			// Assert
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, decimal.NewFromInt(tt.wantFee).Equal(quote.Fee), "fee %s", quote.Fee)
+			assert.Equal(t, tt.wantZone, quote.Zone)
+			assert.InDelta(t, area.Origin.DistanceTo(tt.point), quote.Distance, 1)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package domain
+
+import "fmt"
+
+// DomainError 领域错误（携带业务错误码，供上层映射）
+type DomainError struct {
+	Code    string
+	Message string
+}
+
+func (e *DomainError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Is 按错误码判断是否为同一类领域错误
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	return ok && t.Code == e.Code
+}
+
+// NewDomainError 创建领域错误
+func NewDomainError(code, message string) *DomainError {
+	return &DomainError{
+		Code:    code,
+		Message: message,
+	}
+}
+
+// 订单相关领域错误
+var (
+	ErrInvalidOrderStatus     = NewDomainError("INVALID_ORDER_STATUS", "operation not allowed in current order status")
+	ErrOrderNotPaid           = NewDomainError("ORDER_NOT_PAID", "order is not paid")
+	ErrPaymentAmountMismatch  = NewDomainError("PAYMENT_AMOUNT_MISMATCH", "paid amount does not match order amount")
+	ErrRefundItemNotFound     = NewDomainError("REFUND_ITEM_NOT_FOUND", "refund item not found in order")
+	ErrRefundQuantityExceeded = NewDomainError("REFUND_QUANTITY_EXCEEDED", "refund quantity exceeds refundable quantity")
+	ErrRefundAmountExceeded   = NewDomainError("REFUND_AMOUNT_EXCEEDED", "total refunds exceed order final amount")
+	ErrNothingToRefund        = NewDomainError("NOTHING_TO_REFUND", "order has nothing left to refund")
+	ErrRefundNotFound         = NewDomainError("REFUND_NOT_FOUND", "refund not found")
+	ErrInvalidRefundStatus    = NewDomainError("INVALID_REFUND_STATUS", "operation not allowed in current refund status")
+	ErrOrderConflict          = NewDomainError("ORDER_CONFLICT", "order was modified concurrently, please retry")
+)
+
+// 购物车相关领域错误
+var (
+	ErrCartItemNotFound = NewDomainError("CART_ITEM_NOT_FOUND", "cart item not found")
+	ErrCartFull         = NewDomainError("CART_FULL", "cart has reached the maximum number of items")
+	ErrCartEmpty        = NewDomainError("CART_EMPTY", "cart is empty")
+)
+
+// 地址簿相关领域错误
+var (
+	ErrAddressNotFound = NewDomainError("ADDRESS_NOT_FOUND", "address not found")
+	ErrAddressBookFull = NewDomainError("ADDRESS_BOOK_FULL", "address book has reached the maximum number of addresses")
+)
+
+// 库存相关领域错误
+var (
+	ErrDishSoldOut = NewDomainError("DISH_SOLD_OUT", "dish is sold out")
+)
+
+// 报价相关领域错误
+var (
+	ErrInvalidQuote      = NewDomainError("INVALID_QUOTE", "quote token is invalid")
+	ErrQuoteExpired      = NewDomainError("QUOTE_EXPIRED", "quote has expired")
+	ErrQuoteMismatch     = NewDomainError("QUOTE_MISMATCH", "order does not match quote")
+	ErrQuotePriceChanged = NewDomainError("QUOTE_PRICE_CHANGED", "order price has changed since quote")
+)
+
+// 商家营业相关领域错误
+var (
+	ErrMerchantClosed      = NewDomainError("MERCHANT_CLOSED", "merchant is outside opening hours")
+	ErrMerchantHoliday     = NewDomainError("MERCHANT_HOLIDAY", "merchant is closed for holiday")
+	ErrMerchantPaused      = NewDomainError("MERCHANT_PAUSED", "merchant is temporarily not accepting orders")
+	ErrBelowMinOrderAmount = NewDomainError("BELOW_MIN_ORDER_AMOUNT", "order amount is below merchant minimum")
+	ErrMerchantAtCapacity  = NewDomainError("MERCHANT_AT_CAPACITY", "merchant has reached maximum concurrent orders")
+	ErrSlotUnavailable     = NewDomainError("SLOT_UNAVAILABLE", "delivery slot is not available")
+	ErrSlotFull            = NewDomainError("SLOT_FULL", "delivery slot is fully booked")
+)
+
+// 配送范围相关领域错误
+var (
+	ErrOutOfDeliveryZone      = NewDomainError("OUT_OF_DELIVERY_ZONE", "delivery address is outside merchant delivery zones")
+	ErrBeyondDeliveryDistance = NewDomainError("BEYOND_DELIVERY_DISTANCE", "delivery address exceeds merchant maximum delivery distance")
+)
+
+// 菜单规格和套餐相关领域错误
+var (
+	ErrDishNotInMenu          = NewDomainError("DISH_NOT_IN_MENU", "dish is not in merchant menu")
+	ErrUnknownDishOption      = NewDomainError("UNKNOWN_DISH_OPTION", "option does not exist for dish")
+	ErrInvalidOptionSelection = NewDomainError("INVALID_OPTION_SELECTION", "option selection violates option group rules")
+	ErrComboNotInMenu         = NewDomainError("COMBO_NOT_IN_MENU", "combo is not in merchant menu")
+	ErrInvalidComboSelection  = NewDomainError("INVALID_COMBO_SELECTION", "combo component selection is invalid")
+)
@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+)
+
+// Event 领域事件
+type Event interface {
+	EventID() string
+	EventType() string
+	SchemaVersion() int
+	AggregateID() string
+	MerchantID() string
+	OccurredAt() time.Time
+}
+
+// 领域事件类型
+const (
+	EventTypeOrderCreated    = "order.created"
+	EventTypeOrderPaid       = "order.paid"
+	EventTypeOrderCancelled  = "order.cancelled"
+	EventTypeOrderAccepted   = "order.accepted"
+	EventTypeOrderReleased   = "order.released"
+	EventTypeOrderRejected   = "order.rejected"
+	EventTypeRefundRequested = "order.refund_requested"
+	EventTypeRefundCompleted = "order.refund_completed"
+	EventTypeRefundFailed    = "order.refund_failed"
+)
+
+// EventTypes 全部领域事件类型
+var EventTypes = []string{
+	EventTypeOrderCreated,
+	EventTypeOrderPaid,
+	EventTypeOrderCancelled,
+	EventTypeOrderAccepted,
+	EventTypeOrderRejected,
+	EventTypeOrderReleased,
+	EventTypeRefundRequested,
+	EventTypeRefundCompleted,
+	EventTypeRefundFailed,
+}
+
+// EventMetadata 领域事件公共元数据
+type EventMetadata struct {
+	ID          string    `json:"eventId"`
+	Type        string    `json:"eventType"`
+	Version     int       `json:"schemaVersion"`
+	OrderNumber string    `json:"orderNumber"`
+	Merchant    string    `json:"merchantId"`
+	Timestamp   time.Time `json:"occurredAt"`
+}
+
+func (m EventMetadata) EventID() string       { return m.ID }
+func (m EventMetadata) EventType() string     { return m.Type }
+func (m EventMetadata) SchemaVersion() int    { return m.Version }
+func (m EventMetadata) AggregateID() string   { return m.OrderNumber }
+func (m EventMetadata) MerchantID() string    { return m.Merchant }
+func (m EventMetadata) OccurredAt() time.Time { return m.Timestamp }
+
+// newEventMetadata 创建事件元数据（事件ID为随机 UUID v4）
+func newEventMetadata(eventType string, version int, order *Order, occurredAt time.Time) EventMetadata {
+	return EventMetadata{
+		ID:          newEventID(),
+		Type:        eventType,
+		Version:     version,
+		OrderNumber: order.OrderNumber,
+		Merchant:    order.MerchantID,
+		Timestamp:   occurredAt,
+	}
+}
+
+// OrderCreated 订单已创建事件（schema v1）
+type OrderCreated struct {
+	EventMetadata
+	UserID       uint64           `json:"userId"`
+	Status       OrderStatus      `json:"status"`
+	Items        []EventOrderItem `json:"items"`
+	ItemsTotal   string           `json:"itemsTotal"`
+	PackagingFee string           `json:"packagingFee"`
+	DeliveryFee  string           `json:"deliveryFee"`
+	FinalAmount  string           `json:"finalAmount"`
+	ScheduledFor *time.Time       `json:"scheduledFor,omitempty"`
+}
+
+// EventOrderItem 事件中的订单项快照（Price 为基础单价，UnitPrice 含规格加价；新增字段向后兼容）
+type EventOrderItem struct {
+	DishID     string               `json:"dishId"`
+	DishName   string               `json:"dishName"`
+	Quantity   int                  `json:"quantity"`
+	Price      string               `json:"price"`
+	UnitPrice  string               `json:"unitPrice"`
+	Options    []EventItemOption    `json:"options,omitempty"`
+	Components []EventItemComponent `json:"components,omitempty"`
+}
+
+// EventItemOption 事件中的已选规格快照
+type EventItemOption struct {
+	GroupID    string `json:"groupId"`
+	GroupName  string `json:"groupName"`
+	OptionID   string `json:"optionId"`
+	OptionName string `json:"optionName"`
+	PriceDelta string `json:"priceDelta"`
+}
+
+// EventItemComponent 事件中的套餐组件快照（allocatedPrice 为每份套餐分摊到该组件的金额）
+type EventItemComponent struct {
+	SlotID         string `json:"slotId"`
+	SlotName       string `json:"slotName"`
+	DishID         string `json:"dishId"`
+	DishName       string `json:"dishName"`
+	Quantity       int    `json:"quantity"`
+	Surcharge      string `json:"surcharge"`
+	AllocatedPrice string `json:"allocatedPrice"`
+}
+
+// OrderPaid 订单已支付事件（schema v1）
+type OrderPaid struct {
+	EventMetadata
+	UserID       uint64      `json:"userId"`
+	Status       OrderStatus `json:"status"`
+	PaymentID    string      `json:"paymentId"`
+	Amount       string      `json:"amount"`
+	ScheduledFor *time.Time  `json:"scheduledFor,omitempty"`
+}
+
+// OrderCancelled 订单已取消事件（schema v1）
+type OrderCancelled struct {
+	EventMetadata
+	UserID         uint64      `json:"userId"`
+	Status         OrderStatus `json:"status"`
+	PreviousStatus OrderStatus `json:"previousStatus"`
+	Reason         string      `json:"reason"`
+}
+
+// OrderAccepted 商家已接单事件（schema v1）
+type OrderAccepted struct {
+	EventMetadata
+	UserID uint64      `json:"userId"`
+	Status OrderStatus `json:"status"`
+}
+
+// OrderRejected 商家已拒单事件（schema v1）
+type OrderRejected struct {
+	EventMetadata
+	UserID uint64      `json:"userId"`
+	Status OrderStatus `json:"status"`
+	Reason string      `json:"reason"`
+}
+
+// OrderReleased 预订单已推送给商家备餐事件（schema v1，在配送时段开始前按提前量发出）
+type OrderReleased struct {
+	EventMetadata
+	UserID       uint64           `json:"userId"`
+	Status       OrderStatus      `json:"status"`
+	Items        []EventOrderItem `json:"items"`
+	FinalAmount  string           `json:"finalAmount"`
+	ScheduledFor time.Time        `json:"scheduledFor"`
+}
+
+// RefundRequested 退款已申请事件（schema v1）
+type RefundRequested struct {
+	EventMetadata
+	UserID   uint64 `json:"userId"`
+	RefundID string `json:"refundId"`
+	Amount   string `json:"amount"`
+	Reason   string `json:"reason"`
+}
+
+// RefundCompleted 退款已完成事件（schema v1）
+type RefundCompleted struct {
+	EventMetadata
+	UserID   uint64      `json:"userId"`
+	Status   OrderStatus `json:"status"`
+	RefundID string      `json:"refundId"`
+	Amount   string      `json:"amount"`
+}
+
+// RefundFailed 退款失败事件（schema v1）
+type RefundFailed struct {
+	EventMetadata
+	UserID   uint64 `json:"userId"`
+	RefundID string `json:"refundId"`
+	Amount   string `json:"amount"`
+	Reason   string `json:"reason"`
+}
+
+// recordEvent 记录领域事件（仓储保存订单时写入 outbox）
+func (o *Order) recordEvent(event Event) {
+	o.events = append(o.events, event)
+}
+
+// PendingEvents 返回尚未发布的领域事件
+func (o *Order) PendingEvents() []Event {
+	return o.events
+}
+
+// PullEvents 取出并清空尚未发布的领域事件
+func (o *Order) PullEvents() []Event {
+	events := o.events
+	o.events = nil
+	return events
+}
+
+// newEventID 生成 UUID v4 格式的事件ID
+func newEventID() string {
+	return newUUID()
+}
+
+// newUUID 生成 UUID v4 字符串
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOrder_RecordsOrderCreatedEvent(t *testing.T) {
+	// Arrange
+	items := []OrderItem{
+		{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 2, Price: decimal.NewFromFloat(28.00)},
+	}
+
+	// Act
+	order := NewOrder(1001, "merchant_001", items, DeliveryInfo{}, "")
+	events := order.PendingEvents()
+
+	// Assert
+	require.Len(t, events, 1)
+	created, ok := events[0].(OrderCreated)
+	require.True(t, ok)
+	assert.Equal(t, EventTypeOrderCreated, created.EventType())
+	assert.Equal(t, 1, created.SchemaVersion())
+	assert.Equal(t, order.OrderNumber, created.AggregateID())
+	assert.Equal(t, order.CreatedAt, created.OccurredAt())
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, created.EventID())
+	assert.Equal(t, "60.00", created.FinalAmount)
+	assert.Len(t, created.Items, 1)
+}
+
+func TestOrder_PullEvents_ClearsPendingEvents(t *testing.T) {
+	order := NewOrder(1001, "merchant_001", nil, DeliveryInfo{}, "")
+
+	assert.Len(t, order.PullEvents(), 1)
+	assert.Empty(t, order.PullEvents())
+}
+
+func TestOrder_StateChangesRecordEvents(t *testing.T) {
+	// Arrange
+	order := newPaidOrder(t)
+	refund, err := order.RequestRefund(nil, "")
+	require.NoError(t, err)
+	require.NoError(t, order.CompleteRefund(refund.RefundID))
+
+	// Act
+	var types []string
+	for _, event := range order.PullEvents() {
+		types = append(types, event.EventType())
+	}
+
+	// Assert
+	assert.Equal(t, []string{
+		EventTypeOrderCreated,
+		EventTypeOrderPaid,
+		EventTypeRefundRequested,
+		EventTypeRefundCompleted,
+	}, types)
+}
+
+func TestOrder_Cancel(t *testing.T) {
+	// Arrange
+	order := NewOrder(1001, "merchant_001", nil, DeliveryInfo{}, "")
+	order.PullEvents()
+
+	// Act
+	err := order.Cancel("不想要了")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, OrderStatusCancelled, order.Status)
+	events := order.PullEvents()
+	require.Len(t, events, 1)
+	cancelled := events[0].(OrderCancelled)
+	assert.Equal(t, OrderStatusPendingPayment, cancelled.PreviousStatus)
+	assert.Equal(t, "不想要了", cancelled.Reason)
+
+	// 已取消订单不能再次取消或支付
+	assert.ErrorIs(t, order.Cancel(""), ErrInvalidOrderStatus)
+	assert.ErrorIs(t, order.MarkPaid("pay_001"), ErrInvalidOrderStatus)
+}
+
+func TestOrderCreated_JSONSchema(t *testing.T) {
+	order := NewOrder(1001, "merchant_001", nil, DeliveryInfo{}, "")
+
+	payload, err := json.Marshal(order.PendingEvents()[0])
+	require.NoError(t, err)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(payload, &fields))
+	for _, key := range []string{"eventId", "eventType", "schemaVersion", "orderNumber", "occurredAt", "userId", "merchantId", "finalAmount"} {
+		assert.Contains(t, fields, key)
+	}
+}
+
+func TestOrder_Release(t *testing.T) {
+	// Arrange
+	slot := time.Date(2025, 6, 3, 12, 30, 0, 0, time.FixedZone("CST", 8*3600))
+	order := NewScheduledOrder(1001, "merchant_001", []OrderItem{
+		{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: decimal.NewFromFloat(28.00)},
+	}, DeliveryInfo{}, "", DefaultFees(), slot)
+	unpaidErr := order.Release(slot.Add(-30 * time.Minute))
+	require.NoError(t, order.MarkPaid("pay_001"))
+	activeBeforeRelease := order.Active()
+
+	// Act
+	err := order.Release(slot.Add(-30 * time.Minute))
+	againErr := order.Release(slot)
+
+	// Assert - 只推送一次已支付的预订单，推送后计入商家处理中的订单
+	require.NoError(t, err)
+	assert.ErrorIs(t, unpaidErr, ErrInvalidOrderStatus)
+	assert.ErrorIs(t, againErr, ErrInvalidOrderStatus)
+	assert.False(t, activeBeforeRelease)
+	assert.True(t, order.Active())
+	events := order.PullEvents()
+	require.Len(t, events, 3)
+	assert.Equal(t, slot, *events[0].(OrderCreated).ScheduledFor)
+	assert.Equal(t, slot, *events[1].(OrderPaid).ScheduledFor)
+	released := events[2].(OrderReleased)
+	assert.Equal(t, EventTypeOrderReleased, released.EventType())
+	assert.Equal(t, slot, released.ScheduledFor)
+	assert.Equal(t, "32.00", released.FinalAmount)
+	assert.Len(t, released.Items, 1)
+}
+
+func TestOrder_Release_RequiresScheduledOrder(t *testing.T) {
+	order := newPaidOrder(t)
+
+	assert.ErrorIs(t, order.Release(time.Now()), ErrInvalidOrderStatus)
+	assert.Nil(t, order.PullEvents()[0].(OrderCreated).ScheduledFor)
+}
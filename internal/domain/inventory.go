@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DishStock 餐品库存配置（套餐按套餐ID计库存）
+type DishStock struct {
+	DishID     string
+	Stock      *int // 剩余库存（未被订单占用的份数），nil 表示不限
+	DailyLimit int  // 每日（商家时区）限售份数，0 表示不限
+}
+
+// StockLine 订单项占用的库存（Item 为订单项在订单中的下标）
+type StockLine struct {
+	Item     int
+	DishID   string
+	DishName string
+	Quantity int
+}
+
+// StockReservation 订单占用的库存（Day 为计入每日限售的日期）
+type StockReservation struct {
+	Day   string
+	Lines []StockLine
+}
+
+// Inventory 商家餐品库存（聚合根）
+// 下单时按订单占用库存和当日限售份数，订单取消、支付超时或被拒单时释放，商家接单后占用转为已售出
+type Inventory struct {
+	MerchantID   string
+	Dishes       []DishStock                 // 按 DishID 升序排列，不在其中的餐品不限量
+	DailySold    map[string]map[string]int   // 日期（HolidayLayout 格式）-> 餐品ID -> 已售份数（含未释放的占用）
+	Reservations map[string]StockReservation // 订单号 -> 订单占用的库存
+	UpdatedAt    time.Time
+}
+
+// NewInventory 创建不限量的空库存
+func NewInventory(merchantID string) *Inventory {
+	return &Inventory{
+		MerchantID:   merchantID,
+		DailySold:    make(map[string]map[string]int),
+		Reservations: make(map[string]StockReservation),
+	}
+}
+
+// SetDishes 整体替换餐品库存配置（已售份数和订单占用保持不变）
+func (inv *Inventory) SetDishes(dishes []DishStock, now time.Time) {
+	inv.Dishes = slices.Clone(dishes)
+	slices.SortFunc(inv.Dishes, func(a, b DishStock) int { return strings.Compare(a.DishID, b.DishID) })
+	inv.UpdatedAt = now
+}
+
+// Dish 查询餐品库存配置
+func (inv *Inventory) Dish(dishID string) (*DishStock, bool) {
+	i, found := slices.BinarySearchFunc(inv.Dishes, dishID, func(d DishStock, id string) int { return strings.Compare(d.DishID, id) })
+	if !found {
+		return nil, false
+	}
+	return &inv.Dishes[i], true
+}
+
+// Sold 餐品在 day 的已售份数
+func (inv *Inventory) Sold(day, dishID string) int {
+	return inv.DailySold[day][dishID]
+}
+
+// Available 餐品在 day 还可下单的份数，不限量时返回 false
+func (inv *Inventory) Available(day, dishID string) (int, bool) {
+	dish, ok := inv.Dish(dishID)
+	if !ok || (dish.Stock == nil && dish.DailyLimit == 0) {
+		return 0, false
+	}
+	available := -1
+	if dish.Stock != nil {
+		available = *dish.Stock
+	}
+	if dish.DailyLimit > 0 {
+		remaining := max(dish.DailyLimit-inv.Sold(day, dishID), 0)
+		if available < 0 || remaining < available {
+			available = remaining
+		}
+	}
+	return available, true
+}
+
+// Check 校验订单项能否下单（同一餐品的多个订单项合计计算），不能下单时返回指明订单项的 ErrDishSoldOut
+func (inv *Inventory) Check(day string, lines []StockLine) error {
+	requested := make(map[string]int)
+	for _, line := range lines {
+		available, limited := inv.Available(day, line.DishID)
+		if !limited {
+			continue
+		}
+		requested[line.DishID] += line.Quantity
+		if requested[line.DishID] > available {
+			return NewDomainError(ErrDishSoldOut.Code, fmt.Sprintf("items[%d] %s (%s) is sold out: requested %d, available %d",
+				line.Item, line.DishID, line.DishName, line.Quantity, max(available-requested[line.DishID]+line.Quantity, 0)))
+		}
+	}
+	return nil
+}
+
+// Reserve 为订单占用库存并计入 day 的已售份数（全部订单项都能满足时才占用；同一订单重复占用视为成功）
+func (inv *Inventory) Reserve(orderNumber, day string, lines []StockLine, now time.Time) error {
+	if _, ok := inv.Reservations[orderNumber]; ok {
+		return nil
+	}
+	if err := inv.Check(day, lines); err != nil {
+		return err
+	}
+
+	var reserved []StockLine
+	for _, line := range lines {
+		if _, limited := inv.Available(day, line.DishID); !limited {
+			continue
+		}
+		reserved = append(reserved, line)
+	}
+	if len(reserved) == 0 {
+		return nil
+	}
+	for _, line := range reserved {
+		inv.adjust(day, line.DishID, line.Quantity)
+	}
+	inv.Reservations[orderNumber] = StockReservation{Day: day, Lines: reserved}
+	inv.UpdatedAt = now
+	return nil
+}
+
+// Release 释放订单占用的库存（订单没有占用时返回 false）
+func (inv *Inventory) Release(orderNumber string, now time.Time) bool {
+	reservation, ok := inv.Reservations[orderNumber]
+	if !ok {
+		return false
+	}
+	for _, line := range reservation.Lines {
+		inv.adjust(reservation.Day, line.DishID, -line.Quantity)
+	}
+	delete(inv.Reservations, orderNumber)
+	inv.UpdatedAt = now
+	return true
+}
+
+// Commit 订单占用转为已售出，之后不再释放（订单没有占用时返回 false）
+func (inv *Inventory) Commit(orderNumber string, now time.Time) bool {
+	if _, ok := inv.Reservations[orderNumber]; !ok {
+		return false
+	}
+	delete(inv.Reservations, orderNumber)
+	inv.UpdatedAt = now
+	return true
+}
+
+// PruneSold 清理 today 之前的已售份数（之后释放这些日期的占用时只恢复库存）
+func (inv *Inventory) PruneSold(today string) {
+	for day := range inv.DailySold {
+		if day < today {
+			delete(inv.DailySold, day)
+		}
+	}
+}
+
+// adjust 占用（quantity 为正）或释放（quantity 为负）餐品库存和 day 的已售份数
+// 商家修改配置后不再限量的餐品只记录已售份数
+func (inv *Inventory) adjust(day, dishID string, quantity int) {
+	if dish, ok := inv.Dish(dishID); ok && dish.Stock != nil {
+		stock := *dish.Stock - quantity
+		dish.Stock = &stock
+	}
+
+	sold := inv.DailySold[day]
+	if sold == nil {
+		if quantity < 0 {
+			return
+		}
+		sold = make(map[string]int)
+		inv.DailySold[day] = sold
+	}
+	sold[dishID] = max(sold[dishID]+quantity, 0)
+	if sold[dishID] == 0 {
+		delete(sold, dishID)
+	}
+	if len(sold) == 0 {
+		delete(inv.DailySold, day)
+	}
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestInventory 宫保鸡丁剩余 5 份，牛肉面每日限售 3 份
+func newTestInventory() *Inventory {
+	stock := 5
+	inv := NewInventory("merchant_001")
+	inv.SetDishes([]DishStock{
+		{DishID: "dish_101", DailyLimit: 3},
+		{DishID: "dish_001", Stock: &stock},
+	}, time.Now())
+	return inv
+}
+
+func TestInventory_ReserveAndRelease(t *testing.T) {
+	// Arrange
+	inv := newTestInventory()
+	now := time.Date(2025, 6, 3, 11, 0, 0, 0, time.UTC)
+	lines := []StockLine{
+		{Item: 0, DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 2},
+		{Item: 1, DishID: "dish_101", DishName: "牛肉面", Quantity: 3},
+		{Item: 2, DishID: "dish_002", DishName: "鱼香肉丝", Quantity: 10},
+	}
+
+	// Act
+	require.NoError(t, inv.Reserve("ORD001", "2025-06-03", lines, now))
+	require.NoError(t, inv.Reserve("ORD001", "2025-06-03", lines, now))
+	stockAfterReserve, _ := inv.Available("2025-06-03", "dish_001")
+	limitAfterReserve, _ := inv.Available("2025-06-03", "dish_101")
+	nextDay, _ := inv.Available("2025-06-04", "dish_101")
+	released := inv.Release("ORD001", now)
+	releasedAgain := inv.Release("ORD001", now)
+
+	// Assert - 不限量的餐品不占用库存，重复占用和释放只生效一次
+	assert.Equal(t, 3, stockAfterReserve)
+	assert.Equal(t, 0, limitAfterReserve)
+	assert.Equal(t, 3, nextDay)
+	assert.True(t, released)
+	assert.False(t, releasedAgain)
+	assert.Equal(t, 5, *inv.Dishes[0].Stock)
+	assert.Empty(t, inv.DailySold)
+	assert.Empty(t, inv.Reservations)
+}
+
+func TestInventory_Reserve_SoldOut(t *testing.T) {
+	// Arrange
+	inv := newTestInventory()
+	require.NoError(t, inv.Reserve("ORD001", "2025-06-03", []StockLine{{Item: 0, DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 4}}, time.Now()))
+
+	// Act - 同一餐品的两个订单项合计超出剩余库存
+	err := inv.Reserve("ORD002", "2025-06-03", []StockLine{
+		{Item: 0, DishID: "dish_101", DishName: "牛肉面", Quantity: 1},
+		{Item: 1, DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1},
+		{Item: 2, DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1},
+	}, time.Now())
+
+	// Assert - 错误指明无法满足的订单项，且不占用任何库存
+	assert.ErrorIs(t, err, ErrDishSoldOut)
+	assert.EqualError(t, err, "DISH_SOLD_OUT: items[2] dish_001 (宫保鸡丁) is sold out: requested 1, available 0")
+	assert.Equal(t, 0, inv.Sold("2025-06-03", "dish_101"))
+	assert.NotContains(t, inv.Reservations, "ORD002")
+}
+
+func TestInventory_CommitAndPruneSold(t *testing.T) {
+	// Arrange
+	inv := newTestInventory()
+	now := time.Now()
+	require.NoError(t, inv.Reserve("ORD001", "2025-06-03", []StockLine{{Item: 0, DishID: "dish_101", DishName: "牛肉面", Quantity: 2}}, now))
+	require.NoError(t, inv.Reserve("ORD002", "2025-06-03", []StockLine{{Item: 0, DishID: "dish_101", DishName: "牛肉面", Quantity: 1}}, now))
+
+	// Act
+	committed := inv.Commit("ORD001", now)
+	released := inv.Release("ORD001", now)
+	soldBeforePrune := inv.Sold("2025-06-03", "dish_101")
+	inv.PruneSold("2025-06-04")
+	inv.Release("ORD002", now)
+
+	// Assert - 已售出的占用不再释放，清理过的日期释放时不会出现负数
+	assert.True(t, committed)
+	assert.False(t, released)
+	assert.Equal(t, 3, soldBeforePrune)
+	assert.Empty(t, inv.DailySold)
+}
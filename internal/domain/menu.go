@@ -0,0 +1,254 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Dish 商家菜单中的餐品（下单时校验规格选项的依据）
+type Dish struct {
+	DishID       string
+	Name         string
+	Price        decimal.Decimal
+	OptionGroups []OptionGroup
+}
+
+// OptionGroup 规格选项组（如份量、辣度、加料）
+// Required 为 true 时至少选择 max(MinSelect, 1) 项；MaxSelect 为 0 表示不限
+type OptionGroup struct {
+	GroupID   string
+	Name      string
+	Required  bool
+	MinSelect int
+	MaxSelect int
+	Options   []DishOption
+}
+
+// DishOption 规格选项，PriceDelta 为在餐品单价上的加价（可为 0）
+type DishOption struct {
+	OptionID   string
+	Name       string
+	PriceDelta decimal.Decimal
+}
+
+// OptionSelection 下单时选择的规格选项
+type OptionSelection struct {
+	GroupID  string
+	OptionID string
+}
+
+// OrderItemOption 订单项已选规格快照（下单时从菜单复制名称和加价，菜单后续变更不影响订单）
+type OrderItemOption struct {
+	GroupID    string
+	GroupName  string
+	OptionID   string
+	OptionName string
+	PriceDelta decimal.Decimal
+}
+
+// minSelections 选项组至少需选择的项数
+func (g OptionGroup) minSelections() int {
+	if g.Required {
+		return max(g.MinSelect, 1)
+	}
+	return g.MinSelect
+}
+
+// findOption 根据选项ID查找选项
+func (g OptionGroup) findOption(optionID string) (DishOption, bool) {
+	for _, option := range g.Options {
+		if option.OptionID == optionID {
+			return option, true
+		}
+	}
+	return DishOption{}, false
+}
+
+// SelectOptions 按选项组规则校验所选规格，返回按菜单中选项组顺序排列的已选规格
+// 未知的选项组或选项、重复选择、选择数量不满足最少/最多要求时返回错误
+func (d *Dish) SelectOptions(selections []OptionSelection) ([]OrderItemOption, error) {
+	chosen := make(map[string][]string, len(selections)) // 选项组ID -> 已选选项ID
+	for _, selection := range selections {
+		group, ok := d.findGroup(selection.GroupID)
+		if !ok {
+			return nil, NewDomainError(ErrUnknownDishOption.Code,
+				fmt.Sprintf("dish %s has no option group %s", d.DishID, selection.GroupID))
+		}
+		if _, ok := group.findOption(selection.OptionID); !ok {
+			return nil, NewDomainError(ErrUnknownDishOption.Code,
+				fmt.Sprintf("option group %s of dish %s has no option %s", group.GroupID, d.DishID, selection.OptionID))
+		}
+		for _, optionID := range chosen[group.GroupID] {
+			if optionID == selection.OptionID {
+				return nil, NewDomainError(ErrInvalidOptionSelection.Code,
+					fmt.Sprintf("option %s in group %s selected more than once", selection.OptionID, group.GroupID))
+			}
+		}
+		chosen[group.GroupID] = append(chosen[group.GroupID], selection.OptionID)
+	}
+
+	var result []OrderItemOption
+	for _, group := range d.OptionGroups {
+		optionIDs := chosen[group.GroupID]
+		if required := group.minSelections(); len(optionIDs) < required {
+			return nil, NewDomainError(ErrInvalidOptionSelection.Code,
+				fmt.Sprintf("option group %s of dish %s requires at least %d selection(s)", group.GroupID, d.DishID, required))
+		}
+		if group.MaxSelect > 0 && len(optionIDs) > group.MaxSelect {
+			return nil, NewDomainError(ErrInvalidOptionSelection.Code,
+				fmt.Sprintf("option group %s of dish %s allows at most %d selection(s)", group.GroupID, d.DishID, group.MaxSelect))
+		}
+		for _, optionID := range optionIDs {
+			option, _ := group.findOption(optionID)
+			result = append(result, OrderItemOption{
+				GroupID:    group.GroupID,
+				GroupName:  group.Name,
+				OptionID:   option.OptionID,
+				OptionName: option.Name,
+				PriceDelta: option.PriceDelta,
+			})
+		}
+	}
+	return result, nil
+}
+
+// findGroup 根据选项组ID查找选项组
+func (d *Dish) findGroup(groupID string) (OptionGroup, bool) {
+	for _, group := range d.OptionGroups {
+		if group.GroupID == groupID {
+			return group, true
+		}
+	}
+	return OptionGroup{}, false
+}
+
+// Combo 商家菜单中的套餐（按套餐价售卖，由若干组件位组成）
+type Combo struct {
+	ComboID string
+	Name    string
+	Price   decimal.Decimal
+	Slots   []ComboSlot
+}
+
+// ComboSlot 套餐组件位（如主食、小食、饮料），Quantity 为每份套餐包含的数量
+// Choices 中第一项为默认餐品，其余为可替换的餐品
+type ComboSlot struct {
+	SlotID   string
+	Name     string
+	Quantity int
+	Choices  []ComboChoice
+}
+
+// ComboChoice 组件位可选的餐品：ListPrice 为单点价（按单点价占比分摊套餐价），Surcharge 为替换加价（每份套餐）
+type ComboChoice struct {
+	DishID    string
+	DishName  string
+	ListPrice decimal.Decimal
+	Surcharge decimal.Decimal
+}
+
+// ComboSelection 下单时组件位的替换选择
+type ComboSelection struct {
+	SlotID string
+	DishID string
+}
+
+// OrderItemComponent 套餐订单项的组件快照
+// AllocatedPrice 为每份套餐分摊到该组件的金额（含替换加价），全部组件之和等于套餐单价，用于部分退款和商家对账
+type OrderItemComponent struct {
+	SlotID         string
+	SlotName       string
+	DishID         string
+	DishName       string
+	Quantity       int
+	Surcharge      decimal.Decimal
+	AllocatedPrice decimal.Decimal
+}
+
+// SelectComponents 按替换选择确定套餐组件（未选择的组件位使用默认餐品），并分摊套餐价
+// 未知的组件位、组件位不可选的餐品、同一组件位重复选择时返回错误
+func (c *Combo) SelectComponents(selections []ComboSelection) ([]OrderItemComponent, error) {
+	chosen := make(map[string]ComboChoice, len(selections)) // 组件位ID -> 所选餐品
+	for _, selection := range selections {
+		slot, ok := c.findSlot(selection.SlotID)
+		if !ok {
+			return nil, NewDomainError(ErrInvalidComboSelection.Code,
+				fmt.Sprintf("combo %s has no slot %s", c.ComboID, selection.SlotID))
+		}
+		if _, exists := chosen[slot.SlotID]; exists {
+			return nil, NewDomainError(ErrInvalidComboSelection.Code,
+				fmt.Sprintf("slot %s of combo %s selected more than once", slot.SlotID, c.ComboID))
+		}
+		choice, ok := slot.findChoice(selection.DishID)
+		if !ok {
+			return nil, NewDomainError(ErrInvalidComboSelection.Code,
+				fmt.Sprintf("dish %s is not a choice of slot %s in combo %s", selection.DishID, slot.SlotID, c.ComboID))
+		}
+		chosen[slot.SlotID] = choice
+	}
+
+	components := make([]OrderItemComponent, len(c.Slots))
+	weights := make([]decimal.Decimal, len(c.Slots))
+	for i, slot := range c.Slots {
+		choice, ok := chosen[slot.SlotID]
+		if !ok {
+			choice = slot.Choices[0]
+		}
+		components[i] = OrderItemComponent{
+			SlotID:    slot.SlotID,
+			SlotName:  slot.Name,
+			DishID:    choice.DishID,
+			DishName:  choice.DishName,
+			Quantity:  slot.Quantity,
+			Surcharge: choice.Surcharge,
+		}
+		weights[i] = choice.ListPrice.Mul(decimal.NewFromInt(int64(slot.Quantity)))
+	}
+	allocateComboPrice(c.Price, components, weights)
+	return components, nil
+}
+
+// allocateComboPrice 按权重（单点价 × 数量）将套餐价分摊到各组件并加上替换加价
+// 分摊额保留2位小数，舍入差额计入最后一个组件，保证分摊之和等于套餐价；权重全为 0 时平均分摊
+func allocateComboPrice(price decimal.Decimal, components []OrderItemComponent, weights []decimal.Decimal) {
+	total := decimal.Zero
+	for _, weight := range weights {
+		total = total.Add(weight)
+	}
+
+	allocated := decimal.Zero
+	for i := range components {
+		var share decimal.Decimal
+		switch {
+		case i == len(components)-1:
+			share = price.Sub(allocated)
+		case total.IsZero():
+			share = price.Div(decimal.NewFromInt(int64(len(components)))).Round(2)
+		default:
+			share = price.Mul(weights[i]).Div(total).Round(2)
+		}
+		allocated = allocated.Add(share)
+		components[i].AllocatedPrice = share.Add(components[i].Surcharge)
+	}
+}
+
+// findSlot 根据组件位ID查找组件位
+func (c *Combo) findSlot(slotID string) (ComboSlot, bool) {
+	for _, slot := range c.Slots {
+		if slot.SlotID == slotID {
+			return slot, true
+		}
+	}
+	return ComboSlot{}, false
+}
+
+// findChoice 根据餐品ID查找组件位的可选餐品
+func (s ComboSlot) findChoice(dishID string) (ComboChoice, bool) {
+	for _, choice := range s.Choices {
+		if choice.DishID == dishID {
+			return choice, true
+		}
+	}
+	return ComboChoice{}, false
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNoodleDish 创建带规格的测试餐品：份量必选一项，辣度可选一项，加料最多两项
+func newNoodleDish() *Dish {
+	return &Dish{
+		DishID: "dish_101",
+		Name:   "牛肉面",
+		Price:  decimal.NewFromInt(22),
+		OptionGroups: []OptionGroup{
+			{GroupID: "size", Name: "份量", Required: true, MaxSelect: 1, Options: []DishOption{
+				{OptionID: "regular", Name: "标准份"},
+				{OptionID: "large", Name: "大份", PriceDelta: decimal.NewFromInt(4)},
+			}},
+			{GroupID: "spice", Name: "辣度", MaxSelect: 1, Options: []DishOption{
+				{OptionID: "mild", Name: "微辣"},
+			}},
+			{GroupID: "addons", Name: "加料", MaxSelect: 2, Options: []DishOption{
+				{OptionID: "egg", Name: "卤蛋", PriceDelta: decimal.NewFromInt(2)},
+				{OptionID: "beef", Name: "加牛肉", PriceDelta: decimal.NewFromInt(8)},
+				{OptionID: "noodles", Name: "加面", PriceDelta: decimal.NewFromInt(3)},
+			}},
+		},
+	}
+}
+
+func TestDish_SelectOptions(t *testing.T) {
+	// Act - 选择顺序与菜单不同
+	options, err := newNoodleDish().SelectOptions([]OptionSelection{
+		{GroupID: "addons", OptionID: "egg"},
+		{GroupID: "size", OptionID: "large"},
+		{GroupID: "addons", OptionID: "beef"},
+	})
+
+	// Assert - 按菜单中选项组顺序返回，并带有名称和加价快照
+	require.NoError(t, err)
+	require.Len(t, options, 3)
+	assert.Equal(t, "size", options[0].GroupID)
+	assert.Equal(t, "大份", options[0].OptionName)
+	assert.Equal(t, "加料", options[1].GroupName)
+	assert.True(t, decimal.NewFromInt(8).Equal(options[2].PriceDelta))
+}
+
+func TestDish_SelectOptions_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		selections []OptionSelection
+		want       *DomainError
+	}{
+		{"missing required group", nil, ErrInvalidOptionSelection},
+		{"unknown group", []OptionSelection{{GroupID: "size", OptionID: "large"}, {GroupID: "sauce", OptionID: "soy"}}, ErrUnknownDishOption},
+		{"unknown option", []OptionSelection{{GroupID: "size", OptionID: "huge"}}, ErrUnknownDishOption},
+		{"duplicate option", []OptionSelection{{GroupID: "size", OptionID: "large"}, {GroupID: "addons", OptionID: "egg"}, {GroupID: "addons", OptionID: "egg"}}, ErrInvalidOptionSelection},
+		{"too many options", []OptionSelection{
+			{GroupID: "size", OptionID: "large"},
+			{GroupID: "addons", OptionID: "egg"}, {GroupID: "addons", OptionID: "beef"}, {GroupID: "addons", OptionID: "noodles"},
+		}, ErrInvalidOptionSelection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newNoodleDish().SelectOptions(tt.selections)
+
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestOrder_PricingIncludesOptionSurcharges(t *testing.T) {
+	// Arrange - (22 + 4 + 2) × 2 = 56
+	options, err := newNoodleDish().SelectOptions([]OptionSelection{
+		{GroupID: "size", OptionID: "large"},
+		{GroupID: "addons", OptionID: "egg"},
+	})
+	require.NoError(t, err)
+	item := OrderItem{DishID: "dish_101", DishName: "牛肉面", Quantity: 2, Price: decimal.NewFromInt(22), Options: options}
+
+	// Act
+	order := NewOrder(1001, "merchant_001", []OrderItem{item}, DeliveryInfo{}, "")
+
+	// Assert
+	assert.Equal(t, "28.00", item.UnitPrice().StringFixed(2))
+	assert.Equal(t, "56.00", order.Pricing.ItemsTotal.StringFixed(2))
+	assert.Equal(t, "60.00", order.Pricing.FinalAmount.StringFixed(2))
+	created := order.PendingEvents()[0].(OrderCreated)
+	assert.Equal(t, "28.00", created.Items[0].UnitPrice)
+	assert.Equal(t, "大份", created.Items[0].Options[0].OptionName)
+}
+
+// newNoodleCombo 创建测试套餐：套餐价 30.00，按单点价 22 : 8 : 6 分摊，饮料可加 5.00 换成橙汁
+func newNoodleCombo() *Combo {
+	return &Combo{
+		ComboID: "combo_201",
+		Name:    "牛肉面套餐",
+		Price:   decimal.NewFromInt(30),
+		Slots: []ComboSlot{
+			{SlotID: "main", Name: "主食", Quantity: 1, Choices: []ComboChoice{
+				{DishID: "dish_101", DishName: "牛肉面", ListPrice: decimal.NewFromInt(22)},
+			}},
+			{SlotID: "side", Name: "小菜", Quantity: 1, Choices: []ComboChoice{
+				{DishID: "dish_102", DishName: "拍黄瓜", ListPrice: decimal.NewFromInt(8)},
+			}},
+			{SlotID: "drink", Name: "饮料", Quantity: 1, Choices: []ComboChoice{
+				{DishID: "dish_104", DishName: "酸梅汤", ListPrice: decimal.NewFromInt(6)},
+				{DishID: "dish_105", DishName: "鲜榨橙汁", ListPrice: decimal.NewFromInt(12), Surcharge: decimal.NewFromInt(5)},
+			}},
+		},
+	}
+}
+
+func TestCombo_SelectComponents_AllocatesComboPrice(t *testing.T) {
+	// Act
+	defaults, err := newNoodleCombo().SelectComponents(nil)
+	require.NoError(t, err)
+	substituted, err := newNoodleCombo().SelectComponents([]ComboSelection{{SlotID: "drink", DishID: "dish_105"}})
+	require.NoError(t, err)
+
+	// Assert - 30 × 22/36 = 18.33，30 × 8/36 = 6.67，舍入差额计入最后一个组件
+	require.Len(t, defaults, 3)
+	assert.Equal(t, "dish_104", defaults[2].DishID)
+	assert.Equal(t, "18.33", defaults[0].AllocatedPrice.StringFixed(2))
+	assert.Equal(t, "6.67", defaults[1].AllocatedPrice.StringFixed(2))
+	assert.Equal(t, "5.00", defaults[2].AllocatedPrice.StringFixed(2))
+
+	// 替换后按新的单点价重新分摊，替换加价计入该组件：30 × 22/42 = 15.71，30 × 8/42 = 5.71
+	assert.Equal(t, "鲜榨橙汁", substituted[2].DishName)
+	assert.Equal(t, "15.71", substituted[0].AllocatedPrice.StringFixed(2))
+	assert.Equal(t, "13.58", substituted[2].AllocatedPrice.StringFixed(2))
+	total := decimal.Zero
+	for _, component := range substituted {
+		total = total.Add(component.AllocatedPrice)
+	}
+	assert.Equal(t, "35.00", total.StringFixed(2))
+}
+
+func TestCombo_SelectComponents_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		selections []ComboSelection
+	}{
+		{"unknown slot", []ComboSelection{{SlotID: "dessert", DishID: "dish_201"}}},
+		{"dish not a choice", []ComboSelection{{SlotID: "drink", DishID: "dish_102"}}},
+		{"slot selected twice", []ComboSelection{{SlotID: "drink", DishID: "dish_104"}, {SlotID: "drink", DishID: "dish_105"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newNoodleCombo().SelectComponents(tt.selections)
+
+			assert.ErrorIs(t, err, ErrInvalidComboSelection)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// HolidayLayout 休息日日期格式（商家时区的日历日）
+const HolidayLayout = "2006-01-02"
+
+// MinutesPerDay 一天的分钟数（营业时段的结束时间最大为 24:00）
+const MinutesPerDay = 24 * 60
+
+// MerchantStatus 商家当前接单状态
+type MerchantStatus string
+
+const (
+	MerchantStatusOpen    MerchantStatus = "OPEN"
+	MerchantStatusClosed  MerchantStatus = "CLOSED"
+	MerchantStatusHoliday MerchantStatus = "HOLIDAY"
+	MerchantStatusPaused  MerchantStatus = "PAUSED"
+)
+
+// OpeningHours 营业时段（商家时区当天 0 点起的分钟数，左闭右开；跨零点的营业拆成两天的两个时段）
+type OpeningHours struct {
+	Weekday time.Weekday
+	Open    int
+	Close   int
+}
+
+// Contains 时段是否包含指定星期和时刻
+func (h OpeningHours) Contains(weekday time.Weekday, minute int) bool {
+	return h.Weekday == weekday && minute >= h.Open && minute < h.Close
+}
+
+// MerchantProfile 商家营业配置（聚合根）
+// 下单时按商家时区校验营业时段、休息日和忙碌暂停，并校验起送金额和同时处理中的订单数
+type MerchantProfile struct {
+	MerchantID      string
+	Location        *time.Location
+	OpeningHours    []OpeningHours  // 为空表示全天营业
+	Holidays        []string        // 休息日（HolidayLayout 格式）
+	PausedUntil     time.Time       // 忙碌暂停截止时间，零值表示未暂停
+	MinOrderAmount  decimal.Decimal // 起送金额（按餐品总额计算，不含打包费和配送费），0 表示不限
+	MaxActiveOrders int             // 同时处理中的订单上限，0 表示不限
+	SlotCapacity    int             // 每个预订配送时段可预订的订单数，0 表示不限
+	UpdatedAt       time.Time
+}
+
+// NewMerchantProfile 创建全天营业、不限起送金额和订单数的商家营业配置
+func NewMerchantProfile(merchantID string, location *time.Location) *MerchantProfile {
+	return &MerchantProfile{
+		MerchantID: merchantID,
+		Location:   location,
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// Status 商家在 now 时刻的接单状态（忙碌暂停优先于休息日，休息日优先于营业时段）
+func (p *MerchantProfile) Status(now time.Time) MerchantStatus {
+	if p.Paused(now) {
+		return MerchantStatusPaused
+	}
+	local := now.In(p.Location)
+	if slices.Contains(p.Holidays, local.Format(HolidayLayout)) {
+		return MerchantStatusHoliday
+	}
+	if len(p.OpeningHours) == 0 {
+		return MerchantStatusOpen
+	}
+	minute := local.Hour()*60 + local.Minute()
+	for _, hours := range p.OpeningHours {
+		if hours.Contains(local.Weekday(), minute) {
+			return MerchantStatusOpen
+		}
+	}
+	return MerchantStatusClosed
+}
+
+// Paused 商家在 now 时刻是否处于忙碌暂停
+func (p *MerchantProfile) Paused(now time.Time) bool {
+	return now.Before(p.PausedUntil)
+}
+
+// Pause 从 now 起忙碌暂停接单 duration（重复暂停时以最后一次为准）
+func (p *MerchantProfile) Pause(now time.Time, duration time.Duration) {
+	p.PausedUntil = now.Add(duration)
+	p.UpdatedAt = now
+}
+
+// Resume 结束忙碌暂停
+func (p *MerchantProfile) Resume(now time.Time) {
+	p.PausedUntil = time.Time{}
+	p.UpdatedAt = now
+}
+
+// CheckAcceptance 校验商家在 now 时刻能否接收餐品总额为 itemsTotal 的订单
+// activeOrders 为商家同时处理中的订单数（未配置订单上限时不使用）
+func (p *MerchantProfile) CheckAcceptance(now time.Time, itemsTotal decimal.Decimal, activeOrders int) error {
+	switch p.Status(now) {
+	case MerchantStatusPaused:
+		return ErrMerchantPaused
+	case MerchantStatusHoliday:
+		return ErrMerchantHoliday
+	case MerchantStatusClosed:
+		return ErrMerchantClosed
+	}
+	if itemsTotal.LessThan(p.MinOrderAmount) {
+		return ErrBelowMinOrderAmount
+	}
+	if p.MaxActiveOrders > 0 && activeOrders >= p.MaxActiveOrders {
+		return ErrMerchantAtCapacity
+	}
+	return nil
+}
+
+// SlotOpen 商家在 [start, start+duration) 配送时段内是否营业（时段须整段落在营业时段内，忙碌暂停覆盖的时段不可预订）
+func (p *MerchantProfile) SlotOpen(start time.Time, duration time.Duration) bool {
+	return p.Status(start) == MerchantStatusOpen && p.Status(start.Add(duration-time.Minute)) == MerchantStatusOpen
+}
+
+// CheckScheduledAcceptance 校验商家能否接收在 slot 时段配送、餐品总额为 itemsTotal 的预订单
+// 预订单不受下单时的营业状态和同时处理中的订单上限限制，时段容量由预订时校验
+func (p *MerchantProfile) CheckScheduledAcceptance(slot time.Time, duration time.Duration, itemsTotal decimal.Decimal) error {
+	if !p.SlotOpen(slot, duration) {
+		return ErrSlotUnavailable
+	}
+	if itemsTotal.LessThan(p.MinOrderAmount) {
+		return ErrBelowMinOrderAmount
+	}
+	return nil
+}
+
+// SlotStart 返回 t 所在配送时段的开始时间（按 location 当天 0 点起每 duration 划分一个时段）
+func SlotStart(t time.Time, location *time.Location, duration time.Duration) time.Time {
+	local := t.In(location)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+	return midnight.Add(local.Sub(midnight) / duration * duration)
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// cst 测试使用的商家时区（UTC+8）
+var cst = time.FixedZone("CST", 8*3600)
+
+// newLunchDinnerProfile 工作日 10:00-14:00、17:00-次日 01:00 营业的商家
+func newLunchDinnerProfile() *MerchantProfile {
+	profile := NewMerchantProfile("merchant_001", cst)
+	for day := time.Monday; day <= time.Friday; day++ {
+		profile.OpeningHours = append(profile.OpeningHours,
+			OpeningHours{Weekday: day, Open: 10 * 60, Close: 14 * 60},
+			OpeningHours{Weekday: day, Open: 17 * 60, Close: MinutesPerDay},
+			OpeningHours{Weekday: day + 1, Open: 0, Close: 60},
+		)
+	}
+	return profile
+}
+
+func TestMerchantProfile_Status(t *testing.T) {
+	profile := newLunchDinnerProfile()
+	profile.Holidays = []string{"2025-06-02"}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want MerchantStatus
+	}{
+		{"午市营业", time.Date(2025, 6, 3, 11, 30, 0, 0, cst), MerchantStatusOpen},
+		{"午市结束时刻不营业", time.Date(2025, 6, 3, 14, 0, 0, 0, cst), MerchantStatusClosed},
+		{"跨零点夜市营业", time.Date(2025, 6, 4, 0, 30, 0, 0, cst), MerchantStatusOpen},
+		{"周六凌晨为周五夜市", time.Date(2025, 6, 7, 0, 30, 0, 0, cst), MerchantStatusOpen},
+		{"周六白天不营业", time.Date(2025, 6, 7, 11, 0, 0, 0, cst), MerchantStatusClosed},
+		{"按商家时区判断", time.Date(2025, 6, 3, 3, 30, 0, 0, time.UTC), MerchantStatusOpen},
+		{"休息日", time.Date(2025, 6, 2, 11, 0, 0, 0, cst), MerchantStatusHoliday},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, profile.Status(tt.now))
+		})
+	}
+}
+
+func TestMerchantProfile_Status_AlwaysOpenWithoutHours(t *testing.T) {
+	// Arrange
+	profile := NewMerchantProfile("merchant_001", cst)
+
+	// Act & Assert
+	assert.Equal(t, MerchantStatusOpen, profile.Status(time.Date(2025, 6, 7, 4, 0, 0, 0, cst)))
+}
+
+func TestMerchantProfile_PauseAndResume(t *testing.T) {
+	// Arrange
+	profile := newLunchDinnerProfile()
+	now := time.Date(2025, 6, 3, 11, 0, 0, 0, cst)
+
+	// Act & Assert - 暂停到期后自动恢复
+	profile.Pause(now, 30*time.Minute)
+	assert.Equal(t, MerchantStatusPaused, profile.Status(now))
+	assert.Equal(t, MerchantStatusOpen, profile.Status(now.Add(30*time.Minute)))
+
+	profile.Resume(now)
+	assert.Equal(t, MerchantStatusOpen, profile.Status(now))
+}
+
+func TestMerchantProfile_CheckAcceptance(t *testing.T) {
+	open := time.Date(2025, 6, 3, 11, 0, 0, 0, cst)
+
+	tests := []struct {
+		name         string
+		now          time.Time
+		pause        bool
+		itemsTotal   int64
+		activeOrders int
+		wantErr      error
+	}{
+		{name: "接单", now: open, itemsTotal: 20, activeOrders: 4},
+		{name: "暂停", now: open, pause: true, itemsTotal: 20, wantErr: ErrMerchantPaused},
+		{name: "休息日", now: time.Date(2025, 6, 2, 11, 0, 0, 0, cst), itemsTotal: 20, wantErr: ErrMerchantHoliday},
+		{name: "非营业时间", now: time.Date(2025, 6, 3, 15, 0, 0, 0, cst), itemsTotal: 20, wantErr: ErrMerchantClosed},
+		{name: "未达起送金额", now: open, itemsTotal: 19, wantErr: ErrBelowMinOrderAmount},
+		{name: "达到订单上限", now: open, itemsTotal: 20, activeOrders: 5, wantErr: ErrMerchantAtCapacity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			profile := newLunchDinnerProfile()
+			profile.Holidays = []string{"2025-06-02"}
+			profile.MinOrderAmount = decimal.NewFromInt(20)
+			profile.MaxActiveOrders = 5
+			if tt.pause {
+				profile.Pause(tt.now, time.Hour)
+			}
+
+			// Act
+			err := profile.CheckAcceptance(tt.now, decimal.NewFromInt(tt.itemsTotal), tt.activeOrders)
+
+			// Assert
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSlotStart(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{"时段开始", time.Date(2025, 6, 3, 12, 30, 0, 0, cst), time.Date(2025, 6, 3, 12, 30, 0, 0, cst)},
+		{"时段中间", time.Date(2025, 6, 3, 12, 44, 59, 0, cst), time.Date(2025, 6, 3, 12, 30, 0, 0, cst)},
+		{"按商家时区划分", time.Date(2025, 6, 3, 4, 40, 0, 0, time.UTC), time.Date(2025, 6, 3, 12, 30, 0, 0, cst)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.want.Equal(SlotStart(tt.t, cst, 30*time.Minute)))
+		})
+	}
+}
+
+func TestMerchantProfile_CheckScheduledAcceptance(t *testing.T) {
+	lunch := time.Date(2025, 6, 3, 12, 30, 0, 0, cst)
+
+	tests := []struct {
+		name       string
+		slot       time.Time
+		pause      time.Duration
+		itemsTotal int64
+		wantErr    error
+	}{
+		{name: "营业时段内", slot: lunch, itemsTotal: 20},
+		{name: "时段跨过打烊时间", slot: time.Date(2025, 6, 3, 13, 45, 0, 0, cst), itemsTotal: 20, wantErr: ErrSlotUnavailable},
+		{name: "非营业时间", slot: time.Date(2025, 6, 3, 15, 0, 0, 0, cst), itemsTotal: 20, wantErr: ErrSlotUnavailable},
+		{name: "休息日", slot: time.Date(2025, 6, 2, 12, 30, 0, 0, cst), itemsTotal: 20, wantErr: ErrSlotUnavailable},
+		{name: "暂停覆盖的时段", slot: lunch, pause: 2 * time.Hour, itemsTotal: 20, wantErr: ErrSlotUnavailable},
+		{name: "暂停结束后的时段", slot: lunch, pause: time.Hour, itemsTotal: 20},
+		{name: "未达起送金额", slot: lunch, itemsTotal: 19, wantErr: ErrBelowMinOrderAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange - 上午 11:00 下单
+			profile := newLunchDinnerProfile()
+			profile.Holidays = []string{"2025-06-02"}
+			profile.MinOrderAmount = decimal.NewFromInt(20)
+			profile.MaxActiveOrders = 1
+			if tt.pause > 0 {
+				profile.Pause(time.Date(2025, 6, 3, 11, 0, 0, 0, cst), tt.pause)
+			}
+
+			// Act
+			err := profile.CheckScheduledAcceptance(tt.slot, 30*time.Minute, decimal.NewFromInt(tt.itemsTotal))
+
+			// Assert
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
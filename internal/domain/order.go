@@ -14,7 +14,10 @@ type OrderStatus string
 const (
 	OrderStatusPendingPayment OrderStatus = "PENDING_PAYMENT"
 	OrderStatusPaid           OrderStatus = "PAID"
+	OrderStatusAccepted       OrderStatus = "ACCEPTED"
+	OrderStatusRejected       OrderStatus = "REJECTED"
 	OrderStatusCancelled      OrderStatus = "CANCELLED"
+	OrderStatusRefunded       OrderStatus = "REFUNDED"
 )
 
 // 业务常量
@@ -23,6 +26,17 @@ var (
 	DefaultDeliveryFee  = decimal.NewFromFloat(3.00)
 )
 
+// Fees 订单固定费用值对象
+type Fees struct {
+	PackagingFee decimal.Decimal
+	DeliveryFee  decimal.Decimal
+}
+
+// DefaultFees 默认固定费用（打包费 1 元，配送费 3 元）
+func DefaultFees() Fees {
+	return Fees{PackagingFee: DefaultPackagingFee, DeliveryFee: DefaultDeliveryFee}
+}
+
 // Order 订单聚合根
 type Order struct {
 	OrderNumber string
@@ -32,9 +46,19 @@ type Order struct {
 	Pricing     Pricing
 	Delivery    DeliveryInfo
 	Remark      string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Items       []OrderItem
+	PaymentID   string
+	PaidAt      time.Time
+	// ScheduledFor 预订配送时段的开始时间，零值表示立即配送
+	ScheduledFor time.Time
+	// ReleasedAt 预订单推送给商家的时间，零值表示尚未推送
+	ReleasedAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Items      []OrderItem
+	Refunds    []Refund
+	// Version 持久化版本号（乐观锁），由仓储在创建和每次保存成功后递增
+	Version int
+	events  []Event
 }
 
 // Pricing 价格信息值对象
@@ -45,58 +69,275 @@ type Pricing struct {
 	FinalAmount  decimal.Decimal
 }
 
-// DeliveryInfo 配送信息值对象
+// DeliveryInfo 配送信息值对象（Location 为收货坐标，未提供时为 nil）
 type DeliveryInfo struct {
 	RecipientName  string
 	RecipientPhone string
 	Address        string
+	Location       *GeoPoint
 }
 
-// OrderItem 订单项实体
+// OrderItem 订单项实体（Price 为餐品基础单价，Options 为已选规格）
+// 套餐订单项的 DishID 为套餐ID，Price 为套餐价，Components 为套餐组件
 type OrderItem struct {
-	DishID   string
-	DishName string
-	Quantity int
-	Price    decimal.Decimal
+	DishID     string
+	DishName   string
+	Quantity   int
+	Price      decimal.Decimal
+	Options    []OrderItemOption
+	Components []OrderItemComponent
+}
+
+// IsCombo 是否为套餐订单项
+func (i OrderItem) IsCombo() bool {
+	return len(i.Components) > 0
 }
 
-// NewOrder 创建新订单（工厂方法）
+// UnitPrice 含规格加价和套餐替换加价的单价
+func (i OrderItem) UnitPrice() decimal.Decimal {
+	price := i.Price
+	for _, option := range i.Options {
+		price = price.Add(option.PriceDelta)
+	}
+	for _, component := range i.Components {
+		price = price.Add(component.Surcharge)
+	}
+	return price
+}
+
+// Subtotal 订单项小计（含规格加价的单价 × 数量）
+func (i OrderItem) Subtotal() decimal.Decimal {
+	return i.UnitPrice().Mul(decimal.NewFromInt(int64(i.Quantity)))
+}
+
+// NewOrder 创建新订单（工厂方法，使用默认费用）
 func NewOrder(userID uint64, merchantID string, items []OrderItem, delivery DeliveryInfo, remark string) *Order {
+	return NewOrderWithFees(userID, merchantID, items, delivery, remark, DefaultFees())
+}
+
+// NewOrderWithFees 使用指定费用创建新订单
+func NewOrderWithFees(userID uint64, merchantID string, items []OrderItem, delivery DeliveryInfo, remark string, fees Fees) *Order {
+	return NewScheduledOrder(userID, merchantID, items, delivery, remark, fees, time.Time{})
+}
+
+// NewScheduledOrder 创建在 scheduledFor 时段配送的预订单（scheduledFor 为零值时为立即配送的订单）
+func NewScheduledOrder(userID uint64, merchantID string, items []OrderItem, delivery DeliveryInfo, remark string, fees Fees, scheduledFor time.Time) *Order {
 	now := time.Now()
-	
+
 	order := &Order{
-		OrderNumber: generateOrderNumber(),
-		UserID:      userID,
-		MerchantID:  merchantID,
-		Status:      OrderStatusPendingPayment,
-		Items:       items,
-		Delivery:    delivery,
-		Remark:      remark,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		OrderNumber:  generateOrderNumber(),
+		UserID:       userID,
+		MerchantID:   merchantID,
+		Status:       OrderStatusPendingPayment,
+		Items:        items,
+		Delivery:     delivery,
+		Remark:       remark,
+		ScheduledFor: scheduledFor,
+		CreatedAt:    now,
+		UpdatedAt:    now,
 	}
-	
-	order.calculatePricing()
+
+	order.calculatePricing(fees)
+	order.recordEvent(OrderCreated{
+		EventMetadata: newEventMetadata(EventTypeOrderCreated, 1, order, now),
+		UserID:        order.UserID,
+		Status:        order.Status,
+		Items:         order.eventItems(),
+		ItemsTotal:    order.Pricing.ItemsTotal.StringFixed(2),
+		PackagingFee:  order.Pricing.PackagingFee.StringFixed(2),
+		DeliveryFee:   order.Pricing.DeliveryFee.StringFixed(2),
+		FinalAmount:   order.Pricing.FinalAmount.StringFixed(2),
+		ScheduledFor:  order.scheduledFor(),
+	})
 	return order
 }
 
 // calculatePricing 计算订单价格（私有方法，创建时自动调用）
-func (o *Order) calculatePricing() {
-	// 计算餐品总价
-	o.Pricing.ItemsTotal = decimal.Zero
-	for _, item := range o.Items {
-		itemTotal := item.Price.Mul(decimal.NewFromInt(int64(item.Quantity)))
-		o.Pricing.ItemsTotal = itemTotal
+func (o *Order) calculatePricing(fees Fees) {
+	o.Pricing = CalculatePricing(o.Items, fees)
+}
+
+// CalculatePricing 按订单项和固定费用计算价格（下单和购物车价格预览共用）
+func CalculatePricing(items []OrderItem, fees Fees) Pricing {
+	var pricing Pricing
+
+	// 计算餐品总价（含规格加价）
+	pricing.ItemsTotal = decimal.Zero
+	for _, item := range items {
+		pricing.ItemsTotal = pricing.ItemsTotal.Add(item.Subtotal())
 	}
-	
+
 	// 设置固定费用
-	o.Pricing.PackagingFee = DefaultPackagingFee
-	o.Pricing.DeliveryFee = DefaultDeliveryFee
-	
+	pricing.PackagingFee = fees.PackagingFee
+	pricing.DeliveryFee = fees.DeliveryFee
+
 	// 计算最终金额
-	o.Pricing.FinalAmount = o.Pricing.ItemsTotal.
-		Add(o.Pricing.PackagingFee).
-		Add(o.Pricing.DeliveryFee)
+	pricing.FinalAmount = pricing.ItemsTotal.
+		Add(pricing.PackagingFee).
+		Add(pricing.DeliveryFee)
+	return pricing
+}
+
+// MarkPaid 标记订单已支付（仅待支付订单可支付）
+func (o *Order) MarkPaid(paymentID string) error {
+	if o.Status != OrderStatusPendingPayment {
+		return ErrInvalidOrderStatus
+	}
+
+	now := time.Now()
+	o.Status = OrderStatusPaid
+	o.PaymentID = paymentID
+	o.PaidAt = now
+	o.UpdatedAt = now
+	o.recordEvent(OrderPaid{
+		EventMetadata: newEventMetadata(EventTypeOrderPaid, 1, o, now),
+		UserID:        o.UserID,
+		Status:        o.Status,
+		PaymentID:     paymentID,
+		Amount:        o.Pricing.FinalAmount.StringFixed(2),
+		ScheduledFor:  o.scheduledFor(),
+	})
+	return nil
+}
+
+// Cancel 取消订单（仅待支付订单可直接取消，已支付订单需走退款）
+func (o *Order) Cancel(reason string) error {
+	if o.Status != OrderStatusPendingPayment {
+		return ErrInvalidOrderStatus
+	}
+
+	now := time.Now()
+	previous := o.Status
+	o.Status = OrderStatusCancelled
+	o.UpdatedAt = now
+	o.recordEvent(OrderCancelled{
+		EventMetadata:  newEventMetadata(EventTypeOrderCancelled, 1, o, now),
+		UserID:         o.UserID,
+		Status:         o.Status,
+		PreviousStatus: previous,
+		Reason:         reason,
+	})
+	return nil
+}
+
+// Accept 商家接单（仅已支付订单可接单）
+func (o *Order) Accept() error {
+	if o.Status != OrderStatusPaid {
+		return ErrInvalidOrderStatus
+	}
+
+	now := time.Now()
+	o.Status = OrderStatusAccepted
+	o.UpdatedAt = now
+	o.recordEvent(OrderAccepted{
+		EventMetadata: newEventMetadata(EventTypeOrderAccepted, 1, o, now),
+		UserID:        o.UserID,
+		Status:        o.Status,
+	})
+	return nil
+}
+
+// Reject 商家拒单（仅已支付订单可拒单，拒单后需全额退款）
+func (o *Order) Reject(reason string) error {
+	if o.Status != OrderStatusPaid {
+		return ErrInvalidOrderStatus
+	}
+
+	now := time.Now()
+	o.Status = OrderStatusRejected
+	o.UpdatedAt = now
+	o.recordEvent(OrderRejected{
+		EventMetadata: newEventMetadata(EventTypeOrderRejected, 1, o, now),
+		UserID:        o.UserID,
+		Status:        o.Status,
+		Reason:        reason,
+	})
+	return nil
+}
+
+// Release 将已支付的预订单推送给商家备餐（每个预订单只推送一次）
+func (o *Order) Release(now time.Time) error {
+	if !o.IsScheduled() || !o.ReleasedAt.IsZero() || o.Status != OrderStatusPaid {
+		return ErrInvalidOrderStatus
+	}
+
+	o.ReleasedAt = now
+	o.UpdatedAt = now
+	o.recordEvent(OrderReleased{
+		EventMetadata: newEventMetadata(EventTypeOrderReleased, 1, o, now),
+		UserID:        o.UserID,
+		Status:        o.Status,
+		Items:         o.eventItems(),
+		FinalAmount:   o.Pricing.FinalAmount.StringFixed(2),
+		ScheduledFor:  o.ScheduledFor,
+	})
+	return nil
+}
+
+// IsScheduled 是否为预订单
+func (o *Order) IsScheduled() bool {
+	return !o.ScheduledFor.IsZero()
+}
+
+// Active 订单是否计入商家同时处理中的订单数（待支付或待接单；预订单推送给商家后才计入）
+func (o *Order) Active() bool {
+	if o.IsScheduled() && o.ReleasedAt.IsZero() {
+		return false
+	}
+	return o.Status == OrderStatusPendingPayment || o.Status == OrderStatusPaid
+}
+
+// scheduledFor 事件中的预订配送时段（立即配送的订单为 nil）
+func (o *Order) scheduledFor() *time.Time {
+	if !o.IsScheduled() {
+		return nil
+	}
+	scheduledFor := o.ScheduledFor
+	return &scheduledFor
+}
+
+// IsPaid 订单是否已支付（已支付、已接单、已拒单的订单都可以退款）
+func (o *Order) IsPaid() bool {
+	switch o.Status {
+	case OrderStatusPaid, OrderStatusAccepted, OrderStatusRejected:
+		return true
+	}
+	return false
+}
+
+// eventItems 生成事件中的订单项快照
+func (o *Order) eventItems() []EventOrderItem {
+	items := make([]EventOrderItem, len(o.Items))
+	for i, item := range o.Items {
+		items[i] = EventOrderItem{
+			DishID:    item.DishID,
+			DishName:  item.DishName,
+			Quantity:  item.Quantity,
+			Price:     item.Price.StringFixed(2),
+			UnitPrice: item.UnitPrice().StringFixed(2),
+		}
+		for _, option := range item.Options {
+			items[i].Options = append(items[i].Options, EventItemOption{
+				GroupID:    option.GroupID,
+				GroupName:  option.GroupName,
+				OptionID:   option.OptionID,
+				OptionName: option.OptionName,
+				PriceDelta: option.PriceDelta.StringFixed(2),
+			})
+		}
+		for _, component := range item.Components {
+			items[i].Components = append(items[i].Components, EventItemComponent{
+				SlotID:         component.SlotID,
+				SlotName:       component.SlotName,
+				DishID:         component.DishID,
+				DishName:       component.DishName,
+				Quantity:       component.Quantity,
+				Surcharge:      component.Surcharge.StringFixed(2),
+				AllocatedPrice: component.AllocatedPrice.StringFixed(2),
+			})
+		}
+	}
+	return items
 }
 
 // generateOrderNumber 生成订单号（格式：yyyyMMddHHmmss + 6位随机数）
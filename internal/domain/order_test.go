@@ -194,3 +194,20 @@ func TestDefaultFees(t *testing.T) {
 	assert.Equal(t, "1.00", DefaultPackagingFee.StringFixed(2))
 	assert.Equal(t, "3.00", DefaultDeliveryFee.StringFixed(2))
 }
+
+func TestNewOrderWithFees_UsesGivenFees(t *testing.T) {
+	// Arrange
+	items := []OrderItem{
+		{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: decimal.NewFromFloat(28.00)},
+	}
+	delivery := DeliveryInfo{RecipientName: "张三", RecipientPhone: "13800138000", Address: "北京市朝阳区xxx"}
+	fees := Fees{PackagingFee: decimal.NewFromFloat(2.00), DeliveryFee: decimal.NewFromFloat(5.50)}
+
+	// Act
+	order := NewOrderWithFees(1001, "merchant_001", items, delivery, "", fees)
+
+	// Assert - 28.00 + 2.00 + 5.50 = 35.50
+	assert.Equal(t, "2.00", order.Pricing.PackagingFee.StringFixed(2))
+	assert.Equal(t, "5.50", order.Pricing.DeliveryFee.StringFixed(2))
+	assert.Equal(t, "35.50", order.Pricing.FinalAmount.StringFixed(2))
+}
@@ -0,0 +1,373 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// RefundStatus 退款状态
+type RefundStatus string
+
+const (
+	RefundStatusPending  RefundStatus = "REFUND_PENDING"
+	RefundStatusRefunded RefundStatus = "REFUNDED"
+	RefundStatusFailed   RefundStatus = "REFUND_FAILED"
+)
+
+// Refund 退款实体（隶属于订单聚合）
+type Refund struct {
+	RefundID     string
+	Items        []RefundItem
+	PackagingFee decimal.Decimal
+	DeliveryFee  decimal.Decimal
+	Amount       decimal.Decimal
+	Reason       string
+	Status       RefundStatus
+	FailReason   string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// RefundItem 退款项（对应订单中的某一行订单项，SlotID 不为空时为套餐中单个组件的退款）
+type RefundItem struct {
+	LineNo   int
+	DishID   string
+	SlotID   string
+	Quantity int
+	Amount   decimal.Decimal
+}
+
+// RefundLine 退款申请行（按餐品ID和数量申请）
+// 退套餐中的单个组件时 DishID 为套餐ID、SlotID 为组件位ID，Quantity 为套餐份数
+type RefundLine struct {
+	DishID   string
+	SlotID   string
+	Quantity int
+}
+
+// active 退款是否占用可退额度（失败的退款不占用）
+func (r *Refund) active() bool {
+	return r.Status != RefundStatusFailed
+}
+
+// RequestRefund 申请退款（lines 为空表示退还全部剩余金额）
+//
+// 退款金额规则：
+//   - 餐品部分按 单价 × 退款数量 计算，套餐组件按该组件分摊的套餐价计算
+//   - 打包费按退款餐品金额占餐品总价的比例退还
+//   - 所有餐品都退完时，退还剩余打包费和全部配送费
+//   - 累计退款金额不得超过订单最终金额
+func (o *Order) RequestRefund(lines []RefundLine, reason string) (*Refund, error) {
+	if !o.IsPaid() {
+		return nil, ErrOrderNotPaid
+	}
+
+	refundable := o.refundableQuantities()
+	if len(lines) == 0 {
+		lines = o.remainingRefundLines(refundable)
+		if len(lines) == 0 {
+			return nil, ErrNothingToRefund
+		}
+	}
+
+	items, err := o.allocateRefundItems(lines, refundable)
+	if err != nil {
+		return nil, err
+	}
+
+	itemsAmount := decimal.Zero
+	for _, item := range items {
+		itemsAmount = itemsAmount.Add(item.Amount)
+	}
+
+	refund := Refund{
+		RefundID: fmt.Sprintf("%sR%02d", o.OrderNumber, len(o.Refunds)+1),
+		Items:    items,
+		Reason:   reason,
+		Status:   RefundStatusPending,
+	}
+
+	if fullyRefunded(refundable) {
+		// 全部餐品退完：退还剩余费用，避免按比例计算的舍入误差
+		refund.PackagingFee = o.remainingPackagingFee()
+		refund.DeliveryFee = o.Pricing.DeliveryFee
+	} else {
+		refund.PackagingFee = o.proportionalPackagingFee(itemsAmount)
+		refund.DeliveryFee = decimal.Zero
+	}
+	refund.Amount = itemsAmount.Add(refund.PackagingFee).Add(refund.DeliveryFee)
+
+	if o.RefundedAmount().Add(refund.Amount).GreaterThan(o.Pricing.FinalAmount) {
+		return nil, ErrRefundAmountExceeded
+	}
+
+	now := time.Now()
+	refund.CreatedAt = now
+	refund.UpdatedAt = now
+	o.Refunds = append(o.Refunds, refund)
+	o.UpdatedAt = now
+	o.recordEvent(RefundRequested{
+		EventMetadata: newEventMetadata(EventTypeRefundRequested, 1, o, now),
+		UserID:        o.UserID,
+		RefundID:      refund.RefundID,
+		Amount:        refund.Amount.StringFixed(2),
+		Reason:        reason,
+	})
+
+	return &o.Refunds[len(o.Refunds)-1], nil
+}
+
+// CompleteRefund 退款成功（支付渠道已退款）
+func (o *Order) CompleteRefund(refundID string) error {
+	refund, err := o.pendingRefund(refundID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	refund.Status = RefundStatusRefunded
+	refund.UpdatedAt = now
+	if o.RefundedAmount().Equal(o.Pricing.FinalAmount) && !o.hasPendingRefund() {
+		o.Status = OrderStatusRefunded
+	}
+	o.UpdatedAt = now
+	o.recordEvent(RefundCompleted{
+		EventMetadata: newEventMetadata(EventTypeRefundCompleted, 1, o, now),
+		UserID:        o.UserID,
+		Status:        o.Status,
+		RefundID:      refund.RefundID,
+		Amount:        refund.Amount.StringFixed(2),
+	})
+	return nil
+}
+
+// FailRefund 退款失败（释放占用的可退额度）
+func (o *Order) FailRefund(refundID string, reason string) error {
+	refund, err := o.pendingRefund(refundID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	refund.Status = RefundStatusFailed
+	refund.FailReason = reason
+	refund.UpdatedAt = now
+	o.UpdatedAt = now
+	o.recordEvent(RefundFailed{
+		EventMetadata: newEventMetadata(EventTypeRefundFailed, 1, o, now),
+		UserID:        o.UserID,
+		RefundID:      refund.RefundID,
+		Amount:        refund.Amount.StringFixed(2),
+		Reason:        reason,
+	})
+	return nil
+}
+
+// FindRefund 根据退款ID查找退款
+func (o *Order) FindRefund(refundID string) (*Refund, bool) {
+	for i := range o.Refunds {
+		if o.Refunds[i].RefundID == refundID {
+			return &o.Refunds[i], true
+		}
+	}
+	return nil, false
+}
+
+// RefundedAmount 已退款及退款中的累计金额
+func (o *Order) RefundedAmount() decimal.Decimal {
+	total := decimal.Zero
+	for _, refund := range o.Refunds {
+		if refund.active() {
+			total = total.Add(refund.Amount)
+		}
+	}
+	return total
+}
+
+// RefundableAmount 剩余可退金额
+func (o *Order) RefundableAmount() decimal.Decimal {
+	return o.Pricing.FinalAmount.Sub(o.RefundedAmount())
+}
+
+// pendingRefund 查找处于退款中的退款
+func (o *Order) pendingRefund(refundID string) (*Refund, error) {
+	refund, ok := o.FindRefund(refundID)
+	if !ok {
+		return nil, ErrRefundNotFound
+	}
+	if refund.Status != RefundStatusPending {
+		return nil, ErrInvalidRefundStatus
+	}
+	return refund, nil
+}
+
+// hasPendingRefund 是否存在退款中的退款
+func (o *Order) hasPendingRefund() bool {
+	for _, refund := range o.Refunds {
+		if refund.Status == RefundStatusPending {
+			return true
+		}
+	}
+	return false
+}
+
+// refundableQuantities 每行订单项剩余可退数量
+// 套餐按组件分别统计（下标与 Components 对应），普通订单项只有一个元素
+func (o *Order) refundableQuantities() [][]int {
+	result := make([][]int, len(o.Items))
+	for i, item := range o.Items {
+		result[i] = make([]int, max(len(item.Components), 1))
+		for j := range result[i] {
+			result[i][j] = item.Quantity
+		}
+	}
+	for _, refund := range o.Refunds {
+		if !refund.active() {
+			continue
+		}
+		for _, item := range refund.Items {
+			consumeRefundable(result[item.LineNo], o.Items[item.LineNo].componentIndex(item.SlotID), item.Quantity)
+		}
+	}
+	return result
+}
+
+// remainingRefundLines 将全部剩余可退数量转换为退款申请行
+// 套餐先按整份退还各组件都剩余的份数，再按组件退还单独剩余的部分
+func (o *Order) remainingRefundLines(refundable [][]int) []RefundLine {
+	var lines []RefundLine
+	for i, units := range refundable {
+		item := o.Items[i]
+		whole := slices.Min(units)
+		if whole > 0 {
+			lines = append(lines, RefundLine{DishID: item.DishID, Quantity: whole})
+		}
+		for j, component := range item.Components {
+			if qty := units[j] - whole; qty > 0 {
+				lines = append(lines, RefundLine{DishID: item.DishID, SlotID: component.SlotID, Quantity: qty})
+			}
+		}
+	}
+	return lines
+}
+
+// allocateRefundItems 将退款申请行分配到订单项（同一餐品多行时按顺序分配），
+// 并扣减 refundable 中的剩余可退数量
+func (o *Order) allocateRefundItems(lines []RefundLine, refundable [][]int) ([]RefundItem, error) {
+	var items []RefundItem
+	for _, line := range lines {
+		remaining := line.Quantity
+		matched := false
+		for i, item := range o.Items {
+			if item.DishID != line.DishID {
+				continue
+			}
+			component := item.componentIndex(line.SlotID)
+			if line.SlotID != "" && component < 0 {
+				continue
+			}
+			matched = true
+			qty := min(remaining, refundableUnits(refundable[i], component))
+			if qty <= 0 {
+				continue
+			}
+			items = append(items, RefundItem{
+				LineNo:   i,
+				DishID:   item.DishID,
+				SlotID:   line.SlotID,
+				Quantity: qty,
+				Amount:   item.refundUnitPrice(component).Mul(decimal.NewFromInt(int64(qty))),
+			})
+			consumeRefundable(refundable[i], component, qty)
+			remaining -= qty
+			if remaining == 0 {
+				break
+			}
+		}
+		target := line.DishID
+		if line.SlotID != "" {
+			target = fmt.Sprintf("component %s of dish %s", line.SlotID, line.DishID)
+		}
+		if !matched {
+			return nil, NewDomainError(ErrRefundItemNotFound.Code, fmt.Sprintf("%s not found in order", target))
+		}
+		if remaining > 0 {
+			return nil, NewDomainError(ErrRefundQuantityExceeded.Code, fmt.Sprintf("%s refund quantity exceeds refundable quantity", target))
+		}
+	}
+	return items, nil
+}
+
+// componentIndex 套餐组件位在 Components 中的下标，slotID 为空或不存在时返回 -1
+func (i OrderItem) componentIndex(slotID string) int {
+	if slotID == "" {
+		return -1
+	}
+	return slices.IndexFunc(i.Components, func(c OrderItemComponent) bool { return c.SlotID == slotID })
+}
+
+// refundUnitPrice 每份的退款单价：整行退款为订单项单价，组件退款为该组件分摊的金额
+func (i OrderItem) refundUnitPrice(component int) decimal.Decimal {
+	if component < 0 {
+		return i.UnitPrice()
+	}
+	return i.Components[component].AllocatedPrice
+}
+
+// refundableUnits 剩余可退份数：整行退款取各组件剩余份数的最小值
+func refundableUnits(units []int, component int) int {
+	if component < 0 {
+		return slices.Min(units)
+	}
+	return units[component]
+}
+
+// consumeRefundable 扣减剩余可退份数：整行退款扣减全部组件
+func consumeRefundable(units []int, component int, qty int) {
+	if component >= 0 {
+		units[component] -= qty
+		return
+	}
+	for j := range units {
+		units[j] -= qty
+	}
+}
+
+// proportionalPackagingFee 按餐品金额占比计算应退打包费（保留2位小数），
+// 不超过剩余可退打包费，避免多次部分退款向上舍入后累计超过打包费
+func (o *Order) proportionalPackagingFee(itemsAmount decimal.Decimal) decimal.Decimal {
+	if o.Pricing.ItemsTotal.IsZero() {
+		return decimal.Zero
+	}
+	fee := o.Pricing.PackagingFee.Mul(itemsAmount).Div(o.Pricing.ItemsTotal).Round(2)
+	return decimal.Min(fee, o.remainingPackagingFee())
+}
+
+// remainingPackagingFee 剩余可退打包费（不小于0）
+func (o *Order) remainingPackagingFee() decimal.Decimal {
+	return decimal.Max(o.Pricing.PackagingFee.Sub(o.refundedPackagingFee()), decimal.Zero)
+}
+
+// refundedPackagingFee 已退（含退款中）的打包费
+func (o *Order) refundedPackagingFee() decimal.Decimal {
+	total := decimal.Zero
+	for _, refund := range o.Refunds {
+		if refund.active() {
+			total = total.Add(refund.PackagingFee)
+		}
+	}
+	return total
+}
+
+// fullyRefunded 是否所有订单项（含套餐组件）都已退完
+func fullyRefunded(refundable [][]int) bool {
+	for _, units := range refundable {
+		if slices.Max(units) > 0 {
+			return false
+		}
+	}
+	return true
+}
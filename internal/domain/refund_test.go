@@ -0,0 +1,273 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPaidOrder 创建已支付的测试订单：2×28.00 + 1×12.00 + 打包费1.00 + 配送费3.00 = 72.00
+func newPaidOrder(t *testing.T) *Order {
+	items := []OrderItem{
+		{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 2, Price: decimal.NewFromFloat(28.00)},
+		{DishID: "dish_002", DishName: "米饭", Quantity: 1, Price: decimal.NewFromFloat(12.00)},
+	}
+	delivery := DeliveryInfo{
+		RecipientName:  "张三",
+		RecipientPhone: "13800138000",
+		Address:        "北京市朝阳区xxx",
+	}
+	order := NewOrder(1001, "merchant_001", items, delivery, "")
+	require.NoError(t, order.MarkPaid("pay_001"))
+	return order
+}
+
+func TestOrder_MarkPaid(t *testing.T) {
+	order := newPaidOrder(t)
+
+	assert.Equal(t, OrderStatusPaid, order.Status)
+	assert.Equal(t, "pay_001", order.PaymentID)
+	assert.False(t, order.PaidAt.IsZero())
+
+	// 重复支付应失败
+	assert.ErrorIs(t, order.MarkPaid("pay_002"), ErrInvalidOrderStatus)
+}
+
+func TestOrder_RequestRefund_NotPaid(t *testing.T) {
+	order := NewOrder(1001, "merchant_001", []OrderItem{
+		{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 1, Price: decimal.NewFromFloat(28.00)},
+	}, DeliveryInfo{}, "")
+
+	_, err := order.RequestRefund(nil, "")
+
+	assert.ErrorIs(t, err, ErrOrderNotPaid)
+}
+
+func TestOrder_RequestRefund_Full(t *testing.T) {
+	// Arrange
+	order := newPaidOrder(t)
+
+	// Act
+	refund, err := order.RequestRefund(nil, "不想要了")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, RefundStatusPending, refund.Status)
+	assert.Equal(t, "72.00", refund.Amount.StringFixed(2))
+	assert.Equal(t, "1.00", refund.PackagingFee.StringFixed(2))
+	assert.Equal(t, "3.00", refund.DeliveryFee.StringFixed(2))
+	assert.Len(t, refund.Items, 2)
+	assert.Equal(t, OrderStatusPaid, order.Status, "退款完成前订单仍为已支付")
+
+	require.NoError(t, order.CompleteRefund(refund.RefundID))
+	assert.Equal(t, OrderStatusRefunded, order.Status)
+	assert.True(t, order.RefundableAmount().IsZero())
+}
+
+func TestOrder_RequestRefund_PartialWithProportionalPackagingFee(t *testing.T) {
+	// Arrange
+	order := newPaidOrder(t)
+
+	// Act - 退 1 份宫保鸡丁：28.00 + 打包费 1.00 × 28/68 ≈ 0.41
+	refund, err := order.RequestRefund([]RefundLine{{DishID: "dish_001", Quantity: 1}}, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "28.00", refund.Items[0].Amount.StringFixed(2))
+	assert.Equal(t, "0.41", refund.PackagingFee.StringFixed(2))
+	assert.True(t, refund.DeliveryFee.IsZero())
+	assert.Equal(t, "28.41", refund.Amount.StringFixed(2))
+	assert.Equal(t, "43.59", order.RefundableAmount().StringFixed(2))
+}
+
+func TestOrder_RequestRefund_PartialsSumToFinalAmount(t *testing.T) {
+	// Arrange
+	order := newPaidOrder(t)
+
+	// Act - 分三次退完
+	r1, err := order.RequestRefund([]RefundLine{{DishID: "dish_001", Quantity: 1}}, "")
+	require.NoError(t, err)
+	require.NoError(t, order.CompleteRefund(r1.RefundID))
+	r2, err := order.RequestRefund([]RefundLine{{DishID: "dish_002", Quantity: 1}}, "")
+	require.NoError(t, err)
+	require.NoError(t, order.CompleteRefund(r2.RefundID))
+	r3, err := order.RequestRefund([]RefundLine{{DishID: "dish_001", Quantity: 1}}, "")
+	require.NoError(t, err)
+
+	// Assert - 最后一次退还剩余打包费和配送费，总额恰好等于最终金额
+	assert.Equal(t, "3.00", r3.DeliveryFee.StringFixed(2))
+	assert.Equal(t, order.Pricing.FinalAmount.StringFixed(2), order.RefundedAmount().StringFixed(2))
+	require.NoError(t, order.CompleteRefund(r3.RefundID))
+	assert.Equal(t, OrderStatusRefunded, order.Status)
+}
+
+func TestOrder_RequestRefund_PackagingFeeNeverExceedsTotal(t *testing.T) {
+	// Arrange - 4 份 10.00，打包费 0.02：每份按比例 0.005，舍入后为 0.01
+	order := NewOrderWithFees(1001, "merchant_001", []OrderItem{
+		{DishID: "dish_001", DishName: "宫保鸡丁", Quantity: 4, Price: decimal.NewFromInt(10)},
+	}, DeliveryInfo{}, "", Fees{PackagingFee: decimal.NewFromFloat(0.02), DeliveryFee: decimal.Zero})
+	require.NoError(t, order.MarkPaid("pay_001"))
+
+	// Act - 逐份退完
+	var fees []string
+	for range 4 {
+		refund, err := order.RequestRefund([]RefundLine{{DishID: "dish_001", Quantity: 1}}, "")
+		require.NoError(t, err)
+		fees = append(fees, refund.PackagingFee.StringFixed(2))
+	}
+
+	// Assert - 累计退还的打包费不超过打包费，每次都不为负
+	assert.Equal(t, []string{"0.01", "0.01", "0.00", "0.00"}, fees)
+	assert.Equal(t, order.Pricing.FinalAmount.StringFixed(2), order.RefundedAmount().StringFixed(2))
+}
+
+func TestOrder_RequestRefund_QuantityExceeded(t *testing.T) {
+	order := newPaidOrder(t)
+
+	_, err := order.RequestRefund([]RefundLine{{DishID: "dish_001", Quantity: 3}}, "")
+
+	assert.ErrorIs(t, err, ErrRefundQuantityExceeded)
+	assert.Contains(t, err.Error(), "dish_001")
+	assert.Empty(t, order.Refunds)
+}
+
+func TestOrder_RequestRefund_PendingRefundHoldsQuantity(t *testing.T) {
+	order := newPaidOrder(t)
+
+	_, err := order.RequestRefund([]RefundLine{{DishID: "dish_001", Quantity: 2}}, "")
+	require.NoError(t, err)
+
+	// 退款中的数量不能再次申请
+	_, err = order.RequestRefund([]RefundLine{{DishID: "dish_001", Quantity: 1}}, "")
+	assert.ErrorIs(t, err, ErrRefundQuantityExceeded)
+}
+
+func TestOrder_RequestRefund_ItemNotFound(t *testing.T) {
+	order := newPaidOrder(t)
+
+	_, err := order.RequestRefund([]RefundLine{{DishID: "dish_999", Quantity: 1}}, "")
+
+	assert.ErrorIs(t, err, ErrRefundItemNotFound)
+}
+
+func TestOrder_RequestRefund_NothingLeft(t *testing.T) {
+	order := newPaidOrder(t)
+	refund, err := order.RequestRefund(nil, "")
+	require.NoError(t, err)
+
+	// 退款中时，订单仍为已支付但已无可退内容
+	_, err = order.RequestRefund(nil, "")
+	assert.ErrorIs(t, err, ErrNothingToRefund)
+
+	require.NoError(t, order.CompleteRefund(refund.RefundID))
+	_, err = order.RequestRefund(nil, "")
+	assert.ErrorIs(t, err, ErrOrderNotPaid)
+}
+
+func TestOrder_FailRefund_ReleasesQuantity(t *testing.T) {
+	// Arrange
+	order := newPaidOrder(t)
+	refund, err := order.RequestRefund([]RefundLine{{DishID: "dish_001", Quantity: 2}}, "")
+	require.NoError(t, err)
+
+	// Act
+	require.NoError(t, order.FailRefund(refund.RefundID, "channel error"))
+
+	// Assert
+	failed, ok := order.FindRefund(refund.RefundID)
+	require.True(t, ok)
+	assert.Equal(t, RefundStatusFailed, failed.Status)
+	assert.Equal(t, "channel error", failed.FailReason)
+	assert.True(t, order.RefundedAmount().IsZero())
+
+	_, err = order.RequestRefund([]RefundLine{{DishID: "dish_001", Quantity: 2}}, "")
+	assert.NoError(t, err)
+}
+
+func TestOrder_CompleteRefund_InvalidStatus(t *testing.T) {
+	order := newPaidOrder(t)
+	refund, err := order.RequestRefund(nil, "")
+	require.NoError(t, err)
+	require.NoError(t, order.CompleteRefund(refund.RefundID))
+
+	assert.ErrorIs(t, order.CompleteRefund(refund.RefundID), ErrInvalidRefundStatus)
+	assert.ErrorIs(t, order.FailRefund("unknown", ""), ErrRefundNotFound)
+}
+
+func TestOrder_Accept(t *testing.T) {
+	order := newPaidOrder(t)
+	order.PullEvents()
+
+	require.NoError(t, order.Accept())
+
+	assert.Equal(t, OrderStatusAccepted, order.Status)
+	events := order.PullEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, EventTypeOrderAccepted, events[0].EventType())
+
+	// 已接单订单不能再拒单，但仍可退款
+	assert.ErrorIs(t, order.Reject(""), ErrInvalidOrderStatus)
+	_, err := order.RequestRefund([]RefundLine{{DishID: "dish_002", Quantity: 1}}, "")
+	assert.NoError(t, err)
+}
+
+func TestOrder_Reject(t *testing.T) {
+	order := newPaidOrder(t)
+	order.PullEvents()
+
+	require.NoError(t, order.Reject("菜品已售罄"))
+
+	assert.Equal(t, OrderStatusRejected, order.Status)
+	rejected := order.PullEvents()[0].(OrderRejected)
+	assert.Equal(t, "菜品已售罄", rejected.Reason)
+
+	// 拒单后全额退款
+	refund, err := order.RequestRefund(nil, "商家拒单")
+	require.NoError(t, err)
+	assert.Equal(t, "72.00", refund.Amount.StringFixed(2))
+	require.NoError(t, order.CompleteRefund(refund.RefundID))
+	assert.Equal(t, OrderStatusRefunded, order.Status)
+}
+
+func TestOrder_Accept_NotPaid(t *testing.T) {
+	order := NewOrder(1001, "merchant_001", nil, DeliveryInfo{}, "")
+
+	assert.ErrorIs(t, order.Accept(), ErrInvalidOrderStatus)
+	assert.ErrorIs(t, order.Reject(""), ErrInvalidOrderStatus)
+}
+
+func TestOrder_RequestRefund_ComboComponent(t *testing.T) {
+	// Arrange - 2 份套餐（每份 30.00：牛肉面 18.33、拍黄瓜 6.67、酸梅汤 5.00），打包费 1.00，配送费 3.00
+	components, err := newNoodleCombo().SelectComponents(nil)
+	require.NoError(t, err)
+	order := NewOrder(1001, "merchant_001", []OrderItem{
+		{DishID: "combo_201", DishName: "牛肉面套餐", Quantity: 2, Price: decimal.NewFromInt(30), Components: components},
+	}, DeliveryInfo{}, "")
+	require.NoError(t, order.MarkPaid("pay_001"))
+
+	// Act - 退 1 份套餐中的饮料，再退 1 整份套餐，最后退还剩余部分
+	drink, err := order.RequestRefund([]RefundLine{{DishID: "combo_201", SlotID: "drink", Quantity: 1}}, "饮料洒了")
+	require.NoError(t, err)
+	whole, err := order.RequestRefund([]RefundLine{{DishID: "combo_201", Quantity: 1}}, "")
+	require.NoError(t, err)
+	_, exceeded := order.RequestRefund([]RefundLine{{DishID: "combo_201", Quantity: 1}}, "")
+	rest, err := order.RequestRefund(nil, "")
+	require.NoError(t, err)
+
+	// Assert - 组件退款按分摊金额计算，打包费按 5.00/60.00 比例退还
+	assert.Equal(t, "5.00", drink.Items[0].Amount.StringFixed(2))
+	assert.Equal(t, "drink", drink.Items[0].SlotID)
+	assert.Equal(t, "0.08", drink.PackagingFee.StringFixed(2))
+	assert.Equal(t, "30.00", whole.Items[0].Amount.StringFixed(2))
+	assert.ErrorIs(t, exceeded, ErrRefundQuantityExceeded)
+
+	// 剩余的牛肉面和拍黄瓜按组件退还，并退还剩余打包费和配送费
+	require.Len(t, rest.Items, 2)
+	assert.Equal(t, "main", rest.Items[0].SlotID)
+	assert.Equal(t, "side", rest.Items[1].SlotID)
+	assert.Equal(t, "25.00", rest.Items[0].Amount.Add(rest.Items[1].Amount).StringFixed(2))
+	assert.Equal(t, "3.00", rest.DeliveryFee.StringFixed(2))
+	assert.True(t, order.RefundedAmount().Equal(order.Pricing.FinalAmount))
+}
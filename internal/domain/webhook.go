@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// WebhookStatus Webhook 订阅状态
+type WebhookStatus string
+
+const (
+	WebhookStatusActive   WebhookStatus = "ACTIVE"
+	WebhookStatusDisabled WebhookStatus = "DISABLED"
+)
+
+// WebhookDeliveryStatus Webhook 投递状态
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "FAILED"
+	WebhookDeliverySkipped   WebhookDeliveryStatus = "SKIPPED"
+)
+
+// WebhookEventAll 订阅全部事件类型的通配符
+const WebhookEventAll = "*"
+
+// WebhookSubscription 商家 Webhook 订阅（聚合根）
+type WebhookSubscription struct {
+	ID                  string
+	MerchantID          string
+	URL                 string
+	EventTypes          []string
+	Secret              string
+	Status              WebhookStatus
+	ConsecutiveFailures int
+	DisabledReason      string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// WebhookDelivery Webhook 投递记录
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	MerchantID     string
+	EventID        string
+	EventType      string
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       []WebhookAttempt
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// WebhookAttempt 单次投递尝试
+type WebhookAttempt struct {
+	AttemptedAt time.Time
+	StatusCode  int
+	Error       string
+	Duration    time.Duration
+}
+
+// NewWebhookSubscription 创建 Webhook 订阅（工厂方法）
+func NewWebhookSubscription(merchantID, url string, eventTypes []string, secret string) *WebhookSubscription {
+	now := time.Now()
+	return &WebhookSubscription{
+		ID:         "whk_" + newUUID(),
+		MerchantID: merchantID,
+		URL:        url,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		Status:     WebhookStatusActive,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Subscribes 订阅是否接收指定类型的事件
+func (s *WebhookSubscription) Subscribes(eventType string) bool {
+	return slices.Contains(s.EventTypes, WebhookEventAll) || slices.Contains(s.EventTypes, eventType)
+}
+
+// Active 订阅是否处于启用状态
+func (s *WebhookSubscription) Active() bool {
+	return s.Status == WebhookStatusActive
+}
+
+// RecordSuccess 记录投递成功（重置连续失败次数）
+func (s *WebhookSubscription) RecordSuccess() {
+	s.ConsecutiveFailures = 0
+	s.UpdatedAt = time.Now()
+}
+
+// RecordFailure 记录投递失败，连续失败达到阈值时自动停用
+// 返回本次是否触发了停用
+func (s *WebhookSubscription) RecordFailure(disableThreshold int) bool {
+	s.ConsecutiveFailures++
+	s.UpdatedAt = time.Now()
+	if s.Active() && s.ConsecutiveFailures >= disableThreshold {
+		s.Status = WebhookStatusDisabled
+		s.DisabledReason = "too many consecutive delivery failures"
+		return true
+	}
+	return false
+}
+
+// Enable 重新启用订阅
+func (s *WebhookSubscription) Enable() {
+	s.Status = WebhookStatusActive
+	s.ConsecutiveFailures = 0
+	s.DisabledReason = ""
+	s.UpdatedAt = time.Now()
+}
+
+// NewWebhookDelivery 为订阅创建一条待投递记录（同一订阅同一事件的投递ID相同，便于去重）
+func NewWebhookDelivery(subscription *WebhookSubscription, event Event, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:             WebhookDeliveryID(subscription.ID, event.EventID()),
+		SubscriptionID: subscription.ID,
+		MerchantID:     subscription.MerchantID,
+		EventID:        event.EventID(),
+		EventType:      event.EventType(),
+		Payload:        payload,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// WebhookDeliveryID 根据订阅ID和事件ID生成投递ID
+func WebhookDeliveryID(subscriptionID, eventID string) string {
+	return subscriptionID + ":" + eventID
+}
+
+// RecordAttempt 记录一次投递尝试
+// 成功时标记为 SUCCEEDED；失败时若还有重试机会则安排 nextAttemptAt，否则标记为 FAILED
+func (d *WebhookDelivery) RecordAttempt(attempt WebhookAttempt, succeeded bool, retryAt time.Time, exhausted bool) {
+	d.Attempts = append(d.Attempts, attempt)
+	d.UpdatedAt = attempt.AttemptedAt
+	switch {
+	case succeeded:
+		d.Status = WebhookDeliverySucceeded
+	case exhausted:
+		d.Status = WebhookDeliveryFailed
+	default:
+		d.NextAttemptAt = retryAt
+	}
+}
+
+// Skip 订阅已停用时跳过投递
+func (d *WebhookDelivery) Skip() {
+	d.Status = WebhookDeliverySkipped
+	d.UpdatedAt = time.Now()
+}
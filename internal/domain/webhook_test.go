@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSubscription_Subscribes(t *testing.T) {
+	specific := NewWebhookSubscription("merchant_001", "https://example.com/hook", []string{EventTypeOrderCreated}, "secret")
+	all := NewWebhookSubscription("merchant_001", "https://example.com/hook", []string{WebhookEventAll}, "secret")
+
+	assert.True(t, specific.Subscribes(EventTypeOrderCreated))
+	assert.False(t, specific.Subscribes(EventTypeOrderPaid))
+	assert.True(t, all.Subscribes(EventTypeOrderPaid))
+	assert.Regexp(t, `^whk_`, specific.ID)
+	assert.Equal(t, WebhookStatusActive, specific.Status)
+}
+
+func TestWebhookSubscription_RecordFailure_AutoDisables(t *testing.T) {
+	// Arrange
+	subscription := NewWebhookSubscription("merchant_001", "https://example.com/hook", []string{WebhookEventAll}, "secret")
+
+	// Act & Assert
+	assert.False(t, subscription.RecordFailure(3))
+	assert.False(t, subscription.RecordFailure(3))
+	assert.True(t, subscription.RecordFailure(3))
+	assert.Equal(t, WebhookStatusDisabled, subscription.Status)
+	assert.NotEmpty(t, subscription.DisabledReason)
+
+	// 已停用时不会再次触发停用
+	assert.False(t, subscription.RecordFailure(3))
+
+	subscription.Enable()
+	assert.True(t, subscription.Active())
+	assert.Equal(t, 0, subscription.ConsecutiveFailures)
+}
+
+func TestWebhookSubscription_RecordSuccess_ResetsFailures(t *testing.T) {
+	subscription := NewWebhookSubscription("merchant_001", "https://example.com/hook", []string{WebhookEventAll}, "secret")
+	subscription.RecordFailure(3)
+	subscription.RecordFailure(3)
+
+	subscription.RecordSuccess()
+
+	assert.Equal(t, 0, subscription.ConsecutiveFailures)
+	assert.False(t, subscription.RecordFailure(3))
+}
+
+func TestWebhookDelivery_RecordAttempt(t *testing.T) {
+	// Arrange
+	subscription := NewWebhookSubscription("merchant_001", "https://example.com/hook", []string{WebhookEventAll}, "secret")
+	order := NewOrder(1001, "merchant_001", nil, DeliveryInfo{}, "")
+	event := order.PendingEvents()[0]
+	delivery := NewWebhookDelivery(subscription, event, []byte(`{}`))
+	now := time.Now()
+
+	// Assert - 投递ID由订阅和事件决定
+	assert.Equal(t, WebhookDeliveryID(subscription.ID, event.EventID()), delivery.ID)
+	assert.Equal(t, WebhookDeliveryPending, delivery.Status)
+
+	// 失败但可重试
+	delivery.RecordAttempt(WebhookAttempt{AttemptedAt: now, StatusCode: 500}, false, now.Add(time.Minute), false)
+	assert.Equal(t, WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, now.Add(time.Minute), delivery.NextAttemptAt)
+
+	// 失败且重试次数用尽
+	delivery.RecordAttempt(WebhookAttempt{AttemptedAt: now, StatusCode: 500}, false, time.Time{}, true)
+	assert.Equal(t, WebhookDeliveryFailed, delivery.Status)
+	assert.Len(t, delivery.Attempts, 2)
+}
@@ -0,0 +1,90 @@
+// Package logging 基于 log/slog 的结构化日志：JSON 输出、context 携带的请求级 logger 和个人信息脱敏
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+)
+
+// 通用日志字段名
+const (
+	KeyRequestID = "request_id"
+	KeyUserID    = "user_id"
+	KeyError     = "error"
+)
+
+// RequestIDHeader 携带请求ID的 HTTP 头（gRPC 使用小写的同名 metadata）
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength 接受的外部请求ID最大长度
+const maxRequestIDLength = 128
+
+// New 创建输出 JSON 的 logger，写出前对个人信息字段脱敏
+func New(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	}))
+}
+
+// loggerKey logger 在 context 中的键
+type loggerKey struct{}
+
+// requestIDKey 请求ID在 context 中的键
+type requestIDKey struct{}
+
+// WithLogger 将 logger 存入 context
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext 返回 context 携带的 logger，未携带时返回 slog.Default()
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With 为 context 携带的 logger 追加字段
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, FromContext(ctx).With(args...))
+}
+
+// WithRequestID 记录请求ID，并将其追加到 context 携带的 logger
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+	return With(ctx, KeyRequestID, requestID)
+}
+
+// RequestIDFromContext 返回当前请求ID，未设置时为空
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// NewRequestID 生成随机请求ID（32 位十六进制）
+func NewRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// ValidRequestID 校验调用方传入的请求ID：1-128 个字母、数字或 -_.: 字符，防止日志注入
+func ValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
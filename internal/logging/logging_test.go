@@ -0,0 +1,92 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeLine 解析单行 JSON 日志
+func decodeLine(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	return entry
+}
+
+func TestFromContext_CarriesRequestIDAndFields(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	ctx := WithLogger(context.Background(), New(&buf, slog.LevelInfo))
+
+	// Act
+	ctx = WithRequestID(ctx, "req-1")
+	ctx = With(ctx, KeyUserID, uint64(1001))
+	FromContext(ctx).Info("order created")
+
+	// Assert
+	entry := decodeLine(t, &buf)
+	assert.Equal(t, "order created", entry["msg"])
+	assert.Equal(t, "req-1", entry[KeyRequestID])
+	assert.Equal(t, float64(1001), entry[KeyUserID])
+	assert.Equal(t, "req-1", RequestIDFromContext(ctx))
+}
+
+func TestFromContext_DefaultsToSlogDefault(t *testing.T) {
+	assert.Same(t, slog.Default(), FromContext(context.Background()))
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+}
+
+func TestNew_RespectsLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelWarn)
+
+	logger.Info("ignored")
+
+	assert.Empty(t, buf.String())
+}
+
+func TestNew_RedactsPII(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	// Act - 不同写法的字段名、分组内的字段和非字符串值都会脱敏
+	logger.Info("delivery",
+		"recipient_phone", "13800138000",
+		"address", "北京市朝阳区xxx街道xxx号",
+		slog.Group("delivery", slog.String("recipientPhone", "13912345678"), slog.Int("phone", 12345)),
+		"order_number", "20250101",
+	)
+
+	// Assert
+	entry := decodeLine(t, &buf)
+	assert.Equal(t, "138****8000", entry["recipient_phone"])
+	assert.Equal(t, "北京市朝阳区****", entry["address"])
+	delivery := entry["delivery"].(map[string]interface{})
+	assert.Equal(t, "139****5678", delivery["recipientPhone"])
+	assert.Equal(t, redactedValue, delivery["phone"])
+	assert.Equal(t, "20250101", entry["order_number"])
+	assert.NotContains(t, buf.String(), "13800138000")
+}
+
+func TestMaskPhoneAndAddress(t *testing.T) {
+	assert.Equal(t, "138****8000", MaskPhone("13800138000"))
+	assert.Equal(t, "****", MaskPhone("1234"))
+	assert.Equal(t, "北京市朝阳区****", MaskAddress("北京市朝阳区xxx"))
+	assert.Equal(t, "**", MaskAddress("北京"))
+}
+
+func TestValidRequestID(t *testing.T) {
+	assert.True(t, ValidRequestID("abc-123_DEF.4:5"))
+	assert.True(t, ValidRequestID(NewRequestID()))
+	assert.False(t, ValidRequestID(""))
+	assert.False(t, ValidRequestID("abc\ninjected"))
+	assert.False(t, ValidRequestID(strings.Repeat("a", maxRequestIDLength+1)))
+	assert.NotEqual(t, NewRequestID(), NewRequestID())
+}
@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// redactedValue 无法按格式部分脱敏时的替代值
+const redactedValue = "[REDACTED]"
+
+// addressKeepRunes 地址脱敏时保留的前缀字符数（大致到区县一级）
+const addressKeepRunes = 6
+
+// sensitiveKeys 需要脱敏的字段（名称忽略大小写、下划线和连字符）及其脱敏方式
+var sensitiveKeys = map[string]func(string) string{
+	"phone":          MaskPhone,
+	"recipientphone": MaskPhone,
+	"address":        MaskAddress,
+}
+
+// MaskPhone 手机号脱敏，保留前 3 位和后 4 位（13800138000 -> 138****8000）
+func MaskPhone(phone string) string {
+	runes := []rune(phone)
+	if len(runes) < 8 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:3]) + strings.Repeat("*", len(runes)-7) + string(runes[len(runes)-4:])
+}
+
+// MaskAddress 地址脱敏，只保留前 6 个字符（北京市朝阳区xxx街道 -> 北京市朝阳区****）
+func MaskAddress(address string) string {
+	runes := []rune(address)
+	if len(runes) <= addressKeepRunes {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:addressKeepRunes]) + "****"
+}
+
+// redactAttr slog ReplaceAttr：按字段名对个人信息脱敏（包括分组内的字段）
+func redactAttr(groups []string, attr slog.Attr) slog.Attr {
+	mask, ok := sensitiveKeys[normalizeKey(attr.Key)]
+	if !ok {
+		return attr
+	}
+	if attr.Value.Kind() != slog.KindString {
+		return slog.String(attr.Key, redactedValue)
+	}
+	return slog.String(attr.Key, mask(attr.Value.String()))
+}
+
+// normalizeKey 统一字段名写法（recipient_phone、recipientPhone -> recipientphone）
+func normalizeKey(key string) string {
+	key = strings.ToLower(key)
+	key = strings.ReplaceAll(key, "_", "")
+	return strings.ReplaceAll(key, "-", "")
+}
@@ -7,7 +7,7 @@ echo ""
 
 # 生成测试 token
 echo "1. 生成测试 JWT token (userID: 1001)..."
-TOKEN=$(go run tools/generate_token.go 1001 | grep "eyJ" | head -1)
+TOKEN=$(ORDER_AUTH_ALLOW_DEMO_SECRET=true go run tools/generate_token.go 1001 | grep "eyJ" | head -1)
 echo "Token: $TOKEN"
 echo ""
 
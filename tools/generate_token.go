@@ -8,12 +8,14 @@ import (
 	"strconv"
 
 	"order-service/internal/adapter/web"
+	"order-service/internal/config"
 )
 
 func main() {
 	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run tools/generate_token.go <userID>")
+		fmt.Println("Usage: go run tools/generate_token.go <userID> [merchantID]")
 		fmt.Println("Example: go run tools/generate_token.go 1001")
+		fmt.Println("Example: go run tools/generate_token.go 1001 merchant_001")
 		os.Exit(1)
 	}
 
@@ -23,13 +25,30 @@ func main() {
 		os.Exit(1)
 	}
 
-	token, err := web.GenerateToken(userID)
+	var merchantID string
+	if len(os.Args) > 2 {
+		merchantID = os.Args[2]
+	}
+
+	// 与服务使用同一份配置（配置文件/环境变量），保证密钥一致
+	cfg, _, err := config.Load(nil, os.LookupEnv)
+	if err != nil {
+		fmt.Printf("Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	web.ConfigureJWT(cfg.Auth.JWTSecret, cfg.Auth.TokenTTL)
+
+	token, err := web.GenerateMerchantToken(userID, merchantID)
 	if err != nil {
 		fmt.Printf("Failed to generate token: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("Generated JWT token for userID %d:\n", userID)
+	if merchantID != "" {
+		fmt.Printf("Generated JWT token for userID %d (merchant %s):\n", userID, merchantID)
+	} else {
+		fmt.Printf("Generated JWT token for userID %d:\n", userID)
+	}
 	fmt.Println(token)
 	fmt.Println("\nUse it in your API requests:")
 	fmt.Printf("Authorization: Bearer %s\n", token)